@@ -7,6 +7,7 @@ package mock
 import (
 	context "context"
 
+	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/core/v2"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -81,3 +82,62 @@ func (_c *MockRetrievalClient_GetBlob_Call) RunAndReturn(run func(ctx context.Co
 	_c.Call.Return(run)
 	return _c
 }
+
+// GetBlobWithOperatorState provides a mock function for the type MockRetrievalClient
+func (_mock *MockRetrievalClient) GetBlobWithOperatorState(ctx context.Context, blobHeader *v2.BlobHeaderWithHashedPayment, operatorState *core.OperatorState, blobParams *core.BlobVersionParameters) ([]byte, error) {
+	ret := _mock.Called(ctx, blobHeader, operatorState, blobParams)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBlobWithOperatorState")
+	}
+
+	var r0 []byte
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *v2.BlobHeaderWithHashedPayment, *core.OperatorState, *core.BlobVersionParameters) ([]byte, error)); ok {
+		return returnFunc(ctx, blobHeader, operatorState, blobParams)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *v2.BlobHeaderWithHashedPayment, *core.OperatorState, *core.BlobVersionParameters) []byte); ok {
+		r0 = returnFunc(ctx, blobHeader, operatorState, blobParams)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *v2.BlobHeaderWithHashedPayment, *core.OperatorState, *core.BlobVersionParameters) error); ok {
+		r1 = returnFunc(ctx, blobHeader, operatorState, blobParams)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRetrievalClient_GetBlobWithOperatorState_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBlobWithOperatorState'
+type MockRetrievalClient_GetBlobWithOperatorState_Call struct {
+	*mock.Call
+}
+
+// GetBlobWithOperatorState is a helper method to define mock.On call
+//   - ctx
+//   - blobHeader
+//   - operatorState
+//   - blobParams
+func (_e *MockRetrievalClient_Expecter) GetBlobWithOperatorState(ctx interface{}, blobHeader interface{}, operatorState interface{}, blobParams interface{}) *MockRetrievalClient_GetBlobWithOperatorState_Call {
+	return &MockRetrievalClient_GetBlobWithOperatorState_Call{Call: _e.mock.On("GetBlobWithOperatorState", ctx, blobHeader, operatorState, blobParams)}
+}
+
+func (_c *MockRetrievalClient_GetBlobWithOperatorState_Call) Run(run func(ctx context.Context, blobHeader *v2.BlobHeaderWithHashedPayment, operatorState *core.OperatorState, blobParams *core.BlobVersionParameters)) *MockRetrievalClient_GetBlobWithOperatorState_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*v2.BlobHeaderWithHashedPayment), args[2].(*core.OperatorState), args[3].(*core.BlobVersionParameters))
+	})
+	return _c
+}
+
+func (_c *MockRetrievalClient_GetBlobWithOperatorState_Call) Return(bytes []byte, err error) *MockRetrievalClient_GetBlobWithOperatorState_Call {
+	_c.Call.Return(bytes, err)
+	return _c
+}
+
+func (_c *MockRetrievalClient_GetBlobWithOperatorState_Call) RunAndReturn(run func(ctx context.Context, blobHeader *v2.BlobHeaderWithHashedPayment, operatorState *core.OperatorState, blobParams *core.BlobVersionParameters) ([]byte, error)) *MockRetrievalClient_GetBlobWithOperatorState_Call {
+	_c.Call.Return(run)
+	return _c
+}
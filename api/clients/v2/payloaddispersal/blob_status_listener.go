@@ -0,0 +1,31 @@
+package payloaddispersal
+
+import (
+	"context"
+
+	dispgrpc "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	core "github.com/Layr-Labs/eigenda/core/v2"
+)
+
+// BlobStatusListener is notified of the status of a blob while SendPayload or ResumePayload waits for it to be signed.
+// It is called once when the blob is dispersed (or, when resuming, when its status is first received), and again each
+// time the status reported by the disperser changes. It is called on the goroutine that called SendPayload or
+// ResumePayload, and should return quickly.
+type BlobStatusListener func(blobKey core.BlobKey, status dispgrpc.BlobStatus)
+
+type blobStatusListenerKey struct{}
+
+// WithBlobStatusListener returns a context that causes SendPayload and ResumePayload to report blob status changes
+// to the listener.
+func WithBlobStatusListener(ctx context.Context, listener BlobStatusListener) context.Context {
+	return context.WithValue(ctx, blobStatusListenerKey{}, listener)
+}
+
+// ReportBlobStatus reports the status of a blob to the listener attached to the context, if there is one. It is
+// exported so that other dispersal implementations (e.g. test doubles) can report their progress the same way.
+func ReportBlobStatus(ctx context.Context, blobKey core.BlobKey, status dispgrpc.BlobStatus) {
+	listener, ok := ctx.Value(blobStatusListenerKey{}).(BlobStatusListener)
+	if ok && listener != nil {
+		listener(blobKey, status)
+	}
+}
@@ -124,14 +124,44 @@ func (pd *PayloadDisperser) SendPayload(
 		return nil, fmt.Errorf("disperse blob: %w", err)
 	}
 	pd.logger.Debug("Successful DisperseBlob", "blobStatus", blobStatus.String(), "blobKey", blobKey.Hex())
+	ReportBlobStatus(ctx, blobKey, blobStatus.ToProfobuf())
 
-	probe.SetStage("QUEUED")
+	return pd.waitForCert(ctx, blobKey, blobStatus.ToProfobuf(), probe)
+}
+
+// ResumePayload finishes the dispersal of a blob that was dispersed by an earlier call to SendPayload, e.g. one that
+// was interrupted by a restart. It polls the disperser for the status of the blob, then builds and verifies the cert
+// exactly like SendPayload does. Blob status changes are reported to the context's BlobStatusListener.
+func (pd *PayloadDisperser) ResumePayload(
+	ctx context.Context,
+	// blobKey is the key of the previously dispersed blob
+	blobKey core.BlobKey,
+) (coretypes.EigenDACert, error) {
+
+	probe := pd.stageTimer.NewSequence()
+	defer probe.End()
+
+	pd.logger.Debug("Resuming dispersal", "blobKey", blobKey.Hex())
+
+	// UNKNOWN is never reported by the disperser, so the first status received is reported to the listener
+	return pd.waitForCert(ctx, blobKey, dispgrpc.BlobStatus_UNKNOWN, probe)
+}
+
+// waitForCert waits for a dispersed blob to be signed, and then builds and verifies its cert.
+func (pd *PayloadDisperser) waitForCert(
+	ctx context.Context,
+	blobKey core.BlobKey,
+	initialStatus dispgrpc.BlobStatus,
+	probe *common.SequenceProbe,
+) (coretypes.EigenDACert, error) {
+
+	probe.SetStage(initialStatus.String())
 
 	// poll the disperser for the status of the blob until it's received adequate signatures in regards to
 	// confirmation thresholds, a terminal error, or a timeout
-	timeoutCtx, cancel = context.WithTimeout(ctx, pd.config.BlobCompleteTimeout)
+	timeoutCtx, cancel := context.WithTimeout(ctx, pd.config.BlobCompleteTimeout)
 	defer cancel()
-	blobStatusReply, err := pd.pollBlobStatusUntilSigned(timeoutCtx, blobKey, blobStatus.ToProfobuf(), probe)
+	blobStatusReply, err := pd.pollBlobStatusUntilSigned(timeoutCtx, blobKey, initialStatus, probe)
 	if err != nil {
 		return nil, fmt.Errorf("poll blob status until signed: %w", err)
 	}
@@ -259,6 +289,7 @@ func (pd *PayloadDisperser) pollBlobStatusUntilSigned(
 					"previous status", previousStatus.String(),
 					"new status", newStatus.String())
 				previousStatus = newStatus
+				ReportBlobStatus(ctx, blobKey, newStatus)
 			}
 
 			// TODO: we'll need to add more in-depth response status processing to derive failover errors
@@ -2,8 +2,10 @@ package relay
 
 import (
 	"context"
+	"crypto/ecdsa"
 	"errors"
 	"fmt"
+	"io"
 	"sync"
 	"time"
 
@@ -13,10 +15,16 @@ import (
 	"github.com/Layr-Labs/eigenda/core"
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/hashicorp/go-multierror"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+// The maximum number of times an interrupted StreamBlob call is resumed before GetBlob gives up.
+const maxStreamBlobResumes = 3
+
 // MessageSigner is a function that signs a message with a private BLS key.
 type MessageSigner func(ctx context.Context, data [32]byte) (*core.Signature, error)
 
@@ -25,6 +33,9 @@ type RelayClientConfig struct {
 	MaxGRPCMessageSize uint
 	OperatorID         *core.OperatorID
 	MessageSigner      MessageSigner
+	// If set, GetBlob requests are signed with this account key. Relays rate limit requests signed by allowlisted
+	// accounts per account, with the limits of the account's tier, rather than per IP address.
+	AccountKey *ecdsa.PrivateKey
 }
 
 type ChunkRequestByRange struct {
@@ -71,6 +82,9 @@ type relayClient struct {
 	grpcRelayClients sync.Map
 	// relayUrlProvider knows how to retrieve the relay URLs
 	relayUrlProvider RelayUrlProvider
+	// streamBlobUnsupported holds the keys of relays that don't implement StreamBlob: `map[corev2.RelayKey]bool`
+	// blobs are fetched from these relays with GetBlob instead
+	streamBlobUnsupported sync.Map
 }
 
 var _ RelayClient = (*relayClient)(nil)
@@ -101,15 +115,39 @@ func NewRelayClient(
 	}, nil
 }
 
+// GetBlob retrieves a blob from a relay. The blob is streamed if the relay supports StreamBlob, so that the size of the
+// blob is not limited by the maximum gRPC message size. Otherwise, the blob is fetched with a single GetBlob call.
 func (c *relayClient) GetBlob(ctx context.Context, relayKey corev2.RelayKey, blobKey corev2.BlobKey) ([]byte, error) {
 	client, err := c.getClient(ctx, relayKey)
 	if err != nil {
 		return nil, fmt.Errorf("get grpc client for key %d: %w", relayKey, err)
 	}
 
-	res, err := client.GetBlob(ctx, &relaygrpc.GetBlobRequest{
+	if _, unsupported := c.streamBlobUnsupported.Load(relayKey); !unsupported {
+		blob, err := c.streamBlob(ctx, client, blobKey)
+		if status.Code(err) != codes.Unimplemented {
+			return blob, err
+		}
+		c.logger.Info("relay does not support StreamBlob, falling back to GetBlob", "relayKey", relayKey)
+		c.streamBlobUnsupported.Store(relayKey, true)
+	}
+
+	request := &relaygrpc.GetBlobRequest{
 		BlobKey: blobKey[:],
-	})
+	}
+	if c.config.AccountKey != nil {
+		request.Timestamp = uint32(time.Now().Unix())
+		hash, err := hashing.HashGetBlobRequest(request)
+		if err != nil {
+			return nil, fmt.Errorf("failed to hash get blob request: %w", err)
+		}
+		request.Signature, err = crypto.Sign(hash, c.config.AccountKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign get blob request: %w", err)
+		}
+	}
+
+	res, err := client.GetBlob(ctx, request)
 	if err != nil {
 		return nil, err
 	}
@@ -117,6 +155,121 @@ func (c *relayClient) GetBlob(ctx context.Context, relayKey corev2.RelayKey, blo
 	return res.GetBlob(), nil
 }
 
+// newStreamBlobRequest builds a StreamBlobRequest, signing it if an account key is configured.
+func (c *relayClient) newStreamBlobRequest(
+	blobKey corev2.BlobKey,
+	offset uint64) (*relaygrpc.StreamBlobRequest, error) {
+
+	request := &relaygrpc.StreamBlobRequest{
+		BlobKey: blobKey[:],
+		Offset:  offset,
+	}
+	if c.config.AccountKey != nil {
+		request.Timestamp = uint32(time.Now().Unix())
+		hash, err := hashing.HashStreamBlobRequest(request)
+		if err != nil {
+			return nil, fmt.Errorf("failed to hash stream blob request: %w", err)
+		}
+		request.Signature, err = crypto.Sign(hash, c.config.AccountKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign stream blob request: %w", err)
+		}
+	}
+	return request, nil
+}
+
+// blobDownload holds the part of a blob that has been received so far.
+type blobDownload struct {
+	// The bytes received so far.
+	data []byte
+	// The size of the blob. Only valid if started is true.
+	size uint64
+	// True once the first frame has been received.
+	started bool
+}
+
+// streamBlob fetches a blob with StreamBlob. If the stream is interrupted after making progress, it is resumed from
+// the first byte not yet received.
+func (c *relayClient) streamBlob(
+	ctx context.Context,
+	client relaygrpc.RelayClient,
+	blobKey corev2.BlobKey) ([]byte, error) {
+
+	download := &blobDownload{}
+	resumes := 0
+	for {
+		request, err := c.newStreamBlobRequest(blobKey, uint64(len(download.data)))
+		if err != nil {
+			return nil, err
+		}
+		progress, err := receiveBlobFrames(ctx, client, request, download)
+		if err == nil {
+			return download.data, nil
+		}
+		if !progress || ctx.Err() != nil || resumes >= maxStreamBlobResumes {
+			return nil, err
+		}
+		resumes++
+		c.logger.Debug("resuming interrupted blob stream",
+			"blobKey", blobKey.Hex(), "offset", len(download.data), "err", err)
+	}
+}
+
+// receiveBlobFrames makes a single StreamBlob call, which must start at the first byte of the blob not yet received,
+// and appends the frames it receives to the download. Returns true if any bytes were received.
+func receiveBlobFrames(
+	ctx context.Context,
+	client relaygrpc.RelayClient,
+	request *relaygrpc.StreamBlobRequest,
+	download *blobDownload) (bool, error) {
+
+	stream, err := client.StreamBlob(ctx, request)
+	if err != nil {
+		return false, err
+	}
+
+	progress := false
+	for {
+		frame, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			if !download.started {
+				return progress, errors.New("blob stream ended without sending any data")
+			}
+			if uint64(len(download.data)) != download.size {
+				return progress, fmt.Errorf("blob stream ended after %d of %d bytes",
+					len(download.data), download.size)
+			}
+			return progress, nil
+		}
+		if err != nil {
+			return progress, err
+		}
+
+		if !download.started {
+			download.started = true
+			download.size = frame.GetBlobSize()
+			download.data = make([]byte, 0)
+		}
+		if frame.GetBlobSize() != download.size {
+			return progress, fmt.Errorf("blob size changed from %d to %d during stream",
+				download.size, frame.GetBlobSize())
+		}
+		if frame.GetOffset() != uint64(len(download.data)) {
+			return progress, fmt.Errorf("expected frame at offset %d, got offset %d",
+				len(download.data), frame.GetOffset())
+		}
+		if uint64(len(download.data))+uint64(len(frame.GetData())) > download.size {
+			return progress, fmt.Errorf("frame at offset %d overruns blob of size %d",
+				frame.GetOffset(), download.size)
+		}
+
+		download.data = append(download.data, frame.GetData()...)
+		if len(frame.GetData()) > 0 {
+			progress = true
+		}
+	}
+}
+
 // signGetChunksRequest signs the GetChunksRequest with the operator's private key
 // and sets the signature in the request.
 func (c *relayClient) signGetChunksRequest(ctx context.Context, request *relaygrpc.GetChunksRequest) error {
@@ -0,0 +1,231 @@
+package relay
+
+import (
+	"context"
+	"net"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	relaygrpc "github.com/Layr-Labs/eigenda/api/grpc/relay"
+	"github.com/Layr-Labs/eigenda/common/testutils"
+	"github.com/Layr-Labs/eigenda/common/testutils/random"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/relay/auth"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// testRelayServer serves a single blob. If streaming is false, StreamBlob is not implemented.
+type testRelayServer struct {
+	relaygrpc.UnimplementedRelayServer
+
+	blob      []byte
+	frameSize int
+	streaming bool
+
+	// If positive, each StreamBlob call fails after sending this many frames.
+	failAfterFrames int
+
+	getBlobCalls    atomic.Int32
+	streamBlobCalls atomic.Int32
+
+	// The accounts that signed the requests received, or the zero address for unsigned requests.
+	signers sync.Map
+}
+
+// recordSigner records the account that signed a request, if any.
+func (s *testRelayServer) recordSigner(rpc string, signature []byte, recoverSigner func() (gethcommon.Address, error)) {
+	signer := gethcommon.Address{}
+	if len(signature) > 0 {
+		var err error
+		signer, err = recoverSigner()
+		if err != nil {
+			return
+		}
+	}
+	s.signers.Store(rpc, signer)
+}
+
+func (s *testRelayServer) GetBlob(
+	_ context.Context,
+	request *relaygrpc.GetBlobRequest) (*relaygrpc.GetBlobReply, error) {
+
+	s.getBlobCalls.Add(1)
+	s.recordSigner("GetBlob", request.GetSignature(), func() (gethcommon.Address, error) {
+		signer, _, err := auth.RecoverGetBlobRequestSigner(request)
+		return signer, err
+	})
+	return &relaygrpc.GetBlobReply{Blob: s.blob}, nil
+}
+
+func (s *testRelayServer) StreamBlob(
+	request *relaygrpc.StreamBlobRequest,
+	stream relaygrpc.Relay_StreamBlobServer) error {
+
+	s.streamBlobCalls.Add(1)
+	s.recordSigner("StreamBlob", request.GetSignature(), func() (gethcommon.Address, error) {
+		signer, _, err := auth.RecoverStreamBlobRequestSigner(request)
+		return signer, err
+	})
+	if !s.streaming {
+		return status.Error(codes.Unimplemented, "method StreamBlob not implemented")
+	}
+
+	offset := int(request.GetOffset())
+	for frames := 0; offset < len(s.blob); frames++ {
+		if s.failAfterFrames > 0 && frames == s.failAfterFrames {
+			return status.Error(codes.Unavailable, "connection reset")
+		}
+		end := min(offset+s.frameSize, len(s.blob))
+		err := stream.Send(&relaygrpc.StreamBlobReply{
+			BlobSize: uint64(len(s.blob)),
+			Offset:   uint64(offset),
+			Data:     s.blob[offset:end],
+		})
+		if err != nil {
+			return err
+		}
+		offset = end
+	}
+	return nil
+}
+
+type testRelayUrlProvider struct {
+	url string
+}
+
+func (p *testRelayUrlProvider) GetRelayUrl(context.Context, corev2.RelayKey) (string, error) {
+	return p.url, nil
+}
+
+func (p *testRelayUrlProvider) GetRelayCount(context.Context) (uint32, error) {
+	return 1, nil
+}
+
+// startTestRelay starts a relay server, and returns a relay client connected to it.
+func startTestRelay(t *testing.T, server *testRelayServer) RelayClient {
+	return startTestRelayWithConfig(t, server, &RelayClientConfig{MaxGRPCMessageSize: 1024 * 1024})
+}
+
+// startTestRelayWithConfig starts a relay server, and returns a relay client with the given config connected to it.
+func startTestRelayWithConfig(t *testing.T, server *testRelayServer, config *RelayClientConfig) RelayClient {
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	grpcServer := grpc.NewServer()
+	relaygrpc.RegisterRelayServer(grpcServer, server)
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+	t.Cleanup(grpcServer.Stop)
+
+	client, err := NewRelayClient(
+		config,
+		testutils.GetLogger(),
+		&testRelayUrlProvider{url: listener.Addr().String()})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+	return client
+}
+
+func TestGetBlobStreaming(t *testing.T) {
+	rand := random.NewTestRandom()
+	server := &testRelayServer{
+		blob:      rand.Bytes(10_000),
+		frameSize: 512,
+		streaming: true,
+	}
+	client := startTestRelay(t, server)
+
+	blob, err := client.GetBlob(context.Background(), 0, corev2.BlobKey{})
+	require.NoError(t, err)
+	require.Equal(t, server.blob, blob)
+	require.Equal(t, int32(1), server.streamBlobCalls.Load())
+	require.Equal(t, int32(0), server.getBlobCalls.Load())
+}
+
+func TestGetBlobResumesInterruptedStream(t *testing.T) {
+	rand := random.NewTestRandom()
+	server := &testRelayServer{
+		blob:            rand.Bytes(4096),
+		frameSize:       512,
+		streaming:       true,
+		failAfterFrames: 3,
+	}
+	client := startTestRelay(t, server)
+
+	// 8 frames are needed, and each call sends 3 frames before failing, so the stream is resumed twice.
+	blob, err := client.GetBlob(context.Background(), 0, corev2.BlobKey{})
+	require.NoError(t, err)
+	require.Equal(t, server.blob, blob)
+	require.Equal(t, int32(3), server.streamBlobCalls.Load())
+
+	// A blob that needs more resumes than are permitted can't be fetched.
+	server.blob = rand.Bytes(512 * 3 * (maxStreamBlobResumes + 2))
+	_, err = client.GetBlob(context.Background(), 0, corev2.BlobKey{})
+	require.Error(t, err)
+	require.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+func TestGetBlobFallsBackToUnaryGetBlob(t *testing.T) {
+	rand := random.NewTestRandom()
+	server := &testRelayServer{
+		blob:      rand.Bytes(1024),
+		streaming: false,
+	}
+	client := startTestRelay(t, server)
+
+	blob, err := client.GetBlob(context.Background(), 0, corev2.BlobKey{})
+	require.NoError(t, err)
+	require.Equal(t, server.blob, blob)
+
+	// Once the relay is known not to support streaming, StreamBlob is not attempted again.
+	blob, err = client.GetBlob(context.Background(), 0, corev2.BlobKey{})
+	require.NoError(t, err)
+	require.Equal(t, server.blob, blob)
+	require.Equal(t, int32(1), server.streamBlobCalls.Load())
+	require.Equal(t, int32(2), server.getBlobCalls.Load())
+}
+
+func TestGetBlobSignsRequests(t *testing.T) {
+	rand := random.NewTestRandom()
+	public, private, err := rand.ECDSA()
+	require.NoError(t, err)
+	account := crypto.PubkeyToAddress(*public)
+
+	// Unsigned requests are sent if no account key is configured.
+	server := &testRelayServer{
+		blob:      rand.Bytes(1024),
+		frameSize: 512,
+		streaming: true,
+	}
+	client := startTestRelay(t, server)
+	_, err = client.GetBlob(context.Background(), 0, corev2.BlobKey{})
+	require.NoError(t, err)
+	signer, ok := server.signers.Load("StreamBlob")
+	require.True(t, ok)
+	require.Equal(t, gethcommon.Address{}, signer)
+
+	// Both StreamBlob and GetBlob requests are signed with the account key.
+	server = &testRelayServer{
+		blob: rand.Bytes(1024),
+	}
+	client = startTestRelayWithConfig(t, server, &RelayClientConfig{
+		MaxGRPCMessageSize: 1024 * 1024,
+		AccountKey:         private,
+	})
+	_, err = client.GetBlob(context.Background(), 0, corev2.BlobKey{})
+	require.NoError(t, err)
+	signer, ok = server.signers.Load("StreamBlob")
+	require.True(t, ok)
+	require.Equal(t, account, signer)
+	signer, ok = server.signers.Load("GetBlob")
+	require.True(t, ok)
+	require.Equal(t, account, signer)
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/core"
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/Layr-Labs/eigenda/encoding"
@@ -21,6 +22,16 @@ type ValidatorClient interface {
 		blobHeader *corev2.BlobHeaderWithHashedPayment,
 		referenceBlockNumber uint64,
 	) ([]byte, error)
+
+	// GetBlobWithOperatorState is identical to GetBlob, except that the operator state and blob parameters are
+	// provided by the caller instead of being read from the chain. The operator state must contain the sockets
+	// of the operators (see core.ChainState.GetOperatorStateWithSocket).
+	GetBlobWithOperatorState(
+		ctx context.Context,
+		blobHeader *corev2.BlobHeaderWithHashedPayment,
+		operatorState *core.OperatorState,
+		blobParams *core.BlobVersionParameters,
+	) ([]byte, error)
 }
 
 type validatorClient struct {
@@ -93,13 +104,6 @@ func (c *validatorClient) GetBlob(
 	probe := c.metrics.newGetBlobProbe()
 	defer probe.End()
 
-	probe.SetStage("verify_commitment")
-	commitmentBatch := []encoding.BlobCommitments{blobHeader.BlobCommitments}
-	err := c.verifier.VerifyCommitEquivalenceBatch(commitmentBatch)
-	if err != nil {
-		return nil, err
-	}
-
 	probe.SetStage("get_operator_state")
 	operatorState, err := c.chainState.GetOperatorStateWithSocket(
 		ctx,
@@ -120,6 +124,38 @@ func (c *validatorClient) GetBlob(
 		return nil, fmt.Errorf("invalid blob version %d", blobHeader.BlobVersion)
 	}
 
+	return c.getBlob(ctx, blobHeader, operatorState, blobParams, probe)
+}
+
+func (c *validatorClient) GetBlobWithOperatorState(
+	ctx context.Context,
+	blobHeader *corev2.BlobHeaderWithHashedPayment,
+	operatorState *core.OperatorState,
+	blobParams *core.BlobVersionParameters,
+) ([]byte, error) {
+
+	probe := c.metrics.newGetBlobProbe()
+	defer probe.End()
+
+	return c.getBlob(ctx, blobHeader, operatorState, blobParams, probe)
+}
+
+// getBlob downloads chunks of a blob from the operators in the given operator state and reconstructs the blob.
+func (c *validatorClient) getBlob(
+	ctx context.Context,
+	blobHeader *corev2.BlobHeaderWithHashedPayment,
+	operatorState *core.OperatorState,
+	blobParams *core.BlobVersionParameters,
+	probe *common.SequenceProbe,
+) ([]byte, error) {
+
+	probe.SetStage("verify_commitment")
+	commitmentBatch := []encoding.BlobCommitments{blobHeader.BlobCommitments}
+	err := c.verifier.VerifyCommitEquivalenceBatch(commitmentBatch)
+	if err != nil {
+		return nil, err
+	}
+
 	probe.SetStage("get_encoding_params")
 	encodingParams, err := corev2.GetEncodingParams(blobHeader.BlobCommitments.Length, blobParams)
 	if err != nil {
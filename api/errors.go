@@ -1,6 +1,7 @@
 package api
 
 import (
+	"errors"
 	"fmt"
 
 	"google.golang.org/grpc/codes"
@@ -58,6 +59,33 @@ func NewErrorDeadlineExceeded(msg string) error {
 	return newErrorGRPC(codes.DeadlineExceeded, msg)
 }
 
+// HTTP Mapping: 503 Service Unavailable
+func NewErrorUnavailable(msg string) error {
+	return newErrorGRPC(codes.Unavailable, msg)
+}
+
+// The message of the error returned by a validator that is in maintenance mode.
+const validatorMaintenanceMsg = "validator is in maintenance mode"
+
+// NewErrorValidatorMaintenance returns the error a validator in maintenance mode returns for StoreChunks requests.
+// The request may be retried once the validator leaves maintenance mode, so an UNAVAILABLE status is used. Callers
+// can distinguish it from other UNAVAILABLE errors (including those generated by the grpc framework itself) with
+// IsErrorValidatorMaintenance.
+func NewErrorValidatorMaintenance() error {
+	return NewErrorUnavailable(validatorMaintenanceMsg)
+}
+
+// IsErrorValidatorMaintenance returns true if the error, or any error it wraps, was created by
+// NewErrorValidatorMaintenance.
+func IsErrorValidatorMaintenance(err error) bool {
+	var grpcErr interface{ GRPCStatus() *status.Status }
+	if !errors.As(err, &grpcErr) {
+		return false
+	}
+	s := grpcErr.GRPCStatus()
+	return s.Code() == codes.Unavailable && s.Message() == validatorMaintenanceMsg
+}
+
 func NewErrorCanceled(msg string) error {
 	return newErrorGRPC(codes.Canceled, msg)
 }
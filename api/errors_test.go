@@ -44,3 +44,26 @@ func TestErrorFailoverZeroValue(t *testing.T) {
 		t.Error("should return 'Failover' for zero value")
 	}
 }
+
+func TestIsErrorValidatorMaintenance(t *testing.T) {
+	maintenanceErr := NewErrorValidatorMaintenance()
+	if !IsErrorValidatorMaintenance(maintenanceErr) {
+		t.Error("should match maintenance error")
+	}
+
+	if !IsErrorValidatorMaintenance(fmt.Errorf("failed to store chunks: %w", maintenanceErr)) {
+		t.Error("should match maintenance error even when wrapped")
+	}
+
+	if IsErrorValidatorMaintenance(NewErrorUnavailable("connection refused")) {
+		t.Error("should not match other unavailable errors")
+	}
+
+	if IsErrorValidatorMaintenance(fmt.Errorf("base error")) {
+		t.Error("should not match non-grpc errors")
+	}
+
+	if IsErrorValidatorMaintenance(nil) {
+		t.Error("should not match nil")
+	}
+}
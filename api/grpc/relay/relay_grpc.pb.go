@@ -19,8 +19,9 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	Relay_GetBlob_FullMethodName   = "/relay.Relay/GetBlob"
-	Relay_GetChunks_FullMethodName = "/relay.Relay/GetChunks"
+	Relay_GetBlob_FullMethodName    = "/relay.Relay/GetBlob"
+	Relay_GetChunks_FullMethodName  = "/relay.Relay/GetChunks"
+	Relay_StreamBlob_FullMethodName = "/relay.Relay/StreamBlob"
 )
 
 // RelayClient is the client API for Relay service.
@@ -31,6 +32,9 @@ type RelayClient interface {
 	GetBlob(ctx context.Context, in *GetBlobRequest, opts ...grpc.CallOption) (*GetBlobReply, error)
 	// GetChunks retrieves chunks from blobs stored by the relay.
 	GetChunks(ctx context.Context, in *GetChunksRequest, opts ...grpc.CallOption) (*GetChunksReply, error)
+	// StreamBlob retrieves a blob stored by the relay as a sequence of frames. Unlike GetBlob, the size of each
+	// message is bounded regardless of the size of the blob, and an interrupted stream can be resumed from an offset.
+	StreamBlob(ctx context.Context, in *StreamBlobRequest, opts ...grpc.CallOption) (Relay_StreamBlobClient, error)
 }
 
 type relayClient struct {
@@ -59,6 +63,38 @@ func (c *relayClient) GetChunks(ctx context.Context, in *GetChunksRequest, opts
 	return out, nil
 }
 
+func (c *relayClient) StreamBlob(ctx context.Context, in *StreamBlobRequest, opts ...grpc.CallOption) (Relay_StreamBlobClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Relay_ServiceDesc.Streams[0], Relay_StreamBlob_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &relayStreamBlobClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Relay_StreamBlobClient interface {
+	Recv() (*StreamBlobReply, error)
+	grpc.ClientStream
+}
+
+type relayStreamBlobClient struct {
+	grpc.ClientStream
+}
+
+func (x *relayStreamBlobClient) Recv() (*StreamBlobReply, error) {
+	m := new(StreamBlobReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // RelayServer is the server API for Relay service.
 // All implementations must embed UnimplementedRelayServer
 // for forward compatibility
@@ -67,6 +103,9 @@ type RelayServer interface {
 	GetBlob(context.Context, *GetBlobRequest) (*GetBlobReply, error)
 	// GetChunks retrieves chunks from blobs stored by the relay.
 	GetChunks(context.Context, *GetChunksRequest) (*GetChunksReply, error)
+	// StreamBlob retrieves a blob stored by the relay as a sequence of frames. Unlike GetBlob, the size of each
+	// message is bounded regardless of the size of the blob, and an interrupted stream can be resumed from an offset.
+	StreamBlob(*StreamBlobRequest, Relay_StreamBlobServer) error
 	mustEmbedUnimplementedRelayServer()
 }
 
@@ -80,6 +119,9 @@ func (UnimplementedRelayServer) GetBlob(context.Context, *GetBlobRequest) (*GetB
 func (UnimplementedRelayServer) GetChunks(context.Context, *GetChunksRequest) (*GetChunksReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetChunks not implemented")
 }
+func (UnimplementedRelayServer) StreamBlob(*StreamBlobRequest, Relay_StreamBlobServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamBlob not implemented")
+}
 func (UnimplementedRelayServer) mustEmbedUnimplementedRelayServer() {}
 
 // UnsafeRelayServer may be embedded to opt out of forward compatibility for this service.
@@ -129,6 +171,27 @@ func _Relay_GetChunks_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Relay_StreamBlob_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamBlobRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RelayServer).StreamBlob(m, &relayStreamBlobServer{stream})
+}
+
+type Relay_StreamBlobServer interface {
+	Send(*StreamBlobReply) error
+	grpc.ServerStream
+}
+
+type relayStreamBlobServer struct {
+	grpc.ServerStream
+}
+
+func (x *relayStreamBlobServer) Send(m *StreamBlobReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // Relay_ServiceDesc is the grpc.ServiceDesc for Relay service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -145,6 +208,12 @@ var Relay_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _Relay_GetChunks_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamBlob",
+			Handler:       _Relay_StreamBlob_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "relay/relay.proto",
 }
@@ -266,6 +266,45 @@ type GetChunksRequest struct {
 	// quorums and the chunks for different quorums at a Node can be different).
 	// The ID must be in range [0, 254].
 	QuorumId uint32 `protobuf:"varint,2,opt,name=quorum_id,json=quorumId,proto3" json:"quorum_id,omitempty"`
+	// Optionally selects a subset of the chunks to retrieve. If not set, all chunks the Node is storing for the blob
+	// are returned. Chunks are identified by their global chunk indices, as in the relay API. The request fails with
+	// an InvalidArgument error if any of the selected chunks are not assigned to the Node.
+	//
+	// Types that are assignable to ChunkRequest:
+	//
+	//	*GetChunksRequest_ByIndex
+	//	*GetChunksRequest_ByRange
+	ChunkRequest isGetChunksRequest_ChunkRequest `protobuf_oneof:"chunk_request"`
+	// Optional. Timestamp of the request in seconds since the Unix epoch. Only used for signed requests. If too far
+	// out of sync with the server's clock, the request is rejected.
+	Timestamp uint32 `protobuf:"varint,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Optional. Signature over the keccak hash of the request, using any ECDSA key the requester controls. Requests
+	// signed by a key that the Node's operator has configured as an authenticated requester are rate limited per
+	// signing key using the Node's quota for authenticated requesters. All other requests, including requests signed
+	// by other keys, are rate limited per client IP address using the Node's quota for anonymous requesters.
+	//
+	// Algorithm for computing the hash is as follows. All integer values are serialized in big-endian order (unsigned).
+	// A reference implementation (golang) can be found at
+	// https://github.com/Layr-Labs/eigenda/blob/master/api/hashing/node_hashing.go
+	//
+	// 1. digest the domain string "validator.GetChunksRequest"
+	// 2. digest len(blob_key) (4 bytes, unsigned big endian)
+	// 3. digest blob_key
+	// 4. digest quorum_id (4 bytes, unsigned big endian)
+	// 5. if by_index is set:
+	//   a. digest the character 'i' (1 byte)
+	//   b. digest len(by_index.chunk_indices) (4 bytes, unsigned big endian)
+	//   c. for each index in by_index.chunk_indices:
+	//     i. digest index (4 bytes, unsigned big endian)
+	// 6. if by_range is set:
+	//   a. digest the character 'r' (1 byte)
+	//   b. digest by_range.start_index (4 bytes, unsigned big endian)
+	//   c. digest by_range.end_index (4 bytes, unsigned big endian)
+	// 7. if neither by_index nor by_range is set, digest the character 'a' (1 byte)
+	// 8. digest timestamp (4 bytes, unsigned big endian)
+	//
+	// Note that this signature is not included in the hash for obvious reasons.
+	Signature []byte `protobuf:"bytes,6,opt,name=signature,proto3" json:"signature,omitempty"`
 }
 
 func (x *GetChunksRequest) Reset() {
@@ -314,13 +353,176 @@ func (x *GetChunksRequest) GetQuorumId() uint32 {
 	return 0
 }
 
+func (m *GetChunksRequest) GetChunkRequest() isGetChunksRequest_ChunkRequest {
+	if m != nil {
+		return m.ChunkRequest
+	}
+	return nil
+}
+
+func (x *GetChunksRequest) GetByIndex() *ChunkRequestByIndex {
+	if x, ok := x.GetChunkRequest().(*GetChunksRequest_ByIndex); ok {
+		return x.ByIndex
+	}
+	return nil
+}
+
+func (x *GetChunksRequest) GetByRange() *ChunkRequestByRange {
+	if x, ok := x.GetChunkRequest().(*GetChunksRequest_ByRange); ok {
+		return x.ByRange
+	}
+	return nil
+}
+
+func (x *GetChunksRequest) GetTimestamp() uint32 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *GetChunksRequest) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+type isGetChunksRequest_ChunkRequest interface {
+	isGetChunksRequest_ChunkRequest()
+}
+
+type GetChunksRequest_ByIndex struct {
+	// Request chunks by their individual indices.
+	ByIndex *ChunkRequestByIndex `protobuf:"bytes,3,opt,name=by_index,json=byIndex,proto3,oneof"`
+}
+
+type GetChunksRequest_ByRange struct {
+	// Request chunks by a range of indices.
+	ByRange *ChunkRequestByRange `protobuf:"bytes,4,opt,name=by_range,json=byRange,proto3,oneof"`
+}
+
+func (*GetChunksRequest_ByIndex) isGetChunksRequest_ChunkRequest() {}
+
+func (*GetChunksRequest_ByRange) isGetChunksRequest_ChunkRequest() {}
+
+// A request for specific chunks of a blob, identified by their global chunk indices (the same indices used by the
+// relay API). Only chunks assigned to the Node may be requested.
+type ChunkRequestByIndex struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The indices of the requested chunks. Chunks are returned in the same order as their indices.
+	ChunkIndices []uint32 `protobuf:"varint,1,rep,packed,name=chunk_indices,json=chunkIndices,proto3" json:"chunk_indices,omitempty"`
+}
+
+func (x *ChunkRequestByIndex) Reset() {
+	*x = ChunkRequestByIndex{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_validator_node_v2_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChunkRequestByIndex) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChunkRequestByIndex) ProtoMessage() {}
+
+func (x *ChunkRequestByIndex) ProtoReflect() protoreflect.Message {
+	mi := &file_validator_node_v2_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChunkRequestByIndex.ProtoReflect.Descriptor instead.
+func (*ChunkRequestByIndex) Descriptor() ([]byte, []int) {
+	return file_validator_node_v2_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ChunkRequestByIndex) GetChunkIndices() []uint32 {
+	if x != nil {
+		return x.ChunkIndices
+	}
+	return nil
+}
+
+// A request for a contiguous range of chunks of a blob, identified by their global chunk indices. Every chunk in the
+// range must be assigned to the Node.
+type ChunkRequestByRange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The first index to start fetching chunks from.
+	StartIndex uint32 `protobuf:"varint,1,opt,name=start_index,json=startIndex,proto3" json:"start_index,omitempty"`
+	// One past the last index to fetch chunks from. Similar semantics to golang slices.
+	EndIndex uint32 `protobuf:"varint,2,opt,name=end_index,json=endIndex,proto3" json:"end_index,omitempty"`
+}
+
+func (x *ChunkRequestByRange) Reset() {
+	*x = ChunkRequestByRange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_validator_node_v2_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChunkRequestByRange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChunkRequestByRange) ProtoMessage() {}
+
+func (x *ChunkRequestByRange) ProtoReflect() protoreflect.Message {
+	mi := &file_validator_node_v2_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChunkRequestByRange.ProtoReflect.Descriptor instead.
+func (*ChunkRequestByRange) Descriptor() ([]byte, []int) {
+	return file_validator_node_v2_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ChunkRequestByRange) GetStartIndex() uint32 {
+	if x != nil {
+		return x.StartIndex
+	}
+	return 0
+}
+
+func (x *ChunkRequestByRange) GetEndIndex() uint32 {
+	if x != nil {
+		return x.EndIndex
+	}
+	return 0
+}
+
 // The response to the GetChunks() RPC.
 type GetChunksReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// All chunks the Node is storing for the requested blob per GetChunksRequest.
+	// The chunks the Node is storing for the requested blob per GetChunksRequest. If the request selected a subset
+	// of the chunks, then the chunks are returned in the order they were requested.
 	Chunks [][]byte `protobuf:"bytes,1,rep,name=chunks,proto3" json:"chunks,omitempty"`
 	// The format how the above chunks are encoded.
 	ChunkEncodingFormat ChunkEncodingFormat `protobuf:"varint,2,opt,name=chunk_encoding_format,json=chunkEncodingFormat,proto3,enum=validator.ChunkEncodingFormat" json:"chunk_encoding_format,omitempty"`
@@ -329,7 +531,7 @@ type GetChunksReply struct {
 func (x *GetChunksReply) Reset() {
 	*x = GetChunksReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_validator_node_v2_proto_msgTypes[3]
+		mi := &file_validator_node_v2_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -342,7 +544,7 @@ func (x *GetChunksReply) String() string {
 func (*GetChunksReply) ProtoMessage() {}
 
 func (x *GetChunksReply) ProtoReflect() protoreflect.Message {
-	mi := &file_validator_node_v2_proto_msgTypes[3]
+	mi := &file_validator_node_v2_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -355,7 +557,7 @@ func (x *GetChunksReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetChunksReply.ProtoReflect.Descriptor instead.
 func (*GetChunksReply) Descriptor() ([]byte, []int) {
-	return file_validator_node_v2_proto_rawDescGZIP(), []int{3}
+	return file_validator_node_v2_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *GetChunksReply) GetChunks() [][]byte {
@@ -382,7 +584,7 @@ type GetNodeInfoRequest struct {
 func (x *GetNodeInfoRequest) Reset() {
 	*x = GetNodeInfoRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_validator_node_v2_proto_msgTypes[4]
+		mi := &file_validator_node_v2_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -395,7 +597,7 @@ func (x *GetNodeInfoRequest) String() string {
 func (*GetNodeInfoRequest) ProtoMessage() {}
 
 func (x *GetNodeInfoRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_validator_node_v2_proto_msgTypes[4]
+	mi := &file_validator_node_v2_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -408,7 +610,7 @@ func (x *GetNodeInfoRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetNodeInfoRequest.ProtoReflect.Descriptor instead.
 func (*GetNodeInfoRequest) Descriptor() ([]byte, []int) {
-	return file_validator_node_v2_proto_rawDescGZIP(), []int{4}
+	return file_validator_node_v2_proto_rawDescGZIP(), []int{6}
 }
 
 // Node info reply
@@ -427,12 +629,15 @@ type GetNodeInfoReply struct {
 	NumCpu uint32 `protobuf:"varint,4,opt,name=num_cpu,json=numCpu,proto3" json:"num_cpu,omitempty"`
 	// The amount of memory on the node in bytes.
 	MemBytes uint64 `protobuf:"varint,5,opt,name=mem_bytes,json=memBytes,proto3" json:"mem_bytes,omitempty"`
+	// True if the node is in maintenance mode. A node in maintenance mode rejects new StoreChunks requests, but
+	// continues to serve GetChunks requests.
+	Maintenance bool `protobuf:"varint,6,opt,name=maintenance,proto3" json:"maintenance,omitempty"`
 }
 
 func (x *GetNodeInfoReply) Reset() {
 	*x = GetNodeInfoReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_validator_node_v2_proto_msgTypes[5]
+		mi := &file_validator_node_v2_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -445,7 +650,7 @@ func (x *GetNodeInfoReply) String() string {
 func (*GetNodeInfoReply) ProtoMessage() {}
 
 func (x *GetNodeInfoReply) ProtoReflect() protoreflect.Message {
-	mi := &file_validator_node_v2_proto_msgTypes[5]
+	mi := &file_validator_node_v2_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -458,7 +663,7 @@ func (x *GetNodeInfoReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetNodeInfoReply.ProtoReflect.Descriptor instead.
 func (*GetNodeInfoReply) Descriptor() ([]byte, []int) {
-	return file_validator_node_v2_proto_rawDescGZIP(), []int{5}
+	return file_validator_node_v2_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *GetNodeInfoReply) GetSemver() string {
@@ -496,6 +701,263 @@ func (x *GetNodeInfoReply) GetMemBytes() uint64 {
 	return 0
 }
 
+func (x *GetNodeInfoReply) GetMaintenance() bool {
+	if x != nil {
+		return x.Maintenance
+	}
+	return false
+}
+
+// The parameter for the GetSigningHistory() RPC.
+type GetSigningHistoryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Only records of StoreChunks requests received at or after this time are returned. In nanoseconds since the
+	// Unix epoch.
+	StartTime uint64 `protobuf:"varint,1,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	// Only records of StoreChunks requests received before this time are returned. In nanoseconds since the Unix
+	// epoch. If zero, there is no upper bound.
+	EndTime uint64 `protobuf:"varint,2,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// If not empty, only records of requests to sign the batch with this batch header hash are returned.
+	BatchHeaderHash []byte `protobuf:"bytes,3,opt,name=batch_header_hash,json=batchHeaderHash,proto3" json:"batch_header_hash,omitempty"`
+	// The maximum number of records to return. If zero, or larger than the node's limit, the node's limit is used.
+	Limit uint32 `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *GetSigningHistoryRequest) Reset() {
+	*x = GetSigningHistoryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_validator_node_v2_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSigningHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSigningHistoryRequest) ProtoMessage() {}
+
+func (x *GetSigningHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_validator_node_v2_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSigningHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetSigningHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_validator_node_v2_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetSigningHistoryRequest) GetStartTime() uint64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
+
+func (x *GetSigningHistoryRequest) GetEndTime() uint64 {
+	if x != nil {
+		return x.EndTime
+	}
+	return 0
+}
+
+func (x *GetSigningHistoryRequest) GetBatchHeaderHash() []byte {
+	if x != nil {
+		return x.BatchHeaderHash
+	}
+	return nil
+}
+
+func (x *GetSigningHistoryRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// A record of a StoreChunks request handled by the node.
+type SigningRecord struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The time when the StoreChunks request was received, in nanoseconds since the Unix epoch.
+	Timestamp uint64 `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// The hash of the header of the batch the node was asked to sign. Empty if the request was too malformed for
+	// the batch header hash to be computed.
+	BatchHeaderHash []byte `protobuf:"bytes,2,opt,name=batch_header_hash,json=batchHeaderHash,proto3" json:"batch_header_hash,omitempty"`
+	// The reference block number of the batch.
+	ReferenceBlockNumber uint64 `protobuf:"varint,3,opt,name=reference_block_number,json=referenceBlockNumber,proto3" json:"reference_block_number,omitempty"`
+	// The keys of the blobs in the batch.
+	BlobKeys [][]byte `protobuf:"bytes,4,rep,name=blob_keys,json=blobKeys,proto3" json:"blob_keys,omitempty"`
+	// The time the node spent handling the request, in nanoseconds.
+	Latency uint64 `protobuf:"varint,5,opt,name=latency,proto3" json:"latency,omitempty"`
+	// True if the node signed the batch.
+	Signed bool `protobuf:"varint,6,opt,name=signed,proto3" json:"signed,omitempty"`
+	// If the node did not sign the batch, the reason why.
+	Error string `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	// The ID of the disperser that sent the request.
+	DisperserId uint32 `protobuf:"varint,8,opt,name=disperser_id,json=disperserId,proto3" json:"disperser_id,omitempty"`
+}
+
+func (x *SigningRecord) Reset() {
+	*x = SigningRecord{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_validator_node_v2_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SigningRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SigningRecord) ProtoMessage() {}
+
+func (x *SigningRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_validator_node_v2_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SigningRecord.ProtoReflect.Descriptor instead.
+func (*SigningRecord) Descriptor() ([]byte, []int) {
+	return file_validator_node_v2_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SigningRecord) GetTimestamp() uint64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *SigningRecord) GetBatchHeaderHash() []byte {
+	if x != nil {
+		return x.BatchHeaderHash
+	}
+	return nil
+}
+
+func (x *SigningRecord) GetReferenceBlockNumber() uint64 {
+	if x != nil {
+		return x.ReferenceBlockNumber
+	}
+	return 0
+}
+
+func (x *SigningRecord) GetBlobKeys() [][]byte {
+	if x != nil {
+		return x.BlobKeys
+	}
+	return nil
+}
+
+func (x *SigningRecord) GetLatency() uint64 {
+	if x != nil {
+		return x.Latency
+	}
+	return 0
+}
+
+func (x *SigningRecord) GetSigned() bool {
+	if x != nil {
+		return x.Signed
+	}
+	return false
+}
+
+func (x *SigningRecord) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *SigningRecord) GetDisperserId() uint32 {
+	if x != nil {
+		return x.DisperserId
+	}
+	return 0
+}
+
+// The reply to the GetSigningHistory() RPC.
+type GetSigningHistoryReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The matching records, in the order the requests were received.
+	Records []*SigningRecord `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
+	// True if more records matched the query than were returned. The remaining records can be fetched by querying
+	// again with start_time set to one more than the timestamp of the last returned record.
+	Truncated bool `protobuf:"varint,2,opt,name=truncated,proto3" json:"truncated,omitempty"`
+}
+
+func (x *GetSigningHistoryReply) Reset() {
+	*x = GetSigningHistoryReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_validator_node_v2_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSigningHistoryReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSigningHistoryReply) ProtoMessage() {}
+
+func (x *GetSigningHistoryReply) ProtoReflect() protoreflect.Message {
+	mi := &file_validator_node_v2_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSigningHistoryReply.ProtoReflect.Descriptor instead.
+func (*GetSigningHistoryReply) Descriptor() ([]byte, []int) {
+	return file_validator_node_v2_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetSigningHistoryReply) GetRecords() []*SigningRecord {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+func (x *GetSigningHistoryReply) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
 var File_validator_node_v2_proto protoreflect.FileDescriptor
 
 var file_validator_node_v2_proto_rawDesc = []byte{
@@ -515,57 +977,121 @@ var file_validator_node_v2_proto_rawDesc = []byte{
 	0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0x30, 0x0a, 0x10,
 	0x53, 0x74, 0x6f, 0x72, 0x65, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79,
 	0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0x4a,
-	0x0a, 0x10, 0x47, 0x65, 0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x6c, 0x6f, 0x62, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x62, 0x6c, 0x6f, 0x62, 0x4b, 0x65, 0x79, 0x12, 0x1b, 0x0a,
-	0x09, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d,
-	0x52, 0x08, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x49, 0x64, 0x22, 0x7c, 0x0a, 0x0e, 0x47, 0x65,
-	0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x16, 0x0a, 0x06,
-	0x63, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x06, 0x63, 0x68,
-	0x75, 0x6e, 0x6b, 0x73, 0x12, 0x52, 0x0a, 0x15, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x5f, 0x65, 0x6e,
-	0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x2e,
-	0x43, 0x68, 0x75, 0x6e, 0x6b, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x46, 0x6f, 0x72,
-	0x6d, 0x61, 0x74, 0x52, 0x13, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x69,
-	0x6e, 0x67, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x22, 0x14, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x4e,
-	0x6f, 0x64, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x84,
-	0x01, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65,
-	0x70, 0x6c, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x6d, 0x76, 0x65, 0x72, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x6d, 0x76, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x61,
-	0x72, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x61, 0x72, 0x63, 0x68, 0x12,
-	0x0e, 0x0a, 0x02, 0x6f, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x6f, 0x73, 0x12,
-	0x17, 0x0a, 0x07, 0x6e, 0x75, 0x6d, 0x5f, 0x63, 0x70, 0x75, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d,
-	0x52, 0x06, 0x6e, 0x75, 0x6d, 0x43, 0x70, 0x75, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x65, 0x6d, 0x5f,
-	0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x6d, 0x65, 0x6d,
-	0x42, 0x79, 0x74, 0x65, 0x73, 0x2a, 0x2d, 0x0a, 0x13, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x45, 0x6e,
-	0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x0b, 0x0a, 0x07,
-	0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x47, 0x4e, 0x41,
-	0x52, 0x4b, 0x10, 0x01, 0x32, 0xa5, 0x01, 0x0a, 0x09, 0x44, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73,
-	0x61, 0x6c, 0x12, 0x4b, 0x0a, 0x0b, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x43, 0x68, 0x75, 0x6e, 0x6b,
-	0x73, 0x12, 0x1d, 0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x53, 0x74,
-	0x6f, 0x72, 0x65, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x1b, 0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x53, 0x74, 0x6f,
-	0x72, 0x65, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12,
-	0x4b, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1d,
-	0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x4e, 0x6f,
-	0x64, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e,
-	0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x4e, 0x6f, 0x64,
-	0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x32, 0x9f, 0x01, 0x0a,
-	0x09, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x61, 0x6c, 0x12, 0x45, 0x0a, 0x09, 0x47, 0x65,
-	0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x12, 0x1b, 0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61,
-	0x74, 0x6f, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72,
-	0x2e, 0x47, 0x65, 0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
-	0x00, 0x12, 0x4b, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x6e, 0x66, 0x6f,
-	0x12, 0x1d, 0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x47, 0x65, 0x74,
-	0x4e, 0x6f, 0x64, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x1b, 0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x4e,
-	0x6f, 0x64, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x42, 0x31,
-	0x5a, 0x2f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x4c, 0x61, 0x79,
-	0x72, 0x2d, 0x4c, 0x61, 0x62, 0x73, 0x2f, 0x65, 0x69, 0x67, 0x65, 0x6e, 0x64, 0x61, 0x2f, 0x61,
-	0x70, 0x69, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f,
-	0x72, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0x91,
+	0x02, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x6c, 0x6f, 0x62, 0x5f, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x62, 0x6c, 0x6f, 0x62, 0x4b, 0x65, 0x79, 0x12, 0x1b,
+	0x0a, 0x09, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x08, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x49, 0x64, 0x12, 0x3b, 0x0a, 0x08, 0x62,
+	0x79, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e,
+	0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x42, 0x79, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x48, 0x00, 0x52,
+	0x07, 0x62, 0x79, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x3b, 0x0a, 0x08, 0x62, 0x79, 0x5f, 0x72,
+	0x61, 0x6e, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x76, 0x61, 0x6c,
+	0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x42, 0x79, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x48, 0x00, 0x52, 0x07, 0x62, 0x79,
+	0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x42, 0x0f, 0x0a, 0x0d, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x22, 0x3a, 0x0a, 0x13, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x42, 0x79, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x68, 0x75,
+	0x6e, 0x6b, 0x5f, 0x69, 0x6e, 0x64, 0x69, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0d,
+	0x52, 0x0c, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x49, 0x6e, 0x64, 0x69, 0x63, 0x65, 0x73, 0x22, 0x53,
+	0x0a, 0x13, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x42, 0x79,
+	0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x69,
+	0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x6e, 0x64, 0x5f, 0x69, 0x6e,
+	0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x65, 0x6e, 0x64, 0x49, 0x6e,
+	0x64, 0x65, 0x78, 0x22, 0x7c, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x73,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x06, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x12, 0x52, 0x0a,
+	0x15, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x5f, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x5f,
+	0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x76,
+	0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x45, 0x6e,
+	0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x52, 0x13, 0x63, 0x68,
+	0x75, 0x6e, 0x6b, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x46, 0x6f, 0x72, 0x6d, 0x61,
+	0x74, 0x22, 0x14, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x6e, 0x66, 0x6f,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xa6, 0x01, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x4e,
+	0x6f, 0x64, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x16, 0x0a, 0x06,
+	0x73, 0x65, 0x6d, 0x76, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65,
+	0x6d, 0x76, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x72, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x61, 0x72, 0x63, 0x68, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x6f, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x6e, 0x75, 0x6d, 0x5f,
+	0x63, 0x70, 0x75, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x43, 0x70,
+	0x75, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x65, 0x6d, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x6d, 0x65, 0x6d, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x20,
+	0x0a, 0x0b, 0x6d, 0x61, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0b, 0x6d, 0x61, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65,
+	0x22, 0x96, 0x01, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x53, 0x69, 0x67, 0x6e, 0x69, 0x6e, 0x67, 0x48,
+	0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a,
+	0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x19, 0x0a, 0x08,
+	0x65, 0x6e, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07,
+	0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x2a, 0x0a, 0x11, 0x62, 0x61, 0x74, 0x63, 0x68,
+	0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x0f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x48,
+	0x61, 0x73, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x97, 0x02, 0x0a, 0x0d, 0x53, 0x69,
+	0x67, 0x6e, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09,
+	0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x2a, 0x0a, 0x11, 0x62, 0x61, 0x74,
+	0x63, 0x68, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x48, 0x65, 0x61, 0x64, 0x65,
+	0x72, 0x48, 0x61, 0x73, 0x68, 0x12, 0x34, 0x0a, 0x16, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e,
+	0x63, 0x65, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x14, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65,
+	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x62,
+	0x6c, 0x6f, 0x62, 0x5f, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x08,
+	0x62, 0x6c, 0x6f, 0x62, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6c, 0x61, 0x74, 0x65,
+	0x6e, 0x63, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x6c, 0x61, 0x74, 0x65, 0x6e,
+	0x63, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x06, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72,
+	0x72, 0x6f, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72,
+	0x12, 0x21, 0x0a, 0x0c, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65,
+	0x72, 0x49, 0x64, 0x22, 0x6a, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x53, 0x69, 0x67, 0x6e, 0x69, 0x6e,
+	0x67, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x32, 0x0a,
+	0x07, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18,
+	0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x69,
+	0x6e, 0x67, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x07, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x73, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x74, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x64, 0x2a,
+	0x2d, 0x0a, 0x13, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67,
+	0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57,
+	0x4e, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x47, 0x4e, 0x41, 0x52, 0x4b, 0x10, 0x01, 0x32, 0xa5,
+	0x01, 0x0a, 0x09, 0x44, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x61, 0x6c, 0x12, 0x4b, 0x0a, 0x0b,
+	0x53, 0x74, 0x6f, 0x72, 0x65, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x12, 0x1d, 0x2e, 0x76, 0x61,
+	0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x43, 0x68, 0x75,
+	0x6e, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x76, 0x61, 0x6c,
+	0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x43, 0x68, 0x75, 0x6e,
+	0x6b, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x4b, 0x0a, 0x0b, 0x47, 0x65, 0x74,
+	0x4e, 0x6f, 0x64, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1d, 0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64,
+	0x61, 0x74, 0x6f, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x6e, 0x66, 0x6f,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61,
+	0x74, 0x6f, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x32, 0x9f, 0x01, 0x0a, 0x09, 0x52, 0x65, 0x74, 0x72, 0x69,
+	0x65, 0x76, 0x61, 0x6c, 0x12, 0x45, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b,
+	0x73, 0x12, 0x1b, 0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x47, 0x65,
+	0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19,
+	0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x68,
+	0x75, 0x6e, 0x6b, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x4b, 0x0a, 0x0b, 0x47,
+	0x65, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1d, 0x2e, 0x76, 0x61, 0x6c,
+	0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x6e,
+	0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x76, 0x61, 0x6c, 0x69,
+	0x64, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x6e, 0x66,
+	0x6f, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x32, 0x69, 0x0a, 0x08, 0x4f, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x6f, 0x72, 0x12, 0x5d, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x53, 0x69, 0x67, 0x6e, 0x69,
+	0x6e, 0x67, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x23, 0x2e, 0x76, 0x61, 0x6c, 0x69,
+	0x64, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x69, 0x67, 0x6e, 0x69, 0x6e, 0x67,
+	0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21,
+	0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x69,
+	0x67, 0x6e, 0x69, 0x6e, 0x67, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x22, 0x00, 0x42, 0x31, 0x5a, 0x2f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2f, 0x4c, 0x61, 0x79, 0x72, 0x2d, 0x4c, 0x61, 0x62, 0x73, 0x2f, 0x65, 0x69, 0x67, 0x65,
+	0x6e, 0x64, 0x61, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x76, 0x61, 0x6c,
+	0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -581,33 +1107,43 @@ func file_validator_node_v2_proto_rawDescGZIP() []byte {
 }
 
 var file_validator_node_v2_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_validator_node_v2_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_validator_node_v2_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
 var file_validator_node_v2_proto_goTypes = []interface{}{
-	(ChunkEncodingFormat)(0),   // 0: validator.ChunkEncodingFormat
-	(*StoreChunksRequest)(nil), // 1: validator.StoreChunksRequest
-	(*StoreChunksReply)(nil),   // 2: validator.StoreChunksReply
-	(*GetChunksRequest)(nil),   // 3: validator.GetChunksRequest
-	(*GetChunksReply)(nil),     // 4: validator.GetChunksReply
-	(*GetNodeInfoRequest)(nil), // 5: validator.GetNodeInfoRequest
-	(*GetNodeInfoReply)(nil),   // 6: validator.GetNodeInfoReply
-	(*v2.Batch)(nil),           // 7: common.v2.Batch
+	(ChunkEncodingFormat)(0),         // 0: validator.ChunkEncodingFormat
+	(*StoreChunksRequest)(nil),       // 1: validator.StoreChunksRequest
+	(*StoreChunksReply)(nil),         // 2: validator.StoreChunksReply
+	(*GetChunksRequest)(nil),         // 3: validator.GetChunksRequest
+	(*ChunkRequestByIndex)(nil),      // 4: validator.ChunkRequestByIndex
+	(*ChunkRequestByRange)(nil),      // 5: validator.ChunkRequestByRange
+	(*GetChunksReply)(nil),           // 6: validator.GetChunksReply
+	(*GetNodeInfoRequest)(nil),       // 7: validator.GetNodeInfoRequest
+	(*GetNodeInfoReply)(nil),         // 8: validator.GetNodeInfoReply
+	(*GetSigningHistoryRequest)(nil), // 9: validator.GetSigningHistoryRequest
+	(*SigningRecord)(nil),            // 10: validator.SigningRecord
+	(*GetSigningHistoryReply)(nil),   // 11: validator.GetSigningHistoryReply
+	(*v2.Batch)(nil),                 // 12: common.v2.Batch
 }
 var file_validator_node_v2_proto_depIdxs = []int32{
-	7, // 0: validator.StoreChunksRequest.batch:type_name -> common.v2.Batch
-	0, // 1: validator.GetChunksReply.chunk_encoding_format:type_name -> validator.ChunkEncodingFormat
-	1, // 2: validator.Dispersal.StoreChunks:input_type -> validator.StoreChunksRequest
-	5, // 3: validator.Dispersal.GetNodeInfo:input_type -> validator.GetNodeInfoRequest
-	3, // 4: validator.Retrieval.GetChunks:input_type -> validator.GetChunksRequest
-	5, // 5: validator.Retrieval.GetNodeInfo:input_type -> validator.GetNodeInfoRequest
-	2, // 6: validator.Dispersal.StoreChunks:output_type -> validator.StoreChunksReply
-	6, // 7: validator.Dispersal.GetNodeInfo:output_type -> validator.GetNodeInfoReply
-	4, // 8: validator.Retrieval.GetChunks:output_type -> validator.GetChunksReply
-	6, // 9: validator.Retrieval.GetNodeInfo:output_type -> validator.GetNodeInfoReply
-	6, // [6:10] is the sub-list for method output_type
-	2, // [2:6] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	12, // 0: validator.StoreChunksRequest.batch:type_name -> common.v2.Batch
+	4,  // 1: validator.GetChunksRequest.by_index:type_name -> validator.ChunkRequestByIndex
+	5,  // 2: validator.GetChunksRequest.by_range:type_name -> validator.ChunkRequestByRange
+	0,  // 3: validator.GetChunksReply.chunk_encoding_format:type_name -> validator.ChunkEncodingFormat
+	10, // 4: validator.GetSigningHistoryReply.records:type_name -> validator.SigningRecord
+	1,  // 5: validator.Dispersal.StoreChunks:input_type -> validator.StoreChunksRequest
+	7,  // 6: validator.Dispersal.GetNodeInfo:input_type -> validator.GetNodeInfoRequest
+	3,  // 7: validator.Retrieval.GetChunks:input_type -> validator.GetChunksRequest
+	7,  // 8: validator.Retrieval.GetNodeInfo:input_type -> validator.GetNodeInfoRequest
+	9,  // 9: validator.Operator.GetSigningHistory:input_type -> validator.GetSigningHistoryRequest
+	2,  // 10: validator.Dispersal.StoreChunks:output_type -> validator.StoreChunksReply
+	8,  // 11: validator.Dispersal.GetNodeInfo:output_type -> validator.GetNodeInfoReply
+	6,  // 12: validator.Retrieval.GetChunks:output_type -> validator.GetChunksReply
+	8,  // 13: validator.Retrieval.GetNodeInfo:output_type -> validator.GetNodeInfoReply
+	11, // 14: validator.Operator.GetSigningHistory:output_type -> validator.GetSigningHistoryReply
+	10, // [10:15] is the sub-list for method output_type
+	5,  // [5:10] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_validator_node_v2_proto_init() }
@@ -653,7 +1189,7 @@ func file_validator_node_v2_proto_init() {
 			}
 		}
 		file_validator_node_v2_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetChunksReply); i {
+			switch v := v.(*ChunkRequestByIndex); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -665,7 +1201,7 @@ func file_validator_node_v2_proto_init() {
 			}
 		}
 		file_validator_node_v2_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetNodeInfoRequest); i {
+			switch v := v.(*ChunkRequestByRange); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -677,6 +1213,30 @@ func file_validator_node_v2_proto_init() {
 			}
 		}
 		file_validator_node_v2_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetChunksReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_validator_node_v2_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetNodeInfoRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_validator_node_v2_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetNodeInfoReply); i {
 			case 0:
 				return &v.state
@@ -688,6 +1248,46 @@ func file_validator_node_v2_proto_init() {
 				return nil
 			}
 		}
+		file_validator_node_v2_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetSigningHistoryRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_validator_node_v2_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SigningRecord); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_validator_node_v2_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetSigningHistoryReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_validator_node_v2_proto_msgTypes[2].OneofWrappers = []interface{}{
+		(*GetChunksRequest_ByIndex)(nil),
+		(*GetChunksRequest_ByRange)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -695,9 +1295,9 @@ func file_validator_node_v2_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_validator_node_v2_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   6,
+			NumMessages:   11,
 			NumExtensions: 0,
-			NumServices:   2,
+			NumServices:   3,
 		},
 		GoTypes:           file_validator_node_v2_proto_goTypes,
 		DependencyIndexes: file_validator_node_v2_proto_depIdxs,
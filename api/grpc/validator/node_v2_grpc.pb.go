@@ -289,3 +289,97 @@ var Retrieval_ServiceDesc = grpc.ServiceDesc{
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "validator/node_v2.proto",
 }
+
+const (
+	Operator_GetSigningHistory_FullMethodName = "/validator.Operator/GetSigningHistory"
+)
+
+// OperatorClient is the client API for Operator service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type OperatorClient interface {
+	// GetSigningHistory fetches the node's record of the StoreChunks requests it has handled, including whether
+	// it signed each batch and, if not, why not.
+	GetSigningHistory(ctx context.Context, in *GetSigningHistoryRequest, opts ...grpc.CallOption) (*GetSigningHistoryReply, error)
+}
+
+type operatorClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewOperatorClient(cc grpc.ClientConnInterface) OperatorClient {
+	return &operatorClient{cc}
+}
+
+func (c *operatorClient) GetSigningHistory(ctx context.Context, in *GetSigningHistoryRequest, opts ...grpc.CallOption) (*GetSigningHistoryReply, error) {
+	out := new(GetSigningHistoryReply)
+	err := c.cc.Invoke(ctx, Operator_GetSigningHistory_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OperatorServer is the server API for Operator service.
+// All implementations must embed UnimplementedOperatorServer
+// for forward compatibility
+type OperatorServer interface {
+	// GetSigningHistory fetches the node's record of the StoreChunks requests it has handled, including whether
+	// it signed each batch and, if not, why not.
+	GetSigningHistory(context.Context, *GetSigningHistoryRequest) (*GetSigningHistoryReply, error)
+	mustEmbedUnimplementedOperatorServer()
+}
+
+// UnimplementedOperatorServer must be embedded to have forward compatible implementations.
+type UnimplementedOperatorServer struct {
+}
+
+func (UnimplementedOperatorServer) GetSigningHistory(context.Context, *GetSigningHistoryRequest) (*GetSigningHistoryReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSigningHistory not implemented")
+}
+func (UnimplementedOperatorServer) mustEmbedUnimplementedOperatorServer() {}
+
+// UnsafeOperatorServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to OperatorServer will
+// result in compilation errors.
+type UnsafeOperatorServer interface {
+	mustEmbedUnimplementedOperatorServer()
+}
+
+func RegisterOperatorServer(s grpc.ServiceRegistrar, srv OperatorServer) {
+	s.RegisterService(&Operator_ServiceDesc, srv)
+}
+
+func _Operator_GetSigningHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSigningHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OperatorServer).GetSigningHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Operator_GetSigningHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OperatorServer).GetSigningHistory(ctx, req.(*GetSigningHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Operator_ServiceDesc is the grpc.ServiceDesc for Operator service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Operator_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "validator.Operator",
+	HandlerType: (*OperatorServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetSigningHistory",
+			Handler:    _Operator_GetSigningHistory_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "validator/node_v2.proto",
+}
@@ -17,6 +17,11 @@ import (
 // different type of object that has the same hash as a StoreChunksRequest.
 const ValidatorStoreChunksRequestDomain = "validator.StoreChunksRequest"
 
+// ValidatorGetChunksRequestDomain is the domain for hashing validator GetChunksRequest messages (i.e. this string
+// is added to the digest before hashing the message). This makes it difficult for an attacker to create a
+// different type of object that has the same hash as a GetChunksRequest.
+const ValidatorGetChunksRequestDomain = "validator.GetChunksRequest"
+
 // HashStoreChunksRequest hashes the given StoreChunksRequest.
 func HashStoreChunksRequest(request *grpc.StoreChunksRequest) ([]byte, error) {
 	hasher := sha3.NewLegacyKeccak256()
@@ -43,6 +48,35 @@ func HashStoreChunksRequest(request *grpc.StoreChunksRequest) ([]byte, error) {
 	return hasher.Sum(nil), nil
 }
 
+// HashValidatorGetChunksRequest hashes the given validator GetChunksRequest.
+func HashValidatorGetChunksRequest(request *grpc.GetChunksRequest) ([]byte, error) {
+	hasher := sha3.NewLegacyKeccak256()
+
+	hasher.Write([]byte(ValidatorGetChunksRequestDomain))
+
+	err := hashByteArray(hasher, request.GetBlobKey())
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash blob key: %w", err)
+	}
+	hashUint32(hasher, request.GetQuorumId())
+	if request.GetByIndex() != nil {
+		hashChar(hasher, 'i')
+		err = hashUint32Array(hasher, request.GetByIndex().GetChunkIndices())
+		if err != nil {
+			return nil, fmt.Errorf("failed to hash ChunkIndices: %w", err)
+		}
+	} else if request.GetByRange() != nil {
+		hashChar(hasher, 'r')
+		hashUint32(hasher, request.GetByRange().GetStartIndex())
+		hashUint32(hasher, request.GetByRange().GetEndIndex())
+	} else {
+		hashChar(hasher, 'a')
+	}
+	hashUint32(hasher, request.GetTimestamp())
+
+	return hasher.Sum(nil), nil
+}
+
 func hashBlobCertificate(hasher hash.Hash, blobCertificate *common.BlobCertificate) error {
 	err := hashBlobHeader(hasher, blobCertificate.GetBlobHeader())
 	if err != nil {
@@ -54,3 +54,44 @@ func HashGetChunksRequest(request *pb.GetChunksRequest) ([]byte, error) {
 
 	return hasher.Sum(nil), nil
 }
+
+// RelayGetBlobRequestDomain is the domain for hashing GetBlobRequest messages (i.e. this string
+// is added to the digest before hashing the message). This makes it difficult for an attacker to create a
+// different type of object that has the same hash as a GetBlobRequest.
+const RelayGetBlobRequestDomain = "relay.GetBlobRequest"
+
+// RelayStreamBlobRequestDomain is the domain for hashing StreamBlobRequest messages (i.e. this string
+// is added to the digest before hashing the message). This makes it difficult for an attacker to create a
+// different type of object that has the same hash as a StreamBlobRequest.
+const RelayStreamBlobRequestDomain = "relay.StreamBlobRequest"
+
+// HashGetBlobRequest hashes the given GetBlobRequest.
+func HashGetBlobRequest(request *pb.GetBlobRequest) ([]byte, error) {
+	hasher := sha3.NewLegacyKeccak256()
+
+	hasher.Write([]byte(RelayGetBlobRequestDomain))
+
+	err := hashByteArray(hasher, request.GetBlobKey())
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash blob key: %w", err)
+	}
+	hashUint32(hasher, request.GetTimestamp())
+
+	return hasher.Sum(nil), nil
+}
+
+// HashStreamBlobRequest hashes the given StreamBlobRequest.
+func HashStreamBlobRequest(request *pb.StreamBlobRequest) ([]byte, error) {
+	hasher := sha3.NewLegacyKeccak256()
+
+	hasher.Write([]byte(RelayStreamBlobRequestDomain))
+
+	err := hashByteArray(hasher, request.GetBlobKey())
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash blob key: %w", err)
+	}
+	hashUint64(hasher, request.GetOffset())
+	hashUint32(hasher, request.GetTimestamp())
+
+	return hasher.Sum(nil), nil
+}
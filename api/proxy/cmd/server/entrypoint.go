@@ -4,12 +4,21 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/Layr-Labs/eigenda/api/proxy/common"
 	"github.com/Layr-Labs/eigenda/api/proxy/config"
 	proxy_logging "github.com/Layr-Labs/eigenda/api/proxy/logging"
 	proxy_metrics "github.com/Layr-Labs/eigenda/api/proxy/metrics"
 	"github.com/Layr-Labs/eigenda/api/proxy/server"
+	"github.com/Layr-Labs/eigenda/api/proxy/server/arbitrum"
+	"github.com/Layr-Labs/eigenda/api/proxy/server/idempotency"
+	"github.com/Layr-Labs/eigenda/api/proxy/server/jobs"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/builder"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/generated_key/memstore/memconfig"
+	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary/redis"
+	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary/s3"
+	"github.com/Layr-Labs/eigenda/common/kvstore"
+	"github.com/Layr-Labs/eigenda/common/kvstore/leveldb"
+	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/gorilla/mux"
 	"github.com/urfave/cli/v2"
 
@@ -49,7 +58,7 @@ func StartProxySvr(cliCtx *cli.Context) error {
 	ctx, ctxCancel := context.WithCancel(cliCtx.Context)
 	defer ctxCancel()
 
-	storeManager, err := builder.BuildStoreManager(
+	storeManager, closeStores, err := builder.BuildStoreManager(
 		ctx,
 		log,
 		metrics,
@@ -59,8 +68,51 @@ func StartProxySvr(cliCtx *cli.Context) error {
 	if err != nil {
 		return fmt.Errorf("build storage manager: %w", err)
 	}
+	// Deferred first, so that it runs after the servers below have stopped.
+	defer func() {
+		// Stop the async secondary writers before closing the stores they write to.
+		ctxCancel()
+		if err := closeStores(); err != nil {
+			log.Error("failed to close storage backends", "err", err)
+		}
+	}()
 
 	proxyServer := server.NewServer(cfg.ServerConfig, storeManager, log, metrics)
+	if cfg.AsyncPutConfig.Enabled {
+		jobStore, err := openJobStore(log, cfg.AsyncPutConfig)
+		if err != nil {
+			return fmt.Errorf("open async put job store: %w", err)
+		}
+		defer func() {
+			// The proxy server is stopped by then, since its defer runs first. Wait for the jobs to stop using the
+			// store before closing it.
+			proxyServer.StopAsyncPuts()
+			if err := jobStore.Shutdown(); err != nil {
+				log.Error("failed to shut down async put job store", "err", err)
+			}
+		}()
+		if err := proxyServer.EnableAsyncPuts(ctx, jobStore, cfg.AsyncPutConfig); err != nil {
+			return fmt.Errorf("enable async puts: %w", err)
+		}
+		log.Info("Enabled async puts", "dbPath", cfg.AsyncPutConfig.DBPath)
+	}
+	if cfg.IdempotencyConfig.Enabled {
+		sharedStore, err := buildIdempotencyStore(cfg)
+		if err != nil {
+			return fmt.Errorf("build idempotency store: %w", err)
+		}
+		if err := proxyServer.EnableIdempotentPuts(cfg.IdempotencyConfig, sharedStore); err != nil {
+			return fmt.Errorf("enable idempotent puts: %w", err)
+		}
+		log.Info("Enabled idempotent puts", "backend", cfg.IdempotencyConfig.Backend, "ttl", cfg.IdempotencyConfig.TTL)
+	}
+	if cfg.MultiBlobConfig.Enabled() {
+		maxBlobSizeBytes := cfg.StoreBuilderConfig.ClientConfigV2.MaxBlobSizeBytes
+		if err := proxyServer.EnableMultiBlobPayloads(cfg.MultiBlobConfig, maxBlobSizeBytes); err != nil {
+			return fmt.Errorf("enable multi-blob payloads: %w", err)
+		}
+		log.Info("Enabled multi-blob payloads", "maxBlobs", cfg.MultiBlobConfig.MaxBlobs)
+	}
 	router := mux.NewRouter()
 	proxyServer.RegisterRoutes(router)
 	if cfg.StoreBuilderConfig.MemstoreEnabled {
@@ -81,6 +133,23 @@ func StartProxySvr(cliCtx *cli.Context) error {
 		log.Info("Successfully shutdown API server")
 	}()
 
+	if cfg.ArbitrumConfig.Enabled {
+		arbitrumServer, err := arbitrum.NewServer(cfg.ArbitrumConfig, storeManager, log)
+		if err != nil {
+			return fmt.Errorf("create arbitrum DA provider server: %w", err)
+		}
+		if err := arbitrumServer.Start(); err != nil {
+			return fmt.Errorf("start arbitrum DA provider server: %w", err)
+		}
+		log.Info("Started Arbitrum DA provider server", "endpoint", arbitrumServer.Endpoint())
+
+		defer func() {
+			if err := arbitrumServer.Stop(); err != nil {
+				log.Error("failed to stop Arbitrum DA provider server", "err", err)
+			}
+		}()
+	}
+
 	if cfg.MetricsServerConfig.Enabled {
 		log.Info("Starting metrics server", "addr", cfg.MetricsServerConfig.Host, "port", cfg.MetricsServerConfig.Port)
 		svr, err := metrics.StartServer(cfg.MetricsServerConfig.Host, cfg.MetricsServerConfig.Port)
@@ -98,3 +167,34 @@ func StartProxySvr(cliCtx *cli.Context) error {
 
 	return ctxinterrupt.Wait(cliCtx.Context)
 }
+
+// openJobStore opens the LevelDB database where asynchronous dispersal jobs are persisted.
+func openJobStore(log logging.Logger, cfg jobs.Config) (kvstore.Store[[]byte], error) {
+	jobStore, err := leveldb.NewStore(log, cfg.DBPath, false, true, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create leveldb store at %s: %w", cfg.DBPath, err)
+	}
+	return jobStore, nil
+}
+
+// buildIdempotencyStore builds the secondary store through which dispersals are claimed and the results of completed
+// dispersals are shared. Returns nil if results are only kept in memory.
+func buildIdempotencyStore(cfg config.AppConfig) (common.ClaimableSecondaryStore, error) {
+	//nolint:exhaustive // the memory backend doesn't need a store
+	switch cfg.IdempotencyConfig.Backend {
+	case idempotency.RedisBackend:
+		redisStore, err := redis.NewStore(&cfg.StoreBuilderConfig.RedisConfig)
+		if err != nil {
+			return nil, fmt.Errorf("create redis store: %w", err)
+		}
+		return redisStore, nil
+	case idempotency.S3Backend:
+		s3Store, err := s3.NewStore(cfg.StoreBuilderConfig.S3Config)
+		if err != nil {
+			return nil, fmt.Errorf("create s3 store: %w", err)
+		}
+		return s3Store, nil
+	default:
+		return nil, nil
+	}
+}
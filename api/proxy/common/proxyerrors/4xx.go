@@ -21,6 +21,8 @@ func Is400(err error) bool {
 	var readRequestBodyErr ReadRequestBodyError
 	var s3KeccakKeyValueMismatchErr s3.Keccak256KeyValueMismatchError
 	return errors.Is(err, ErrProxyOversizedBlob) ||
+		errors.Is(err, ErrAsyncPutsDisabled) ||
+		errors.Is(err, ErrIdempotencyKeyReused) ||
 		errors.As(err, &parsingError) ||
 		errors.As(err, &certHexDecodingError) ||
 		errors.As(err, &invalidBackendErr) ||
@@ -51,6 +53,10 @@ func Is429(err error) bool {
 
 var (
 	ErrProxyOversizedBlob = fmt.Errorf("encoded blob is larger than max blob size")
+	// ErrAsyncPutsDisabled is returned when an asynchronous dispersal is requested, but async puts are not enabled.
+	ErrAsyncPutsDisabled = fmt.Errorf("asynchronous dispersals are not enabled")
+	// ErrIdempotencyKeyReused is returned when an Idempotency-Key header is reused for a different payload.
+	ErrIdempotencyKeyReused = fmt.Errorf("idempotency key was already used for a different payload")
 )
 
 type CertHexDecodingError struct {
@@ -3,8 +3,10 @@ package common
 import (
 	"context"
 	"strings"
+	"time"
 
 	"github.com/Layr-Labs/eigenda/api/proxy/common/types/certs"
+	core "github.com/Layr-Labs/eigenda/core/v2"
 )
 
 // BackendType ... Storage backend type
@@ -17,6 +19,7 @@ const (
 	MemstoreV2BackendType
 	S3BackendType
 	RedisBackendType
+	LittDBBackendType
 
 	UnknownBackendType
 )
@@ -35,6 +38,8 @@ func (b BackendType) String() string {
 		return "S3"
 	case RedisBackendType:
 		return "Redis"
+	case LittDBBackendType:
+		return "LittDB"
 	case UnknownBackendType:
 		fallthrough
 	default:
@@ -58,6 +63,8 @@ func StringToBackendType(s string) BackendType {
 		return S3BackendType
 	case "redis":
 		return RedisBackendType
+	case "littdb":
+		return LittDBBackendType
 	case "unknown":
 		fallthrough
 	default:
@@ -107,6 +114,13 @@ type EigenDAV2Store interface {
 	Verify(ctx context.Context, versionedCert certs.VersionedCert, opts CertVerificationOpts) error
 }
 
+// EigenDAV2Resumer is implemented by EigenDA V2 stores that can finish a dispersal started by an earlier Put, given
+// the key of the dispersed blob. This allows a dispersal that was interrupted by a restart to be completed.
+type EigenDAV2Resumer interface {
+	// Resume waits for the previously dispersed blob to be signed, and returns its serialized cert.
+	Resume(ctx context.Context, blobKey core.BlobKey) (serializedCert []byte, err error)
+}
+
 // SecondaryStore is the interface for a key-value data store that uses keccak(value) as the key.
 // It is used for Optimism altda keccak commitments, as well as for caching EigenDAStore entries.
 type SecondaryStore interface {
@@ -118,3 +132,14 @@ type SecondaryStore interface {
 	// Verify verifies the given key-value pair.
 	Verify(ctx context.Context, key []byte, value []byte) error
 }
+
+// ClaimableSecondaryStore is a SecondaryStore that supports conditional writes, which lets several proxy instances
+// agree on which of them performs some work.
+type ClaimableSecondaryStore interface {
+	SecondaryStore
+	// Claim writes the value only if the key is not already present, and returns true if it was written. The claim
+	// expires after ttl, so that a claim held by an instance that crashed is eventually released.
+	Claim(ctx context.Context, key []byte, value []byte, ttl time.Duration) (bool, error)
+	// Release deletes a claim, so that the key can be claimed again.
+	Release(ctx context.Context, key []byte) error
+}
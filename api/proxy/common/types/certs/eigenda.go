@@ -15,6 +15,13 @@ const (
 	// All future CertVersions will be against EigenDA V2 Blazar (https://docs.eigenda.xyz/releases/blazar)
 	V1VersionByte
 	V2VersionByte
+
+	// Not an EigenDA cert, but a manifest listing the certs of a payload that was split across multiple blobs.
+	// See [Manifest]. Kept far from the cert versions so that future cert versions don't collide with it.
+	//
+	// Manifests are only understood by the proxy. The EigenDACertVerifier contracts and the rollups' fault-proof
+	// and validity-proof integrations don't recognize this version byte, so a manifest can't be verified on-chain.
+	ManifestVersionByte VersionByte = 0xff
 )
 
 func ByteToVersion(b byte) (VersionByte, error) {
@@ -25,6 +32,8 @@ func ByteToVersion(b byte) (VersionByte, error) {
 		return V1VersionByte, nil
 	case byte(V2VersionByte):
 		return V2VersionByte, nil
+	case byte(ManifestVersionByte):
+		return ManifestVersionByte, nil
 	default:
 		return 0, fmt.Errorf("unknown EigenDA cert version: %d", b)
 	}
@@ -58,6 +67,8 @@ func (c VersionedCert) ToCoreCertType() (coretypes.CertificateVersion, error) {
 		return coretypes.VersionTwoCert, nil
 	case V2VersionByte:
 		return coretypes.VersionThreeCert, nil
+	case ManifestVersionByte:
+		return 0, fmt.Errorf("manifests are not certs, their children must be converted individually")
 	default:
 		return 0, fmt.Errorf("unsupported version byte %d", c.Version)
 	}
@@ -0,0 +1,85 @@
+package certs
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// MaxManifestChildren is the maximum number of blobs a payload can be split across.
+const MaxManifestChildren = 256
+
+// Manifest is the cert of a payload that was too large for a single blob. The payload is split into consecutive
+// chunks, each of which is dispersed as its own blob. The payload is the concatenation of the payloads of the
+// children, in order.
+type Manifest struct {
+	// The length of the payload, in bytes.
+	PayloadLength uint64
+	// The certs of the blobs holding each chunk of the payload. Children are never manifests themselves.
+	Children []VersionedCert
+}
+
+// manifestChild is the RLP encoding of a child of a manifest.
+type manifestChild struct {
+	Version        uint8
+	SerializedCert []byte
+}
+
+// manifestRLP is the RLP encoding of a manifest.
+type manifestRLP struct {
+	PayloadLength uint64
+	Children      []manifestChild
+}
+
+// Serialize returns the RLP encoding of the manifest.
+func (m *Manifest) Serialize() ([]byte, error) {
+	encoded := manifestRLP{
+		PayloadLength: m.PayloadLength,
+		Children:      make([]manifestChild, len(m.Children)),
+	}
+	for i, child := range m.Children {
+		encoded.Children[i] = manifestChild{
+			Version:        uint8(child.Version),
+			SerializedCert: child.SerializedCert,
+		}
+	}
+
+	serialized, err := rlp.EncodeToBytes(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("RLP encode manifest: %w", err)
+	}
+	return serialized, nil
+}
+
+// DeserializeManifest decodes a manifest serialized with [Manifest.Serialize], and checks that it is well formed.
+func DeserializeManifest(serialized []byte) (*Manifest, error) {
+	var encoded manifestRLP
+	err := rlp.DecodeBytes(serialized, &encoded)
+	if err != nil {
+		return nil, fmt.Errorf("RLP decode manifest: %w", err)
+	}
+
+	if len(encoded.Children) == 0 {
+		return nil, fmt.Errorf("manifest has no children")
+	}
+	if len(encoded.Children) > MaxManifestChildren {
+		return nil, fmt.Errorf("manifest has %d children, at most %d are permitted",
+			len(encoded.Children), MaxManifestChildren)
+	}
+
+	manifest := &Manifest{
+		PayloadLength: encoded.PayloadLength,
+		Children:      make([]VersionedCert, len(encoded.Children)),
+	}
+	for i, child := range encoded.Children {
+		version, err := ByteToVersion(child.Version)
+		if err != nil {
+			return nil, fmt.Errorf("child %d: %w", i, err)
+		}
+		if version == ManifestVersionByte {
+			return nil, fmt.Errorf("child %d is a manifest, manifests can't be nested", i)
+		}
+		manifest.Children[i] = NewVersionedCert(child.SerializedCert, version)
+	}
+	return manifest, nil
+}
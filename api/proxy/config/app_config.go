@@ -8,6 +8,10 @@ import (
 	"github.com/Layr-Labs/eigenda/api/proxy/config/v2/eigendaflags"
 	"github.com/Layr-Labs/eigenda/api/proxy/metrics"
 	"github.com/Layr-Labs/eigenda/api/proxy/server"
+	"github.com/Layr-Labs/eigenda/api/proxy/server/arbitrum"
+	"github.com/Layr-Labs/eigenda/api/proxy/server/idempotency"
+	"github.com/Layr-Labs/eigenda/api/proxy/server/jobs"
+	"github.com/Layr-Labs/eigenda/api/proxy/server/multiblob"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/builder"
 	"github.com/urfave/cli/v2"
 )
@@ -17,6 +21,10 @@ type AppConfig struct {
 	StoreBuilderConfig  builder.Config
 	SecretConfig        common.SecretConfigV2
 	ServerConfig        server.Config
+	ArbitrumConfig      arbitrum.Config
+	AsyncPutConfig      jobs.Config
+	IdempotencyConfig   idempotency.Config
+	MultiBlobConfig     multiblob.Config
 	MetricsServerConfig metrics.Config
 }
 
@@ -27,7 +35,36 @@ func (c AppConfig) Check() error {
 		return fmt.Errorf("check eigenDAConfig: %w", err)
 	}
 
+	if c.AsyncPutConfig.Enabled {
+		err = c.AsyncPutConfig.Check()
+		if err != nil {
+			return fmt.Errorf("check async put config: %w", err)
+		}
+	}
+
+	if c.IdempotencyConfig.Enabled {
+		err = c.IdempotencyConfig.Check()
+		if err != nil {
+			return fmt.Errorf("check idempotency config: %w", err)
+		}
+		if c.IdempotencyConfig.Backend == idempotency.RedisBackend && c.StoreBuilderConfig.RedisConfig.Endpoint == "" {
+			return fmt.Errorf("idempotency backend is redis, but no redis endpoint is configured")
+		}
+		if c.IdempotencyConfig.Backend == idempotency.S3Backend && c.StoreBuilderConfig.S3Config.Bucket == "" {
+			return fmt.Errorf("idempotency backend is s3, but no s3 bucket is configured")
+		}
+	}
+
 	v2Enabled := slices.Contains(c.StoreBuilderConfig.StoreConfig.BackendsToEnable, common.V2EigenDABackend)
+	if c.MultiBlobConfig.Enabled() {
+		err = c.MultiBlobConfig.Check()
+		if err != nil {
+			return fmt.Errorf("check multi-blob config: %w", err)
+		}
+		if !v2Enabled {
+			return fmt.Errorf("multi-blob payloads are enabled, but the EigenDA V2 backend is not")
+		}
+	}
 	if v2Enabled && !c.StoreBuilderConfig.MemstoreEnabled {
 		err = c.SecretConfig.Check()
 		if err != nil {
@@ -48,6 +85,10 @@ func ReadAppConfig(ctx *cli.Context) (AppConfig, error) {
 		StoreBuilderConfig:  storeBuilderConfig,
 		SecretConfig:        eigendaflags.ReadSecretConfigV2(ctx),
 		ServerConfig:        server.ReadConfig(ctx),
+		ArbitrumConfig:      arbitrum.ReadConfig(ctx),
+		AsyncPutConfig:      jobs.ReadConfig(ctx),
+		IdempotencyConfig:   idempotency.ReadConfig(ctx),
+		MultiBlobConfig:     multiblob.ReadConfig(ctx),
 		MetricsServerConfig: metrics.ReadConfig(ctx),
 	}, nil
 }
@@ -4,12 +4,17 @@ import (
 	"github.com/Layr-Labs/eigenda/api/proxy/config/eigendaflags"
 	eigenda_v2_flags "github.com/Layr-Labs/eigenda/api/proxy/config/v2/eigendaflags"
 	"github.com/Layr-Labs/eigenda/api/proxy/server"
+	"github.com/Layr-Labs/eigenda/api/proxy/server/arbitrum"
+	"github.com/Layr-Labs/eigenda/api/proxy/server/idempotency"
+	"github.com/Layr-Labs/eigenda/api/proxy/server/jobs"
+	"github.com/Layr-Labs/eigenda/api/proxy/server/multiblob"
 	"github.com/Layr-Labs/eigenda/api/proxy/store"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/generated_key/eigenda/verify"
 
 	"github.com/Layr-Labs/eigenda/api/proxy/logging"
 	"github.com/Layr-Labs/eigenda/api/proxy/metrics"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/generated_key/memstore"
+	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary/littdb"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary/redis"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary/s3"
 	"github.com/urfave/cli/v2"
@@ -24,9 +29,14 @@ const (
 	StorageFlagsCategory    = "Storage"
 	RedisCategory           = "Redis Cache/Fallback"
 	S3Category              = "S3 Cache/Fallback"
+	LittDBCategory          = "LittDB Cache/Fallback"
 	VerifierCategory        = "Cert Verifier (V1 only)"
 	KZGCategory             = "KZG"
 	ProxyServerCategory     = "Proxy Server"
+	ArbitrumCategory        = "Arbitrum DA Provider"
+	AsyncPutCategory        = "Async Put"
+	IdempotencyCategory     = "Idempotent Put"
+	MultiBlobCategory       = "Multi-Blob Payloads"
 )
 
 // EnvVar prefix added in front of all environment variables accepted by the binary.
@@ -38,6 +48,10 @@ var Flags = []cli.Flag{}
 
 func init() {
 	Flags = append(Flags, server.CLIFlags(GlobalEnvVarPrefix, ProxyServerCategory)...)
+	Flags = append(Flags, arbitrum.CLIFlags(GlobalEnvVarPrefix, ArbitrumCategory)...)
+	Flags = append(Flags, jobs.CLIFlags(GlobalEnvVarPrefix, AsyncPutCategory)...)
+	Flags = append(Flags, idempotency.CLIFlags(GlobalEnvVarPrefix, IdempotencyCategory)...)
+	Flags = append(Flags, multiblob.CLIFlags(GlobalEnvVarPrefix, MultiBlobCategory)...)
 	Flags = append(Flags, logging.CLIFlags(GlobalEnvVarPrefix, LoggingFlagsCategory)...)
 	Flags = append(Flags, metrics.CLIFlags(GlobalEnvVarPrefix, MetricsFlagCategory)...)
 	Flags = append(Flags, eigendaflags.CLIFlags(GlobalEnvVarPrefix, EigenDAClientCategory)...)
@@ -45,6 +59,7 @@ func init() {
 	Flags = append(Flags, store.CLIFlags(GlobalEnvVarPrefix, StorageFlagsCategory)...)
 	Flags = append(Flags, redis.CLIFlags(GlobalEnvVarPrefix, RedisCategory)...)
 	Flags = append(Flags, s3.CLIFlags(GlobalEnvVarPrefix, S3Category)...)
+	Flags = append(Flags, littdb.CLIFlags(GlobalEnvVarPrefix, LittDBCategory)...)
 	Flags = append(Flags, memstore.CLIFlags(GlobalEnvVarPrefix, MemstoreFlagsCategory)...)
 	Flags = append(Flags, verify.VerifierCLIFlags(GlobalEnvVarPrefix, VerifierCategory)...)
 	Flags = append(Flags, verify.KZGCLIFlags(GlobalEnvVarPrefix, KZGCategory)...)
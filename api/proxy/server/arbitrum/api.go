@@ -0,0 +1,248 @@
+// Package arbitrum implements the Arbitrum Nitro DA provider JSON-RPC API on top of the proxy's storage manager.
+// Nitro nodes configured with an external DA provider call these methods to post batches to EigenDA, and to
+// recover batches (and the preimages needed to prove them) from the certificates posted to the sequencer inbox.
+package arbitrum
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda/api/proxy/common"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/proxyerrors"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/certs"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/commitments"
+	"github.com/Layr-Labs/eigenda/api/proxy/store"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// The JSON-RPC namespace of the Nitro DA provider API.
+	namespace = "daprovider"
+
+	// DACertificateHeaderByte is the header byte Nitro uses to identify a sequencer message that contains a
+	// DA certificate from an external DA provider. It is the first byte of every certificate returned by Store.
+	DACertificateHeaderByte byte = 0x01
+
+	// DACertificatePreimageType is the Nitro preimage type of a payload keyed by the hash of its DA certificate.
+	DACertificatePreimageType uint8 = 3
+
+	// The length of the header Nitro adds to each sequencer message: the min and max timestamps, the min and max L1
+	// block numbers, and the number of delayed messages read, each 8 bytes.
+	sequencerMessageHeaderLength = 40
+
+	// The JSON-RPC error code returned when a certificate is invalid. Nitro treats batches with invalid certificates
+	// as empty, so they must be distinguishable from transient failures.
+	invalidCertificateErrorCode = -32001
+)
+
+// StoreResult is the result of daprovider_store.
+type StoreResult struct {
+	// The certificate to post to the sequencer inbox, prefixed with DACertificateHeaderByte.
+	SerializedDACert hexutil.Bytes `json:"serialized-da-cert,omitempty"`
+}
+
+// PayloadResult is the result of daprovider_recoverPayload.
+type PayloadResult struct {
+	Payload hexutil.Bytes `json:"payload"`
+}
+
+// PreimagesResult is the result of daprovider_collectPreimages. Preimages are keyed by preimage type, then by hash.
+type PreimagesResult struct {
+	Preimages map[uint8]map[gethcommon.Hash][]byte `json:"preimages"`
+}
+
+// SupportedHeaderBytesResult is the result of daprovider_getSupportedHeaderBytes.
+type SupportedHeaderBytesResult struct {
+	HeaderBytes hexutil.Bytes `json:"headerBytes"`
+}
+
+// invalidCertificateError is returned when a certificate fails verification. It implements rpc.Error so that the
+// JSON-RPC server reports it with invalidCertificateErrorCode.
+type invalidCertificateError struct {
+	err error
+}
+
+func (e invalidCertificateError) Error() string {
+	return fmt.Sprintf("invalid DA certificate: %v", e.err)
+}
+
+func (e invalidCertificateError) ErrorCode() int {
+	return invalidCertificateErrorCode
+}
+
+func (e invalidCertificateError) Unwrap() error {
+	return e.err
+}
+
+// DAProviderAPI implements the methods of the Nitro daprovider JSON-RPC namespace.
+//
+// Payloads are dispersed with the standard commitment mode, so the certificates returned by Store are the
+// standard commitments served by the REST routes, prefixed with DACertificateHeaderByte.
+type DAProviderAPI struct {
+	log logging.Logger
+	sm  store.IManager
+}
+
+// NewDAProviderAPI creates a new DAProviderAPI.
+func NewDAProviderAPI(log logging.Logger, sm store.IManager) *DAProviderAPI {
+	return &DAProviderAPI{
+		log: log,
+		sm:  sm,
+	}
+}
+
+// GetSupportedHeaderBytes returns the sequencer message header bytes that this DA provider can recover payloads for.
+func (api *DAProviderAPI) GetSupportedHeaderBytes(_ context.Context) (*SupportedHeaderBytesResult, error) {
+	return &SupportedHeaderBytesResult{
+		HeaderBytes: []byte{DACertificateHeaderByte},
+	}, nil
+}
+
+// Store disperses a batch to EigenDA and returns the certificate to post to the sequencer inbox.
+//
+// The timeout is the time, in seconds since the unix epoch, until which Nitro expects the batch to be available.
+// It is ignored, since EigenDA availability is governed by the network's retention period.
+func (api *DAProviderAPI) Store(
+	ctx context.Context,
+	message hexutil.Bytes,
+	timeout hexutil.Uint64,
+) (*StoreResult, error) {
+	serializedCert, err := api.sm.Put(ctx, commitments.StandardCommitmentMode, message)
+	if err != nil {
+		return nil, fmt.Errorf("put payload: %w", err)
+	}
+
+	var certVersion certs.VersionByte
+	switch api.sm.GetDispersalBackend() {
+	case common.V1EigenDABackend:
+		certVersion = certs.V0VersionByte
+	case common.V2EigenDABackend:
+		certVersion = certs.V2VersionByte
+	default:
+		return nil, fmt.Errorf("unknown dispersal backend: %v", api.sm.GetDispersalBackend())
+	}
+	versionedCert := certs.NewVersionedCert(serializedCert, certVersion)
+
+	commitment, err := commitments.EncodeCommitment(versionedCert, commitments.StandardCommitmentMode)
+	if err != nil {
+		return nil, fmt.Errorf("encode commitment: %w", err)
+	}
+
+	api.log.Info("Processed daprovider_store request", "certVersion", versionedCert.Version,
+		"payloadSize", len(message), "timeout", uint64(timeout))
+
+	return &StoreResult{
+		SerializedDACert: append([]byte{DACertificateHeaderByte}, commitment...),
+	}, nil
+}
+
+// RecoverPayload returns the batch referenced by the certificate in a sequencer message. The certificate is verified
+// before the payload is returned.
+func (api *DAProviderAPI) RecoverPayload(
+	ctx context.Context,
+	batchNum hexutil.Uint64,
+	batchBlockHash gethcommon.Hash,
+	sequencerMsg hexutil.Bytes,
+) (*PayloadResult, error) {
+	_, payload, err := api.recoverPayload(ctx, sequencerMsg)
+	if err != nil {
+		if IsInvalidCertificateError(err) {
+			// Returned unwrapped, so that the JSON-RPC server reports its error code.
+			return nil, err
+		}
+		return nil, fmt.Errorf("recover payload for batch %d: %w", uint64(batchNum), err)
+	}
+
+	api.log.Info("Processed daprovider_recoverPayload request", "batchNum", uint64(batchNum),
+		"batchBlockHash", batchBlockHash, "payloadSize", len(payload))
+
+	return &PayloadResult{Payload: payload}, nil
+}
+
+// CollectPreimages returns the preimages needed to prove the execution of the batch referenced by the certificate
+// in a sequencer message: the payload, keyed by the keccak256 hash of the certificate.
+func (api *DAProviderAPI) CollectPreimages(
+	ctx context.Context,
+	batchNum hexutil.Uint64,
+	batchBlockHash gethcommon.Hash,
+	sequencerMsg hexutil.Bytes,
+) (*PreimagesResult, error) {
+	certificate, payload, err := api.recoverPayload(ctx, sequencerMsg)
+	if err != nil {
+		if IsInvalidCertificateError(err) {
+			// Returned unwrapped, so that the JSON-RPC server reports its error code.
+			return nil, err
+		}
+		return nil, fmt.Errorf("collect preimages for batch %d: %w", uint64(batchNum), err)
+	}
+
+	api.log.Info("Processed daprovider_collectPreimages request", "batchNum", uint64(batchNum),
+		"batchBlockHash", batchBlockHash, "payloadSize", len(payload))
+
+	return &PreimagesResult{
+		Preimages: map[uint8]map[gethcommon.Hash][]byte{
+			DACertificatePreimageType: {
+				crypto.Keccak256Hash(certificate): payload,
+			},
+		},
+	}, nil
+}
+
+// recoverPayload parses the certificate out of a sequencer message, then fetches and verifies the payload it
+// references. Returns the certificate (including DACertificateHeaderByte) and the payload.
+func (api *DAProviderAPI) recoverPayload(ctx context.Context, sequencerMsg []byte) ([]byte, []byte, error) {
+	versionedCert, err := ParseSequencerMessage(sequencerMsg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The sequencer message does not carry the L1 block the certificate was included in, so the recency check is
+	// skipped.
+	payload, err := api.sm.Get(ctx, versionedCert, commitments.StandardCommitmentMode, common.CertVerificationOpts{})
+	if err != nil {
+		if proxyerrors.Is418(err) {
+			return nil, nil, invalidCertificateError{err: err}
+		}
+		return nil, nil, fmt.Errorf("get payload: %w", err)
+	}
+
+	return sequencerMsg[sequencerMessageHeaderLength:], payload, nil
+}
+
+// ParseSequencerMessage parses the certificate out of a sequencer message. A sequencer message is the 40 byte
+// header added by Nitro, followed by a certificate returned by DAProviderAPI.Store.
+func ParseSequencerMessage(sequencerMsg []byte) (certs.VersionedCert, error) {
+	// The header, the header byte, and the version byte.
+	if len(sequencerMsg) < sequencerMessageHeaderLength+2 {
+		return certs.VersionedCert{}, invalidCertificateError{
+			err: fmt.Errorf("sequencer message too short: %d bytes", len(sequencerMsg)),
+		}
+	}
+	certificate := sequencerMsg[sequencerMessageHeaderLength:]
+	if certificate[0] != DACertificateHeaderByte {
+		return certs.VersionedCert{}, invalidCertificateError{
+			err: fmt.Errorf("unsupported header byte 0x%02x", certificate[0]),
+		}
+	}
+	certVersion, err := certs.ByteToVersion(certificate[1])
+	if err != nil {
+		return certs.VersionedCert{}, invalidCertificateError{err: err}
+	}
+	// The daprovider only disperses single blobs, so it never produces multi-blob manifests.
+	if certVersion == certs.ManifestVersionByte {
+		return certs.VersionedCert{}, invalidCertificateError{
+			err: fmt.Errorf("multi-blob manifests are not supported by the daprovider"),
+		}
+	}
+	return certs.NewVersionedCert(certificate[2:], certVersion), nil
+}
+
+// IsInvalidCertificateError returns true if the error was caused by an invalid certificate.
+func IsInvalidCertificateError(err error) bool {
+	var target invalidCertificateError
+	return errors.As(err, &target)
+}
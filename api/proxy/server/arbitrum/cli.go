@@ -0,0 +1,52 @@
+package arbitrum
+
+import (
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	EnabledFlagName    = "arbitrum-da-provider.enabled"
+	ListenAddrFlagName = "arbitrum-da-provider.addr"
+	PortFlagName       = "arbitrum-da-provider.port"
+)
+
+func withEnvPrefix(prefix, s string) []string {
+	return []string{prefix + "_ARBITRUM_DA_PROVIDER_" + s}
+}
+
+func CLIFlags(envPrefix string, category string) []cli.Flag {
+	flags := []cli.Flag{
+		&cli.BoolFlag{
+			Name: EnabledFlagName,
+			Usage: "Serve the Arbitrum Nitro daprovider JSON-RPC API (daprovider_store, daprovider_recoverPayload, " +
+				"daprovider_collectPreimages) alongside the REST API.",
+			Value:    false,
+			EnvVars:  withEnvPrefix(envPrefix, "ENABLED"),
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name:     ListenAddrFlagName,
+			Usage:    "Arbitrum DA provider server listening address",
+			Value:    "0.0.0.0",
+			EnvVars:  withEnvPrefix(envPrefix, "ADDR"),
+			Category: category,
+		},
+		&cli.IntFlag{
+			Name:     PortFlagName,
+			Usage:    "Arbitrum DA provider server listening port",
+			Value:    3101,
+			EnvVars:  withEnvPrefix(envPrefix, "PORT"),
+			Category: category,
+		},
+	}
+
+	return flags
+}
+
+func ReadConfig(ctx *cli.Context) Config {
+	return Config{
+		Enabled: ctx.Bool(EnabledFlagName),
+		Host:    ctx.String(ListenAddrFlagName),
+		Port:    ctx.Int(PortFlagName),
+	}
+}
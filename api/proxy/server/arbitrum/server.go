@@ -0,0 +1,102 @@
+package arbitrum
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/proxy/store"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// Payloads are limited to 32 MiB, like the POST routes of the REST server. They are hex encoded in JSON-RPC
+	// requests, so the request body limit is a bit more than twice that.
+	maxRequestBodySize = 2*32*1024*1024 + 1024*1024
+)
+
+// Config ... Config for the Arbitrum DA provider JSON-RPC server
+type Config struct {
+	Enabled bool
+	Host    string
+	Port    int
+}
+
+// Server serves the Nitro daprovider JSON-RPC API over HTTP.
+type Server struct {
+	log        logging.Logger
+	endpoint   string
+	rpcServer  *rpc.Server
+	httpServer *http.Server
+	listener   net.Listener
+}
+
+// NewServer creates a new Server backed by the given storage manager.
+func NewServer(cfg Config, sm store.IManager, log logging.Logger) (*Server, error) {
+	rpcServer := rpc.NewServer()
+	rpcServer.SetHTTPBodyLimit(maxRequestBodySize)
+	err := rpcServer.RegisterName(namespace, NewDAProviderAPI(log, sm))
+	if err != nil {
+		return nil, fmt.Errorf("register %s API: %w", namespace, err)
+	}
+
+	endpoint := net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port))
+	return &Server{
+		log:       log,
+		endpoint:  endpoint,
+		rpcServer: rpcServer,
+		httpServer: &http.Server{
+			Addr:              endpoint,
+			Handler:           rpcServer,
+			ReadHeaderTimeout: 10 * time.Second,
+			// aligned with existing blob finalization times
+			WriteTimeout: 40 * time.Minute,
+		},
+	}, nil
+}
+
+func (svr *Server) Start() error {
+	listener, err := net.Listen("tcp", svr.endpoint)
+	if err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+	svr.listener = listener
+
+	svr.log.Info("Starting Arbitrum DA provider server", "endpoint", listener.Addr().String())
+	errCh := make(chan error, 1)
+	go func() {
+		if err := svr.httpServer.Serve(svr.listener); err != nil {
+			errCh <- err
+		}
+	}()
+
+	// verify that the server comes up
+	tick := time.NewTimer(10 * time.Millisecond)
+	defer tick.Stop()
+
+	select {
+	case err := <-errCh:
+		return fmt.Errorf("arbitrum DA provider server failed: %w", err)
+	case <-tick.C:
+		return nil
+	}
+}
+
+func (svr *Server) Endpoint() string {
+	return svr.listener.Addr().String()
+}
+
+func (svr *Server) Stop() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	svr.rpcServer.Stop()
+	if err := svr.httpServer.Shutdown(ctx); err != nil {
+		svr.log.Error("Failed to shutdown Arbitrum DA provider server", "err", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package arbitrum
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2/verification"
+	"github.com/Layr-Labs/eigenda/api/proxy/common"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/certs"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/commitments"
+	"github.com/Layr-Labs/eigenda/api/proxy/test/mocks"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var testLogger = logging.NewTextSLogger(os.Stdout, &logging.SLoggerOptions{})
+
+// startTestServer starts a DA provider server backed by the given manager, and returns a client connected to it.
+func startTestServer(t *testing.T, sm *mocks.MockIManager) *rpc.Client {
+	server, err := NewServer(Config{Host: "localhost", Port: 0}, sm, testLogger)
+	require.NoError(t, err)
+	require.NoError(t, server.Start())
+	t.Cleanup(func() {
+		_ = server.Stop()
+	})
+
+	client, err := rpc.Dial("http://" + server.Endpoint())
+	require.NoError(t, err)
+	t.Cleanup(client.Close)
+	return client
+}
+
+// sequencerMessage prefixes a certificate with a Nitro sequencer message header.
+func sequencerMessage(certificate []byte) hexutil.Bytes {
+	return append(make([]byte, sequencerMessageHeaderLength), certificate...)
+}
+
+func TestStoreAndRecoverPayload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	sm := mocks.NewMockIManager(ctrl)
+	client := startTestServer(t, sm)
+	ctx := context.Background()
+
+	payload := []byte("a batch of transactions")
+	serializedCert := []byte("a certificate")
+
+	sm.EXPECT().Put(gomock.Any(), commitments.StandardCommitmentMode, payload).Return(serializedCert, nil)
+	sm.EXPECT().GetDispersalBackend().Return(common.V2EigenDABackend)
+
+	var storeResult StoreResult
+	err := client.CallContext(ctx, &storeResult, "daprovider_store", hexutil.Bytes(payload), hexutil.Uint64(0))
+	require.NoError(t, err)
+	expectedCertificate := append([]byte{DACertificateHeaderByte, byte(certs.V2VersionByte)}, serializedCert...)
+	require.Equal(t, hexutil.Bytes(expectedCertificate), storeResult.SerializedDACert)
+
+	// The payload is recovered from a sequencer message containing the certificate.
+	expectedCert := certs.NewVersionedCert(serializedCert, certs.V2VersionByte)
+	sm.EXPECT().Get(gomock.Any(), expectedCert, commitments.StandardCommitmentMode, common.CertVerificationOpts{}).
+		Return(payload, nil).Times(2)
+
+	var payloadResult PayloadResult
+	err = client.CallContext(ctx, &payloadResult, "daprovider_recoverPayload",
+		hexutil.Uint64(1), gethcommon.Hash{}, sequencerMessage(storeResult.SerializedDACert))
+	require.NoError(t, err)
+	require.Equal(t, hexutil.Bytes(payload), payloadResult.Payload)
+
+	// The preimages map the hash of the certificate to the payload.
+	var preimagesResult PreimagesResult
+	err = client.CallContext(ctx, &preimagesResult, "daprovider_collectPreimages",
+		hexutil.Uint64(1), gethcommon.Hash{}, sequencerMessage(storeResult.SerializedDACert))
+	require.NoError(t, err)
+	require.Equal(t, map[uint8]map[gethcommon.Hash][]byte{
+		DACertificatePreimageType: {crypto.Keccak256Hash(expectedCertificate): payload},
+	}, preimagesResult.Preimages)
+
+	var headerBytesResult SupportedHeaderBytesResult
+	err = client.CallContext(ctx, &headerBytesResult, "daprovider_getSupportedHeaderBytes")
+	require.NoError(t, err)
+	require.Equal(t, hexutil.Bytes{DACertificateHeaderByte}, headerBytesResult.HeaderBytes)
+}
+
+func TestRecoverPayloadErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	sm := mocks.NewMockIManager(ctrl)
+	client := startTestServer(t, sm)
+	ctx := context.Background()
+
+	recoverPayload := func(certificate []byte) error {
+		var result PayloadResult
+		return client.CallContext(ctx, &result, "daprovider_recoverPayload",
+			hexutil.Uint64(1), gethcommon.Hash{}, sequencerMessage(certificate))
+	}
+	requireErrorCode := func(t *testing.T, err error, code int) {
+		var rpcErr rpc.Error
+		require.True(t, errors.As(err, &rpcErr))
+		require.Equal(t, code, rpcErr.ErrorCode())
+	}
+
+	// Malformed certificates are rejected without reading from the manager.
+	requireErrorCode(t, recoverPayload(nil), invalidCertificateErrorCode)
+	requireErrorCode(t, recoverPayload([]byte{0x88, byte(certs.V2VersionByte), 1, 2, 3}), invalidCertificateErrorCode)
+	requireErrorCode(t, recoverPayload([]byte{DACertificateHeaderByte, 0xff, 1, 2, 3}), invalidCertificateErrorCode)
+
+	// Certificates that fail verification are reported as invalid.
+	sm.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, &verification.CertVerificationFailedError{Msg: "bad cert"})
+	requireErrorCode(t, recoverPayload([]byte{DACertificateHeaderByte, byte(certs.V2VersionByte), 1, 2, 3}),
+		invalidCertificateErrorCode)
+
+	// Other failures are reported as internal errors, so that Nitro retries them.
+	sm.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("relay unavailable"))
+	err := recoverPayload([]byte{DACertificateHeaderByte, byte(certs.V2VersionByte), 1, 2, 3})
+	require.Error(t, err)
+	var rpcErr rpc.Error
+	require.True(t, errors.As(err, &rpcErr))
+	require.NotEqual(t, invalidCertificateErrorCode, rpcErr.ErrorCode())
+}
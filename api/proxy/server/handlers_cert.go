@@ -4,7 +4,9 @@
 package server
 
 import (
+	"context"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -14,7 +16,10 @@ import (
 	"github.com/Layr-Labs/eigenda/api/proxy/common/proxyerrors"
 	"github.com/Layr-Labs/eigenda/api/proxy/common/types/certs"
 	"github.com/Layr-Labs/eigenda/api/proxy/common/types/commitments"
+	"github.com/Layr-Labs/eigenda/api/proxy/server/idempotency"
 	"github.com/Layr-Labs/eigenda/api/proxy/server/middleware"
+	"github.com/Layr-Labs/eigenda/api/proxy/server/multiblob"
+	core "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/gorilla/mux"
 )
 
@@ -90,12 +95,16 @@ func (svr *Server) handleGetShared(
 	if err != nil {
 		return err // doesn't need to be wrapped; already a proxyerrors
 	}
-	input, err := svr.sm.Get(
-		r.Context(),
-		versionedCert,
-		mode,
-		common.CertVerificationOpts{L1InclusionBlockNum: l1InclusionBlockNum},
-	)
+	verifyOpts := common.CertVerificationOpts{L1InclusionBlockNum: l1InclusionBlockNum}
+	var input []byte
+	if versionedCert.Version == certs.ManifestVersionByte {
+		input, err = multiblob.Get(r.Context(), versionedCert.SerializedCert,
+			func(ctx context.Context, child certs.VersionedCert) ([]byte, error) {
+				return svr.sm.Get(ctx, child, mode, verifyOpts)
+			})
+	} else {
+		input, err = svr.sm.Get(r.Context(), versionedCert, mode, verifyOpts)
+	}
 	if err != nil {
 		return fmt.Errorf("get request failed with serializedCert (version %v) %v: %w",
 			versionedCert.Version, serializedCertHex, err)
@@ -180,35 +189,23 @@ func (svr *Server) handlePostShared(
 	r *http.Request,
 	mode commitments.CommitmentMode,
 ) error {
-	payload, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxPOSTRequestBodySize))
+	maxPayloadSize := svr.maxPayloadSize()
+	payload, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxPayloadSize))
 	if err != nil {
-		return proxyerrors.NewReadRequestBodyError(err, maxPOSTRequestBodySize)
+		return proxyerrors.NewReadRequestBodyError(err, maxPayloadSize)
 	}
 
-	serializedCert, err := svr.sm.Put(r.Context(), mode, payload)
-	if err != nil {
-		return fmt.Errorf("post request failed: %w", err)
+	if r.URL.Query().Get("async") == "true" {
+		return svr.handlePostAsync(w, r, mode, payload)
 	}
 
-	var certVersion certs.VersionByte
-	switch svr.sm.GetDispersalBackend() {
-	case common.V1EigenDABackend:
-		certVersion = certs.V0VersionByte
-	case common.V2EigenDABackend:
-		certVersion = certs.V2VersionByte
-	default:
-		return fmt.Errorf("unknown dispersal backend: %v", svr.sm.GetDispersalBackend())
-	}
-	versionedCert := certs.NewVersionedCert(serializedCert, certVersion)
-
-	responseCommit, err := commitments.EncodeCommitment(versionedCert, mode)
+	responseCommit, err := svr.putCommitment(r.Context(), mode, payload, r.Header.Get(headerIdempotencyKey))
 	if err != nil {
-		// This error is only possible if we have a bug in the code.
-		return fmt.Errorf("failed to encode serializedCert %v: %w", serializedCert, err)
+		return err
 	}
 
 	svr.log.Info("Processed request", "method", r.Method, "url", r.URL.Path, "commitmentMode", mode,
-		"certVersion", versionedCert.Version, "cert", hex.EncodeToString(serializedCert))
+		"commitment", hex.EncodeToString(responseCommit))
 
 	// We write the commitment as bytes directly instead of hex encoded.
 	// The spec https://specs.optimism.io/experimental/alt-da.html#da-server says it should be hex-encoded,
@@ -219,8 +216,187 @@ func (svr *Server) handlePostShared(
 	if err != nil {
 		// If the write fails, we will already have sent a 200 header. But we still return an error
 		// here so that the logging middleware can log it.
-		return fmt.Errorf("failed to write response for POST serializedCert (version %v) %x: %w",
-			versionedCert.Version, serializedCert, err)
+		return fmt.Errorf("failed to write response for POST commitment %x: %w", responseCommit, err)
+	}
+	return nil
+}
+
+// handlePostAsync starts dispersing the payload in the background, and responds with a 202 containing the job
+// that tracks the dispersal. The job's progress, and eventually its commitment, is served by GET /put/status/{id}.
+func (svr *Server) handlePostAsync(
+	w http.ResponseWriter,
+	r *http.Request,
+	mode commitments.CommitmentMode,
+	payload []byte,
+) error {
+	if svr.jobs == nil {
+		return proxyerrors.ErrAsyncPutsDisabled
+	}
+
+	// Payloads split across several blobs can't be resumed from a single blob key.
+	job, err := svr.jobs.Submit(mode, payload, r.Header.Get(headerIdempotencyKey), !svr.splitsPayload(payload))
+	if err != nil {
+		return fmt.Errorf("submit async dispersal: %w", err)
+	}
+
+	svr.log.Info("Accepted async request", "method", r.Method, "url", r.URL.Path, "commitmentMode", mode,
+		"jobID", job.ID)
+
+	response, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("marshal job %s: %w", job.ID, err)
+	}
+	w.Header().Set(headerContentType, contentTypeJSON)
+	w.WriteHeader(http.StatusAccepted)
+	_, err = w.Write(response)
+	if err != nil {
+		return fmt.Errorf("failed to write response for async POST job %s: %w", job.ID, err)
 	}
 	return nil
 }
+
+// putCommitment disperses the payload and returns the commitment to return to the client. If idempotent puts are
+// enabled, requests are deduplicated by the idempotency key if it is not empty, and by the payload otherwise.
+func (svr *Server) putCommitment(
+	ctx context.Context,
+	mode commitments.CommitmentMode,
+	payload []byte,
+	idempotencyKey string,
+) ([]byte, error) {
+	disperse := func(ctx context.Context) ([]byte, error) {
+		responseCommit, _, err := svr.disperse(ctx, mode, payload)
+		return responseCommit, err
+	}
+	if svr.dedup == nil {
+		return disperse(ctx)
+	}
+
+	key := idempotency.PayloadKey(mode, payload)
+	if idempotencyKey != "" {
+		key = idempotency.HeaderKey(mode, idempotencyKey)
+	}
+	return svr.dedup.Disperse(ctx, key, payload, disperse)
+}
+
+// disperse disperses the payload to the current dispersal backend, and returns the commitment to return to the
+// client along with the cert it encodes. If multi-blob payloads are enabled, payloads that don't fit in a single
+// V2 blob are split across several blobs, and committed to with a manifest.
+func (svr *Server) disperse(
+	ctx context.Context,
+	mode commitments.CommitmentMode,
+	payload []byte,
+) ([]byte, certs.VersionedCert, error) {
+	putChild := func(ctx context.Context, chunk []byte) (certs.VersionedCert, error) {
+		return svr.putChild(ctx, mode, chunk)
+	}
+
+	var versionedCert certs.VersionedCert
+	var err error
+	if svr.splitsPayload(payload) {
+		versionedCert, err = svr.multiBlob.Put(ctx, payload, putChild)
+	} else {
+		versionedCert, err = svr.putBlob(ctx, mode, payload)
+	}
+	if err != nil {
+		return nil, certs.VersionedCert{}, fmt.Errorf("post request failed: %w", err)
+	}
+
+	responseCommit, err := commitments.EncodeCommitment(versionedCert, mode)
+	if err != nil {
+		// This error is only possible if we have a bug in the code.
+		return nil, certs.VersionedCert{}, fmt.Errorf("failed to encode serializedCert %v: %w",
+			versionedCert.SerializedCert, err)
+	}
+	return responseCommit, versionedCert, nil
+}
+
+// putChild disperses a chunk of a payload that is split across several blobs, and returns its cert. If idempotent
+// puts are enabled, chunks are deduplicated by their payload. A chunk keeps dispersing after a sibling fails, so a
+// retry of the payload reuses the certs of the chunks that succeeded instead of dispersing them again.
+func (svr *Server) putChild(
+	ctx context.Context,
+	mode commitments.CommitmentMode,
+	chunk []byte,
+) (certs.VersionedCert, error) {
+	if svr.dedup == nil {
+		return svr.putBlob(ctx, mode, chunk)
+	}
+
+	encodedCert, err := svr.dedup.Disperse(ctx, idempotency.ChildKey(chunk), chunk,
+		func(ctx context.Context) ([]byte, error) {
+			versionedCert, err := svr.putBlob(ctx, mode, chunk)
+			if err != nil {
+				return nil, err
+			}
+			return versionedCert.Encode(), nil
+		})
+	if err != nil {
+		return certs.VersionedCert{}, err
+	}
+	if len(encodedCert) == 0 {
+		return certs.VersionedCert{}, fmt.Errorf("empty cert for chunk")
+	}
+	version, err := certs.ByteToVersion(encodedCert[0])
+	if err != nil {
+		return certs.VersionedCert{}, fmt.Errorf("decode chunk cert: %w", err)
+	}
+	return certs.NewVersionedCert(encodedCert[1:], version), nil
+}
+
+// splitsPayload returns true if the payload is dispersed as several blobs committed to with a manifest.
+func (svr *Server) splitsPayload(payload []byte) bool {
+	return svr.multiBlob != nil &&
+		svr.sm.GetDispersalBackend() == common.V2EigenDABackend &&
+		svr.multiBlob.NeedsSplit(payload)
+}
+
+// resumeCommitment finishes a V2 dispersal that was interrupted by a restart, given the key of its blob, and returns
+// the commitment to return to the client.
+func (svr *Server) resumeCommitment(
+	ctx context.Context,
+	mode commitments.CommitmentMode,
+	blobKey core.BlobKey,
+) ([]byte, error) {
+	serializedCert, err := svr.sm.ResumePut(ctx, mode, blobKey)
+	if err != nil {
+		return nil, fmt.Errorf("resume dispersal: %w", err)
+	}
+
+	versionedCert := certs.NewVersionedCert(serializedCert, certs.V2VersionByte)
+	responseCommit, err := commitments.EncodeCommitment(versionedCert, mode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode serializedCert %v: %w", serializedCert, err)
+	}
+	return responseCommit, nil
+}
+
+// putBlob disperses a payload as a single blob to the current dispersal backend, and returns its cert.
+func (svr *Server) putBlob(
+	ctx context.Context,
+	mode commitments.CommitmentMode,
+	payload []byte,
+) (certs.VersionedCert, error) {
+	serializedCert, err := svr.sm.Put(ctx, mode, payload)
+	if err != nil {
+		return certs.VersionedCert{}, err
+	}
+
+	var certVersion certs.VersionByte
+	switch svr.sm.GetDispersalBackend() {
+	case common.V1EigenDABackend:
+		certVersion = certs.V0VersionByte
+	case common.V2EigenDABackend:
+		certVersion = certs.V2VersionByte
+	default:
+		return certs.VersionedCert{}, fmt.Errorf("unknown dispersal backend: %v", svr.sm.GetDispersalBackend())
+	}
+	return certs.NewVersionedCert(serializedCert, certVersion), nil
+}
+
+// maxPayloadSize returns the size of the largest payload accepted by POST routes.
+func (svr *Server) maxPayloadSize() int64 {
+	if svr.multiBlob != nil {
+		return max(maxPOSTRequestBodySize, int64(svr.multiBlob.MaxPayloadSize())) //nolint:gosec // bounded by config
+	}
+	return maxPOSTRequestBodySize
+}
@@ -5,19 +5,31 @@ package server
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/Layr-Labs/eigenda/api"
 	"github.com/Layr-Labs/eigenda/api/proxy/common"
 	"github.com/Layr-Labs/eigenda/api/proxy/common/proxyerrors"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/certs"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/commitments"
 	"github.com/Layr-Labs/eigenda/api/proxy/metrics"
+	"github.com/Layr-Labs/eigenda/api/proxy/server/idempotency"
+	"github.com/Layr-Labs/eigenda/api/proxy/server/jobs"
+	"github.com/Layr-Labs/eigenda/api/proxy/server/multiblob"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary/s3"
 	"github.com/Layr-Labs/eigenda/api/proxy/test/mocks"
+	"github.com/Layr-Labs/eigenda/common/kvstore/mapstore"
+	"github.com/Layr-Labs/eigenda/encoding/utils/codec"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/require"
@@ -362,3 +374,214 @@ func TestHandlerPutKeccakErrors(t *testing.T) {
 			})
 	}
 }
+
+func TestHandlerPutAsync(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockStorageMgr := mocks.NewMockIManager(ctrl)
+	mockStorageMgr.EXPECT().GetDispersalBackend().AnyTimes().Return(common.V1EigenDABackend)
+	mockStorageMgr.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return([]byte(testCommitStr), nil)
+
+	r := mux.NewRouter()
+	server := NewServer(testCfg, mockStorageMgr, testLogger, metrics.NoopMetrics)
+	server.RegisterRoutes(r)
+
+	// Async puts are rejected until they are enabled.
+	req := httptest.NewRequest(http.MethodPost, "/put?async=true", strings.NewReader("some data"))
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+
+	err := server.EnableAsyncPuts(context.Background(), mapstore.NewStore(), jobs.Config{
+		Enabled:     true,
+		DBPath:      t.TempDir(),
+		Retention:   time.Hour,
+		MaxInFlight: 1,
+	})
+	require.NoError(t, err)
+
+	req = httptest.NewRequest(http.MethodPost, "/put?async=true", strings.NewReader("some data"))
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusAccepted, rec.Code)
+	job := &jobs.Job{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), job))
+	require.Equal(t, commitments.OptimismGenericCommitmentMode, job.CommitmentMode)
+
+	// Once complete, the status endpoint returns the same commitment as a synchronous put.
+	require.Eventually(t, func() bool {
+		req := httptest.NewRequest(http.MethodGet, "/put/status/"+job.ID, nil)
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), job))
+		return job.Status == jobs.StatusComplete
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, opGenericPrefixStr+testCommitStr, string(job.Commitment))
+
+	req = httptest.NewRequest(http.MethodGet, "/put/status/0123456789abcdef", nil)
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestHandlerPutIdempotent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockStorageMgr := mocks.NewMockIManager(ctrl)
+	mockStorageMgr.EXPECT().GetDispersalBackend().AnyTimes().Return(common.V1EigenDABackend)
+	// Each distinct request is only dispersed once.
+	mockStorageMgr.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Times(2).Return([]byte(testCommitStr), nil)
+
+	r := mux.NewRouter()
+	server := NewServer(testCfg, mockStorageMgr, testLogger, metrics.NoopMetrics)
+	server.RegisterRoutes(r)
+	err := server.EnableIdempotentPuts(idempotency.Config{
+		Enabled:    true,
+		Backend:    idempotency.MemoryBackend,
+		TTL:        time.Hour,
+		MaxEntries: 10,
+	}, nil)
+	require.NoError(t, err)
+
+	post := func(body string, idempotencyKey string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/put", strings.NewReader(body))
+		if idempotencyKey != "" {
+			req.Header.Set(headerIdempotencyKey, idempotencyKey)
+		}
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// Requests are deduplicated by payload.
+	for range 2 {
+		rec := post("some data", "")
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Equal(t, opGenericPrefixStr+testCommitStr, rec.Body.String())
+	}
+
+	// Requests are deduplicated by the Idempotency-Key header, which can't be reused for another payload.
+	for range 2 {
+		rec := post("other data", "batch-1")
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Equal(t, opGenericPrefixStr+testCommitStr, rec.Body.String())
+	}
+	rec := post("some data", "batch-1")
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestHandlerMultiBlobPayload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockStorageMgr := mocks.NewMockIManager(ctrl)
+	mockStorageMgr.EXPECT().GetDispersalBackend().AnyTimes().Return(common.V2EigenDABackend)
+	// The mock uses each blob's payload as its cert, so that it can be served back from the cert alone.
+	mockStorageMgr.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Times(3).DoAndReturn(
+		func(_ context.Context, _ commitments.CommitmentMode, payload []byte) ([]byte, error) {
+			return bytes.Clone(payload), nil
+		})
+	mockStorageMgr.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(3).DoAndReturn(
+		func(
+			_ context.Context, versionedCert certs.VersionedCert, _ commitments.CommitmentMode,
+			_ common.CertVerificationOpts,
+		) ([]byte, error) {
+			require.Equal(t, certs.V2VersionByte, versionedCert.Version)
+			return versionedCert.SerializedCert, nil
+		})
+
+	r := mux.NewRouter()
+	server := NewServer(testCfg, mockStorageMgr, testLogger, metrics.NoopMetrics)
+	server.RegisterRoutes(r)
+	err := server.EnableMultiBlobPayloads(multiblob.Config{MaxBlobs: 3}, 128)
+	require.NoError(t, err)
+
+	// A payload that fills all 3 blobs.
+	maxBlobPayloadSize, err := codec.BlobSizeToMaxPayloadSize(128)
+	require.NoError(t, err)
+	payload := make([]byte, 3*maxBlobPayloadSize)
+	for i := range payload {
+		payload[i] = byte(i)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/put?commitment_mode=standard", bytes.NewReader(payload))
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	commitment := rec.Body.Bytes()
+	require.Equal(t, byte(certs.ManifestVersionByte), commitment[0])
+
+	req = httptest.NewRequest(http.MethodGet,
+		fmt.Sprintf("/get/0x%x?commitment_mode=standard", commitment), nil)
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, payload, rec.Body.Bytes())
+
+	// Payloads that don't fit in all 3 blobs are rejected.
+	req = httptest.NewRequest(http.MethodPost, "/put?commitment_mode=standard",
+		bytes.NewReader(append(payload, 0x00)))
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestHandlerMultiBlobRetryReusesChildren(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockStorageMgr := mocks.NewMockIManager(ctrl)
+	mockStorageMgr.EXPECT().GetDispersalBackend().AnyTimes().Return(common.V2EigenDABackend)
+
+	maxBlobPayloadSize, err := codec.BlobSizeToMaxPayloadSize(128)
+	require.NoError(t, err)
+	payload := make([]byte, 3*maxBlobPayloadSize)
+	for i := range payload {
+		payload[i] = byte(i / int(maxBlobPayloadSize))
+	}
+
+	// The second chunk fails to disperse the first time.
+	var lock sync.Mutex
+	dispersed := make(map[byte]int)
+	mockStorageMgr.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+		func(_ context.Context, _ commitments.CommitmentMode, chunk []byte) ([]byte, error) {
+			lock.Lock()
+			defer lock.Unlock()
+			dispersed[chunk[0]]++
+			if chunk[0] == 1 && dispersed[chunk[0]] == 1 {
+				return nil, errors.New("disperse failed")
+			}
+			return bytes.Clone(chunk), nil
+		})
+
+	r := mux.NewRouter()
+	server := NewServer(testCfg, mockStorageMgr, testLogger, metrics.NoopMetrics)
+	server.RegisterRoutes(r)
+	require.NoError(t, server.EnableMultiBlobPayloads(multiblob.Config{MaxBlobs: 3}, 128))
+	require.NoError(t, server.EnableIdempotentPuts(idempotency.Config{
+		Enabled:    true,
+		Backend:    idempotency.MemoryBackend,
+		TTL:        time.Hour,
+		MaxEntries: 10,
+	}, nil))
+
+	req := httptest.NewRequest(http.MethodPost, "/put?commitment_mode=standard", bytes.NewReader(payload))
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusInternalServerError, rec.Code)
+
+	// The retry only disperses the chunk that failed, once the other chunks have completed.
+	require.Eventually(t, func() bool {
+		lock.Lock()
+		defer lock.Unlock()
+		return dispersed[0] == 1 && dispersed[2] == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	req = httptest.NewRequest(http.MethodPost, "/put?commitment_mode=standard", bytes.NewReader(payload))
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, byte(certs.ManifestVersionByte), rec.Body.Bytes()[0])
+
+	lock.Lock()
+	defer lock.Unlock()
+	require.Equal(t, map[byte]int{0: 1, 1: 2, 2: 1}, dispersed)
+}
@@ -10,17 +10,22 @@ package server
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 
 	"github.com/Layr-Labs/eigenda/api/proxy/common"
 	"github.com/Layr-Labs/eigenda/api/proxy/common/proxyerrors"
+	"github.com/Layr-Labs/eigenda/api/proxy/server/jobs"
+	"github.com/gorilla/mux"
 )
 
 const (
 	// HTTP headers
-	headerContentType = "Content-Type"
+	headerContentType    = "Content-Type"
+	headerIdempotencyKey = "Idempotency-Key"
 
 	// Content types
 	contentTypeJSON = "application/json"
@@ -36,6 +41,29 @@ func (svr *Server) logDispersalGetError(w http.ResponseWriter, _ *http.Request)
 	w.WriteHeader(http.StatusMethodNotAllowed)
 }
 
+// handleGetAsyncPutStatus handles the GET request to check the progress of an asynchronous dispersal.
+// The response is the job returned by POST /put?async=true, with its latest status, and its commitment once complete.
+func (svr *Server) handleGetAsyncPutStatus(w http.ResponseWriter, r *http.Request) {
+	if svr.jobs == nil {
+		http.Error(w, proxyerrors.ErrAsyncPutsDisabled.Error(), http.StatusBadRequest)
+		return
+	}
+
+	jobID := strings.ToLower(mux.Vars(r)[routingVarNameJobID])
+	job, err := svr.jobs.Get(jobID)
+	if err != nil {
+		if errors.Is(err, jobs.ErrJobNotFound) {
+			http.Error(w, fmt.Sprintf("job %s not found", jobID), http.StatusNotFound)
+			return
+		}
+		svr.log.Error("failed to get job", "method", r.Method, "path", r.URL.Path, "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	svr.writeJSON(w, r, job)
+}
+
 type EigenDADispersalBackendJSON struct {
 	EigenDADispersalBackend string `json:"eigenDADispersalBackend"`
 }
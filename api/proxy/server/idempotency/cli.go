@@ -0,0 +1,107 @@
+package idempotency
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	EnabledFlagName    = "idempotency.enabled"
+	BackendFlagName    = "idempotency.backend"
+	TTLFlagName        = "idempotency.ttl"
+	MaxEntriesFlagName = "idempotency.max-entries"
+)
+
+// Backend is where the results of completed dispersals are shared.
+type Backend string
+
+const (
+	// MemoryBackend keeps results in memory, so they are only seen by this proxy instance.
+	MemoryBackend Backend = "memory"
+	// RedisBackend shares results through the redis secondary store.
+	RedisBackend Backend = "redis"
+	// S3Backend shares results through the S3 secondary store.
+	S3Backend Backend = "s3"
+)
+
+// Config ... Config for idempotent puts
+type Config struct {
+	// Deduplicate POST /put requests
+	Enabled bool
+	// Where the results of completed dispersals are shared, in addition to this instance's memory
+	Backend Backend
+	// How long the result of a completed dispersal is returned to repeated requests, and how long claims last
+	TTL time.Duration
+	// The maximum number of completed dispersals kept in memory
+	MaxEntries int
+}
+
+func withEnvPrefix(prefix, s string) []string {
+	return []string{prefix + "_IDEMPOTENCY_" + s}
+}
+
+func CLIFlags(envPrefix string, category string) []cli.Flag {
+	flags := []cli.Flag{
+		&cli.BoolFlag{
+			Name: EnabledFlagName,
+			Usage: "Deduplicate POST /put requests by payload hash, or by the Idempotency-Key header if it is set. " +
+				"Repeated requests join the dispersal in flight, or return the commitment it produced.",
+			Value:    false,
+			EnvVars:  withEnvPrefix(envPrefix, "ENABLED"),
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name: BackendFlagName,
+			Usage: fmt.Sprintf("Where the results of completed dispersals are shared. One of %s, %s, %s. "+
+				"The redis and s3 backends use the secondary storage configuration, and share results "+
+				"between proxy instances and across restarts.", MemoryBackend, RedisBackend, S3Backend),
+			Value:    string(MemoryBackend),
+			EnvVars:  withEnvPrefix(envPrefix, "BACKEND"),
+			Category: category,
+		},
+		&cli.DurationFlag{
+			Name: TTLFlagName,
+			Usage: "How long the commitment of a completed dispersal is returned to repeated requests. Should be " +
+				"well within the cert's reference block number recency window. With a shared backend, this is " +
+				"also how long a dispersal claimed by a proxy that crashed blocks other proxies.",
+			Value:    10 * time.Minute,
+			EnvVars:  withEnvPrefix(envPrefix, "TTL"),
+			Category: category,
+		},
+		&cli.IntFlag{
+			Name:     MaxEntriesFlagName,
+			Usage:    "Maximum number of completed dispersals kept in memory.",
+			Value:    10_000,
+			EnvVars:  withEnvPrefix(envPrefix, "MAX_ENTRIES"),
+			Category: category,
+		},
+	}
+
+	return flags
+}
+
+func (c Config) Check() error {
+	switch c.Backend {
+	case MemoryBackend, RedisBackend, S3Backend:
+	default:
+		return fmt.Errorf("unknown idempotency backend %q", c.Backend)
+	}
+	if c.TTL <= 0 {
+		return fmt.Errorf("idempotency TTL must be positive, got %v", c.TTL)
+	}
+	if c.MaxEntries <= 0 {
+		return fmt.Errorf("idempotency max entries must be positive, got %d", c.MaxEntries)
+	}
+	return nil
+}
+
+func ReadConfig(ctx *cli.Context) Config {
+	return Config{
+		Enabled:    ctx.Bool(EnabledFlagName),
+		Backend:    Backend(ctx.String(BackendFlagName)),
+		TTL:        ctx.Duration(TTLFlagName),
+		MaxEntries: ctx.Int(MaxEntriesFlagName),
+	}
+}
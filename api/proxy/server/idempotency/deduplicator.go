@@ -0,0 +1,429 @@
+package idempotency
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2/payloaddispersal"
+	dispgrpc "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	"github.com/Layr-Labs/eigenda/api/proxy/common"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/proxyerrors"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/commitments"
+	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary/s3"
+	"github.com/Layr-Labs/eigenda/common/cache"
+	core "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Key identifies a dispersal. Requests with the same key are deduplicated.
+type Key [32]byte
+
+// PayloadKey returns the key of a request that is identified by its payload.
+func PayloadKey(mode commitments.CommitmentMode, payload []byte) Key {
+	return Key(crypto.Keccak256Hash([]byte("eigenda-proxy-payload"), []byte(mode), payload))
+}
+
+// ChildKey returns the key of a chunk of a payload that is split across several blobs. Chunks are keyed by their
+// payload alone, since the cert of a blob doesn't depend on the commitment mode.
+func ChildKey(chunk []byte) Key {
+	return Key(crypto.Keccak256Hash([]byte("eigenda-proxy-child"), chunk))
+}
+
+// HeaderKey returns the key of a request that is identified by the client supplied Idempotency-Key header.
+func HeaderKey(mode commitments.CommitmentMode, idempotencyKey string) Key {
+	return Key(crypto.Keccak256Hash([]byte("eigenda-proxy-idempotency-key"), []byte(mode), []byte(idempotencyKey)))
+}
+
+// DisperseFunc disperses a payload and returns the commitment to return to the client.
+type DisperseFunc func(ctx context.Context) ([]byte, error)
+
+const (
+	// Prefixes the keys of entries in the shared store, which is also used to cache payloads by cert.
+	resultKeyPrefix = "eigenda-proxy-idempotency-result:"
+	claimKeyPrefix  = "eigenda-proxy-idempotency-claim:"
+	// How often an instance waiting for a dispersal claimed by another instance checks whether it has completed.
+	claimPollInterval = time.Second
+)
+
+// resultKey returns the key under which the result of a dispersal is shared.
+func resultKey(key Key) []byte {
+	return append([]byte(resultKeyPrefix), key[:]...)
+}
+
+// claimKey returns the key under which an instance claims a dispersal.
+func claimKey(key Key) []byte {
+	return append([]byte(claimKeyPrefix), key[:]...)
+}
+
+// result is the outcome of a completed dispersal. Results are serialized as JSON when shared through a secondary
+// store.
+type result struct {
+	PayloadHash gethcommon.Hash `json:"payload_hash"`
+	Commitment  hexutil.Bytes   `json:"commitment"`
+	ExpiresAt   time.Time       `json:"expires_at"`
+}
+
+// claim records which payload a proxy instance is dispersing for a key, so that instances that find the key claimed
+// can reject a different payload.
+type claim struct {
+	PayloadHash gethcommon.Hash `json:"payload_hash"`
+}
+
+// dispersal is a dispersal in flight, which repeated requests wait for.
+type dispersal struct {
+	payloadHash gethcommon.Hash
+	// Closed once the dispersal is complete, after which commitment and err are set.
+	done       chan struct{}
+	commitment []byte
+	err        error
+
+	// Protects the fields below, which track the blob reported by the dispersal so that every waiting request can
+	// report it to its own BlobStatusListener.
+	lock       sync.Mutex
+	blobKey    core.BlobKey
+	blobStatus dispgrpc.BlobStatus
+	// Incremented each time a blob status is reported. Zero until the first report.
+	blobUpdates uint64
+	// Signalled when a blob status is reported. Each channel is buffered, so that updates are coalesced rather than
+	// blocking the dispersal.
+	watchers map[chan struct{}]struct{}
+}
+
+// observe records a blob status reported by the dispersal, and notifies the waiting requests. It is the
+// BlobStatusListener of the dispersal's own context.
+func (f *dispersal) observe(blobKey core.BlobKey, blobStatus dispgrpc.BlobStatus) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.blobKey = blobKey
+	f.blobStatus = blobStatus
+	f.blobUpdates++
+	for watcher := range f.watchers {
+		select {
+		case watcher <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// watch returns a channel that is signalled when a blob status is reported, and a function that stops watching. The
+// channel is signalled straight away if a status has already been reported.
+func (f *dispersal) watch() (<-chan struct{}, func()) {
+	watcher := make(chan struct{}, 1)
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.watchers[watcher] = struct{}{}
+	if f.blobUpdates > 0 {
+		watcher <- struct{}{}
+	}
+
+	return watcher, func() {
+		f.lock.Lock()
+		defer f.lock.Unlock()
+		delete(f.watchers, watcher)
+	}
+}
+
+// blob returns the latest blob status reported by the dispersal, and how many statuses have been reported.
+func (f *dispersal) blob() (core.BlobKey, dispgrpc.BlobStatus, uint64) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.blobKey, f.blobStatus, f.blobUpdates
+}
+
+// Deduplicator makes dispersals idempotent. A request with the same key as a dispersal in flight waits for that
+// dispersal, and a request with the same key as a completed dispersal returns its commitment, instead of paying
+// for the payload to be dispersed again. Failed dispersals are not remembered, so they can be retried.
+//
+// Requests are joined in memory within an instance. If there is a shared store, instances also agree on which of
+// them disperses a key by claiming it with a conditional write, and the others wait for the result to be shared.
+type Deduplicator struct {
+	log    logging.Logger
+	config Config
+
+	// Where dispersals are claimed and their results shared with other proxy instances. Nil if results are only
+	// kept in memory.
+	shared common.ClaimableSecondaryStore
+
+	// Protects inFlight and completed.
+	lock      sync.Mutex
+	inFlight  map[Key]*dispersal
+	completed cache.Cache[Key, *result]
+}
+
+// NewDeduplicator creates a new Deduplicator. If shared is not nil, dispersals are claimed in it, and the results of
+// completed dispersals are also written to and read from it.
+func NewDeduplicator(
+	log logging.Logger,
+	config Config,
+	shared common.ClaimableSecondaryStore,
+) (*Deduplicator, error) {
+	err := config.Check()
+	if err != nil {
+		return nil, fmt.Errorf("check idempotency config: %w", err)
+	}
+	if config.Backend != MemoryBackend && shared == nil {
+		return nil, fmt.Errorf("idempotency backend %s requires a secondary store", config.Backend)
+	}
+
+	return &Deduplicator{
+		log:       log,
+		config:    config,
+		shared:    shared,
+		inFlight:  make(map[Key]*dispersal),
+		completed: cache.NewLRUCache[Key, *result](uint64(config.MaxEntries), nil, nil),
+	}, nil
+}
+
+// Disperse returns the commitment of the dispersal with the given key, calling disperse if there isn't one in flight
+// or completed within the TTL. The dispersal continues if ctx is cancelled, so that a client that times out and
+// retries joins the original dispersal instead of starting another. If the key was already used for a different
+// payload, proxyerrors.ErrIdempotencyKeyReused is returned.
+//
+// The dispersal doesn't inherit the BlobStatusListener of ctx, since it may outlive the request. Instead, the blob
+// statuses it reports are passed on to the listener of every request waiting for it, on that request's goroutine,
+// until the request returns.
+func (d *Deduplicator) Disperse(ctx context.Context, key Key, payload []byte, disperse DisperseFunc) ([]byte, error) {
+	payloadHash := crypto.Keccak256Hash(payload)
+
+	d.lock.Lock()
+	flight, ok := d.inFlight[key]
+	if !ok {
+		completed, ok := d.completed.Get(key)
+		if ok && time.Now().Before(completed.ExpiresAt) {
+			d.lock.Unlock()
+			if completed.PayloadHash != payloadHash {
+				return nil, proxyerrors.ErrIdempotencyKeyReused
+			}
+			d.log.Info("Returning commitment of completed dispersal", "key", hexutil.Encode(key[:]))
+			return completed.Commitment, nil
+		}
+
+		flight = &dispersal{
+			payloadHash: payloadHash,
+			done:        make(chan struct{}),
+			watchers:    make(map[chan struct{}]struct{}),
+		}
+		d.inFlight[key] = flight
+		d.lock.Unlock()
+		dispersalCtx := payloaddispersal.WithBlobStatusListener(context.WithoutCancel(ctx), flight.observe)
+		go d.run(dispersalCtx, key, flight, disperse)
+	} else {
+		d.lock.Unlock()
+		d.log.Info("Joining dispersal in flight", "key", hexutil.Encode(key[:]))
+	}
+
+	if flight.payloadHash != payloadHash {
+		return nil, proxyerrors.ErrIdempotencyKeyReused
+	}
+
+	return d.wait(ctx, flight)
+}
+
+// wait waits for a dispersal to complete, and reports the blob statuses it reports to the listener of ctx, if any.
+func (d *Deduplicator) wait(ctx context.Context, flight *dispersal) ([]byte, error) {
+	updates, stopWatching := flight.watch()
+	defer stopWatching()
+
+	var reported uint64
+	for {
+		select {
+		case <-flight.done:
+			return flight.commitment, flight.err
+		case <-ctx.Done():
+			return nil, fmt.Errorf("wait for dispersal: %w", ctx.Err())
+		case <-updates:
+			blobKey, blobStatus, count := flight.blob()
+			if count != reported {
+				reported = count
+				payloaddispersal.ReportBlobStatus(ctx, blobKey, blobStatus)
+			}
+		}
+	}
+}
+
+// run completes a dispersal, either with a result shared by another proxy instance, or by dispersing the payload.
+func (d *Deduplicator) run(ctx context.Context, key Key, flight *dispersal, disperse DisperseFunc) {
+	var completed *result
+	defer func() {
+		d.lock.Lock()
+		delete(d.inFlight, key)
+		if completed != nil {
+			d.completed.Put(key, completed)
+		}
+		d.lock.Unlock()
+		close(flight.done)
+	}()
+
+	completed, flight.err = d.claimOrWait(ctx, key, flight.payloadHash)
+	if flight.err != nil {
+		return
+	}
+	if completed != nil {
+		d.log.Info("Returning commitment of dispersal shared by another proxy", "key", hexutil.Encode(key[:]))
+		flight.commitment = completed.Commitment
+		return
+	}
+
+	commitment, err := disperse(ctx)
+	if err != nil {
+		d.release(ctx, key)
+		flight.err = err
+		return
+	}
+	flight.commitment = commitment
+	completed = &result{
+		PayloadHash: flight.payloadHash,
+		Commitment:  commitment,
+		ExpiresAt:   time.Now().Add(d.config.TTL),
+	}
+	d.putShared(ctx, key, completed)
+	d.release(ctx, key)
+}
+
+// claimOrWait returns the result of a dispersal shared by another proxy instance, or nil if this instance should
+// disperse the payload. If another instance has claimed the key, it waits for that instance to share its result, or
+// to release its claim. Without a shared store, this instance always disperses the payload.
+func (d *Deduplicator) claimOrWait(ctx context.Context, key Key, payloadHash gethcommon.Hash) (*result, error) {
+	if d.shared == nil {
+		return nil, nil
+	}
+
+	value, err := json.Marshal(&claim{PayloadHash: payloadHash})
+	if err != nil {
+		return nil, fmt.Errorf("serialize idempotency claim: %w", err)
+	}
+
+	for {
+		completed := d.getShared(ctx, key)
+		if completed != nil {
+			if completed.PayloadHash != payloadHash {
+				return nil, proxyerrors.ErrIdempotencyKeyReused
+			}
+			return completed, nil
+		}
+
+		// Claims last as long as results, so that an instance that crashed mid-dispersal doesn't block the key for
+		// longer than a completed dispersal would.
+		claimed, err := d.shared.Claim(ctx, claimKey(key), value, d.config.TTL)
+		if err != nil {
+			// Dispersing without a claim risks a duplicate dispersal, but doesn't block the client.
+			d.log.Warn("Failed to claim idempotency key", "backend", d.shared.BackendType(), "err", err)
+			return nil, nil
+		}
+		if claimed {
+			// The previous holder may have shared its result and released its claim since the result was read.
+			completed = d.getShared(ctx, key)
+			if completed == nil {
+				return nil, nil
+			}
+			d.release(ctx, key)
+			if completed.PayloadHash != payloadHash {
+				return nil, proxyerrors.ErrIdempotencyKeyReused
+			}
+			return completed, nil
+		}
+
+		holder := d.getClaim(ctx, key)
+		if holder != nil && holder.PayloadHash != payloadHash {
+			return nil, proxyerrors.ErrIdempotencyKeyReused
+		}
+
+		d.log.Debug("Waiting for dispersal claimed by another proxy", "key", hexutil.Encode(key[:]))
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("wait for dispersal claimed by another proxy: %w", ctx.Err())
+		case <-time.After(claimPollInterval):
+		}
+	}
+}
+
+// getClaim reads the claim on a key. Returns nil if the key isn't claimed, or the claim can't be read.
+func (d *Deduplicator) getClaim(ctx context.Context, key Key) *claim {
+	value, err := d.shared.Get(ctx, claimKey(key))
+	if err != nil {
+		if !errors.Is(err, s3.ErrKeccakKeyNotFound) {
+			d.log.Warn("Failed to read idempotency claim", "backend", d.shared.BackendType(), "err", err)
+		}
+		return nil
+	}
+	if len(value) == 0 {
+		return nil
+	}
+
+	holder := &claim{}
+	err = json.Unmarshal(value, holder)
+	if err != nil {
+		d.log.Warn("Failed to deserialize idempotency claim", "backend", d.shared.BackendType(), "err", err)
+		return nil
+	}
+	return holder
+}
+
+// release releases this instance's claim on a key, if there is a shared store. Failures are logged, since the claim
+// expires eventually.
+func (d *Deduplicator) release(ctx context.Context, key Key) {
+	if d.shared == nil {
+		return
+	}
+	err := d.shared.Release(ctx, claimKey(key))
+	if err != nil {
+		d.log.Warn("Failed to release idempotency claim", "backend", d.shared.BackendType(), "err", err)
+	}
+}
+
+// getShared reads the result of a completed dispersal from the shared store. Returns nil if there is no shared
+// store, the result isn't found or has expired, or the store can't be read.
+func (d *Deduplicator) getShared(ctx context.Context, key Key) *result {
+	if d.shared == nil {
+		return nil
+	}
+
+	value, err := d.shared.Get(ctx, resultKey(key))
+	if err != nil {
+		if !errors.Is(err, s3.ErrKeccakKeyNotFound) {
+			d.log.Warn("Failed to read idempotency result", "backend", d.shared.BackendType(), "err", err)
+		}
+		return nil
+	}
+	if len(value) == 0 {
+		return nil
+	}
+
+	completed := &result{}
+	err = json.Unmarshal(value, completed)
+	if err != nil {
+		d.log.Warn("Failed to deserialize idempotency result", "backend", d.shared.BackendType(), "err", err)
+		return nil
+	}
+	if !time.Now().Before(completed.ExpiresAt) {
+		return nil
+	}
+	return completed
+}
+
+// putShared writes the result of a completed dispersal to the shared store, if there is one. Failures are logged,
+// since the dispersal itself succeeded.
+func (d *Deduplicator) putShared(ctx context.Context, key Key, completed *result) {
+	if d.shared == nil {
+		return
+	}
+
+	value, err := json.Marshal(completed)
+	if err != nil {
+		d.log.Warn("Failed to serialize idempotency result", "err", err)
+		return
+	}
+	err = d.shared.Put(ctx, resultKey(key), value)
+	if err != nil {
+		d.log.Warn("Failed to write idempotency result", "backend", d.shared.BackendType(), "err", err)
+	}
+}
@@ -0,0 +1,391 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2/payloaddispersal"
+	dispgrpc "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	"github.com/Layr-Labs/eigenda/api/proxy/common"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/proxyerrors"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/commitments"
+	core "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testLogger = logging.NewTextSLogger(os.Stdout, &logging.SLoggerOptions{})
+	testConfig = Config{
+		Enabled:    true,
+		Backend:    MemoryBackend,
+		TTL:        time.Hour,
+		MaxEntries: 100,
+	}
+)
+
+// testSharedStore is an in-memory secondary store. Like the redis store, it returns nil for missing keys.
+type testSharedStore struct {
+	lock sync.Mutex
+	data map[string][]byte
+}
+
+var _ common.ClaimableSecondaryStore = (*testSharedStore)(nil)
+
+func newTestSharedStore() *testSharedStore {
+	return &testSharedStore{data: make(map[string][]byte)}
+}
+
+func (s *testSharedStore) BackendType() common.BackendType {
+	return common.RedisBackendType
+}
+
+func (s *testSharedStore) Put(_ context.Context, key []byte, value []byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.data[string(key)] = value
+	return nil
+}
+
+func (s *testSharedStore) Get(_ context.Context, key []byte) ([]byte, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.data[string(key)], nil
+}
+
+// Claim ignores the TTL, since claims are always released by the tests.
+func (s *testSharedStore) Claim(_ context.Context, key []byte, value []byte, _ time.Duration) (bool, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if _, ok := s.data[string(key)]; ok {
+		return false, nil
+	}
+	s.data[string(key)] = value
+	return true, nil
+}
+
+func (s *testSharedStore) Release(_ context.Context, key []byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.data, string(key))
+	return nil
+}
+
+func (s *testSharedStore) Verify(context.Context, []byte, []byte) error {
+	return nil
+}
+
+// countingDisperser returns the payload as the commitment, and counts how many times it was called.
+type countingDisperser struct {
+	calls atomic.Int32
+	// If not nil, dispersals wait for it to be closed.
+	release chan struct{}
+	err     error
+}
+
+func (c *countingDisperser) disperse(payload []byte) DisperseFunc {
+	return func(ctx context.Context) ([]byte, error) {
+		c.calls.Add(1)
+		if c.release != nil {
+			<-c.release
+		}
+		if c.err != nil {
+			return nil, c.err
+		}
+		return payload, nil
+	}
+}
+
+func TestConcurrentRequestsJoin(t *testing.T) {
+	dedup, err := NewDeduplicator(testLogger, testConfig, nil)
+	require.NoError(t, err)
+
+	payload := []byte("payload")
+	key := PayloadKey(commitments.OptimismGenericCommitmentMode, payload)
+	disperser := &countingDisperser{release: make(chan struct{})}
+
+	var wg sync.WaitGroup
+	results := make([][]byte, 5)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, err := dedup.Disperse(context.Background(), key, payload, disperser.disperse(payload))
+			require.NoError(t, err)
+			results[i] = result
+		}()
+	}
+	require.Eventually(t, func() bool {
+		return disperser.calls.Load() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	close(disperser.release)
+	wg.Wait()
+
+	require.Equal(t, int32(1), disperser.calls.Load())
+	for _, result := range results {
+		require.Equal(t, payload, result)
+	}
+
+	// A repeated request returns the completed result.
+	result, err := dedup.Disperse(context.Background(), key, payload, disperser.disperse(payload))
+	require.NoError(t, err)
+	require.Equal(t, payload, result)
+	require.Equal(t, int32(1), disperser.calls.Load())
+
+	// A different payload is dispersed.
+	otherPayload := []byte("other payload")
+	otherKey := PayloadKey(commitments.OptimismGenericCommitmentMode, otherPayload)
+	result, err = dedup.Disperse(context.Background(), otherKey, otherPayload, disperser.disperse(otherPayload))
+	require.NoError(t, err)
+	require.Equal(t, otherPayload, result)
+	require.Equal(t, int32(2), disperser.calls.Load())
+}
+
+func TestCancelledRequestDoesNotCancelDispersal(t *testing.T) {
+	dedup, err := NewDeduplicator(testLogger, testConfig, nil)
+	require.NoError(t, err)
+
+	payload := []byte("payload")
+	key := HeaderKey(commitments.StandardCommitmentMode, "batch-1")
+	disperser := &countingDisperser{release: make(chan struct{})}
+
+	// The client times out while the payload is being dispersed.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = dedup.Disperse(ctx, key, payload, disperser.disperse(payload))
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// The retry joins the original dispersal.
+	close(disperser.release)
+	result, err := dedup.Disperse(context.Background(), key, payload, disperser.disperse(payload))
+	require.NoError(t, err)
+	require.Equal(t, payload, result)
+	require.Equal(t, int32(1), disperser.calls.Load())
+}
+
+func TestBlobStatusReportedToWaitingRequests(t *testing.T) {
+	dedup, err := NewDeduplicator(testLogger, testConfig, nil)
+	require.NoError(t, err)
+
+	payload := []byte("payload")
+	key := PayloadKey(commitments.StandardCommitmentMode, payload)
+	blobKey := core.BlobKey{1, 2, 3}
+	reported := make(chan struct{})
+	release := make(chan struct{})
+	disperse := func(ctx context.Context) ([]byte, error) {
+		payloaddispersal.ReportBlobStatus(ctx, blobKey, dispgrpc.BlobStatus_QUEUED)
+		close(reported)
+		<-release
+		payloaddispersal.ReportBlobStatus(ctx, blobKey, dispgrpc.BlobStatus_ENCODED)
+		return payload, nil
+	}
+
+	// listener records the statuses reported to a request, and fails the test if it is called after the request
+	// returned.
+	listener := func(returned *atomic.Bool, statuses chan dispgrpc.BlobStatus) payloaddispersal.BlobStatusListener {
+		return func(key core.BlobKey, status dispgrpc.BlobStatus) {
+			require.False(t, returned.Load())
+			require.Equal(t, blobKey, key)
+			statuses <- status
+		}
+	}
+
+	// The first request gives up while the payload is being dispersed.
+	var firstReturned atomic.Bool
+	firstStatuses := make(chan dispgrpc.BlobStatus, 10)
+	ctx, cancel := context.WithCancel(
+		payloaddispersal.WithBlobStatusListener(context.Background(), listener(&firstReturned, firstStatuses)))
+	firstDone := make(chan struct{})
+	go func() {
+		defer close(firstDone)
+		_, err := dedup.Disperse(ctx, key, payload, disperse)
+		firstReturned.Store(true)
+		require.ErrorIs(t, err, context.Canceled)
+	}()
+	<-reported
+	require.Equal(t, dispgrpc.BlobStatus_QUEUED, <-firstStatuses)
+	cancel()
+	<-firstDone
+
+	// A request joining the dispersal in flight is told the key of its blob straight away.
+	var secondReturned atomic.Bool
+	secondStatuses := make(chan dispgrpc.BlobStatus, 10)
+	secondCtx := payloaddispersal.WithBlobStatusListener(context.Background(), listener(&secondReturned, secondStatuses))
+	secondDone := make(chan struct{})
+	go func() {
+		defer close(secondDone)
+		result, err := dedup.Disperse(secondCtx, key, payload, disperse)
+		secondReturned.Store(true)
+		require.NoError(t, err)
+		require.Equal(t, payload, result)
+	}()
+	require.Equal(t, dispgrpc.BlobStatus_QUEUED, <-secondStatuses)
+
+	// Later statuses are only reported to the request still waiting.
+	close(release)
+	<-secondDone
+	require.Empty(t, firstStatuses)
+}
+
+func TestFailedDispersalIsRetried(t *testing.T) {
+	dedup, err := NewDeduplicator(testLogger, testConfig, nil)
+	require.NoError(t, err)
+
+	payload := []byte("payload")
+	key := PayloadKey(commitments.StandardCommitmentMode, payload)
+	disperser := &countingDisperser{err: errors.New("disperser unavailable")}
+
+	_, err = dedup.Disperse(context.Background(), key, payload, disperser.disperse(payload))
+	require.Error(t, err)
+
+	disperser.err = nil
+	result, err := dedup.Disperse(context.Background(), key, payload, disperser.disperse(payload))
+	require.NoError(t, err)
+	require.Equal(t, payload, result)
+	require.Equal(t, int32(2), disperser.calls.Load())
+}
+
+func TestIdempotencyKeyReused(t *testing.T) {
+	dedup, err := NewDeduplicator(testLogger, testConfig, nil)
+	require.NoError(t, err)
+
+	key := HeaderKey(commitments.StandardCommitmentMode, "batch-1")
+	disperser := &countingDisperser{}
+
+	_, err = dedup.Disperse(context.Background(), key, []byte("payload"), disperser.disperse([]byte("payload")))
+	require.NoError(t, err)
+
+	_, err = dedup.Disperse(context.Background(), key, []byte("other"), disperser.disperse([]byte("other")))
+	require.ErrorIs(t, err, proxyerrors.ErrIdempotencyKeyReused)
+	require.True(t, proxyerrors.Is400(err))
+	require.Equal(t, int32(1), disperser.calls.Load())
+}
+
+func TestResultsExpire(t *testing.T) {
+	config := testConfig
+	config.TTL = 50 * time.Millisecond
+	dedup, err := NewDeduplicator(testLogger, config, nil)
+	require.NoError(t, err)
+
+	payload := []byte("payload")
+	key := PayloadKey(commitments.StandardCommitmentMode, payload)
+	disperser := &countingDisperser{}
+
+	_, err = dedup.Disperse(context.Background(), key, payload, disperser.disperse(payload))
+	require.NoError(t, err)
+	time.Sleep(100 * time.Millisecond)
+	_, err = dedup.Disperse(context.Background(), key, payload, disperser.disperse(payload))
+	require.NoError(t, err)
+	require.Equal(t, int32(2), disperser.calls.Load())
+}
+
+func TestSharedResults(t *testing.T) {
+	config := testConfig
+	config.Backend = RedisBackend
+
+	_, err := NewDeduplicator(testLogger, config, nil)
+	require.Error(t, err)
+
+	// Two proxy instances share results through the same store.
+	shared := newTestSharedStore()
+	first, err := NewDeduplicator(testLogger, config, shared)
+	require.NoError(t, err)
+	second, err := NewDeduplicator(testLogger, config, shared)
+	require.NoError(t, err)
+
+	payload := []byte("payload")
+	key := PayloadKey(commitments.StandardCommitmentMode, payload)
+	disperser := &countingDisperser{}
+
+	result, err := first.Disperse(context.Background(), key, payload, disperser.disperse(payload))
+	require.NoError(t, err)
+	require.Equal(t, payload, result)
+
+	result, err = second.Disperse(context.Background(), key, payload, disperser.disperse(payload))
+	require.NoError(t, err)
+	require.Equal(t, payload, result)
+	require.Equal(t, int32(1), disperser.calls.Load())
+
+	// Results are namespaced, so they can't collide with payloads cached by cert in the same store.
+	value, err := shared.Get(context.Background(), key[:])
+	require.NoError(t, err)
+	require.Nil(t, value)
+	value, err = shared.Get(context.Background(), resultKey(key))
+	require.NoError(t, err)
+	require.NotNil(t, value)
+}
+
+func TestConcurrentRequestsJoinAcrossInstances(t *testing.T) {
+	config := testConfig
+	config.Backend = RedisBackend
+	shared := newTestSharedStore()
+	first, err := NewDeduplicator(testLogger, config, shared)
+	require.NoError(t, err)
+	second, err := NewDeduplicator(testLogger, config, shared)
+	require.NoError(t, err)
+
+	payload := []byte("payload")
+	key := HeaderKey(commitments.StandardCommitmentMode, "batch-1")
+	disperser := &countingDisperser{release: make(chan struct{})}
+
+	// The first instance claims the key, and the second waits for its result instead of dispersing.
+	firstResult := make(chan []byte, 1)
+	go func() {
+		result, err := first.Disperse(context.Background(), key, payload, disperser.disperse(payload))
+		require.NoError(t, err)
+		firstResult <- result
+	}()
+	require.Eventually(t, func() bool {
+		return disperser.calls.Load() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// A different payload with the same key is rejected by the second instance.
+	_, err = second.Disperse(context.Background(), key, []byte("other"), disperser.disperse([]byte("other")))
+	require.ErrorIs(t, err, proxyerrors.ErrIdempotencyKeyReused)
+
+	secondResult := make(chan []byte, 1)
+	go func() {
+		result, err := second.Disperse(context.Background(), key, payload, disperser.disperse(payload))
+		require.NoError(t, err)
+		secondResult <- result
+	}()
+
+	close(disperser.release)
+	require.Equal(t, payload, <-firstResult)
+	require.Equal(t, payload, <-secondResult)
+	require.Equal(t, int32(1), disperser.calls.Load())
+
+	// The claim is released once the result is shared.
+	value, err := shared.Get(context.Background(), claimKey(key))
+	require.NoError(t, err)
+	require.Nil(t, value)
+}
+
+func TestFailedDispersalReleasesClaim(t *testing.T) {
+	config := testConfig
+	config.Backend = RedisBackend
+	shared := newTestSharedStore()
+	first, err := NewDeduplicator(testLogger, config, shared)
+	require.NoError(t, err)
+	second, err := NewDeduplicator(testLogger, config, shared)
+	require.NoError(t, err)
+
+	payload := []byte("payload")
+	key := PayloadKey(commitments.StandardCommitmentMode, payload)
+	disperser := &countingDisperser{err: errors.New("disperser unavailable")}
+
+	_, err = first.Disperse(context.Background(), key, payload, disperser.disperse(payload))
+	require.Error(t, err)
+
+	// Another instance can retry the dispersal straight away.
+	disperser.err = nil
+	result, err := second.Disperse(context.Background(), key, payload, disperser.disperse(payload))
+	require.NoError(t, err)
+	require.Equal(t, payload, result)
+	require.Equal(t, int32(2), disperser.calls.Load())
+}
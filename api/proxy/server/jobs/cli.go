@@ -0,0 +1,90 @@
+package jobs
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	EnabledFlagName     = "async-put.enabled"
+	DBPathFlagName      = "async-put.db-path"
+	RetentionFlagName   = "async-put.retention"
+	MaxInFlightFlagName = "async-put.max-in-flight"
+)
+
+// Config ... Config for asynchronous dispersals
+type Config struct {
+	// Accept POST /put?async=true requests
+	Enabled bool
+	// Directory of the LevelDB database where jobs are persisted. Required if async puts are enabled.
+	DBPath string
+	// How long completed and failed jobs are kept before they are deleted
+	Retention time.Duration
+	// The maximum number of asynchronous dispersals in flight at once
+	MaxInFlight int
+}
+
+func withEnvPrefix(prefix, s string) []string {
+	return []string{prefix + "_ASYNC_PUT_" + s}
+}
+
+func CLIFlags(envPrefix string, category string) []cli.Flag {
+	flags := []cli.Flag{
+		&cli.BoolFlag{
+			Name: EnabledFlagName,
+			Usage: "Accept asynchronous dispersals (POST /put?async=true), whose progress is reported by " +
+				"GET /put/status/{id}.",
+			Value:    false,
+			EnvVars:  withEnvPrefix(envPrefix, "ENABLED"),
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name: DBPathFlagName,
+			Usage: "Directory where asynchronous dispersal jobs are persisted, so that their results survive a " +
+				"restart. Required if async puts are enabled.",
+			Value:    "",
+			EnvVars:  withEnvPrefix(envPrefix, "DB_PATH"),
+			Category: category,
+		},
+		&cli.DurationFlag{
+			Name:     RetentionFlagName,
+			Usage:    "How long completed and failed asynchronous dispersal jobs are kept.",
+			Value:    24 * time.Hour,
+			EnvVars:  withEnvPrefix(envPrefix, "RETENTION"),
+			Category: category,
+		},
+		&cli.IntFlag{
+			Name:     MaxInFlightFlagName,
+			Usage:    "Maximum number of asynchronous dispersals in flight. Further requests are rejected with a 429.",
+			Value:    32,
+			EnvVars:  withEnvPrefix(envPrefix, "MAX_IN_FLIGHT"),
+			Category: category,
+		},
+	}
+
+	return flags
+}
+
+func (c Config) Check() error {
+	if c.Enabled && c.DBPath == "" {
+		return fmt.Errorf("async put DB path is required when async puts are enabled")
+	}
+	if c.Retention <= 0 {
+		return fmt.Errorf("async put retention must be positive, got %v", c.Retention)
+	}
+	if c.MaxInFlight <= 0 {
+		return fmt.Errorf("async put max in flight must be positive, got %d", c.MaxInFlight)
+	}
+	return nil
+}
+
+func ReadConfig(ctx *cli.Context) Config {
+	return Config{
+		Enabled:     ctx.Bool(EnabledFlagName),
+		DBPath:      ctx.String(DBPathFlagName),
+		Retention:   ctx.Duration(RetentionFlagName),
+		MaxInFlight: ctx.Int(MaxInFlightFlagName),
+	}
+}
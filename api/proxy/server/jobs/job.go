@@ -0,0 +1,47 @@
+package jobs
+
+import (
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/commitments"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Status is the progress of an asynchronous dispersal.
+type Status string
+
+const (
+	// StatusQueued means the payload is waiting to be encoded by the disperser.
+	StatusQueued Status = "QUEUED"
+	// StatusEncoded means the blob has been encoded and is waiting to be sent to validators.
+	StatusEncoded Status = "ENCODED"
+	// StatusGatheringSignatures means the blob has been sent to validators, and their signatures are being gathered.
+	StatusGatheringSignatures Status = "GATHERING_SIGNATURES"
+	// StatusComplete means the dispersal succeeded, and the job's commitment is set.
+	StatusComplete Status = "COMPLETE"
+	// StatusFailed means the dispersal failed, and the job's error is set.
+	StatusFailed Status = "FAILED"
+)
+
+// IsTerminal returns true if the job will not make further progress.
+func (s Status) IsTerminal() bool {
+	return s == StatusComplete || s == StatusFailed
+}
+
+// Job is an asynchronous dispersal. Jobs are persisted as JSON, and the same representation is served by the
+// status endpoint.
+type Job struct {
+	ID             string                     `json:"id"`
+	CommitmentMode commitments.CommitmentMode `json:"commitment_mode"`
+	Status         Status                     `json:"status"`
+	// The hex encoded key of the job's blob, once it has been dispersed. Used to resume the dispersal if the proxy
+	// restarts. Not set for payloads dispersed as several blobs.
+	BlobKey string `json:"blob_key,omitempty"`
+	// The commitment returned by a synchronous POST /put request for the same payload. Only set once the job
+	// is complete.
+	Commitment hexutil.Bytes `json:"commitment,omitempty"`
+	// The reason the dispersal failed. Only set if the job has failed.
+	Error     string    `json:"error,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,378 @@
+package jobs
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2/payloaddispersal"
+	dispgrpc "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/commitments"
+	"github.com/Layr-Labs/eigenda/common/kvstore"
+	core "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// The length of a job ID, in bytes, before hex encoding.
+	jobIDLength = 16
+	// How often completed and failed jobs are checked for expiry.
+	pruneInterval = 10 * time.Minute
+)
+
+// ErrJobNotFound is returned when a job does not exist, or has expired.
+var ErrJobNotFound = errors.New("dispersal job not found")
+
+// DisperseFunc disperses a payload and returns the commitment to return to the client. The idempotency key is the
+// client supplied Idempotency-Key header, or empty if it wasn't set.
+type DisperseFunc func(
+	ctx context.Context,
+	mode commitments.CommitmentMode,
+	payload []byte,
+	idempotencyKey string,
+) ([]byte, error)
+
+// ResumeFunc finishes a dispersal that was interrupted by a restart, given the key of its blob, and returns the
+// commitment to return to the client.
+type ResumeFunc func(ctx context.Context, mode commitments.CommitmentMode, blobKey core.BlobKey) ([]byte, error)
+
+// Tracker runs dispersals in the background and tracks their progress. Jobs are persisted in a key-value store,
+// so that the result of a completed dispersal is not lost if the proxy restarts.
+type Tracker struct {
+	ctx      context.Context
+	cancel   context.CancelFunc
+	log      logging.Logger
+	store    kvstore.Store[[]byte]
+	config   Config
+	disperse DisperseFunc
+	resume   ResumeFunc
+
+	// Protects writes to the store, so that updates to a job are never reordered.
+	lock sync.Mutex
+
+	// Limits the number of dispersals in flight.
+	inFlight chan struct{}
+
+	// Tracks the goroutines that use the store, so that Stop can wait for them.
+	running sync.WaitGroup
+}
+
+// NewTracker creates a new Tracker. Jobs that were in flight when the proxy last stopped are resumed with the resume
+// function if their blob had been dispersed, and are marked as failed otherwise. Jobs are dispersed with the given
+// context, and are abandoned when it is cancelled or when Stop is called.
+func NewTracker(
+	ctx context.Context,
+	log logging.Logger,
+	store kvstore.Store[[]byte],
+	config Config,
+	disperse DisperseFunc,
+	resume ResumeFunc,
+) (*Tracker, error) {
+	err := config.Check()
+	if err != nil {
+		return nil, fmt.Errorf("check async put config: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	tracker := &Tracker{
+		ctx:      ctx,
+		cancel:   cancel,
+		log:      log,
+		store:    store,
+		config:   config,
+		disperse: disperse,
+		resume:   resume,
+		inFlight: make(chan struct{}, config.MaxInFlight),
+	}
+
+	err = tracker.recover()
+	if err != nil {
+		tracker.Stop()
+		return nil, fmt.Errorf("recover dispersal jobs: %w", err)
+	}
+
+	tracker.running.Add(1)
+	go tracker.pruneExpiredJobs()
+
+	return tracker, nil
+}
+
+// Stop abandons the dispersals in flight, and waits until the tracker no longer uses its store. The store must only be
+// closed once Stop has returned. Submit must not be called concurrently with or after Stop.
+func (t *Tracker) Stop() {
+	t.cancel()
+	t.running.Wait()
+}
+
+// Submit starts dispersing a payload in the background, and returns the job tracking its progress. If too many
+// dispersals are already in flight, a ResourceExhausted error is returned, which is reported to the client as a 429.
+//
+// The idempotency key is passed through to the disperse function. If resumable is true, the key of the payload's
+// blob is persisted as soon as it has been dispersed, so that the dispersal can be resumed if the proxy restarts.
+// This must be false for payloads dispersed as several blobs, since a single blob key doesn't identify their
+// dispersal.
+func (t *Tracker) Submit(
+	mode commitments.CommitmentMode,
+	payload []byte,
+	idempotencyKey string,
+	resumable bool,
+) (*Job, error) {
+	select {
+	case t.inFlight <- struct{}{}:
+	default:
+		return nil, status.Errorf(codes.ResourceExhausted,
+			"too many asynchronous dispersals in flight (max %d)", t.config.MaxInFlight)
+	}
+
+	id := make([]byte, jobIDLength)
+	_, err := rand.Read(id)
+	if err != nil {
+		<-t.inFlight
+		return nil, fmt.Errorf("generate job ID: %w", err)
+	}
+
+	now := time.Now()
+	job := &Job{
+		ID:             hex.EncodeToString(id),
+		CommitmentMode: mode,
+		Status:         StatusQueued,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	err = t.put(job)
+	if err != nil {
+		<-t.inFlight
+		return nil, err
+	}
+
+	t.running.Add(1)
+	go t.run(*job, resumable, func(ctx context.Context) ([]byte, error) {
+		return t.disperse(ctx, job.CommitmentMode, payload, idempotencyKey)
+	})
+
+	return job, nil
+}
+
+// Get returns the job with the given ID.
+func (t *Tracker) Get(id string) (*Job, error) {
+	value, err := t.store.Get([]byte(id))
+	if err != nil {
+		if errors.Is(err, kvstore.ErrNotFound) {
+			return nil, ErrJobNotFound
+		}
+		return nil, fmt.Errorf("read job %s: %w", id, err)
+	}
+
+	job := &Job{}
+	err = json.Unmarshal(value, job)
+	if err != nil {
+		return nil, fmt.Errorf("deserialize job %s: %w", id, err)
+	}
+	return job, nil
+}
+
+// run runs the dispersal of a job, and records its progress. If recordBlobKey is true, the key of the job's blob is
+// persisted as soon as it is reported.
+func (t *Tracker) run(job Job, recordBlobKey bool, disperse func(ctx context.Context) ([]byte, error)) {
+	defer func() {
+		<-t.inFlight
+		t.running.Done()
+	}()
+
+	ctx := payloaddispersal.WithBlobStatusListener(t.ctx,
+		func(blobKey core.BlobKey, blobStatus dispgrpc.BlobStatus) {
+			changed := false
+			if recordBlobKey && job.BlobKey == "" {
+				job.BlobKey = blobKey.Hex()
+				changed = true
+			}
+			jobStatus, ok := statusFromBlobStatus(blobStatus)
+			if ok && jobStatus != job.Status {
+				job.Status = jobStatus
+				changed = true
+			}
+			if changed {
+				t.update(&job)
+			}
+		})
+
+	commitment, err := disperse(ctx)
+	if err != nil {
+		t.log.Warn("Asynchronous dispersal failed", "jobID", job.ID, "err", err)
+		job.Status = StatusFailed
+		job.Error = err.Error()
+	} else {
+		t.log.Info("Asynchronous dispersal complete", "jobID", job.ID, "commitmentMode", job.CommitmentMode)
+		job.Status = StatusComplete
+		job.Commitment = commitment
+	}
+	t.update(&job)
+}
+
+// update persists the latest state of a job. Errors are logged rather than returned, since the dispersal should
+// continue regardless. Once the tracker's context is cancelled updates are dropped, and the job is marked as failed
+// when the proxy next starts.
+func (t *Tracker) update(job *Job) {
+	if t.ctx.Err() != nil {
+		t.log.Warn("Dropping update to abandoned dispersal job", "jobID", job.ID, "status", job.Status)
+		return
+	}
+	job.UpdatedAt = time.Now()
+	err := t.put(job)
+	if err != nil {
+		t.log.Error("Failed to persist dispersal job", "jobID", job.ID, "status", job.Status, "err", err)
+	}
+}
+
+// put writes a job to the store.
+func (t *Tracker) put(job *Job) error {
+	value, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("serialize job %s: %w", job.ID, err)
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	err = t.store.Put([]byte(job.ID), value)
+	if err != nil {
+		return fmt.Errorf("write job %s: %w", job.ID, err)
+	}
+	return nil
+}
+
+// recover resumes jobs that were in flight when the proxy last stopped, and deletes expired jobs. Jobs whose blob key
+// was persisted are resumed by polling the status of their blob. Jobs without a blob key are marked as failed, since
+// their payload is no longer available.
+func (t *Tracker) recover() error {
+	jobs, err := t.loadJobs()
+	if err != nil {
+		return err
+	}
+
+	for _, job := range jobs {
+		if job.Status.IsTerminal() {
+			continue
+		}
+
+		if job.BlobKey != "" {
+			blobKey, err := core.HexToBlobKey(job.BlobKey)
+			if err == nil {
+				t.log.Info("Resuming dispersal job interrupted by a restart",
+					"jobID", job.ID, "status", job.Status, "blobKey", job.BlobKey)
+				t.running.Add(1)
+				go t.resumeJob(*job, blobKey)
+				continue
+			}
+			t.log.Warn("Dispersal job has an invalid blob key", "jobID", job.ID, "blobKey", job.BlobKey, "err", err)
+		}
+
+		t.log.Warn("Dispersal job was interrupted by a restart", "jobID", job.ID, "status", job.Status)
+		job.Status = StatusFailed
+		job.Error = "dispersal was interrupted by a proxy restart"
+		job.UpdatedAt = time.Now()
+		err = t.put(job)
+		if err != nil {
+			return err
+		}
+	}
+
+	return t.prune()
+}
+
+// resumeJob waits for room among the dispersals in flight, and then resumes a job interrupted by a restart.
+func (t *Tracker) resumeJob(job Job, blobKey core.BlobKey) {
+	select {
+	case t.inFlight <- struct{}{}:
+	case <-t.ctx.Done():
+		t.running.Done()
+		return
+	}
+
+	t.run(job, false, func(ctx context.Context) ([]byte, error) {
+		return t.resume(ctx, job.CommitmentMode, blobKey)
+	})
+}
+
+// pruneExpiredJobs periodically deletes expired jobs, until the tracker's context is cancelled.
+func (t *Tracker) pruneExpiredJobs() {
+	defer t.running.Done()
+	ticker := time.NewTicker(pruneInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-t.ctx.Done():
+			return
+		case <-ticker.C:
+			err := t.prune()
+			if err != nil {
+				t.log.Error("Failed to prune expired dispersal jobs", "err", err)
+			}
+		}
+	}
+}
+
+// prune deletes completed and failed jobs that have not been updated within the retention period.
+func (t *Tracker) prune() error {
+	jobs, err := t.loadJobs()
+	if err != nil {
+		return err
+	}
+
+	cutoff := time.Now().Add(-t.config.Retention)
+	for _, job := range jobs {
+		if !job.Status.IsTerminal() || job.UpdatedAt.After(cutoff) {
+			continue
+		}
+		err = t.store.Delete([]byte(job.ID))
+		if err != nil {
+			return fmt.Errorf("delete job %s: %w", job.ID, err)
+		}
+	}
+	return nil
+}
+
+// loadJobs reads all jobs from the store.
+func (t *Tracker) loadJobs() ([]*Job, error) {
+	iterator, err := t.store.NewIterator(nil)
+	if err != nil {
+		return nil, fmt.Errorf("iterate jobs: %w", err)
+	}
+	defer iterator.Release()
+
+	jobs := make([]*Job, 0)
+	for iterator.Next() {
+		job := &Job{}
+		err = json.Unmarshal(iterator.Value(), job)
+		if err != nil {
+			return nil, fmt.Errorf("deserialize job %s: %w", iterator.Key(), err)
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+// statusFromBlobStatus converts the status of a blob reported by the disperser into the status of a job. Returns
+// false for blob statuses that don't correspond to a job status. COMPLETE is not converted, since the cert still
+// needs to be built and verified before the job is complete.
+func statusFromBlobStatus(blobStatus dispgrpc.BlobStatus) (Status, bool) {
+	//nolint:exhaustive // the remaining statuses are either terminal or unknown
+	switch blobStatus {
+	case dispgrpc.BlobStatus_QUEUED:
+		return StatusQueued, true
+	case dispgrpc.BlobStatus_ENCODED:
+		return StatusEncoded, true
+	case dispgrpc.BlobStatus_GATHERING_SIGNATURES, dispgrpc.BlobStatus_COMPLETE:
+		return StatusGatheringSignatures, true
+	default:
+		return "", false
+	}
+}
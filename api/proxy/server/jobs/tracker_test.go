@@ -0,0 +1,275 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2/payloaddispersal"
+	dispgrpc "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/proxyerrors"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/commitments"
+	"github.com/Layr-Labs/eigenda/common/kvstore/mapstore"
+	core "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testLogger = logging.NewTextSLogger(os.Stdout, &logging.SLoggerOptions{})
+	testConfig = Config{
+		Enabled: true,
+		// The tests pass their own store, so the path is never opened.
+		DBPath:      "unused",
+		Retention:   time.Hour,
+		MaxInFlight: 2,
+	}
+)
+
+// waitForStatus polls a job until it reaches the given status.
+func waitForStatus(t *testing.T, tracker *Tracker, id string, status Status) *Job {
+	var job *Job
+	require.Eventually(t, func() bool {
+		var err error
+		job, err = tracker.Get(id)
+		require.NoError(t, err)
+		return job.Status == status
+	}, 5*time.Second, 10*time.Millisecond)
+	return job
+}
+
+func TestTrackerDispersal(t *testing.T) {
+	ctx := context.Background()
+	store := mapstore.NewStore()
+
+	release := make(chan struct{})
+	tracker, err := NewTracker(ctx, testLogger, store, testConfig,
+		func(ctx context.Context, mode commitments.CommitmentMode, payload []byte, key string) ([]byte, error) {
+			<-release
+			if string(payload) == "bad payload" {
+				return nil, errors.New("dispersal failed")
+			}
+			return append(append([]byte(mode), payload...), key...), nil
+		}, nil)
+	require.NoError(t, err)
+
+	job, err := tracker.Submit(commitments.StandardCommitmentMode, []byte("payload"), "-batch-1", true)
+	require.NoError(t, err)
+	require.Equal(t, StatusQueued, job.Status)
+	failedJob, err := tracker.Submit(commitments.StandardCommitmentMode, []byte("bad payload"), "", true)
+	require.NoError(t, err)
+	require.NotEqual(t, job.ID, failedJob.ID)
+
+	// Further dispersals are rejected with a 429 while the limit is reached.
+	_, err = tracker.Submit(commitments.StandardCommitmentMode, []byte("payload"), "", true)
+	require.True(t, proxyerrors.Is429(err))
+
+	queuedJob, err := tracker.Get(job.ID)
+	require.NoError(t, err)
+	require.Equal(t, StatusQueued, queuedJob.Status)
+
+	close(release)
+
+	completeJob := waitForStatus(t, tracker, job.ID, StatusComplete)
+	// The idempotency key is passed through to the dispersal.
+	require.Equal(t, []byte("standardpayload-batch-1"), []byte(completeJob.Commitment))
+	require.Empty(t, completeJob.Error)
+
+	failedJob = waitForStatus(t, tracker, failedJob.ID, StatusFailed)
+	require.Empty(t, failedJob.Commitment)
+	require.Contains(t, failedJob.Error, "dispersal failed")
+
+	_, err = tracker.Get("unknown")
+	require.ErrorIs(t, err, ErrJobNotFound)
+}
+
+func TestTrackerRestart(t *testing.T) {
+	store := mapstore.NewStore()
+	blobKey := core.BlobKey{1, 2, 3}
+
+	// The first tracker completes one dispersal, and is stopped while others are in flight. The blob of one of them
+	// has been dispersed, and the other hasn't reached the disperser yet.
+	ctx, cancel := context.WithCancel(context.Background())
+	release := make(chan struct{})
+	tracker, err := NewTracker(ctx, testLogger, store, Config{
+		Enabled:     true,
+		DBPath:      "unused",
+		Retention:   time.Hour,
+		MaxInFlight: 3,
+	},
+		func(ctx context.Context, mode commitments.CommitmentMode, payload []byte, _ string) ([]byte, error) {
+			switch string(payload) {
+			case "dispersed payload":
+				payloaddispersal.ReportBlobStatus(ctx, blobKey, dispgrpc.BlobStatus_QUEUED)
+				<-release
+			case "slow payload":
+				<-release
+			}
+			return payload, nil
+		}, nil)
+	require.NoError(t, err)
+
+	completeJob, err := tracker.Submit(commitments.OptimismGenericCommitmentMode, []byte("payload"), "", true)
+	require.NoError(t, err)
+	waitForStatus(t, tracker, completeJob.ID, StatusComplete)
+	dispersedJob, err := tracker.Submit(commitments.OptimismGenericCommitmentMode, []byte("dispersed payload"), "", true)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		job, err := tracker.Get(dispersedJob.ID)
+		require.NoError(t, err)
+		return job.BlobKey == blobKey.Hex()
+	}, 5*time.Second, 10*time.Millisecond)
+	interruptedJob, err := tracker.Submit(commitments.OptimismGenericCommitmentMode, []byte("slow payload"), "", true)
+	require.NoError(t, err)
+	cancel()
+
+	// The second tracker still serves the completed job, resumes the dispersed one, and fails the one whose blob
+	// was never dispersed.
+	resumed := make(chan core.BlobKey, 1)
+	tracker, err = NewTracker(context.Background(), testLogger, store, testConfig,
+		func(ctx context.Context, mode commitments.CommitmentMode, payload []byte, _ string) ([]byte, error) {
+			return payload, nil
+		},
+		func(ctx context.Context, mode commitments.CommitmentMode, blobKey core.BlobKey) ([]byte, error) {
+			resumed <- blobKey
+			return []byte("resumed"), nil
+		})
+	require.NoError(t, err)
+
+	job, err := tracker.Get(completeJob.ID)
+	require.NoError(t, err)
+	require.Equal(t, StatusComplete, job.Status)
+	require.Equal(t, []byte("payload"), []byte(job.Commitment))
+
+	job = waitForStatus(t, tracker, dispersedJob.ID, StatusComplete)
+	require.Equal(t, []byte("resumed"), []byte(job.Commitment))
+	require.Equal(t, blobKey, <-resumed)
+
+	job, err = tracker.Get(interruptedJob.ID)
+	require.NoError(t, err)
+	require.Equal(t, StatusFailed, job.Status)
+	require.NotEmpty(t, job.Error)
+
+	// The abandoned dispersals finishing late are not reported as the jobs' results.
+	close(release)
+	require.Never(t, func() bool {
+		job, err := tracker.Get(interruptedJob.ID)
+		require.NoError(t, err)
+		return job.Status != StatusFailed
+	}, 100*time.Millisecond, 10*time.Millisecond)
+}
+
+func TestTrackerStop(t *testing.T) {
+	store := mapstore.NewStore()
+	started := make(chan struct{})
+	stopped := make(chan struct{})
+	tracker, err := NewTracker(context.Background(), testLogger, store, testConfig,
+		func(ctx context.Context, mode commitments.CommitmentMode, payload []byte, _ string) ([]byte, error) {
+			close(started)
+			<-ctx.Done()
+			<-stopped
+			return payload, nil
+		}, nil)
+	require.NoError(t, err)
+
+	job, err := tracker.Submit(commitments.StandardCommitmentMode, []byte("payload"), "", true)
+	require.NoError(t, err)
+	<-started
+
+	// Stop waits for the abandoned dispersal to return.
+	done := make(chan struct{})
+	go func() {
+		tracker.Stop()
+		close(done)
+	}()
+	require.Never(t, func() bool {
+		select {
+		case <-done:
+			return true
+		default:
+			return false
+		}
+	}, 100*time.Millisecond, 10*time.Millisecond)
+	close(stopped)
+	<-done
+
+	// The result of the abandoned dispersal is not written to the store.
+	job, err = tracker.Get(job.ID)
+	require.NoError(t, err)
+	require.Equal(t, StatusQueued, job.Status)
+}
+
+func TestTrackerOnlyRecordsResumableBlobKeys(t *testing.T) {
+	tracker, err := NewTracker(context.Background(), testLogger, mapstore.NewStore(), testConfig,
+		func(ctx context.Context, mode commitments.CommitmentMode, payload []byte, _ string) ([]byte, error) {
+			payloaddispersal.ReportBlobStatus(ctx, core.BlobKey{1}, dispgrpc.BlobStatus_ENCODED)
+			return payload, nil
+		}, nil)
+	require.NoError(t, err)
+
+	// The blob of a payload split across several blobs doesn't identify its dispersal.
+	job, err := tracker.Submit(commitments.StandardCommitmentMode, []byte("split payload"), "", false)
+	require.NoError(t, err)
+	job = waitForStatus(t, tracker, job.ID, StatusComplete)
+	require.Empty(t, job.BlobKey)
+
+	job, err = tracker.Submit(commitments.StandardCommitmentMode, []byte("payload"), "", true)
+	require.NoError(t, err)
+	job = waitForStatus(t, tracker, job.ID, StatusComplete)
+	require.Equal(t, core.BlobKey{1}.Hex(), job.BlobKey)
+}
+
+func TestTrackerPrunesExpiredJobs(t *testing.T) {
+	store := mapstore.NewStore()
+	tracker, err := NewTracker(context.Background(), testLogger, store, testConfig,
+		func(ctx context.Context, mode commitments.CommitmentMode, payload []byte, _ string) ([]byte, error) {
+			return payload, nil
+		}, nil)
+	require.NoError(t, err)
+
+	expired := &Job{
+		ID:        "expired",
+		Status:    StatusComplete,
+		CreatedAt: time.Now().Add(-2 * time.Hour),
+		UpdatedAt: time.Now().Add(-2 * time.Hour),
+	}
+	recent := &Job{
+		ID:        "recent",
+		Status:    StatusFailed,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	require.NoError(t, tracker.put(expired))
+	require.NoError(t, tracker.put(recent))
+
+	require.NoError(t, tracker.prune())
+
+	_, err = tracker.Get(expired.ID)
+	require.ErrorIs(t, err, ErrJobNotFound)
+	_, err = tracker.Get(recent.ID)
+	require.NoError(t, err)
+}
+
+func TestStatusFromBlobStatus(t *testing.T) {
+	status, ok := statusFromBlobStatus(dispgrpc.BlobStatus_QUEUED)
+	require.True(t, ok)
+	require.Equal(t, StatusQueued, status)
+
+	status, ok = statusFromBlobStatus(dispgrpc.BlobStatus_ENCODED)
+	require.True(t, ok)
+	require.Equal(t, StatusEncoded, status)
+
+	status, ok = statusFromBlobStatus(dispgrpc.BlobStatus_GATHERING_SIGNATURES)
+	require.True(t, ok)
+	require.Equal(t, StatusGatheringSignatures, status)
+
+	// The job is only complete once the cert has been returned.
+	status, ok = statusFromBlobStatus(dispgrpc.BlobStatus_COMPLETE)
+	require.True(t, ok)
+	require.Equal(t, StatusGatheringSignatures, status)
+
+	_, ok = statusFromBlobStatus(dispgrpc.BlobStatus_FAILED)
+	require.False(t, ok)
+}
@@ -0,0 +1,65 @@
+package multiblob
+
+import (
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/certs"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	MaxBlobsFlagName = "multi-blob.max-blobs"
+)
+
+// Config ... Config for payloads that are split across multiple blobs
+type Config struct {
+	// The maximum number of blobs a payload can be split across. 1 disables splitting.
+	//
+	// The commitment of a split payload is a manifest holding the cert of every blob, and is posted to L1 calldata
+	// like any other commitment. Each V3 cert is on the order of 1 KB, so a manifest of the maximum of 256 certs is a
+	// few hundred KB, which exceeds the size of a typical L1 transaction. MaxBlobs should be kept small enough that
+	// the manifest fits in the rollup's batch transactions.
+	MaxBlobs int
+}
+
+// Enabled returns true if payloads can be split across multiple blobs.
+func (c Config) Enabled() bool {
+	return c.MaxBlobs > 1
+}
+
+func withEnvPrefix(prefix, s string) []string {
+	return []string{prefix + "_MULTI_BLOB_" + s}
+}
+
+func CLIFlags(envPrefix string, category string) []cli.Flag {
+	flags := []cli.Flag{
+		&cli.IntFlag{
+			Name: MaxBlobsFlagName,
+			Usage: fmt.Sprintf("Maximum number of EigenDA V2 blobs a payload can be split across. Payloads that "+
+				"don't fit in a single blob are dispersed as several blobs in parallel, and are committed to with "+
+				"a manifest cert (version byte 0x%x), which holds the cert of every blob (about 1 KB each) and is "+
+				"only understood by the proxy, not by on-chain or fault-proof cert verification. 1 disables "+
+				"splitting. At most %d.",
+				byte(certs.ManifestVersionByte), certs.MaxManifestChildren),
+			Value:    1,
+			EnvVars:  withEnvPrefix(envPrefix, "MAX_BLOBS"),
+			Category: category,
+		},
+	}
+
+	return flags
+}
+
+func (c Config) Check() error {
+	if c.MaxBlobs < 1 || c.MaxBlobs > certs.MaxManifestChildren {
+		return fmt.Errorf("multi-blob max blobs must be between 1 and %d, got %d",
+			certs.MaxManifestChildren, c.MaxBlobs)
+	}
+	return nil
+}
+
+func ReadConfig(ctx *cli.Context) Config {
+	return Config{
+		MaxBlobs: ctx.Int(MaxBlobsFlagName),
+	}
+}
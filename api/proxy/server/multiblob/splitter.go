@@ -0,0 +1,143 @@
+package multiblob
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda/api/proxy/common/proxyerrors"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/certs"
+	eigenda_v2 "github.com/Layr-Labs/eigenda/api/proxy/store/generated_key/v2"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/encoding/utils/codec"
+	"golang.org/x/sync/errgroup"
+)
+
+// PutBlobFunc disperses a payload that fits in a single blob, and returns its cert.
+type PutBlobFunc func(ctx context.Context, payload []byte) (certs.VersionedCert, error)
+
+// GetBlobFunc fetches and verifies the payload of a single blob.
+type GetBlobFunc func(ctx context.Context, versionedCert certs.VersionedCert) ([]byte, error)
+
+// Splitter splits payloads that are too large for a single blob into chunks, which are dispersed as separate blobs.
+type Splitter struct {
+	maxBlobs int
+	// The size of the largest payload that fits in a single blob.
+	maxBlobPayloadSize uint64
+}
+
+// NewSplitter creates a new Splitter for blobs of at most maxBlobSizeBytes.
+func NewSplitter(config Config, maxBlobSizeBytes uint64) (*Splitter, error) {
+	err := config.Check()
+	if err != nil {
+		return nil, fmt.Errorf("check multi-blob config: %w", err)
+	}
+	if maxBlobSizeBytes < encoding.BYTES_PER_SYMBOL || maxBlobSizeBytes > uint64(^uint32(0)) {
+		return nil, fmt.Errorf("invalid max blob size %d", maxBlobSizeBytes)
+	}
+
+	// Blobs are padded to a power of 2, so the largest usable blob is the largest power of 2 within the limit.
+	blobSize := uint32(1)
+	for uint64(blobSize)*2 <= maxBlobSizeBytes {
+		blobSize *= 2
+	}
+	maxBlobPayloadSize, err := codec.BlobSizeToMaxPayloadSize(blobSize)
+	if err != nil {
+		return nil, fmt.Errorf("max payload size for blob size %d: %w", blobSize, err)
+	}
+
+	return &Splitter{
+		maxBlobs:           config.MaxBlobs,
+		maxBlobPayloadSize: uint64(maxBlobPayloadSize),
+	}, nil
+}
+
+// MaxPayloadSize returns the size of the largest payload that can be dispersed.
+func (s *Splitter) MaxPayloadSize() uint64 {
+	return uint64(s.maxBlobs) * s.maxBlobPayloadSize
+}
+
+// NeedsSplit returns true if the payload is too large for a single blob.
+func (s *Splitter) NeedsSplit(payload []byte) bool {
+	return uint64(len(payload)) > s.maxBlobPayloadSize
+}
+
+// Put splits the payload into chunks that each fit in a single blob, disperses them in parallel, and returns a
+// manifest listing their certs. If any chunk fails to disperse, the whole payload fails. putBlob may deduplicate
+// chunks, so that a retry of the payload reuses the certs of the chunks that were dispersed.
+func (s *Splitter) Put(ctx context.Context, payload []byte, putBlob PutBlobFunc) (certs.VersionedCert, error) {
+	if uint64(len(payload)) > s.MaxPayloadSize() {
+		return certs.VersionedCert{}, fmt.Errorf(
+			"%w: payload length %d, max payload size across %d blobs %d",
+			proxyerrors.ErrProxyOversizedBlob, len(payload), s.maxBlobs, s.MaxPayloadSize())
+	}
+
+	chunkCount := (uint64(len(payload)) + s.maxBlobPayloadSize - 1) / s.maxBlobPayloadSize
+	manifest := &certs.Manifest{
+		PayloadLength: uint64(len(payload)),
+		Children:      make([]certs.VersionedCert, chunkCount),
+	}
+
+	group, groupCtx := errgroup.WithContext(ctx)
+	for i := range manifest.Children {
+		start := uint64(i) * s.maxBlobPayloadSize
+		end := min(start+s.maxBlobPayloadSize, uint64(len(payload)))
+		group.Go(func() error {
+			child, err := putBlob(groupCtx, payload[start:end])
+			if err != nil {
+				return fmt.Errorf("disperse blob %d of %d: %w", i+1, chunkCount, err)
+			}
+			if child.Version == certs.ManifestVersionByte {
+				return fmt.Errorf("blob %d of %d returned a manifest", i+1, chunkCount)
+			}
+			manifest.Children[i] = child
+			return nil
+		})
+	}
+	err := group.Wait()
+	if err != nil {
+		return certs.VersionedCert{}, err
+	}
+
+	serializedManifest, err := manifest.Serialize()
+	if err != nil {
+		return certs.VersionedCert{}, fmt.Errorf("serialize manifest: %w", err)
+	}
+	return certs.NewVersionedCert(serializedManifest, certs.ManifestVersionByte), nil
+}
+
+// Get fetches the payloads of all the children of a manifest in parallel, and reassembles them into the payload.
+// A manifest that is malformed, or that doesn't match the payloads of its children, is reported as a
+// [verification.CertVerificationFailedError], so that it is dropped by the rollup like any other invalid cert.
+func Get(ctx context.Context, serializedManifest []byte, getBlob GetBlobFunc) ([]byte, error) {
+	manifest, err := certs.DeserializeManifest(serializedManifest)
+	if err != nil {
+		return nil, eigenda_v2.NewCertParsingFailedError(hex.EncodeToString(serializedManifest), err.Error())
+	}
+
+	chunks := make([][]byte, len(manifest.Children))
+	group, groupCtx := errgroup.WithContext(ctx)
+	for i, child := range manifest.Children {
+		group.Go(func() error {
+			chunk, err := getBlob(groupCtx, child)
+			if err != nil {
+				return fmt.Errorf("get blob %d of %d: %w", i+1, len(manifest.Children), err)
+			}
+			chunks[i] = chunk
+			return nil
+		})
+	}
+	err = group.Wait()
+	if err != nil {
+		return nil, err
+	}
+
+	payload := bytes.Join(chunks, nil)
+	if uint64(len(payload)) != manifest.PayloadLength {
+		return nil, eigenda_v2.NewCertParsingFailedError(hex.EncodeToString(serializedManifest),
+			fmt.Sprintf("manifest payload length is %d, but its blobs hold %d bytes",
+				manifest.PayloadLength, len(payload)))
+	}
+	return payload, nil
+}
@@ -0,0 +1,124 @@
+package multiblob
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2/verification"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/proxyerrors"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/certs"
+	"github.com/Layr-Labs/eigenda/encoding/utils/codec"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDisperser stores each blob under its index, and uses the index as the blob's cert.
+type fakeDisperser struct {
+	mu    sync.Mutex
+	blobs [][]byte
+}
+
+func (d *fakeDisperser) put(_ context.Context, payload []byte) (certs.VersionedCert, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.blobs = append(d.blobs, bytes.Clone(payload))
+	return certs.NewVersionedCert([]byte{byte(len(d.blobs) - 1)}, certs.V2VersionByte), nil
+}
+
+func (d *fakeDisperser) get(_ context.Context, versionedCert certs.VersionedCert) ([]byte, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	index := int(versionedCert.SerializedCert[0])
+	if index >= len(d.blobs) {
+		return nil, fmt.Errorf("blob %d not found", index)
+	}
+	return d.blobs[index], nil
+}
+
+func TestSplitterRoundTrip(t *testing.T) {
+	splitter, err := NewSplitter(Config{MaxBlobs: 4}, 200)
+	require.NoError(t, err)
+	// Blobs are rounded down to a power of 2.
+	maxBlobPayloadSize, err := codec.BlobSizeToMaxPayloadSize(128)
+	require.NoError(t, err)
+	require.Equal(t, 4*uint64(maxBlobPayloadSize), splitter.MaxPayloadSize())
+
+	for _, length := range []int{1, int(splitter.maxBlobPayloadSize) + 1, int(splitter.MaxPayloadSize())} {
+		t.Run(fmt.Sprintf("length %d", length), func(t *testing.T) {
+			payload := bytes.Repeat([]byte{0xab}, length)
+			payload[0] = 0x01
+			disperser := &fakeDisperser{}
+
+			versionedCert, err := splitter.Put(t.Context(), payload, disperser.put)
+			require.NoError(t, err)
+			require.Equal(t, certs.ManifestVersionByte, versionedCert.Version)
+
+			expectedBlobs := (uint64(length) + splitter.maxBlobPayloadSize - 1) / splitter.maxBlobPayloadSize
+			require.Len(t, disperser.blobs, int(expectedBlobs))
+
+			retrieved, err := Get(t.Context(), versionedCert.SerializedCert, disperser.get)
+			require.NoError(t, err)
+			require.Equal(t, payload, retrieved)
+		})
+	}
+}
+
+func TestSplitterPutErrors(t *testing.T) {
+	splitter, err := NewSplitter(Config{MaxBlobs: 2}, 128)
+	require.NoError(t, err)
+
+	disperser := &fakeDisperser{}
+	_, err = splitter.Put(t.Context(), make([]byte, splitter.MaxPayloadSize()+1), disperser.put)
+	require.ErrorIs(t, err, proxyerrors.ErrProxyOversizedBlob)
+	require.Empty(t, disperser.blobs)
+
+	// The payload fails if any of its blobs fails to disperse.
+	errDisperse := errors.New("disperse failed")
+	_, err = splitter.Put(t.Context(), make([]byte, splitter.MaxPayloadSize()),
+		func(ctx context.Context, payload []byte) (certs.VersionedCert, error) {
+			if payload[len(payload)-1] == 0 {
+				return certs.VersionedCert{}, errDisperse
+			}
+			return disperser.put(ctx, payload)
+		})
+	require.ErrorIs(t, err, errDisperse)
+}
+
+func TestGetInvalidManifest(t *testing.T) {
+	disperser := &fakeDisperser{}
+	_, err := disperser.put(t.Context(), []byte("hello"))
+	require.NoError(t, err)
+
+	var certErr *verification.CertVerificationFailedError
+	tests := []struct {
+		name     string
+		manifest []byte
+	}{
+		{
+			name:     "malformed",
+			manifest: []byte{0x01, 0x02, 0x03},
+		},
+		{
+			name: "payload length mismatch",
+			manifest: mustSerialize(t, certs.Manifest{
+				PayloadLength: 6,
+				Children:      []certs.VersionedCert{certs.NewVersionedCert([]byte{0}, certs.V2VersionByte)},
+			}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Get(t.Context(), tt.manifest, disperser.get)
+			require.ErrorAs(t, err, &certErr)
+		})
+	}
+}
+
+func mustSerialize(t *testing.T, manifest certs.Manifest) []byte {
+	serialized, err := manifest.Serialize()
+	require.NoError(t, err)
+	return serialized
+}
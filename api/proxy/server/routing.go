@@ -15,6 +15,7 @@ const (
 	routingVarNamePayloadHex          = "payload_hex"
 	routingVarNameVersionByteHex      = "version_byte_hex"
 	routingVarNameCommitTypeByteHex   = "commit_type_byte_hex"
+	routingVarNameJobID               = "job_id"
 )
 
 func (svr *Server) RegisterRoutes(r *mux.Router) {
@@ -114,6 +115,9 @@ func (svr *Server) RegisterRoutes(r *mux.Router) {
 	// this is done to explicitly log capture potential redirect errors
 	r.HandleFunc("/put", svr.logDispersalGetError).Methods("GET")
 
+	// progress of asynchronous dispersals (POST /put?async=true)
+	r.HandleFunc("/put/status/{"+routingVarNameJobID+":[0-9a-fA-F]+}", svr.handleGetAsyncPutStatus).Methods("GET")
+
 	// Only register admin endpoints if explicitly enabled in configuration
 	//
 	// Note: A common pattern for admin endpoints is to generate a random API key on startup for authentication.
@@ -13,7 +13,11 @@ import (
 	"github.com/Layr-Labs/eigenda/api/proxy/common"
 	"github.com/Layr-Labs/eigenda/api/proxy/common/types/certs"
 	"github.com/Layr-Labs/eigenda/api/proxy/metrics"
+	"github.com/Layr-Labs/eigenda/api/proxy/server/idempotency"
+	"github.com/Layr-Labs/eigenda/api/proxy/server/jobs"
+	"github.com/Layr-Labs/eigenda/api/proxy/server/multiblob"
 	"github.com/Layr-Labs/eigenda/api/proxy/store"
+	"github.com/Layr-Labs/eigenda/common/kvstore"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/gorilla/mux"
 )
@@ -42,6 +46,12 @@ type Server struct {
 	httpServer *http.Server
 	listener   net.Listener
 	config     Config
+	// Tracks asynchronous dispersals. Nil unless async puts are enabled.
+	jobs *jobs.Tracker
+	// Deduplicates dispersals. Nil unless idempotent puts are enabled.
+	dedup *idempotency.Deduplicator
+	// Splits payloads across multiple blobs. Nil unless multi-blob payloads are enabled.
+	multiBlob *multiblob.Splitter
 }
 
 func NewServer(
@@ -111,6 +121,51 @@ func (svr *Server) Stop() error {
 	return nil
 }
 
+// EnableAsyncPuts allows payloads to be dispersed asynchronously, with POST /put?async=true. Jobs are persisted in
+// the given store, and their dispersals are abandoned when ctx is cancelled. V2 dispersals abandoned by a previous
+// run are resumed.
+func (svr *Server) EnableAsyncPuts(ctx context.Context, store kvstore.Store[[]byte], cfg jobs.Config) error {
+	tracker, err := jobs.NewTracker(ctx, svr.log, store, cfg,
+		svr.putCommitment,
+		svr.resumeCommitment)
+	if err != nil {
+		return fmt.Errorf("create dispersal job tracker: %w", err)
+	}
+	svr.jobs = tracker
+	return nil
+}
+
+// StopAsyncPuts abandons the asynchronous dispersals in flight, and waits until their jobs are no longer written to
+// the store passed to EnableAsyncPuts. It must be called after Stop, and before that store is closed. Does nothing
+// if async puts are not enabled.
+func (svr *Server) StopAsyncPuts() {
+	if svr.jobs != nil {
+		svr.jobs.Stop()
+	}
+}
+
+// EnableIdempotentPuts deduplicates dispersals, so that a client retrying a POST /put request doesn't pay for the
+// payload to be dispersed twice. If shared is not nil, dispersals are claimed and their results shared through it.
+func (svr *Server) EnableIdempotentPuts(cfg idempotency.Config, shared common.ClaimableSecondaryStore) error {
+	dedup, err := idempotency.NewDeduplicator(svr.log, cfg, shared)
+	if err != nil {
+		return fmt.Errorf("create deduplicator: %w", err)
+	}
+	svr.dedup = dedup
+	return nil
+}
+
+// EnableMultiBlobPayloads allows payloads larger than a single V2 blob of maxBlobSizeBytes to be dispersed, by
+// splitting them across several blobs committed to with a manifest. Manifests are served regardless of this setting.
+func (svr *Server) EnableMultiBlobPayloads(cfg multiblob.Config, maxBlobSizeBytes uint64) error {
+	splitter, err := multiblob.NewSplitter(cfg, maxBlobSizeBytes)
+	if err != nil {
+		return fmt.Errorf("create multi-blob splitter: %w", err)
+	}
+	svr.multiBlob = splitter
+	return nil
+}
+
 // SetDispersalBackend configures which version of eigenDA the server disperses to
 func (svr *Server) SetDispersalBackend(backend common.EigenDABackend) {
 	svr.sm.SetDispersalBackend(backend)
@@ -12,6 +12,7 @@ import (
 	"github.com/Layr-Labs/eigenda/api/proxy/store/generated_key/eigenda/verify"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/generated_key/memstore"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/generated_key/memstore/memconfig"
+	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary/littdb"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary/redis"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary/s3"
 	"github.com/Layr-Labs/eigenda/encoding/kzg"
@@ -33,8 +34,9 @@ type Config struct {
 	MemstoreEnabled bool
 
 	// secondary storage cfgs
-	RedisConfig redis.Config
-	S3Config    s3.Config
+	RedisConfig  redis.Config
+	S3Config     s3.Config
+	LittDBConfig littdb.Config
 }
 
 // ReadConfig ... parses the Config from the provided flags or environment variables.
@@ -89,6 +91,7 @@ func ReadConfig(ctx *cli.Context) (Config, error) {
 		MemstoreEnabled:  ctx.Bool(memstore.EnabledFlagName),
 		RedisConfig:      redis.ReadConfig(ctx),
 		S3Config:         s3.ReadConfig(ctx),
+		LittDBConfig:     littdb.ReadConfig(ctx),
 	}
 
 	return cfg, nil
@@ -125,6 +128,17 @@ func (cfg *Config) Check() error {
 		return fmt.Errorf("redis password is set, but endpoint is not")
 	}
 
+	littDBTargeted := slices.ContainsFunc(slices.Concat(cfg.StoreConfig.CacheTargets, cfg.StoreConfig.FallbackTargets),
+		func(target string) bool {
+			return common.StringToBackendType(target) == common.LittDBBackendType
+		})
+	if littDBTargeted && cfg.LittDBConfig.Path == "" {
+		return fmt.Errorf("littdb is a cache or fallback target, but no littdb path is set")
+	}
+	if cfg.LittDBConfig.TTL < 0 {
+		return fmt.Errorf("littdb ttl must not be negative")
+	}
+
 	return cfg.StoreConfig.Check()
 }
 
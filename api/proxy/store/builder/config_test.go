@@ -203,5 +203,19 @@ func TestConfigVerification(t *testing.T) {
 			err := cfg.Check()
 			require.Error(t, err)
 		})
+
+		t.Run("MissingLittDBPath", func(t *testing.T) {
+			cfg := validCfg()
+
+			cfg.StoreConfig.CacheTargets = []string{"littdb"}
+			cfg.LittDBConfig.Path = ""
+
+			err := cfg.Check()
+			require.Error(t, err)
+
+			cfg.LittDBConfig.Path = t.TempDir()
+			err = cfg.Check()
+			require.NoError(t, err)
+		})
 	})
 }
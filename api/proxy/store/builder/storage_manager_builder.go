@@ -7,6 +7,7 @@ import (
 	"math/rand"
 	"regexp"
 	"slices"
+	"sync"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/api/clients"
@@ -25,6 +26,7 @@ import (
 	memstore_v2 "github.com/Layr-Labs/eigenda/api/proxy/store/generated_key/memstore/v2"
 	eigenda_v2 "github.com/Layr-Labs/eigenda/api/proxy/store/generated_key/v2"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary"
+	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary/littdb"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary/redis"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary/s3"
 	common_eigenda "github.com/Layr-Labs/eigenda/common"
@@ -47,16 +49,21 @@ import (
 // BuildStoreManager is the main builder for proxy's store.
 // It builds all the different store clients, and injects them into
 // a new store manager, which it returns when successful.
+//
+// Async secondary writers run until ctx is cancelled. The returned close function waits for them to stop, and then
+// closes the stores that hold resources (e.g. LittDB). It must only be called once ctx has been cancelled and nothing
+// else uses the manager, i.e. after the servers have stopped.
 func BuildStoreManager(
 	ctx context.Context,
 	log logging.Logger,
 	metrics metrics.Metricer,
 	config Config,
 	secrets common.SecretConfigV2,
-) (*store.Manager, error) {
+) (*store.Manager, func() error, error) {
 	var err error
 	var s3Store *s3.Store
 	var redisStore *redis.Store
+	var littDBStore *littdb.Store
 	var eigenDAV1Store common.EigenDAV1Store
 	var eigenDAV2Store common.EigenDAV2Store
 
@@ -64,7 +71,7 @@ func BuildStoreManager(
 		log.Info("Using S3 storage backend")
 		s3Store, err = s3.NewStore(config.S3Config)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 	}
 
@@ -72,17 +79,36 @@ func BuildStoreManager(
 		log.Info("Using Redis storage backend")
 		redisStore, err = redis.NewStore(&config.RedisConfig)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
+		}
+	}
+
+	// built is set once the manager is returned. Until then, the stores that hold resources are closed on error, since
+	// the caller never receives them.
+	built := false
+	if config.LittDBConfig.Path != "" {
+		log.Info("Using LittDB storage backend", "path", config.LittDBConfig.Path, "ttl", config.LittDBConfig.TTL)
+		littDBStore, err = littdb.NewStore(log, config.LittDBConfig)
+		if err != nil {
+			return nil, nil, err
 		}
+		defer func() {
+			if built {
+				return
+			}
+			if err := littDBStore.Close(); err != nil {
+				log.Error("Failed to close LittDB storage backend", "err", err)
+			}
+		}()
 	}
 
 	v1Enabled := slices.Contains(config.StoreConfig.BackendsToEnable, common.V1EigenDABackend)
 	v2Enabled := slices.Contains(config.StoreConfig.BackendsToEnable, common.V2EigenDABackend)
 
 	if config.StoreConfig.DispersalBackend == common.V2EigenDABackend && !v2Enabled {
-		return nil, fmt.Errorf("dispersal backend is set to V2, but V2 backend is not enabled")
+		return nil, nil, fmt.Errorf("dispersal backend is set to V2, but V2 backend is not enabled")
 	} else if config.StoreConfig.DispersalBackend == common.V1EigenDABackend && !v1Enabled {
-		return nil, fmt.Errorf("dispersal backend is set to V1, but V1 backend is not enabled")
+		return nil, nil, fmt.Errorf("dispersal backend is set to V1, but V1 backend is not enabled")
 	}
 
 	var kzgVerifier *kzgverifier.Verifier
@@ -100,7 +126,7 @@ func BuildStoreManager(
 
 		kzgVerifier, err = kzgverifier.NewVerifier(&kzgConfig, nil)
 		if err != nil {
-			return nil, fmt.Errorf("new kzg verifier: %w", err)
+			return nil, nil, fmt.Errorf("new kzg verifier: %w", err)
 		}
 	}
 
@@ -108,7 +134,7 @@ func BuildStoreManager(
 		log.Info("Building EigenDA v1 storage backend")
 		eigenDAV1Store, err = buildEigenDAV1Backend(ctx, log, config, kzgVerifier)
 		if err != nil {
-			return nil, fmt.Errorf("build v1 backend: %w", err)
+			return nil, nil, fmt.Errorf("build v1 backend: %w", err)
 		}
 	}
 
@@ -116,35 +142,28 @@ func BuildStoreManager(
 		log.Info("Building EigenDA v2 storage backend")
 		eigenDAV2Store, err = buildEigenDAV2Backend(ctx, log, config, secrets, kzgVerifier)
 		if err != nil {
-			return nil, fmt.Errorf("build v2 backend: %w", err)
+			return nil, nil, fmt.Errorf("build v2 backend: %w", err)
 		}
 	}
 
-	fallbacks := buildSecondaries(config.StoreConfig.FallbackTargets, s3Store, redisStore)
-	caches := buildSecondaries(config.StoreConfig.CacheTargets, s3Store, redisStore)
+	fallbacks := buildSecondaries(config.StoreConfig.FallbackTargets, s3Store, redisStore, littDBStore)
+	caches := buildSecondaries(config.StoreConfig.CacheTargets, s3Store, redisStore, littDBStore)
 	secondary := secondary.NewSecondaryManager(log, metrics, caches, fallbacks)
 
-	if secondary.Enabled() { // only spin-up go routines if secondary storage is enabled
-		log.Info("Starting secondary write loop(s)", "count", config.StoreConfig.AsyncPutWorkers)
-
-		for i := 0; i < config.StoreConfig.AsyncPutWorkers; i++ {
-			go secondary.WriteSubscriptionLoop(ctx)
-		}
-	}
-
 	log.Info(
 		"Created storage backends",
 		"eigenda_v1", eigenDAV1Store != nil,
 		"eigenda_v2", eigenDAV2Store != nil,
 		"s3", s3Store != nil,
 		"redis", redisStore != nil,
+		"littdb", littDBStore != nil,
 		"read_fallback", len(fallbacks) > 0,
 		"caching", len(caches) > 0,
 		"async_secondary_writes", (secondary.Enabled() && config.StoreConfig.AsyncPutWorkers > 0),
 		"verify_v1_certs", config.VerifierConfigV1.VerifyCerts,
 	)
 
-	return store.NewManager(
+	manager, err := store.NewManager(
 		eigenDAV1Store,
 		eigenDAV2Store,
 		s3Store,
@@ -152,6 +171,35 @@ func BuildStoreManager(
 		secondary,
 		config.StoreConfig.DispersalBackend,
 	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var writers sync.WaitGroup
+	if secondary.Enabled() { // only spin-up go routines if secondary storage is enabled
+		log.Info("Starting secondary write loop(s)", "count", config.StoreConfig.AsyncPutWorkers)
+
+		for i := 0; i < config.StoreConfig.AsyncPutWorkers; i++ {
+			writers.Add(1)
+			go func() {
+				defer writers.Done()
+				secondary.WriteSubscriptionLoop(ctx)
+			}()
+		}
+	}
+	closeStores := func() error {
+		writers.Wait()
+		if littDBStore != nil {
+			err := littDBStore.Close()
+			if err != nil {
+				return fmt.Errorf("close LittDB storage backend: %w", err)
+			}
+		}
+		return nil
+	}
+
+	built = true
+	return manager, closeStores, nil
 }
 
 // buildSecondaries ... Creates a slice of secondary targets used for either read
@@ -160,6 +208,7 @@ func buildSecondaries(
 	targets []string,
 	s3Store common.SecondaryStore,
 	redisStore *redis.Store,
+	littDBStore *littdb.Store,
 ) []common.SecondaryStore {
 	stores := make([]common.SecondaryStore, len(targets))
 
@@ -176,6 +225,11 @@ func buildSecondaries(
 				panic(fmt.Sprintf("S3 backend not configured: %s", target))
 			}
 			stores[i] = s3Store
+		case common.LittDBBackendType:
+			if littDBStore == nil {
+				panic(fmt.Sprintf("LittDB backend not configured: %s", target))
+			}
+			stores[i] = littDBStore
 
 		default:
 			panic(fmt.Sprintf("Invalid backend target: %s", target))
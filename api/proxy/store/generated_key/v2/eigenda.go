@@ -14,6 +14,7 @@ import (
 	"github.com/Layr-Labs/eigenda/api/proxy/common"
 	"github.com/Layr-Labs/eigenda/api/proxy/common/types/certs"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/generated_key/utils"
+	core "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/avast/retry-go/v4"
 	"github.com/ethereum/go-ethereum/rlp"
@@ -43,6 +44,7 @@ type Store struct {
 }
 
 var _ common.EigenDAV2Store = (*Store)(nil)
+var _ common.EigenDAV2Resumer = (*Store)(nil)
 
 func NewStore(
 	log logging.Logger,
@@ -162,6 +164,24 @@ func (e Store) Put(ctx context.Context, value []byte) ([]byte, error) {
 		return nil, err
 	}
 
+	return serializeCert(cert)
+}
+
+// Resume finishes a dispersal that was started by an earlier Put, e.g. one that was interrupted by a restart, given
+// the key of the dispersed blob. It returns the RLP encoded certificate commit, exactly like Put. The dispersal is not
+// retried if it fails, since the payload is no longer available.
+func (e Store) Resume(ctx context.Context, blobKey core.BlobKey) ([]byte, error) {
+	e.log.Debug("Resuming dispersal to EigenDA V2 network", "blobKey", blobKey.Hex())
+
+	cert, err := e.disperser.ResumePayload(ctx, blobKey)
+	if err != nil {
+		return nil, fmt.Errorf("resume dispersal of blob %s: %w", blobKey.Hex(), err)
+	}
+	return serializeCert(cert)
+}
+
+// serializeCert returns the RLP encoding of a cert returned by the payload disperser.
+func serializeCert(cert coretypes.EigenDACert) ([]byte, error) {
 	switch cert.Version() {
 	case coretypes.VersionTwoCert:
 		return nil, fmt.Errorf("EigenDA V2 certs are not supported anymore, use V3 instead")
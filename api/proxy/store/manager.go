@@ -11,6 +11,7 @@ import (
 	"github.com/Layr-Labs/eigenda/api/proxy/common/types/commitments"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary/s3"
+	core "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 )
 
@@ -20,6 +21,8 @@ import (
 type IManager interface {
 	// See [Manager.Put]
 	Put(ctx context.Context, cm commitments.CommitmentMode, value []byte) ([]byte, error)
+	// See [Manager.ResumePut]
+	ResumePut(ctx context.Context, cm commitments.CommitmentMode, blobKey core.BlobKey) ([]byte, error)
 	// See [Manager.Get]
 	Get(ctx context.Context, versionedCert certs.VersionedCert,
 		cm commitments.CommitmentMode, verifyOpts common.CertVerificationOpts) ([]byte, error)
@@ -190,6 +193,28 @@ func (m *Manager) Put(ctx context.Context, cm commitments.CommitmentMode, value
 	return commit, nil
 }
 
+// ResumePut finishes a V2 dispersal that was started by an earlier Put, e.g. one that was interrupted by a restart,
+// given the key of the dispersed blob. It returns the serialized cert, like Put. Since the payload is not available,
+// it is not written to secondary storage backends.
+func (m *Manager) ResumePut(
+	ctx context.Context,
+	cm commitments.CommitmentMode,
+	blobKey core.BlobKey,
+) ([]byte, error) {
+	//nolint:exhaustive // keccak commitments are never dispersed to EigenDA
+	switch cm {
+	case commitments.OptimismGenericCommitmentMode, commitments.StandardCommitmentMode:
+	default:
+		return nil, fmt.Errorf("cannot resume dispersal for commitment mode %v", cm)
+	}
+
+	resumer, ok := m.eigendaV2.(common.EigenDAV2Resumer)
+	if !ok {
+		return nil, errors.New("EigenDA V2 backend does not support resuming dispersals")
+	}
+	return resumer.Resume(ctx, blobKey)
+}
+
 // getVerifyMethod returns the correct verify method based on commitment type
 func (m *Manager) getVerifyMethod(commitmentType certs.VersionByte) (
 	func(context.Context, []byte, []byte, common.CertVerificationOpts) error,
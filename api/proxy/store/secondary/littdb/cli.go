@@ -0,0 +1,57 @@
+package littdb
+
+import (
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	PathFlagName          = withFlagPrefix("path")
+	TTLFlagName           = withFlagPrefix("ttl")
+	ReadCacheSizeFlagName = withFlagPrefix("read-cache-size")
+)
+
+func withFlagPrefix(s string) string {
+	return "littdb." + s
+}
+
+func withEnvPrefix(envPrefix, s string) []string {
+	return []string{envPrefix + "_LITTDB_" + s}
+}
+
+// CLIFlags ... used for LittDB backend configuration
+// category is used to group the flags in the help output (see https://cli.urfave.org/v2/examples/flags/#grouping)
+func CLIFlags(envPrefix, category string) []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name: PathFlagName,
+			Usage: "Local directory where LittDB stores payloads. Setting it enables the \"littdb\" cache and " +
+				"fallback target.",
+			EnvVars:  withEnvPrefix(envPrefix, "PATH"),
+			Category: category,
+		},
+		&cli.DurationFlag{
+			Name:     TTLFlagName,
+			Usage:    "How long payloads are kept in LittDB. 0 keeps them forever.",
+			Value:    24 * time.Hour,
+			EnvVars:  withEnvPrefix(envPrefix, "TTL"),
+			Category: category,
+		},
+		&cli.Uint64Flag{
+			Name:     ReadCacheSizeFlagName,
+			Usage:    "Size in bytes of the in-memory cache of payloads recently read from LittDB. 0 disables it.",
+			Value:    0,
+			EnvVars:  withEnvPrefix(envPrefix, "READ_CACHE_SIZE"),
+			Category: category,
+		},
+	}
+}
+
+func ReadConfig(ctx *cli.Context) Config {
+	return Config{
+		Path:          ctx.String(PathFlagName),
+		TTL:           ctx.Duration(TTLFlagName),
+		ReadCacheSize: ctx.Uint64(ReadCacheSizeFlagName),
+	}
+}
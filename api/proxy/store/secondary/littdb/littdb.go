@@ -0,0 +1,136 @@
+package littdb
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/proxy/common"
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/littbuilder"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// The name of the LittDB table holding payloads.
+const tableName = "payloads"
+
+// Config ... user configurable
+type Config struct {
+	// The directory where the database is stored. Empty disables the store.
+	Path string
+	// How long payloads are kept. 0 keeps them forever.
+	TTL time.Duration
+	// The size of the in-memory read cache, in bytes.
+	ReadCacheSize uint64
+}
+
+// Store ... LittDB storage backend implementation, which keeps payloads on local disk
+// All LittDB table methods are safe for concurrent usage.
+type Store struct {
+	db    litt.DB
+	table litt.Table
+
+	// Serializes the existence check and write of each Put, since LittDB keys must not be written twice.
+	putLock sync.Mutex
+}
+
+var _ common.SecondaryStore = (*Store)(nil)
+
+// NewStore ... constructor. The store must be closed with Close once it is no longer needed.
+func NewStore(log logging.Logger, cfg Config) (*Store, error) {
+	littConfig, err := litt.DefaultConfig(cfg.Path)
+	if err != nil {
+		return nil, fmt.Errorf("create littdb config: %w", err)
+	}
+	littConfig.Logger = log
+	littConfig.TTL = cfg.TTL
+
+	db, err := littbuilder.NewDB(littConfig)
+	if err != nil {
+		return nil, fmt.Errorf("open littdb at %s: %w", cfg.Path, err)
+	}
+	table, err := db.GetTable(tableName)
+	if err != nil {
+		return nil, closeOnError(db, fmt.Errorf("get littdb table %s: %w", tableName, err))
+	}
+	// A table's TTL is not persisted, so it must be set every time the database is opened.
+	err = table.SetTTL(cfg.TTL)
+	if err != nil {
+		return nil, closeOnError(db, fmt.Errorf("set littdb TTL: %w", err))
+	}
+	err = table.SetReadCacheSize(cfg.ReadCacheSize)
+	if err != nil {
+		return nil, closeOnError(db, fmt.Errorf("set littdb read cache size: %w", err))
+	}
+
+	return &Store{
+		db:    db,
+		table: table,
+	}, nil
+}
+
+func closeOnError(db litt.DB, err error) error {
+	if closeErr := db.Close(); closeErr != nil {
+		return fmt.Errorf("%w (close littdb: %v)", err, closeErr)
+	}
+	return err
+}
+
+// Get ... retrieves a value from the LittDB store. Returns nil if the key is not found vs. an error
+// if the key is found but the value is not retrievable.
+func (s *Store) Get(_ context.Context, key []byte) ([]byte, error) {
+	value, exists, err := s.table.Get(key)
+	if err != nil {
+		return nil, fmt.Errorf("get from littdb: %w", err)
+	}
+	if !exists {
+		return nil, nil
+	}
+	// LittDB values must not be mutated, so hand out a copy.
+	return bytes.Clone(value), nil
+}
+
+// Put ... inserts a value into the LittDB store, and flushes it to disk.
+// LittDB values can't be overwritten, so a key that is already present is left as is.
+func (s *Store) Put(_ context.Context, key []byte, value []byte) error {
+	s.putLock.Lock()
+	exists, err := s.table.Exists(key)
+	if err != nil {
+		s.putLock.Unlock()
+		return fmt.Errorf("check littdb for key: %w", err)
+	}
+	if exists {
+		s.putLock.Unlock()
+		return nil
+	}
+	err = s.table.Put(bytes.Clone(key), bytes.Clone(value))
+	s.putLock.Unlock()
+	if err != nil {
+		return fmt.Errorf("put to littdb: %w", err)
+	}
+
+	err = s.table.Flush()
+	if err != nil {
+		return fmt.Errorf("flush littdb: %w", err)
+	}
+	return nil
+}
+
+// Close ... flushes all data to disk and closes the database
+func (s *Store) Close() error {
+	err := s.db.Close()
+	if err != nil {
+		return fmt.Errorf("close littdb: %w", err)
+	}
+	return nil
+}
+
+func (s *Store) Verify(_ context.Context, _, _ []byte) error {
+	return nil
+}
+
+func (s *Store) BackendType() common.BackendType {
+	return common.LittDBBackendType
+}
@@ -0,0 +1,45 @@
+package littdb
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/proxy/common"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/stretchr/testify/require"
+)
+
+var testLogger = logging.NewTextSLogger(os.Stdout, &logging.SLoggerOptions{})
+
+func TestStorePutGet(t *testing.T) {
+	ctx := t.Context()
+	cfg := Config{Path: t.TempDir(), TTL: time.Hour}
+
+	store, err := NewStore(testLogger, cfg)
+	require.NoError(t, err)
+	require.Equal(t, common.LittDBBackendType, store.BackendType())
+
+	key := []byte("key")
+	value, err := store.Get(ctx, key)
+	require.NoError(t, err)
+	require.Nil(t, value)
+
+	require.NoError(t, store.Put(ctx, key, []byte("value")))
+	// Keys can't be overwritten, so the first value is kept.
+	require.NoError(t, store.Put(ctx, key, []byte("other value")))
+	value, err = store.Get(ctx, key)
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), value)
+
+	// Values survive a restart.
+	require.NoError(t, store.Close())
+	store, err = NewStore(testLogger, cfg)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, store.Close())
+	}()
+	value, err = store.Get(ctx, key)
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), value)
+}
@@ -40,7 +40,7 @@ type Store struct {
 	client *redis.Client
 }
 
-var _ common.SecondaryStore = (*Store)(nil)
+var _ common.ClaimableSecondaryStore = (*Store)(nil)
 
 // NewStore ... constructor
 func NewStore(cfg *Config) (*Store, error) {
@@ -84,6 +84,25 @@ func (r *Store) Put(ctx context.Context, key []byte, value []byte) error {
 	return r.client.Set(ctx, string(key), string(value), r.eviction).Err()
 }
 
+// Claim inserts a value into the Redis store only if the key is not present (SET NX). The key expires after ttl,
+// rather than after the store's eviction time.
+func (r *Store) Claim(ctx context.Context, key []byte, value []byte, ttl time.Duration) (bool, error) {
+	claimed, err := r.client.SetNX(ctx, string(key), string(value), ttl).Result()
+	if err != nil {
+		return false, fmt.Errorf("redis SETNX: %w", err)
+	}
+	return claimed, nil
+}
+
+// Release deletes a key claimed with Claim.
+func (r *Store) Release(ctx context.Context, key []byte) error {
+	err := r.client.Del(ctx, string(key)).Err()
+	if err != nil {
+		return fmt.Errorf("redis DEL: %w", err)
+	}
+	return nil
+}
+
 func (r *Store) Verify(_ context.Context, _, _ []byte) error {
 	return nil
 }
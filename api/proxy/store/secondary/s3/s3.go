@@ -9,6 +9,7 @@ import (
 	"io"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/Layr-Labs/eigenda/api/proxy/common"
 	"github.com/Layr-Labs/eigenda/core"
@@ -38,7 +39,7 @@ func StringToCredentialType(s string) CredentialType {
 	}
 }
 
-var _ common.SecondaryStore = (*Store)(nil)
+var _ common.ClaimableSecondaryStore = (*Store)(nil)
 
 type CredentialType string
 type Config struct {
@@ -138,6 +139,73 @@ func (s *Store) Put(ctx context.Context, key []byte, value []byte) error {
 	return nil
 }
 
+// Claim inserts a value only if no object exists under the key, using a conditional write (If-None-Match: *). S3 has
+// no per-object expiry, so an existing claim that was last modified more than ttl ago is treated as abandoned: it is
+// deleted, and the conditional write is retried once.
+func (s *Store) Claim(ctx context.Context, key []byte, value []byte, ttl time.Duration) (bool, error) {
+	objectName := path.Join(s.cfg.Path, hex.EncodeToString(key))
+
+	claimed, err := s.putIfAbsent(ctx, objectName, value)
+	if err != nil || claimed {
+		return claimed, err
+	}
+
+	info, err := s.client.StatObject(ctx, s.cfg.Bucket, objectName, minio.StatObjectOptions{})
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			// The claim was released in the meantime.
+			return s.putIfAbsent(ctx, objectName, value)
+		}
+		return false, fmt.Errorf("S3 Stat: %w", err)
+	}
+	if time.Since(info.LastModified) < ttl {
+		return false, nil
+	}
+
+	err = s.client.RemoveObject(ctx, s.cfg.Bucket, objectName, minio.RemoveObjectOptions{})
+	if err != nil {
+		return false, fmt.Errorf("S3 Remove: %w", err)
+	}
+	return s.putIfAbsent(ctx, objectName, value)
+}
+
+// putIfAbsent writes an object only if it doesn't exist. Returns false if it already exists.
+func (s *Store) putIfAbsent(ctx context.Context, objectName string, value []byte) (bool, error) {
+	options := s.putObjectOptions
+	options.SetMatchETagExcept("*")
+	_, err := s.client.PutObject(
+		ctx,
+		s.cfg.Bucket,
+		objectName,
+		bytes.NewReader(value),
+		int64(len(value)),
+		options,
+	)
+	if err != nil {
+		code := minio.ToErrorResponse(err).Code
+		// A concurrent conditional write of the same key may fail with ConditionalRequestConflict instead.
+		if code == "PreconditionFailed" || code == "ConditionalRequestConflict" {
+			return false, nil
+		}
+		return false, fmt.Errorf("S3 conditional Put: %w", err)
+	}
+	return true, nil
+}
+
+// Release deletes an object claimed with Claim.
+func (s *Store) Release(ctx context.Context, key []byte) error {
+	err := s.client.RemoveObject(
+		ctx,
+		s.cfg.Bucket,
+		path.Join(s.cfg.Path, hex.EncodeToString(key)),
+		minio.RemoveObjectOptions{},
+	)
+	if err != nil {
+		return fmt.Errorf("S3 Remove: %w", err)
+	}
+	return nil
+}
+
 // TODO: this should probably live elsewhere, it's related to op keccak commitments, not to S3.
 func (s *Store) Verify(_ context.Context, key []byte, value []byte) error {
 	keccakedValue := crypto.Keccak256Hash(value)
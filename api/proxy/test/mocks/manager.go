@@ -16,6 +16,7 @@ import (
 	common "github.com/Layr-Labs/eigenda/api/proxy/common"
 	certs "github.com/Layr-Labs/eigenda/api/proxy/common/types/certs"
 	commitments "github.com/Layr-Labs/eigenda/api/proxy/common/types/commitments"
+	v2 "github.com/Layr-Labs/eigenda/core/v2"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -116,6 +117,21 @@ func (mr *MockIManagerMockRecorder) PutOPKeccakPairInS3(ctx, key, value any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutOPKeccakPairInS3", reflect.TypeOf((*MockIManager)(nil).PutOPKeccakPairInS3), ctx, key, value)
 }
 
+// ResumePut mocks base method.
+func (m *MockIManager) ResumePut(ctx context.Context, cm commitments.CommitmentMode, blobKey v2.BlobKey) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResumePut", ctx, cm, blobKey)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResumePut indicates an expected call of ResumePut.
+func (mr *MockIManagerMockRecorder) ResumePut(ctx, cm, blobKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumePut", reflect.TypeOf((*MockIManager)(nil).ResumePut), ctx, cm, blobKey)
+}
+
 // SetDispersalBackend mocks base method.
 func (m *MockIManager) SetDispersalBackend(backend common.EigenDABackend) {
 	m.ctrl.T.Helper()
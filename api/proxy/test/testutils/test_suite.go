@@ -66,14 +66,17 @@ func CreateTestSuite(
 	// 	configString,
 	// )
 
-	storeManager, err := builder.BuildStoreManager(
-		ctx,
+	// The store manager gets its own context, so that kill can stop its async secondary writers.
+	storeCtx, storeCancel := context.WithCancel(ctx)
+	storeManager, closeStores, err := builder.BuildStoreManager(
+		storeCtx,
 		logger,
 		metrics,
 		appConfig.StoreBuilderConfig,
 		appConfig.SecretConfig,
 	)
 	if err != nil {
+		storeCancel()
 		panic(fmt.Sprintf("build storage manager: %v", err.Error()))
 	}
 
@@ -93,6 +96,10 @@ func CreateTestSuite(
 		if err := proxyServer.Stop(); err != nil {
 			logger.Error("failed to stop proxy server", "err", err)
 		}
+		storeCancel()
+		if err := closeStores(); err != nil {
+			logger.Error("failed to close storage backends", "err", err)
+		}
 	}
 
 	return TestSuite{
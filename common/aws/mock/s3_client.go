@@ -3,6 +3,7 @@ package mock
 import (
 	"context"
 	"errors"
+	"io"
 	"strings"
 
 	"github.com/Layr-Labs/eigenda/common/aws/s3"
@@ -22,6 +23,8 @@ func NewS3Client() *S3Client {
 			"DownloadObject":           0,
 			"HeadObject":               0,
 			"UploadObject":             0,
+			"UploadObjectFromReader":   0,
+			"DownloadObjectToWriter":   0,
 			"DeleteObject":             0,
 			"ListObjects":              0,
 			"CreateBucket":             0,
@@ -56,6 +59,30 @@ func (s *S3Client) UploadObject(ctx context.Context, bucket string, key string,
 	return nil
 }
 
+func (s *S3Client) UploadObjectFromReader(ctx context.Context, bucket string, key string, reader io.Reader) error {
+	s.Called["UploadObjectFromReader"]++
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+	s.bucket[key] = data
+	return nil
+}
+
+func (s *S3Client) DownloadObjectToWriter(
+	ctx context.Context,
+	bucket string,
+	key string,
+	writer io.WriterAt) (int64, error) {
+	s.Called["DownloadObjectToWriter"]++
+	data, ok := s.bucket[key]
+	if !ok {
+		return 0, s3.ErrObjectNotFound
+	}
+	n, err := writer.WriteAt(data, 0)
+	return int64(n), err
+}
+
 func (s *S3Client) DeleteObject(ctx context.Context, bucket string, key string) error {
 	s.Called["DeleteObject"]++
 	delete(s.bucket, key)
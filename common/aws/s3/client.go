@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"io"
 	"runtime"
 	"sync"
 
@@ -173,6 +174,43 @@ func (s *client) UploadObject(ctx context.Context, bucket string, key string, da
 	return nil
 }
 
+func (s *client) UploadObjectFromReader(ctx context.Context, bucket string, key string, reader io.Reader) error {
+	var partMiBs int64 = 10
+	uploader := manager.NewUploader(s.s3Client, func(u *manager.Uploader) {
+		u.PartSize = partMiBs * 1024 * 1024 // 10MiB per part
+		u.Concurrency = 3                   //The number of goroutines to spin up in parallel per call to upload when sending parts
+	})
+
+	_, err := uploader.Upload(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+		Body:   reader,
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *client) DownloadObjectToWriter(
+	ctx context.Context,
+	bucket string,
+	key string,
+	writer io.WriterAt) (int64, error) {
+
+	var partMiBs int64 = 10
+	downloader := manager.NewDownloader(s.s3Client, func(d *manager.Downloader) {
+		d.PartSize = partMiBs * 1024 * 1024 // 10MB per part
+		d.Concurrency = 3                   //The number of goroutines to spin up in parallel per call to Upload when sending parts
+	})
+
+	return downloader.Download(ctx, writer, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+}
+
 func (s *client) DeleteObject(ctx context.Context, bucket string, key string) error {
 	_, err := s.s3Client.DeleteObject(ctx, &s3.DeleteObjectInput{
 		Bucket: aws.String(bucket),
@@ -1,6 +1,9 @@
 package s3
 
-import "context"
+import (
+	"context"
+	"io"
+)
 
 // Client encapsulates the functionality of an S3 client.
 type Client interface {
@@ -14,6 +17,14 @@ type Client interface {
 	// UploadObject uploads an object to S3.
 	UploadObject(ctx context.Context, bucket string, key string, data []byte) error
 
+	// UploadObjectFromReader uploads an object to S3, reading its contents from the given reader until EOF. Large
+	// objects are sent as a multipart upload, so the object does not need to fit in memory.
+	UploadObjectFromReader(ctx context.Context, bucket string, key string, reader io.Reader) error
+
+	// DownloadObjectToWriter downloads an object from S3 into the given writer (e.g. an *os.File), so the object
+	// does not need to fit in memory. Returns the number of bytes written.
+	DownloadObjectToWriter(ctx context.Context, bucket string, key string, writer io.WriterAt) (int64, error)
+
 	// DeleteObject deletes an object from S3.
 	DeleteObject(ctx context.Context, bucket string, key string) error
 
@@ -17,6 +17,9 @@ type Cache[K comparable, V any] interface {
 	// of the cache in and of itself.
 	Put(key K, value V)
 
+	// Remove removes the key from the cache. This is a no-op if the key is not in the cache.
+	Remove(key K)
+
 	// Size returns the number of key-value pairs in the cache.
 	Size() int
 
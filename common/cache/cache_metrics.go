@@ -15,6 +15,8 @@ type CacheMetrics struct {
 	keysAdded       *prometheus.CounterVec
 	weightAdded     *prometheus.CounterVec
 	evictionLatency *prometheus.SummaryVec
+	hits            *prometheus.CounterVec
+	misses          *prometheus.CounterVec
 }
 
 // NewCacheMetrics creates a new CacheMetrics instance. If the registry is nil, it returns nil.
@@ -69,12 +71,32 @@ func NewCacheMetrics(registry *prometheus.Registry, namespace string, cacheName
 		[]string{},
 	)
 
+	hits := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      cacheName + "_cache_hit_count",
+			Help:      "Reports on the number of lookups that found their key in the cache",
+		},
+		[]string{},
+	)
+
+	misses := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      cacheName + "_cache_miss_count",
+			Help:      "Reports on the number of lookups that did not find their key in the cache",
+		},
+		[]string{},
+	)
+
 	return &CacheMetrics{
 		keyCount:        keyCount,
 		weight:          weight,
 		keysAdded:       keysAdded,
 		weightAdded:     weightAdded,
 		evictionLatency: evictionLatency,
+		hits:            hits,
+		misses:          misses,
 	}
 }
 
@@ -106,3 +128,21 @@ func (m *CacheMetrics) reportCurrentSize(size int, weight uint64) {
 	m.keyCount.WithLabelValues().Set(float64(size))
 	m.weight.WithLabelValues().Set(float64(weight))
 }
+
+// reportHit is used to report a lookup that found its key in the cache.
+func (m *CacheMetrics) reportHit() {
+	if m == nil {
+		return
+	}
+
+	m.hits.WithLabelValues().Inc()
+}
+
+// reportMiss is used to report a lookup that did not find its key in the cache.
+func (m *CacheMetrics) reportMiss() {
+	if m == nil {
+		return
+	}
+
+	m.misses.WithLabelValues().Inc()
+}
@@ -0,0 +1,53 @@
+package cache
+
+import "fmt"
+
+// EvictionPolicy determines which key-value pairs a Cache evicts when its weight exceeds its capacity.
+type EvictionPolicy string
+
+const (
+	// FIFOEvictionPolicy evicts the least recently added key-value pair. See FIFOCache.
+	FIFOEvictionPolicy EvictionPolicy = "fifo"
+	// LRUEvictionPolicy evicts the least recently accessed key-value pair. See LRUCache.
+	LRUEvictionPolicy EvictionPolicy = "lru"
+	// S3FIFOEvictionPolicy evicts key-value pairs using the S3-FIFO algorithm. See S3FIFOCache.
+	S3FIFOEvictionPolicy EvictionPolicy = "s3fifo"
+)
+
+// ParseEvictionPolicy parses the name of an eviction policy. The empty string is parsed as FIFOEvictionPolicy.
+func ParseEvictionPolicy(name string) (EvictionPolicy, error) {
+	switch EvictionPolicy(name) {
+	case "", FIFOEvictionPolicy:
+		return FIFOEvictionPolicy, nil
+	case LRUEvictionPolicy:
+		return LRUEvictionPolicy, nil
+	case S3FIFOEvictionPolicy:
+		return S3FIFOEvictionPolicy, nil
+	default:
+		return "", fmt.Errorf("unknown cache eviction policy %q, expected one of %q, %q, or %q",
+			name, FIFOEvictionPolicy, LRUEvictionPolicy, S3FIFOEvictionPolicy)
+	}
+}
+
+// NewCache creates a new Cache that uses the given eviction policy. An empty policy is treated as
+// FIFOEvictionPolicy. If the calculator is nil, the weight of each key-value pair will be 1.
+func NewCache[K comparable, V any](
+	policy EvictionPolicy,
+	maxWeight uint64,
+	calculator WeightCalculator[K, V],
+	metrics *CacheMetrics) (Cache[K, V], error) {
+
+	policy, err := ParseEvictionPolicy(string(policy))
+	if err != nil {
+		return nil, err
+	}
+
+	switch policy {
+	case LRUEvictionPolicy:
+		return NewLRUCache(maxWeight, calculator, metrics), nil
+	case S3FIFOEvictionPolicy:
+		return NewS3FIFOCache(maxWeight, calculator, metrics), nil
+	default:
+		return NewFIFOCache(maxWeight, calculator, metrics), nil
+	}
+}
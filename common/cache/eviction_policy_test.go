@@ -0,0 +1,150 @@
+package cache
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEvictionPolicy(t *testing.T) {
+	policy, err := ParseEvictionPolicy("")
+	require.NoError(t, err)
+	require.Equal(t, FIFOEvictionPolicy, policy)
+
+	for _, expected := range []EvictionPolicy{FIFOEvictionPolicy, LRUEvictionPolicy, S3FIFOEvictionPolicy} {
+		policy, err = ParseEvictionPolicy(string(expected))
+		require.NoError(t, err)
+		require.Equal(t, expected, policy)
+	}
+
+	_, err = ParseEvictionPolicy("random")
+	require.Error(t, err)
+}
+
+func TestNewCache(t *testing.T) {
+	c, err := NewCache[int, int](LRUEvictionPolicy, 10, nil, nil)
+	require.NoError(t, err)
+	require.IsType(t, &LRUCache[int, int]{}, c)
+
+	c, err = NewCache[int, int](S3FIFOEvictionPolicy, 10, nil, nil)
+	require.NoError(t, err)
+	require.IsType(t, &S3FIFOCache[int, int]{}, c)
+
+	c, err = NewCache[int, int]("", 10, nil, nil)
+	require.NoError(t, err)
+	require.IsType(t, &FIFOCache[int, int]{}, c)
+
+	_, err = NewCache[int, int]("random", 10, nil, nil)
+	require.Error(t, err)
+}
+
+func TestRemove(t *testing.T) {
+	for _, policy := range []EvictionPolicy{FIFOEvictionPolicy, LRUEvictionPolicy, S3FIFOEvictionPolicy} {
+		t.Run(string(policy), func(t *testing.T) {
+			c, err := NewCache[int, int](policy, 3, nil, nil)
+			require.NoError(t, err)
+			c = NewThreadSafeCache(c)
+
+			c.Put(1, 1)
+			c.Put(2, 2)
+			c.Remove(1)
+			c.Remove(4)
+			_, ok := c.Get(1)
+			require.False(t, ok)
+			require.Equal(t, 1, c.Size())
+			require.Equal(t, uint64(1), c.Weight())
+
+			// Removed keys free up their weight, so the remaining keys are not evicted early.
+			c.Put(1, 1)
+			c.Put(3, 3)
+			require.Equal(t, 3, c.Size())
+			for key := 1; key <= 3; key++ {
+				value, ok := c.Get(key)
+				require.True(t, ok)
+				require.Equal(t, key, value)
+			}
+
+			if policy == FIFOEvictionPolicy {
+				// A re-added key is not evicted in place of a key that was added before it.
+				c.Put(4, 4)
+				_, ok = c.Get(1)
+				require.True(t, ok)
+				_, ok = c.Get(2)
+				require.False(t, ok)
+			}
+		})
+	}
+}
+
+func TestThreadSafeCacheGetLock(t *testing.T) {
+	expectedReadOnly := map[EvictionPolicy]bool{
+		FIFOEvictionPolicy:   true,
+		LRUEvictionPolicy:    false,
+		S3FIFOEvictionPolicy: false,
+	}
+	for policy, readOnly := range expectedReadOnly {
+		t.Run(string(policy), func(t *testing.T) {
+			c, err := NewCache[int, int](policy, 10, nil, nil)
+			require.NoError(t, err)
+			c = NewThreadSafeCache(c)
+			require.Equal(t, readOnly, c.(*threadSafeCache[int, int]).readOnlyGet)
+
+			c.Put(1, 1)
+			var wg sync.WaitGroup
+			for i := 0; i < 8; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for j := 0; j < 100; j++ {
+						_, _ = c.Get(1)
+						_, _ = c.Get(2)
+					}
+				}()
+			}
+			wg.Wait()
+		})
+	}
+}
+
+func TestHitRatioMetrics(t *testing.T) {
+	for _, policy := range []EvictionPolicy{FIFOEvictionPolicy, LRUEvictionPolicy, S3FIFOEvictionPolicy} {
+		t.Run(string(policy), func(t *testing.T) {
+			registry := prometheus.NewRegistry()
+			metrics := NewCacheMetrics(registry, "test", "test")
+			c, err := NewCache[int, int](policy, 10, nil, metrics)
+			require.NoError(t, err)
+
+			c.Put(1, 1)
+			_, _ = c.Get(1)
+			_, _ = c.Get(1)
+			_, _ = c.Get(1)
+			_, _ = c.Get(2)
+
+			values := gatherMetrics(t, registry)
+			require.Equal(t, 3.0, values["test_test_cache_hit_count"])
+			require.Equal(t, 1.0, values["test_test_cache_miss_count"])
+			_, ok := values["test_test_cache_hit_ratio"]
+			require.False(t, ok, "the hit ratio should be derived from the hit and miss counters")
+		})
+	}
+}
+
+// gatherMetrics returns the value of each counter and gauge in the registry.
+func gatherMetrics(t *testing.T, registry *prometheus.Registry) map[string]float64 {
+	families, err := registry.Gather()
+	require.NoError(t, err)
+
+	values := make(map[string]float64)
+	for _, family := range families {
+		for _, metric := range family.GetMetric() {
+			if metric.GetCounter() != nil {
+				values[family.GetName()] = metric.GetCounter().GetValue()
+			} else if metric.GetGauge() != nil {
+				values[family.GetName()] = metric.GetGauge().GetValue()
+			}
+		}
+	}
+	return values
+}
@@ -18,7 +18,10 @@ type FIFOCache[K comparable, V any] struct {
 	maxWeight     uint64
 	data          map[K]V
 	evictionQueue queues.Queue
-	metrics       *CacheMetrics
+	// For each removed key, the number of its insertion records that are still in the eviction queue. Since the
+	// queue is ordered by insertion, these are always the oldest records for the key.
+	staleRecords map[K]int
+	metrics      *CacheMetrics
 }
 
 // insertionRecord is a record of when a key was inserted into the cache, and is used to decide when it should be
@@ -45,15 +48,26 @@ func NewFIFOCache[K comparable, V any](
 		data:             make(map[K]V),
 		weightCalculator: calculator,
 		evictionQueue:    linkedlistqueue.New(),
+		staleRecords:     make(map[K]int),
 		metrics:          metrics,
 	}
 }
 
 func (f *FIFOCache[K, V]) Get(key K) (V, bool) {
 	val, ok := f.data[key]
+	if ok {
+		f.metrics.reportHit()
+	} else {
+		f.metrics.reportMiss()
+	}
 	return val, ok
 }
 
+// mutatesOnGet returns false. Get only reads the cache, so concurrent lookups may share a read lock.
+func (f *FIFOCache[K, V]) mutatesOnGet() bool {
+	return false
+}
+
 func (f *FIFOCache[K, V]) Put(key K, value V) {
 	weight := f.weightCalculator(key, value)
 	if weight > f.maxWeight {
@@ -82,6 +96,18 @@ func (f *FIFOCache[K, V]) Put(key K, value V) {
 	f.metrics.reportCurrentSize(len(f.data), f.currentWeight)
 }
 
+func (f *FIFOCache[K, V]) Remove(key K) {
+	value, ok := f.data[key]
+	if !ok {
+		return
+	}
+	// The key's insertion record stays in the eviction queue, and is skipped once it reaches the front.
+	f.staleRecords[key]++
+	delete(f.data, key)
+	f.currentWeight -= f.weightCalculator(key, value)
+	f.metrics.reportCurrentSize(len(f.data), f.currentWeight)
+}
+
 func (f *FIFOCache[K, V]) evict() {
 	now := time.Now()
 
@@ -89,6 +115,15 @@ func (f *FIFOCache[K, V]) evict() {
 		next, _ := f.evictionQueue.Dequeue()
 		record := next.(*insertionRecord)
 		keyToEvict := record.key.(K)
+		if count, ok := f.staleRecords[keyToEvict]; ok {
+			// This key was removed after this record was inserted.
+			if count == 1 {
+				delete(f.staleRecords, keyToEvict)
+			} else {
+				f.staleRecords[keyToEvict] = count - 1
+			}
+			continue
+		}
 		weightToEvict := f.weightCalculator(keyToEvict, f.data[keyToEvict])
 		delete(f.data, keyToEvict)
 		f.currentWeight -= weightToEvict
@@ -0,0 +1,137 @@
+package cache
+
+import (
+	"container/list"
+	"time"
+)
+
+var _ Cache[string, string] = &LRUCache[string, string]{}
+
+// LRUCache is a cache that evicts the least recently accessed item when the cache is full. Both Get and Put count
+// as an access. Useful for situations where recently read items are likely to be read again.
+//
+// Unlike FIFOCache, Get modifies the state of the cache, so concurrent calls to Get must be synchronized.
+type LRUCache[K comparable, V any] struct {
+	weightCalculator WeightCalculator[K, V]
+
+	currentWeight uint64
+	maxWeight     uint64
+	data          map[K]*list.Element
+	// Items ordered by the time of their most recent access, with the most recently accessed item at the front.
+	recencyList *list.List
+	metrics     *CacheMetrics
+}
+
+// lruEntry is a key-value pair stored in an LRUCache.
+type lruEntry[K comparable, V any] struct {
+	key    K
+	value  V
+	weight uint64
+	// The time at which the key was added to the cache.
+	timestamp time.Time
+}
+
+// NewLRUCache creates a new LRUCache. If the calculator is nil, the weight of each key-value pair will be 1.
+func NewLRUCache[K comparable, V any](
+	maxWeight uint64,
+	calculator WeightCalculator[K, V],
+	metrics *CacheMetrics) Cache[K, V] {
+
+	if calculator == nil {
+		calculator = func(K, V) uint64 { return 1 }
+	}
+
+	return &LRUCache[K, V]{
+		maxWeight:        maxWeight,
+		data:             make(map[K]*list.Element),
+		weightCalculator: calculator,
+		recencyList:      list.New(),
+		metrics:          metrics,
+	}
+}
+
+func (l *LRUCache[K, V]) Get(key K) (V, bool) {
+	element, ok := l.data[key]
+	if !ok {
+		l.metrics.reportMiss()
+		var zero V
+		return zero, false
+	}
+
+	l.metrics.reportHit()
+	l.recencyList.MoveToFront(element)
+	return element.Value.(*lruEntry[K, V]).value, true
+}
+
+// mutatesOnGet returns true. Get moves the entry to the front of the recency list.
+func (l *LRUCache[K, V]) mutatesOnGet() bool {
+	return true
+}
+
+func (l *LRUCache[K, V]) Put(key K, value V) {
+	weight := l.weightCalculator(key, value)
+	if weight > l.maxWeight {
+		// this item won't fit in the cache no matter what we evict
+		return
+	}
+
+	element, ok := l.data[key]
+	if ok {
+		entry := element.Value.(*lruEntry[K, V])
+		l.currentWeight -= entry.weight
+		entry.value = value
+		entry.weight = weight
+		l.recencyList.MoveToFront(element)
+	} else {
+		l.data[key] = l.recencyList.PushFront(&lruEntry[K, V]{
+			key:       key,
+			value:     value,
+			weight:    weight,
+			timestamp: time.Now(),
+		})
+	}
+	l.currentWeight += weight
+
+	if l.currentWeight > l.maxWeight {
+		l.evict()
+	}
+
+	l.metrics.reportInsertion(weight)
+	l.metrics.reportCurrentSize(len(l.data), l.currentWeight)
+}
+
+func (l *LRUCache[K, V]) Remove(key K) {
+	element, ok := l.data[key]
+	if !ok {
+		return
+	}
+	entry := l.recencyList.Remove(element).(*lruEntry[K, V])
+	delete(l.data, key)
+	l.currentWeight -= entry.weight
+	l.metrics.reportCurrentSize(len(l.data), l.currentWeight)
+}
+
+func (l *LRUCache[K, V]) evict() {
+	now := time.Now()
+
+	for l.currentWeight > l.maxWeight {
+		entry := l.recencyList.Remove(l.recencyList.Back()).(*lruEntry[K, V])
+		delete(l.data, entry.key)
+		l.currentWeight -= entry.weight
+		l.metrics.reportEviction(now.Sub(entry.timestamp))
+	}
+}
+
+func (l *LRUCache[K, V]) Size() int {
+	return len(l.data)
+}
+
+func (l *LRUCache[K, V]) Weight() uint64 {
+	return l.currentWeight
+}
+
+func (l *LRUCache[K, V]) SetMaxWeight(capacity uint64) {
+	l.maxWeight = capacity
+	l.evict()
+	l.metrics.reportCurrentSize(len(l.data), l.currentWeight)
+}
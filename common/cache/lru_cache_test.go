@@ -0,0 +1,81 @@
+package cache
+
+import (
+	"testing"
+
+	tu "github.com/Layr-Labs/eigenda/common/testutils"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/rand"
+)
+
+func TestLRUEvictionOrder(t *testing.T) {
+	tu.InitializeRandom()
+
+	maxWeight := uint64(10 + rand.Intn(10))
+	c := NewLRUCache[int, int](maxWeight, nil, nil)
+
+	expectedValues := make(map[int]int)
+	for i := 1; i <= int(maxWeight); i++ {
+		value := rand.Int()
+		expectedValues[i] = value
+		c.Put(i, value)
+		require.Equal(t, uint64(i), c.Weight())
+		require.Equal(t, i, c.Size())
+	}
+
+	// Read the oldest key, so that the second oldest key becomes the least recently used.
+	value, ok := c.Get(1)
+	require.True(t, ok)
+	require.Equal(t, expectedValues[1], value)
+
+	c.Put(-1, rand.Int())
+	require.Equal(t, maxWeight, c.Weight())
+
+	_, ok = c.Get(2)
+	require.False(t, ok)
+	_, ok = c.Get(1)
+	require.True(t, ok)
+
+	// Overwriting a key counts as an access.
+	c.Put(3, 1234)
+	c.Put(-2, rand.Int())
+	_, ok = c.Get(4)
+	require.False(t, ok)
+	value, ok = c.Get(3)
+	require.True(t, ok)
+	require.Equal(t, 1234, value)
+}
+
+func TestLRUWeightedValues(t *testing.T) {
+	tu.InitializeRandom()
+
+	weightCalculator := func(key int, value int) uint64 {
+		return uint64(key)
+	}
+	c := NewLRUCache[int, int](10, weightCalculator, nil)
+
+	c.Put(3, 3)
+	c.Put(4, 4)
+	c.Put(2, 2)
+	require.Equal(t, uint64(9), c.Weight())
+
+	// Items heavier than the capacity are ignored.
+	c.Put(11, 11)
+	require.Equal(t, uint64(9), c.Weight())
+	require.Equal(t, 3, c.Size())
+
+	// Accessing 3 makes 4 the least recently used, and evicting 4 makes room for 5.
+	_, ok := c.Get(3)
+	require.True(t, ok)
+	c.Put(5, 5)
+	require.Equal(t, uint64(10), c.Weight())
+	_, ok = c.Get(4)
+	require.False(t, ok)
+
+	// Shrinking the cache evicts the least recently used items.
+	c.SetMaxWeight(5)
+	require.Equal(t, uint64(5), c.Weight())
+	require.Equal(t, 1, c.Size())
+	_, ok = c.Get(5)
+	require.True(t, ok)
+}
@@ -0,0 +1,274 @@
+package cache
+
+import (
+	"container/list"
+	"time"
+)
+
+var _ Cache[string, string] = &S3FIFOCache[string, string]{}
+
+const (
+	// The fraction of the cache's capacity, in tenths, that is reserved for the small queue.
+	s3FIFOSmallQueueTenths = 1
+	// The maximum value of an entry's access frequency counter.
+	s3FIFOMaxFrequency = 3
+)
+
+// S3FIFOCache is a cache that implements the S3-FIFO eviction algorithm (https://doi.org/10.1145/3600006.3613147),
+// adapted to weighted entries.
+//
+// New entries are added to a small FIFO queue that holds about 10% of the cache's weight. Entries that are read
+// again before they reach the end of the small queue are moved to a main FIFO queue, and the rest are evicted. This
+// quickly removes entries that are only read once, so that a large scan can't push frequently read entries out of
+// the cache. Entries at the end of the main queue are given another pass through the queue if they have been read
+// since their last pass, and are evicted otherwise. The keys of entries recently evicted from the small queue are
+// remembered in a ghost queue, and if such a key is added again it goes straight into the main queue.
+//
+// Unlike FIFOCache, Get modifies the state of the cache, so concurrent calls to Get must be synchronized.
+type S3FIFOCache[K comparable, V any] struct {
+	weightCalculator WeightCalculator[K, V]
+
+	maxWeight uint64
+	data      map[K]*list.Element
+
+	// The small queue, with the most recently added entry at the front.
+	small       *list.List
+	smallWeight uint64
+
+	// The main queue, with the most recently added entry at the front.
+	main       *list.List
+	mainWeight uint64
+
+	// The keys of entries recently evicted from the small queue, with the most recently evicted at the front.
+	ghost       *list.List
+	ghostKeys   map[K]*list.Element
+	ghostWeight uint64
+
+	metrics *CacheMetrics
+}
+
+// s3FIFOEntry is a key-value pair stored in an S3FIFOCache.
+type s3FIFOEntry[K comparable, V any] struct {
+	key    K
+	value  V
+	weight uint64
+	// The number of times the entry has been read since it was added to its current queue, up to
+	// s3FIFOMaxFrequency.
+	frequency uint8
+	// True if the entry is in the main queue, false if it is in the small queue.
+	inMain bool
+	// The time at which the key was added to the cache.
+	timestamp time.Time
+}
+
+// s3FIFOGhost is the key of an entry that was evicted from the small queue of an S3FIFOCache.
+type s3FIFOGhost[K comparable] struct {
+	key    K
+	weight uint64
+}
+
+// NewS3FIFOCache creates a new S3FIFOCache. If the calculator is nil, the weight of each key-value pair will be 1.
+func NewS3FIFOCache[K comparable, V any](
+	maxWeight uint64,
+	calculator WeightCalculator[K, V],
+	metrics *CacheMetrics) Cache[K, V] {
+
+	if calculator == nil {
+		calculator = func(K, V) uint64 { return 1 }
+	}
+
+	return &S3FIFOCache[K, V]{
+		weightCalculator: calculator,
+		maxWeight:        maxWeight,
+		data:             make(map[K]*list.Element),
+		small:            list.New(),
+		main:             list.New(),
+		ghost:            list.New(),
+		ghostKeys:        make(map[K]*list.Element),
+		metrics:          metrics,
+	}
+}
+
+func (s *S3FIFOCache[K, V]) Get(key K) (V, bool) {
+	element, ok := s.data[key]
+	if !ok {
+		s.metrics.reportMiss()
+		var zero V
+		return zero, false
+	}
+
+	s.metrics.reportHit()
+	entry := element.Value.(*s3FIFOEntry[K, V])
+	if entry.frequency < s3FIFOMaxFrequency {
+		entry.frequency++
+	}
+	return entry.value, true
+}
+
+// mutatesOnGet returns true. Get increments the entry's access frequency.
+func (s *S3FIFOCache[K, V]) mutatesOnGet() bool {
+	return true
+}
+
+func (s *S3FIFOCache[K, V]) Put(key K, value V) {
+	weight := s.weightCalculator(key, value)
+	if weight > s.maxWeight {
+		// this item won't fit in the cache no matter what we evict
+		return
+	}
+
+	element, ok := s.data[key]
+	if ok {
+		// Replace the value without changing the entry's position.
+		entry := element.Value.(*s3FIFOEntry[K, V])
+		if entry.inMain {
+			s.mainWeight = s.mainWeight - entry.weight + weight
+		} else {
+			s.smallWeight = s.smallWeight - entry.weight + weight
+		}
+		entry.value = value
+		entry.weight = weight
+	} else {
+		entry := &s3FIFOEntry[K, V]{
+			key:       key,
+			value:     value,
+			weight:    weight,
+			timestamp: time.Now(),
+		}
+		if ghostElement, ok := s.ghostKeys[key]; ok {
+			// This key was evicted from the small queue too early, give it a place in the main queue.
+			s.removeGhost(ghostElement)
+			entry.inMain = true
+			s.data[key] = s.main.PushFront(entry)
+			s.mainWeight += weight
+		} else {
+			s.data[key] = s.small.PushFront(entry)
+			s.smallWeight += weight
+		}
+	}
+
+	if s.Weight() > s.maxWeight {
+		s.evict()
+	}
+
+	s.metrics.reportInsertion(weight)
+	s.metrics.reportCurrentSize(len(s.data), s.Weight())
+}
+
+func (s *S3FIFOCache[K, V]) Remove(key K) {
+	if ghostElement, ok := s.ghostKeys[key]; ok {
+		s.removeGhost(ghostElement)
+	}
+
+	element, ok := s.data[key]
+	if !ok {
+		return
+	}
+	entry := element.Value.(*s3FIFOEntry[K, V])
+	if entry.inMain {
+		s.main.Remove(element)
+		s.mainWeight -= entry.weight
+	} else {
+		s.small.Remove(element)
+		s.smallWeight -= entry.weight
+	}
+	delete(s.data, key)
+	s.metrics.reportCurrentSize(len(s.data), s.Weight())
+}
+
+// smallTargetWeight returns the weight the small queue is allowed to grow to before entries are evicted from it
+// in preference to entries in the main queue.
+func (s *S3FIFOCache[K, V]) smallTargetWeight() uint64 {
+	return s.maxWeight * s3FIFOSmallQueueTenths / 10
+}
+
+// evict removes entries until the weight of the cache does not exceed its capacity.
+func (s *S3FIFOCache[K, V]) evict() {
+	now := time.Now()
+
+	for s.Weight() > s.maxWeight {
+		if s.smallWeight > s.smallTargetWeight() || s.main.Len() == 0 {
+			s.evictFromSmall(now)
+		} else {
+			s.evictFromMain(now)
+		}
+	}
+}
+
+// evictFromSmall removes the oldest entry from the small queue. If it was read while in the small queue, it is moved
+// to the main queue. Otherwise, it is evicted from the cache and its key is remembered in the ghost queue.
+func (s *S3FIFOCache[K, V]) evictFromSmall(now time.Time) {
+	entry := s.small.Remove(s.small.Back()).(*s3FIFOEntry[K, V])
+	s.smallWeight -= entry.weight
+
+	if entry.frequency > 0 {
+		entry.frequency = 0
+		entry.inMain = true
+		s.data[entry.key] = s.main.PushFront(entry)
+		s.mainWeight += entry.weight
+		return
+	}
+
+	delete(s.data, entry.key)
+	s.metrics.reportEviction(now.Sub(entry.timestamp))
+	s.addGhost(entry.key, entry.weight)
+}
+
+// evictFromMain removes the oldest entry from the main queue. If it was read since it was last added to the main
+// queue, it is added back to the front of the queue with its frequency decremented. Otherwise, it is evicted.
+func (s *S3FIFOCache[K, V]) evictFromMain(now time.Time) {
+	element := s.main.Back()
+	entry := element.Value.(*s3FIFOEntry[K, V])
+
+	if entry.frequency > 0 {
+		entry.frequency--
+		s.main.MoveToFront(element)
+		return
+	}
+
+	s.main.Remove(element)
+	s.mainWeight -= entry.weight
+	delete(s.data, entry.key)
+	s.metrics.reportEviction(now.Sub(entry.timestamp))
+}
+
+// addGhost remembers the key of an entry evicted from the small queue. The ghost queue tracks about as much weight
+// as the main queue can hold.
+func (s *S3FIFOCache[K, V]) addGhost(key K, weight uint64) {
+	s.ghostKeys[key] = s.ghost.PushFront(&s3FIFOGhost[K]{
+		key:    key,
+		weight: weight,
+	})
+	s.ghostWeight += weight
+	s.trimGhosts()
+}
+
+// trimGhosts forgets the oldest ghost keys until the ghost queue fits within its capacity.
+func (s *S3FIFOCache[K, V]) trimGhosts() {
+	capacity := s.maxWeight - s.smallTargetWeight()
+	for s.ghostWeight > capacity {
+		s.removeGhost(s.ghost.Back())
+	}
+}
+
+// removeGhost removes a key from the ghost queue.
+func (s *S3FIFOCache[K, V]) removeGhost(element *list.Element) {
+	ghost := s.ghost.Remove(element).(*s3FIFOGhost[K])
+	delete(s.ghostKeys, ghost.key)
+	s.ghostWeight -= ghost.weight
+}
+
+func (s *S3FIFOCache[K, V]) Size() int {
+	return len(s.data)
+}
+
+func (s *S3FIFOCache[K, V]) Weight() uint64 {
+	return s.smallWeight + s.mainWeight
+}
+
+func (s *S3FIFOCache[K, V]) SetMaxWeight(capacity uint64) {
+	s.maxWeight = capacity
+	s.evict()
+	s.trimGhosts()
+	s.metrics.reportCurrentSize(len(s.data), s.Weight())
+}
@@ -0,0 +1,91 @@
+package cache
+
+import (
+	"testing"
+
+	tu "github.com/Layr-Labs/eigenda/common/testutils"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/rand"
+)
+
+func TestS3FIFOEvictsKeysReadOnce(t *testing.T) {
+	tu.InitializeRandom()
+
+	c := NewS3FIFOCache[int, int](100, nil, nil)
+
+	// Fill the cache and read each key, so that they are promoted to the main queue when evicted from the small queue.
+	for i := 0; i < 100; i++ {
+		c.Put(i, i)
+		_, ok := c.Get(i)
+		require.True(t, ok)
+	}
+	require.Equal(t, uint64(100), c.Weight())
+	require.Equal(t, 100, c.Size())
+
+	// Add a scan of keys that are never read. The small queue keeps evicting the scan, so nearly all of the keys
+	// that were read remain in the cache.
+	for i := 0; i < 1000; i++ {
+		c.Put(-i-1, rand.Int())
+		require.Equal(t, uint64(100), c.Weight())
+	}
+
+	present := 0
+	for i := 0; i < 100; i++ {
+		value, ok := c.Get(i)
+		if ok {
+			require.Equal(t, i, value)
+			present++
+		}
+	}
+	require.GreaterOrEqual(t, present, 90)
+}
+
+func TestS3FIFOGhostKeys(t *testing.T) {
+	c := NewS3FIFOCache[int, int](10, nil, nil).(*S3FIFOCache[int, int])
+
+	for i := 0; i < 11; i++ {
+		c.Put(i, i)
+	}
+
+	// The first key was never read, so it was evicted and remembered as a ghost.
+	_, ok := c.Get(0)
+	require.False(t, ok)
+	require.Contains(t, c.ghostKeys, 0)
+
+	// Adding it again puts it straight into the main queue.
+	c.Put(0, 0)
+	require.NotContains(t, c.ghostKeys, 0)
+	require.True(t, c.data[0].Value.(*s3FIFOEntry[int, int]).inMain)
+	require.Equal(t, uint64(10), c.Weight())
+}
+
+func TestS3FIFOWeightedValues(t *testing.T) {
+	weightCalculator := func(key int, value int) uint64 {
+		return uint64(value)
+	}
+	c := NewS3FIFOCache[int, int](100, weightCalculator, nil)
+
+	// Items heavier than the capacity are ignored.
+	c.Put(1, 101)
+	require.Equal(t, 0, c.Size())
+
+	c.Put(1, 40)
+	c.Put(2, 40)
+	require.Equal(t, uint64(80), c.Weight())
+
+	// Overwriting a key updates its weight.
+	c.Put(1, 20)
+	require.Equal(t, uint64(60), c.Weight())
+	value, ok := c.Get(1)
+	require.True(t, ok)
+	require.Equal(t, 20, value)
+
+	c.Put(3, 50)
+	require.LessOrEqual(t, c.Weight(), uint64(100))
+	_, ok = c.Get(3)
+	require.True(t, ok)
+
+	// Shrinking the cache evicts items until it fits.
+	c.SetMaxWeight(50)
+	require.LessOrEqual(t, c.Weight(), uint64(50))
+}
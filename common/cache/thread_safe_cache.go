@@ -8,18 +8,39 @@ var _ Cache[string, string] = &threadSafeCache[string, string]{}
 type threadSafeCache[K comparable, V any] struct {
 	cache Cache[K, V]
 	lock  sync.RWMutex
+	// True if the wrapped cache's Get has no side effects, so that concurrent reads may share a read lock. Caches
+	// that don't declare whether Get mutates their state are assumed to mutate it.
+	readOnlyGet bool
 }
 
 // NewThreadSafeCache wraps a Cache in a thread-safe wrapper.
 func NewThreadSafeCache[K comparable, V any](cache Cache[K, V]) Cache[K, V] {
+	readOnlyGet := false
+	if mutator, ok := cache.(getMutator); ok {
+		readOnlyGet = !mutator.mutatesOnGet()
+	}
+
 	return &threadSafeCache[K, V]{
-		cache: cache,
+		cache:       cache,
+		readOnlyGet: readOnlyGet,
 	}
 }
 
+// getMutator is implemented by caches that declare whether Get modifies their internal state.
+type getMutator interface {
+	// mutatesOnGet returns true if Get modifies the cache's internal state (e.g. LRU bookkeeping), and therefore
+	// requires an exclusive lock.
+	mutatesOnGet() bool
+}
+
 func (t *threadSafeCache[K, V]) Get(key K) (V, bool) {
-	t.lock.RLock()
-	defer t.lock.RUnlock()
+	if t.readOnlyGet {
+		t.lock.RLock()
+		defer t.lock.RUnlock()
+	} else {
+		t.lock.Lock()
+		defer t.lock.Unlock()
+	}
 	return t.cache.Get(key)
 }
 
@@ -29,6 +50,12 @@ func (t *threadSafeCache[K, V]) Put(key K, value V) {
 	t.cache.Put(key, value)
 }
 
+func (t *threadSafeCache[K, V]) Remove(key K) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.cache.Remove(key)
+}
+
 func (t *threadSafeCache[K, V]) Size() int {
 	t.lock.RLock()
 	defer t.lock.RUnlock()
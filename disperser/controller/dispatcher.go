@@ -11,6 +11,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Layr-Labs/eigenda/api"
 	"github.com/Layr-Labs/eigenda/api/clients/v2"
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/healthcheck"
@@ -305,6 +306,16 @@ func (d *Dispatcher) HandleBatch(
 					break
 				}
 
+				if api.IsErrorValidatorMaintenance(err) {
+					// The validator is deliberately out of service. Retrying won't help, and the validator is
+					// expected not to sign.
+					d.metrics.reportValidatorMaintenance()
+					d.logger.Info("validator is in maintenance mode, not sending chunks",
+						"operator", opID.Hex(),
+						"batchHeader", hex.EncodeToString(batchData.BatchHeaderHash[:]))
+					break
+				}
+
 				d.logger.Warn("failed to send chunks",
 					"operator", opID.Hex(),
 					"NumAttempts", i,
@@ -314,11 +325,13 @@ func (d *Dispatcher) HandleBatch(
 			}
 
 			if lastErr != nil {
-				d.logger.Warn("failed to send chunks",
-					"operator", opID.Hex(),
-					"NumAttempts", i,
-					"batchHeader", hex.EncodeToString(batchData.BatchHeaderHash[:]),
-					"err", lastErr)
+				if !api.IsErrorValidatorMaintenance(lastErr) {
+					d.logger.Warn("failed to send chunks",
+						"operator", opID.Hex(),
+						"NumAttempts", i,
+						"batchHeader", hex.EncodeToString(batchData.BatchHeaderHash[:]),
+						"err", lastErr)
+				}
 				storeErr := d.blobMetadataStore.PutDispersalResponse(ctx, &corev2.DispersalResponse{
 					DispersalRequest: req,
 					RespondedAt:      uint64(time.Now().UnixNano()),
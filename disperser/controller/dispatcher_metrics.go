@@ -17,6 +17,7 @@ const dispatcherNamespace = "eigenda_dispatcher"
 // dispatcherMetrics is a struct that holds the metrics for the dispatcher.
 type dispatcherMetrics struct {
 	sendChunksRetryCount         *prometheus.GaugeVec
+	validatorMaintenanceCount    *prometheus.CounterVec
 	processSigningMessageLatency *prometheus.SummaryVec
 	signingMessageChannelLatency *prometheus.SummaryVec
 	attestationUpdateLatency     *prometheus.SummaryVec
@@ -65,6 +66,15 @@ func newDispatcherMetrics(
 		[]string{},
 	)
 
+	validatorMaintenanceCount := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: dispatcherNamespace,
+			Name:      "validator_maintenance_count",
+			Help:      "The number of StoreChunks requests rejected by validators in maintenance mode.",
+		},
+		[]string{},
+	)
+
 	processSigningMessageLatency := promauto.With(registry).NewSummaryVec(
 		prometheus.SummaryOpts{
 			Namespace:  dispatcherNamespace,
@@ -218,6 +228,7 @@ func newDispatcherMetrics(
 
 	return &dispatcherMetrics{
 		sendChunksRetryCount:         sendChunksRetryCount,
+		validatorMaintenanceCount:    validatorMaintenanceCount,
 		processSigningMessageLatency: processSigningMessageLatency,
 		signingMessageChannelLatency: signingMessageChannelLatency,
 		attestationUpdateLatency:     attestationUpdateLatency,
@@ -242,6 +253,10 @@ func (m *dispatcherMetrics) reportSendChunksRetryCount(retries float64) {
 	m.sendChunksRetryCount.WithLabelValues().Set(retries)
 }
 
+func (m *dispatcherMetrics) reportValidatorMaintenance() {
+	m.validatorMaintenanceCount.WithLabelValues().Inc()
+}
+
 func (m *dispatcherMetrics) reportProcessSigningMessageLatency(duration time.Duration) {
 	m.processSigningMessageLatency.WithLabelValues().Observe(common.ToMilliseconds(duration))
 }
@@ -9,6 +9,7 @@ import (
 	"slices"
 	"time"
 
+	"github.com/Layr-Labs/eigenda/api"
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 )
@@ -72,6 +73,10 @@ type signatureReceiver struct {
 
 	// The number of errors encountered while processing SigningMessages.
 	errorCount int
+
+	// The number of SigningMessages from validators in maintenance mode. These validators are expected not to sign,
+	// so their messages are not counted as errors.
+	maintenanceCount int
 }
 
 // ReceiveSignatures receives SigningMessages over the signingMessageChan, and yields QuorumAttestations produced
@@ -162,8 +167,9 @@ forLoop:
 		case <-ctx.Done():
 			sr.logger.Infof(
 				"global batch attestation timeout exceeded for batch %s. Received and processed %d/%d signing "+
-					"messages. %d of the signing messages caused an error during processing", hex.EncodeToString(
-					sr.batchHeaderHash[:]), len(sr.signatureMessageReceived), operatorCount, sr.errorCount)
+					"messages. %d of the signing messages caused an error during processing, %d were from "+
+					"validators in maintenance mode", hex.EncodeToString(sr.batchHeaderHash[:]),
+				len(sr.signatureMessageReceived), operatorCount, sr.errorCount, sr.maintenanceCount)
 			break forLoop
 		case signingMessage, ok := <-sr.signingMessageChan:
 			if !ok {
@@ -225,6 +231,14 @@ func (sr *signatureReceiver) handleNextSignature(
 	// this map records messages received, whether the messages are valid or not
 	sr.signatureMessageReceived[signingMessage.Operator] = true
 
+	if api.IsErrorValidatorMaintenance(signingMessage.Err) {
+		sr.maintenanceCount++
+		sr.logger.Debug("operator is in maintenance mode",
+			"batchHeaderHash", hex.EncodeToString(sr.batchHeaderHash[:]),
+			"operatorID", signingMessage.Operator.Hex())
+		return
+	}
+
 	thresholdCrossed, err := sr.processSigningMessage(signingMessage, indexedOperatorInfo)
 	if err != nil {
 		sr.errorCount++
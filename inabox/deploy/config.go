@@ -385,6 +385,10 @@ func (env *Config) generateRelayVars(ind int, graphUrl, grpcPort string) RelayVa
 		RELAY_ONCHAIN_STATE_REFRESH_INTERVAL:        "1s",
 		RELAY_MAX_CONCURRENT_GET_CHUNK_OPS_CLIENT:   "10",
 		RELAY_MAX_GET_CHUNK_BYTES_PER_SECOND_CLIENT: "100000000",
+		RELAY_MAX_GET_BLOB_OPS_PER_SECOND_CLIENT:    "1024",
+		RELAY_GET_BLOB_OPS_BURSTINESS_CLIENT:        "1024",
+		RELAY_MAX_GET_BLOB_BYTES_PER_SECOND_CLIENT:  "20971520",
+		RELAY_MAX_CONCURRENT_GET_BLOB_OPS_CLIENT:    "1024",
 		RELAY_AUTHENTICATION_DISABLED:               "false",
 		RELAY_ENABLE_METRICS:                        "true",
 	}
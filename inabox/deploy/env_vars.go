@@ -484,6 +484,8 @@ type OperatorVars struct {
 
 	NODE_LITT_DB_READ_CACHE_SIZE_FRACTION string
 
+	NODE_LITT_DB_CACHE_EVICTION_POLICY string
+
 	NODE_LITT_DB_STORAGE_PATHS string
 
 	NODE_GET_CHUNKS_HOT_CACHE_READ_LIMIT_MB string
@@ -810,6 +812,8 @@ type RelayVars struct {
 
 	RELAY_CHUNK_MAX_CONCURRENCY string
 
+	RELAY_CACHE_EVICTION_POLICY string
+
 	RELAY_MAX_KEYS_PER_GET_CHUNKS_REQUEST string
 
 	RELAY_MAX_GET_BLOB_OPS_PER_SECOND string
@@ -822,6 +826,22 @@ type RelayVars struct {
 
 	RELAY_MAX_CONCURRENT_GET_BLOB_OPS string
 
+	RELAY_MAX_GET_BLOB_OPS_PER_SECOND_CLIENT string
+
+	RELAY_GET_BLOB_OPS_BURSTINESS_CLIENT string
+
+	RELAY_MAX_GET_BLOB_BYTES_PER_SECOND_CLIENT string
+
+	RELAY_GET_BLOB_BYTES_BURSTINESS_CLIENT string
+
+	RELAY_MAX_CONCURRENT_GET_BLOB_OPS_CLIENT string
+
+	RELAY_GET_BLOB_CLIENT_TIERS_FILE string
+
+	RELAY_GET_BLOB_CLIENT_CACHE_SIZE string
+
+	RELAY_CLIENT_IP_HEADER string
+
 	RELAY_MAX_GET_CHUNK_OPS_PER_SECOND string
 
 	RELAY_GET_CHUNK_OPS_BURSTINESS string
@@ -0,0 +1,27 @@
+package litt
+
+import "context"
+
+// BackupTarget is a destination for incremental backups of a database's sealed segments. See DB.Backup().
+//
+// A backup pass calls BackupSegment() for each sealed segment in each table, followed by BackupTable() once all
+// of a table's segments have been visited, followed by a single call to Commit(). A target is only ever used by
+// one backup pass at a time.
+type BackupTarget interface {
+
+	// BackupSegment backs up the files of a sealed segment. Sealed segment files are immutable, and so the target
+	// may skip segments that it has already backed up. The files are protected from garbage collection until this
+	// method returns.
+	BackupSegment(ctx context.Context, tableName string, segmentIndex uint32, filePaths []string) error
+
+	// BackupTable is called after all of a table's sealed segments have been passed to BackupSegment().
+	// The metadata is the table's serialized metadata (i.e. the contents of the table's metadata file), and
+	// segmentIndices contains the index of each sealed segment that currently exists in the table. Segments that
+	// the target previously backed up but that are not in segmentIndices have been deleted by the garbage
+	// collector.
+	BackupTable(ctx context.Context, tableName string, metadata []byte, segmentIndices []uint32) error
+
+	// Commit is called at the end of each backup pass. Data passed to the target during the pass should not be
+	// considered to be backed up until this method returns successfully.
+	Commit(ctx context.Context) error
+}
@@ -0,0 +1,122 @@
+package backup
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// ManifestFileName is the name of the manifest object, relative to the backup's prefix.
+const ManifestFileName = "manifest.json"
+
+// manifestVersion is the serialization version of the manifest. Version 0 stored each file as multiple fixed size
+// fragments, and is no longer supported.
+const manifestVersion = 1
+
+// Manifest describes the contents of a backup. An object is only considered to be part of the backup once it is
+// recorded in the manifest, and an object is only deleted from the object store once it has been removed from the
+// manifest. The manifest is always consistent with itself, i.e. it describes a set of segments that can be used to
+// rebuild each table.
+type Manifest struct {
+	// The serialization version of the manifest.
+	Version int `json:"version"`
+
+	// The tables in the backup, keyed by table name.
+	Tables map[string]*TableManifest `json:"tables"`
+}
+
+// TableManifest describes the backed up data for a single table.
+type TableManifest struct {
+	// The table's serialized metadata, i.e. the contents of the table's metadata file.
+	Metadata []byte `json:"metadata"`
+
+	// The table's backed up segments, keyed by segment index.
+	Segments map[uint32]*SegmentManifest `json:"segments"`
+}
+
+// SegmentManifest describes the backed up files for a single segment.
+type SegmentManifest struct {
+	// The segment's files (i.e. the metadata file, the key file, and the value files).
+	Files []*FileManifest `json:"files"`
+}
+
+// FileManifest describes a single backed up file.
+type FileManifest struct {
+	// The name of the file on disk.
+	Name string `json:"name"`
+
+	// The size of the file, in bytes.
+	Size int64 `json:"size"`
+}
+
+// newManifest creates an empty manifest.
+func newManifest() *Manifest {
+	return &Manifest{
+		Version: manifestVersion,
+		Tables:  make(map[string]*TableManifest),
+	}
+}
+
+// deserializeManifest parses a manifest.
+func deserializeManifest(data []byte) (*Manifest, error) {
+	manifest := &Manifest{}
+	err := json.Unmarshal(data, manifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	if manifest.Version != manifestVersion {
+		return nil, fmt.Errorf("unsupported manifest version %d", manifest.Version)
+	}
+	if manifest.Tables == nil {
+		manifest.Tables = make(map[string]*TableManifest)
+	}
+	for _, table := range manifest.Tables {
+		if table.Segments == nil {
+			table.Segments = make(map[uint32]*SegmentManifest)
+		}
+	}
+	return manifest, nil
+}
+
+// serialize converts the manifest to bytes.
+func (m *Manifest) serialize() ([]byte, error) {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize manifest: %w", err)
+	}
+	return data, nil
+}
+
+// TableNames returns the sorted names of the tables in the manifest.
+func (m *Manifest) TableNames() []string {
+	names := make([]string, 0, len(m.Tables))
+	for name := range m.Tables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SegmentIndices returns the sorted indices of the segments in the table.
+func (t *TableManifest) SegmentIndices() []uint32 {
+	indices := make([]uint32, 0, len(t.Segments))
+	for index := range t.Segments {
+		indices = append(indices, index)
+	}
+	sort.Slice(indices, func(i, j int) bool {
+		return indices[i] < indices[j]
+	})
+	return indices
+}
+
+// getFile returns the manifest of the file with the given name, or nil if the table has no such file.
+func (t *TableManifest) getFile(name string) *FileManifest {
+	for _, seg := range t.Segments {
+		for _, file := range seg.Files {
+			if file.Name == name {
+				return file
+			}
+		}
+	}
+	return nil
+}
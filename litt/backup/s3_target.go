@@ -0,0 +1,386 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/Layr-Labs/eigenda/common/aws/s3"
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/littbuilder"
+	"github.com/Layr-Labs/eigenda/litt/util"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+var _ litt.BackupTarget = (*S3Target)(nil)
+
+// S3Target is a litt.BackupTarget that uploads sealed segment files to an object store via the s3.Client interface.
+//
+// Within the bucket, each segment file is stored as a single object at <prefix>/<table>/<file name>, and the manifest
+// is stored at <prefix>/manifest.json. Each segment file is uploaded exactly once. When the garbage collector deletes
+// a segment from the DB, the segment is removed from the manifest and then deleted from the object store, so the
+// backup mirrors the retention of the DB.
+//
+// Files are streamed from disk as multipart uploads, so memory usage during a backup does not depend on the DB's
+// segment file size.
+//
+// An S3Target is not thread safe, and only one S3Target (i.e. one DB) should write to a given bucket and prefix.
+type S3Target struct {
+	logger logging.Logger
+
+	// The client used to communicate with the object store.
+	client s3.Client
+
+	// The bucket where the backup is stored.
+	bucket string
+
+	// The prefix of all objects in the backup.
+	prefix string
+
+	// Describes the data in the backup. May contain changes that have not yet been committed.
+	manifest *Manifest
+
+	// Files that have been removed from the manifest, but not yet from the object store. Deleted after the
+	// manifest is next committed.
+	pendingDeletions []string
+
+	// True if the manifest has changed since it was last committed.
+	dirty bool
+}
+
+// NewS3Target creates a new S3Target. If a backup already exists at the given bucket and prefix, then new data is
+// added to the existing backup.
+func NewS3Target(
+	ctx context.Context,
+	logger logging.Logger,
+	client s3.Client,
+	bucket string,
+	prefix string) (*S3Target, error) {
+
+	manifest, err := LoadManifest(ctx, client, bucket, prefix)
+	if err != nil {
+		return nil, err
+	}
+	if manifest == nil {
+		manifest = newManifest()
+	}
+
+	return &S3Target{
+		logger:   logger,
+		client:   client,
+		bucket:   bucket,
+		prefix:   prefix,
+		manifest: manifest,
+	}, nil
+}
+
+// LoadManifest downloads the manifest of the backup at the given bucket and prefix. Returns nil if there is no
+// backup at that location.
+func LoadManifest(ctx context.Context, client s3.Client, bucket string, prefix string) (*Manifest, error) {
+	manifestKey := path.Join(prefix, ManifestFileName)
+
+	_, err := client.HeadObject(ctx, bucket, manifestKey)
+	if errors.Is(err, s3.ErrObjectNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to check for manifest %s: %w", manifestKey, err)
+	}
+
+	data, err := client.DownloadObject(ctx, bucket, manifestKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download manifest %s: %w", manifestKey, err)
+	}
+
+	manifest, err := deserializeManifest(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to deserialize manifest %s: %w", manifestKey, err)
+	}
+
+	return manifest, nil
+}
+
+func (t *S3Target) BackupSegment(
+	ctx context.Context,
+	tableName string,
+	segmentIndex uint32,
+	filePaths []string) error {
+
+	table := t.getTableManifest(tableName)
+	if _, ok := table.Segments[segmentIndex]; ok {
+		// Sealed segments are immutable, there is no need to upload this segment again.
+		return nil
+	}
+
+	files := make([]*FileManifest, 0, len(filePaths))
+	for _, filePath := range filePaths {
+		file, err := t.uploadFile(ctx, tableName, filePath)
+		if err != nil {
+			return fmt.Errorf("failed to upload file %s: %w", filePath, err)
+		}
+		files = append(files, file)
+	}
+
+	table.Segments[segmentIndex] = &SegmentManifest{
+		Files: files,
+	}
+	t.dirty = true
+
+	return nil
+}
+
+// uploadFile uploads a single segment file.
+func (t *S3Target) uploadFile(ctx context.Context, tableName string, filePath string) (*FileManifest, error) {
+	reader, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+
+	info, err := reader.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat file: %w", err)
+	}
+
+	file := &FileManifest{
+		Name: filepath.Base(filePath),
+		Size: info.Size(),
+	}
+
+	if file.Size == 0 {
+		// Empty files are recorded in the manifest, but there is nothing to upload.
+		return file, nil
+	}
+
+	// Sealed segment files are immutable, so the file can't change while it is being uploaded.
+	err = t.client.UploadObjectFromReader(ctx, t.bucket, t.fileKey(tableName, file.Name), reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload: %w", err)
+	}
+
+	return file, nil
+}
+
+func (t *S3Target) BackupTable(
+	ctx context.Context,
+	tableName string,
+	metadata []byte,
+	segmentIndices []uint32) error {
+
+	table := t.getTableManifest(tableName)
+	if string(table.Metadata) != string(metadata) {
+		table.Metadata = metadata
+		t.dirty = true
+	}
+
+	present := make(map[uint32]struct{}, len(segmentIndices))
+	for _, index := range segmentIndices {
+		present[index] = struct{}{}
+	}
+
+	for index, seg := range table.Segments {
+		if _, ok := present[index]; ok {
+			continue
+		}
+
+		// This segment has been deleted from the DB by the garbage collector.
+		for _, file := range seg.Files {
+			if file.Size > 0 {
+				t.pendingDeletions = append(t.pendingDeletions, t.fileKey(tableName, file.Name))
+			}
+		}
+		delete(table.Segments, index)
+		t.dirty = true
+	}
+
+	return nil
+}
+
+func (t *S3Target) Commit(ctx context.Context) error {
+	if !t.dirty {
+		return nil
+	}
+
+	data, err := t.manifest.serialize()
+	if err != nil {
+		return err
+	}
+
+	manifestKey := path.Join(t.prefix, ManifestFileName)
+	err = t.client.UploadObject(ctx, t.bucket, manifestKey, data)
+	if err != nil {
+		return fmt.Errorf("failed to upload manifest %s: %w", manifestKey, err)
+	}
+	t.dirty = false
+
+	// Now that the manifest no longer references these objects, it is safe to delete them.
+	for len(t.pendingDeletions) > 0 {
+		key := t.pendingDeletions[len(t.pendingDeletions)-1]
+		err = t.client.DeleteObject(ctx, t.bucket, key)
+		if err != nil {
+			return fmt.Errorf("failed to delete object %s: %w", key, err)
+		}
+		t.pendingDeletions = t.pendingDeletions[:len(t.pendingDeletions)-1]
+	}
+
+	return nil
+}
+
+// getTableManifest returns the manifest for a table, creating it if it does not yet exist.
+func (t *S3Target) getTableManifest(tableName string) *TableManifest {
+	table, ok := t.manifest.Tables[tableName]
+	if !ok {
+		table = &TableManifest{
+			Segments: make(map[uint32]*SegmentManifest),
+		}
+		t.manifest.Tables[tableName] = table
+	}
+	return table
+}
+
+// fileKey returns the key of a segment file in the object store. The file name is unique within the table, since
+// segment file names contain the segment index.
+func (t *S3Target) fileKey(tableName string, fileName string) string {
+	return fileKey(t.prefix, tableName, fileName)
+}
+
+// fileKey returns the key of a segment file in the object store.
+func fileKey(prefix string, tableName string, fileName string) string {
+	return path.Join(prefix, tableName, fileName)
+}
+
+// Restore builds a database from a backup created by an S3Target (see littbuilder.Restore()). Segment files are
+// streamed from the object store directly to disk.
+//
+// None of the tables in the backup may already exist in the target database. The backup is not modified.
+func Restore(ctx context.Context, config *litt.Config, client s3.Client, bucket string, prefix string) error {
+	manifest, err := LoadManifest(ctx, client, bucket, prefix)
+	if err != nil {
+		return fmt.Errorf("error loading manifest: %w", err)
+	}
+	if manifest == nil {
+		return fmt.Errorf("no backup found in bucket %s with prefix %s", bucket, prefix)
+	}
+
+	return littbuilder.Restore(config, &s3Source{
+		ctx:      ctx,
+		client:   client,
+		bucket:   bucket,
+		prefix:   prefix,
+		manifest: manifest,
+	})
+}
+
+var _ littbuilder.RestoreSource = (*s3Source)(nil)
+
+// s3Source is a littbuilder.RestoreSource that downloads tables from a backup created by an S3Target.
+type s3Source struct {
+	ctx context.Context
+
+	// The client used to communicate with the object store.
+	client s3.Client
+
+	// The bucket where the backup is stored.
+	bucket string
+
+	// The prefix of all objects in the backup.
+	prefix string
+
+	// Describes the data in the backup.
+	manifest *Manifest
+}
+
+func (s *s3Source) String() string {
+	return fmt.Sprintf("backup in bucket %s with prefix %s", s.bucket, s.prefix)
+}
+
+func (s *s3Source) GetTables() ([]string, error) {
+	tables := make([]string, 0, len(s.manifest.Tables))
+	for _, tableName := range s.manifest.TableNames() {
+		// A table without metadata is skipped, since its first backup pass never completed.
+		if len(s.manifest.Tables[tableName].Metadata) > 0 {
+			tables = append(tables, tableName)
+		}
+	}
+	return tables, nil
+}
+
+func (s *s3Source) GetSegmentFiles(tableName string) ([]string, error) {
+	table := s.manifest.Tables[tableName]
+	files := make([]string, 0)
+	for _, index := range table.SegmentIndices() {
+		for _, file := range table.Segments[index].Files {
+			files = append(files, file.Name)
+		}
+	}
+	return files, nil
+}
+
+func (s *s3Source) RestoreSegmentFile(tableName string, fileName string, destination string, fsync bool) error {
+	file := s.manifest.Tables[tableName].getFile(fileName)
+	if file == nil {
+		return fmt.Errorf("file %s is not in the manifest of table %s", fileName, tableName)
+	}
+
+	err := util.EnsureParentDirectoryExists(destination, fsync)
+	if err != nil {
+		return fmt.Errorf("failed to create parent directory for %s: %w", destination, err)
+	}
+
+	writer, err := os.Create(destination)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", destination, err)
+	}
+	defer func() {
+		_ = writer.Close()
+	}()
+
+	if file.Size > 0 {
+		key := s.fileKey(tableName, fileName)
+		size, err := s.client.DownloadObjectToWriter(s.ctx, s.bucket, key, writer)
+		if err != nil {
+			return fmt.Errorf("failed to download %s: %w", key, err)
+		}
+		if size != file.Size {
+			return fmt.Errorf("downloaded %d bytes for %s, expected %d", size, key, file.Size)
+		}
+	}
+
+	if fsync {
+		err = writer.Sync()
+		if err != nil {
+			return fmt.Errorf("failed to sync %s: %w", destination, err)
+		}
+	}
+
+	err = writer.Close()
+	if err != nil {
+		return fmt.Errorf("failed to close %s: %w", destination, err)
+	}
+
+	return nil
+}
+
+func (s *s3Source) RestoreTableMetadata(tableName string, destination string, fsync bool) error {
+	err := util.EnsureParentDirectoryExists(destination, fsync)
+	if err != nil {
+		return fmt.Errorf("failed to create parent directory for %s: %w", destination, err)
+	}
+
+	err = util.AtomicWrite(destination, s.manifest.Tables[tableName].Metadata, fsync)
+	if err != nil {
+		return fmt.Errorf("failed to write table metadata: %w", err)
+	}
+
+	return nil
+}
+
+// fileKey returns the key of a segment file in the object store.
+func (s *s3Source) fileKey(tableName string, fileName string) string {
+	return fileKey(s.prefix, tableName, fileName)
+}
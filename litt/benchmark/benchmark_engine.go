@@ -19,6 +19,9 @@ import (
 	"golang.org/x/time/rate"
 )
 
+// tableName is the name of the table where the benchmark stores data.
+const tableName = "benchmark"
+
 // BenchmarkEngine is a tool for benchmarking LittDB performance.
 type BenchmarkEngine struct {
 	ctx    context.Context
@@ -75,7 +78,7 @@ func NewBenchmarkEngine(configPath string) (*BenchmarkEngine, error) {
 		return nil, fmt.Errorf("failed to create db: %w", err)
 	}
 
-	table, err := db.GetTable("benchmark")
+	table, err := db.GetTable(tableName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create table: %w", err)
 	}
@@ -1,12 +1,21 @@
 package benchmark
 
 import (
+	"bufio"
 	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"sync/atomic"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/litt/benchmark/config"
+	"github.com/Layr-Labs/eigenda/litt/disktable/keymap"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 )
 
@@ -23,6 +32,9 @@ type metrics struct {
 	// The time when the benchmark started.
 	startTime time.Time
 
+	// The number of bytes the process had written to disk when the benchmark started.
+	startDiskBytesWritten uint64
+
 	// The number of bytes written since the benchmark started.
 	bytesWritten atomic.Uint64
 
@@ -64,11 +76,17 @@ func newMetrics(
 	config *config.BenchmarkConfig,
 ) *metrics {
 
+	startDiskBytesWritten, err := diskBytesWritten()
+	if err != nil {
+		logger.Warnf("Unable to read disk write statistics, write amplification will not be reported: %v", err)
+	}
+
 	m := &metrics{
-		ctx:       ctx,
-		logger:    logger,
-		config:    config,
-		startTime: time.Now(),
+		ctx:                   ctx,
+		logger:                logger,
+		config:                config,
+		startTime:             time.Now(),
+		startDiskBytesWritten: startDiskBytesWritten,
 	}
 
 	go m.reportGenerator()
@@ -212,4 +230,101 @@ func (m *metrics) logMetrics() {
 		common.PrettyPrintTime(averageFlushLatency),
 		common.PrettyPrintTime(m.longestFlushDuration.Load()))
 
+	m.logWriteAmplification(bytesWritten)
+}
+
+// logWriteAmplification logs the number of bytes written to disk relative to the number of bytes written to the DB,
+// as well as the size of the keymap. Since the amount of data written to segment files does not depend on the keymap,
+// running the benchmark with different keymap types allows the write amplification of the keymaps to be compared.
+func (m *metrics) logWriteAmplification(bytesWritten uint64) {
+	currentDiskBytesWritten, err := diskBytesWritten()
+	if err != nil {
+		return
+	}
+	diskBytes := currentDiskBytesWritten - m.startDiskBytesWritten
+
+	writeAmplification := 0.0
+	if bytesWritten > 0 {
+		writeAmplification = float64(diskBytes) / float64(bytesWritten)
+	}
+
+	keymapSize := uint64(0)
+	for _, root := range m.config.LittConfig.Paths {
+		size, err := directorySize(path.Join(root, tableName, keymap.KeymapDirectoryName))
+		if err != nil {
+			m.logger.Warnf("Unable to compute keymap size: %v", err)
+			continue
+		}
+		keymapSize += size
+	}
+
+	m.logger.Infof("Write Amplification (since most recent restart):\n"+
+		"    Keymap Type:            %s\n"+
+		"    Keymap Size:            %s\n"+
+		"    Disk Bytes Written:     %s\n"+
+		"    Write Amplification:    %.3f",
+		m.config.LittConfig.KeymapType,
+		common.PrettyPrintBytes(keymapSize),
+		common.PrettyPrintBytes(diskBytes),
+		writeAmplification)
+}
+
+// diskBytesWritten returns the number of bytes this process has caused to be written to disk. Only supported on
+// Linux.
+func diskBytesWritten() (uint64, error) {
+	file, err := os.Open("/proc/self/io")
+	if err != nil {
+		return 0, fmt.Errorf("failed to open /proc/self/io: %w", err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		value, found := strings.CutPrefix(scanner.Text(), "write_bytes:")
+		if !found {
+			continue
+		}
+		bytes, err := strconv.ParseUint(strings.TrimSpace(value), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse write_bytes: %w", err)
+		}
+		return bytes, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, fmt.Errorf("failed to read /proc/self/io: %w", err)
+	}
+
+	return 0, fmt.Errorf("write_bytes not found in /proc/self/io")
+}
+
+// directorySize returns the total size of all files in a directory. Returns 0 if the directory does not exist.
+func directorySize(directory string) (uint64, error) {
+	size := uint64(0)
+	err := filepath.WalkDir(directory, func(_ string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			if os.IsNotExist(err) {
+				// The keymap may delete files while they are being counted.
+				return nil
+			}
+			return err
+		}
+		size += uint64(info.Size())
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to walk directory %s: %w", directory, err)
+	}
+	return size, nil
 }
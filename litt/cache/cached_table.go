@@ -1,6 +1,7 @@
 package cache
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -72,6 +73,15 @@ func (c *cachedTable) PutBatch(batch []*types.KVPair) error {
 	return nil
 }
 
+func (c *cachedTable) PutWithExpiry(key []byte, value []byte, expiry time.Time) error {
+	err := c.base.PutWithExpiry(key, value, expiry)
+	if err != nil {
+		return err
+	}
+	c.writeCache.Put(util.UnsafeBytesToString(key), value)
+	return nil
+}
+
 func (c *cachedTable) Get(key []byte) (value []byte, exists bool, err error) {
 	value, exists, _, err = c.CacheAwareGet(key, false)
 	return value, exists, err
@@ -156,6 +166,11 @@ func (c *cachedTable) CacheAwareGet(
 	return value, exists, hot, err
 }
 
+func (c *cachedTable) Iterate(fn func(key []byte, value []byte) error) error {
+	// Every value in the caches is also present in the base table.
+	return c.base.Iterate(fn)
+}
+
 func (c *cachedTable) Exists(key []byte) (exists bool, err error) {
 	_, exists = c.writeCache.Get(util.UnsafeBytesToString(key))
 	if exists {
@@ -211,3 +226,19 @@ func (c *cachedTable) SetShardingFactor(shardingFactor uint32) error {
 func (c *cachedTable) RunGC() error {
 	return c.base.RunGC()
 }
+
+func (c *cachedTable) Snapshot(directory string) error {
+	return c.base.Snapshot(directory)
+}
+
+func (c *cachedTable) Backup(ctx context.Context, target litt.BackupTarget) error {
+	return c.base.Backup(ctx, target)
+}
+
+func (c *cachedTable) AddRoot(root string) error {
+	return c.base.AddRoot(root)
+}
+
+func (c *cachedTable) RemoveRoot(root string) (func() error, error) {
+	return c.base.RemoveRoot(root)
+}
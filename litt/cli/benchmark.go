@@ -0,0 +1,32 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda/litt/benchmark"
+	"github.com/urfave/cli/v2"
+)
+
+// benchmarkCommand is the CLI command handler for "litt benchmark".
+func benchmarkCommand(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return fmt.Errorf("benchmark requires exactly one argument: <config-file-path>")
+	}
+	configPath := ctx.Args().First()
+
+	engine, err := benchmark.NewBenchmarkEngine(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to create benchmark engine: %w", err)
+	}
+
+	engine.Logger().Infof("Configuration loaded from %s", configPath)
+	engine.Logger().Info("Press Ctrl+C to stop the benchmark")
+
+	err = engine.Run()
+	if err != nil {
+		return fmt.Errorf("benchmark failed: %w", err)
+	}
+	engine.Logger().Info("Benchmark Terminated")
+
+	return nil
+}
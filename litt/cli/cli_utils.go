@@ -0,0 +1,167 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"sort"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/litt/disktable"
+	"github.com/Layr-Labs/eigenda/litt/disktable/keymap"
+	"github.com/Layr-Labs/eigenda/litt/util"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// buildLogger creates the logger used by CLI commands.
+func buildLogger() (logging.Logger, error) {
+	logger, err := common.NewLogger(common.DefaultConsoleLoggerConfig())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create logger: %w", err)
+	}
+	return logger, nil
+}
+
+// sanitizePaths sanitizes a list of paths (i.e. expands "~", converts to absolute paths, etc.). Duplicate paths
+// are not permitted.
+func sanitizePaths(paths []string) ([]string, error) {
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("at least one path is required")
+	}
+
+	sanitized := make([]string, 0, len(paths))
+	seen := make(map[string]struct{}, len(paths))
+	for _, p := range paths {
+		sanitizedPath, err := util.SanitizePath(p)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sanitize path %s: %w", p, err)
+		}
+		if _, ok := seen[sanitizedPath]; ok {
+			return nil, fmt.Errorf("duplicate path: %s", sanitizedPath)
+		}
+		seen[sanitizedPath] = struct{}{}
+		sanitized = append(sanitized, sanitizedPath)
+	}
+
+	return sanitized, nil
+}
+
+// lockSources acquires the LittDB lock on each of the given root directories. The root directories must already
+// exist. Returns a function that releases the locks.
+func lockSources(logger logging.Logger, sources []string, fsync bool) (func(), error) {
+	for _, source := range sources {
+		err := util.ErrIfNotExists(source)
+		if err != nil {
+			return nil, fmt.Errorf("source directory %s does not exist: %w", source, err)
+		}
+	}
+
+	release, err := util.LockDirectories(logger, sources, util.LockfileName, fsync)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock source directories (is the DB currently running?): %w", err)
+	}
+	return release, nil
+}
+
+// listTables returns the sorted names of all tables found in the given root directories. A table is a directory
+// in a root that contains a segment directory.
+func listTables(roots []string) ([]string, error) {
+	tableSet := make(map[string]struct{})
+
+	for _, root := range roots {
+		entries, err := os.ReadDir(root)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read directory %s: %w", root, err)
+		}
+
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				continue
+			}
+			exists, err := util.Exists(path.Join(root, entry.Name(), disktable.SegmentDirectory))
+			if err != nil {
+				return nil, fmt.Errorf("failed to check for segment directory: %w", err)
+			}
+			if exists {
+				tableSet[entry.Name()] = struct{}{}
+			}
+		}
+	}
+
+	tables := make([]string, 0, len(tableSet))
+	for table := range tableSet {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+
+	return tables, nil
+}
+
+// tableRoots returns the root directories for a table, i.e. the table's directory in each of the DB's roots.
+func tableRoots(roots []string, tableName string) []string {
+	tableDirs := make([]string, 0, len(roots))
+	for _, root := range roots {
+		tableDirs = append(tableDirs, path.Join(root, tableName))
+	}
+	return tableDirs
+}
+
+// segmentDirectories returns the segment directories for a table that exist on disk.
+func segmentDirectories(roots []string, tableName string) ([]string, error) {
+	segDirs := make([]string, 0, len(roots))
+	for _, tableDir := range tableRoots(roots, tableName) {
+		segDir := path.Join(tableDir, disktable.SegmentDirectory)
+		exists, err := util.Exists(segDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check if segment directory %s exists: %w", segDir, err)
+		}
+		if exists {
+			segDirs = append(segDirs, segDir)
+		}
+	}
+	return segDirs, nil
+}
+
+// errIfTableNotFound returns an error if the table's metadata file can not be found in any of the roots.
+// This usually means that either the table does not exist or that not all source directories were provided.
+func errIfTableNotFound(roots []string, tableName string) error {
+	for _, tableDir := range tableRoots(roots, tableName) {
+		exists, err := util.Exists(path.Join(tableDir, disktable.TableMetadataFileName))
+		if err != nil {
+			return fmt.Errorf("failed to check for table metadata: %w", err)
+		}
+		if exists {
+			return nil
+		}
+	}
+	return fmt.Errorf("table %s not found in %v, are all source directories specified?", tableName, roots)
+}
+
+// findKeymapDirectory returns the path to a table's keymap directory, or an empty string if the table does
+// not currently have a keymap on disk. If the DB crashed while moving the keymap to a different root, there may be
+// more than one keymap directory, in which case the one that is initialized is preferred.
+func findKeymapDirectory(roots []string, tableName string) (string, error) {
+	var keymapDir string
+	for _, tableDir := range tableRoots(roots, tableName) {
+		possibleKeymapDir := path.Join(tableDir, keymap.KeymapDirectoryName)
+		exists, err := keymap.KeymapFileExists(possibleKeymapDir)
+		if err != nil {
+			return "", fmt.Errorf("failed to check for keymap type file: %w", err)
+		}
+		if !exists {
+			continue
+		}
+
+		initialized, err := util.Exists(path.Join(possibleKeymapDir, keymap.KeymapInitializedFileName))
+		if err != nil {
+			return "", fmt.Errorf("failed to check for keymap initialized file: %w", err)
+		}
+		if initialized {
+			return possibleKeymapDir, nil
+		}
+		if keymapDir == "" {
+			keymapDir = possibleKeymapDir
+		}
+	}
+	return keymapDir, nil
+}
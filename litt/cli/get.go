@@ -0,0 +1,116 @@
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/disktable/keymap"
+	"github.com/Layr-Labs/eigenda/litt/littbuilder"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/urfave/cli/v2"
+)
+
+// getCommand is the CLI command handler for "litt get".
+func getCommand(ctx *cli.Context) error {
+	if ctx.NArg() != 2 {
+		return fmt.Errorf("get requires exactly two arguments: <table-name> <key>")
+	}
+	tableName := ctx.Args().Get(0)
+	keyString := ctx.Args().Get(1)
+	useHex := ctx.Bool(hexFlag.Name)
+
+	key := []byte(keyString)
+	if useHex {
+		var err error
+		key, err = hex.DecodeString(keyString)
+		if err != nil {
+			return fmt.Errorf("failed to decode hex key %s: %w", keyString, err)
+		}
+	}
+
+	logger, err := buildLogger()
+	if err != nil {
+		return err
+	}
+
+	sources, err := sanitizePaths(ctx.StringSlice(srcFlag.Name))
+	if err != nil {
+		return err
+	}
+
+	value, exists, err := get(logger, sources, tableName, key, true)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("key %s not found in table %s", keyString, tableName)
+	}
+
+	if useHex {
+		fmt.Println(hex.EncodeToString(value))
+	} else {
+		fmt.Println(string(value))
+	}
+
+	return nil
+}
+
+// get reads the value associated with a key from a table. The DB must not be running while this is called.
+func get(
+	logger logging.Logger,
+	sources []string,
+	tableName string,
+	key []byte,
+	fsync bool,
+) (value []byte, exists bool, err error) {
+
+	// Checking for the table before opening the DB prevents the DB from creating an empty table as a side effect.
+	err = errIfTableNotFound(sources, tableName)
+	if err != nil {
+		return nil, false, err
+	}
+
+	config, err := litt.DefaultConfig(sources...)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to build DB config: %w", err)
+	}
+	config.Logger = logger
+	config.Fsync = fsync
+
+	// Use whatever keymap type is already on disk, otherwise the DB would rebuild the keymap when it is opened.
+	keymapDir, err := findKeymapDirectory(sources, tableName)
+	if err != nil {
+		return nil, false, err
+	}
+	if keymapDir != "" {
+		keymapTypeFile, err := keymap.LoadKeymapTypeFile(keymapDir)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to load keymap type file: %w", err)
+		}
+		config.KeymapType = keymapTypeFile.Type()
+	}
+
+	db, err := littbuilder.NewDB(config)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to open DB (is the DB currently running?): %w", err)
+	}
+	defer func() {
+		closeErr := db.Close()
+		if closeErr != nil && err == nil {
+			err = fmt.Errorf("failed to close DB: %w", closeErr)
+		}
+	}()
+
+	table, err := db.GetTable(tableName)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get table %s: %w", tableName, err)
+	}
+
+	value, exists, err = table.Get(key)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read key from table %s: %w", tableName, err)
+	}
+
+	return value, exists, nil
+}
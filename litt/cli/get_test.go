@@ -0,0 +1,38 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/testutils/random"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGet(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+
+	logger, err := common.NewLogger(common.DefaultConsoleLoggerConfig())
+	require.NoError(t, err)
+
+	roots := buildRoots(t, 2)
+	data := writeTestData(t, rand, roots, []string{"table"}, nil, 20)
+
+	for key, expectedValue := range data["table"] {
+		value, exists, err := get(logger, roots, "table", []byte(key), false)
+		require.NoError(t, err)
+		require.True(t, exists)
+		require.Equal(t, expectedValue, value)
+	}
+
+	_, exists, err := get(logger, roots, "table", rand.PrintableBytes(32), false)
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	// Reading from a table that doesn't exist should not create it.
+	_, _, err = get(logger, roots, "not-a-table", rand.PrintableBytes(32), false)
+	require.Error(t, err)
+	tables, err := ls(logger, roots, false)
+	require.NoError(t, err)
+	require.Equal(t, []string{"table"}, tables)
+}
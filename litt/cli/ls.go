@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/urfave/cli/v2"
+)
+
+// lsCommand is the CLI command handler for "litt ls".
+func lsCommand(ctx *cli.Context) error {
+	logger, err := buildLogger()
+	if err != nil {
+		return err
+	}
+
+	sources, err := sanitizePaths(ctx.StringSlice(srcFlag.Name))
+	if err != nil {
+		return err
+	}
+
+	tables, err := ls(logger, sources, true)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("Tables found:")
+	for _, table := range tables {
+		fmt.Println(table)
+	}
+
+	return nil
+}
+
+// ls returns the sorted names of the tables in the LittDB instance stored in the given root directories.
+func ls(logger logging.Logger, sources []string, fsync bool) ([]string, error) {
+	release, err := lockSources(logger, sources, fsync)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	tables, err := listTables(sources)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tables: %w", err)
+	}
+
+	return tables, nil
+}
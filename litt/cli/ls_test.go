@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/testutils/random"
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/littbuilder"
+	"github.com/Layr-Labs/eigenda/litt/util"
+	"github.com/stretchr/testify/require"
+)
+
+// writeTestData writes random data into the given tables of a DB stored in the given roots. Returns a map from
+// table name to the key-value pairs written to that table.
+func writeTestData(
+	t *testing.T,
+	rand *random.TestRandom,
+	roots []string,
+	tableNames []string,
+	clock func() time.Time,
+	pairsPerTable int,
+) map[string]map[string][]byte {
+
+	config, err := litt.DefaultConfig(roots...)
+	require.NoError(t, err)
+	config.Fsync = false
+	config.TargetSegmentFileSize = 1024
+	config.ShardingFactor = 2
+	if clock != nil {
+		config.Clock = clock
+	}
+
+	db, err := littbuilder.NewDB(config)
+	require.NoError(t, err)
+
+	data := make(map[string]map[string][]byte)
+	for _, tableName := range tableNames {
+		table, err := db.GetTable(tableName)
+		require.NoError(t, err)
+
+		data[tableName] = make(map[string][]byte)
+		for i := 0; i < pairsPerTable; i++ {
+			key := rand.PrintableBytes(32)
+			value := rand.PrintableVariableBytes(1, 128)
+			err = table.Put(key, value)
+			require.NoError(t, err)
+			data[tableName][string(key)] = value
+		}
+	}
+
+	err = db.Close()
+	require.NoError(t, err)
+
+	return data
+}
+
+// buildRoots creates a number of root directories inside a test directory.
+func buildRoots(t *testing.T, count int) []string {
+	testDirectory := t.TempDir()
+	roots := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		roots = append(roots, path.Join(testDirectory, fmt.Sprintf("root%d", i)))
+	}
+	return roots
+}
+
+func TestLs(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+
+	logger, err := common.NewLogger(common.DefaultConsoleLoggerConfig())
+	require.NoError(t, err)
+
+	roots := buildRoots(t, 3)
+	writeTestData(t, rand, roots, []string{"tableC", "tableA", "tableB"}, nil, 10)
+
+	tables, err := ls(logger, roots, false)
+	require.NoError(t, err)
+	require.Equal(t, []string{"tableA", "tableB", "tableC"}, tables)
+
+	// The lock files should have been cleaned up.
+	for _, root := range roots {
+		exists, err := util.Exists(path.Join(root, util.LockfileName))
+		require.NoError(t, err)
+		require.False(t, exists)
+	}
+}
+
+func TestLsWhileDBIsRunning(t *testing.T) {
+	t.Parallel()
+
+	logger, err := common.NewLogger(common.DefaultConsoleLoggerConfig())
+	require.NoError(t, err)
+
+	roots := buildRoots(t, 2)
+
+	config, err := litt.DefaultConfig(roots...)
+	require.NoError(t, err)
+	config.Fsync = false
+	db, err := littbuilder.NewDB(config)
+	require.NoError(t, err)
+
+	_, err = ls(logger, roots, false)
+	require.Error(t, err)
+
+	err = db.Close()
+	require.NoError(t, err)
+
+	_, err = ls(logger, roots, false)
+	require.NoError(t, err)
+}
+
+func TestLsMissingSource(t *testing.T) {
+	t.Parallel()
+
+	logger, err := common.NewLogger(common.DefaultConsoleLoggerConfig())
+	require.NoError(t, err)
+
+	roots := buildRoots(t, 1)
+	require.NoError(t, os.MkdirAll(roots[0], 0755))
+
+	_, err = ls(logger, append(roots, path.Join(t.TempDir(), "does-not-exist")), false)
+	require.Error(t, err)
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v2"
+)
+
+// main is the entry point for the LittDB CLI. Documentation for this CLI can be found in docs/littdb_cli.md.
+func main() {
+	app := buildCLIParser()
+	err := app.Run(os.Args)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+var srcFlag = &cli.StringSliceFlag{
+	Name:     "src",
+	Aliases:  []string{"s"},
+	Usage:    "Source paths where the DB data is found, at least one is required. May be specified multiple times.",
+	Required: true,
+}
+
+var dstFlag = &cli.StringSliceFlag{
+	Name:     "dst",
+	Aliases:  []string{"d"},
+	Usage:    "Destination paths where the DB data will be written, at least one is required. May be specified multiple times.",
+	Required: true,
+}
+
+var hexFlag = &cli.BoolFlag{
+	Name:  "hex",
+	Usage: "Interpret the key as a hex string, and print the value as a hex string.",
+}
+
+var maxAgeFlag = &cli.Uint64Flag{
+	Name:     "max-age",
+	Usage:    "The maximum age of data to keep, in seconds. Data older than this will be deleted.",
+	Required: true,
+}
+
+var fsyncFlag = &cli.BoolFlag{
+	Name:  "fsync",
+	Usage: "If true, sync files to disk after modifying them. Slower, but safer in the event of a crash.",
+	Value: true,
+}
+
+// buildCLIParser builds the CLI parser for the LittDB CLI.
+func buildCLIParser() *cli.App {
+	return &cli.App{
+		Name:  "litt",
+		Usage: "LittDB command line interface",
+		Commands: []*cli.Command{
+			{
+				Name:      "ls",
+				Usage:     "List the tables in a LittDB instance.",
+				ArgsUsage: "--src <path1> ... --src <pathN>",
+				Flags:     []cli.Flag{srcFlag},
+				Action:    lsCommand,
+			},
+			{
+				Name:      "table-info",
+				Usage:     "Get information about a LittDB table.",
+				ArgsUsage: "--src <path1> ... --src <pathN> <table-name>",
+				Flags:     []cli.Flag{srcFlag},
+				Action:    tableInfoCommand,
+			},
+			{
+				Name:      "get",
+				Usage:     "Read the value associated with a key from a LittDB table.",
+				ArgsUsage: "--src <path1> ... --src <pathN> <table-name> <key>",
+				Flags:     []cli.Flag{srcFlag, hexFlag},
+				Action:    getCommand,
+			},
+			{
+				Name:      "prune",
+				Usage:     "Delete data older than a given age from a LittDB instance or snapshot.",
+				ArgsUsage: "--src <path1> ... --src <pathN> --max-age <seconds>",
+				Flags:     []cli.Flag{srcFlag, maxAgeFlag, fsyncFlag},
+				Action:    pruneCommand,
+			},
+			{
+				Name:      "rebase",
+				Usage:     "Move LittDB data from one set of root directories to another.",
+				ArgsUsage: "--src <path1> ... --src <pathN> --dst <path1> ... --dst <pathN>",
+				Flags:     []cli.Flag{srcFlag, dstFlag, fsyncFlag},
+				Action:    rebaseCommand,
+			},
+			{
+				Name:      "benchmark",
+				Usage:     "Run a LittDB benchmark.",
+				ArgsUsage: "<config-file-path>",
+				Action:    benchmarkCommand,
+			},
+		},
+	}
+}
@@ -0,0 +1,231 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/litt/disktable"
+	"github.com/Layr-Labs/eigenda/litt/disktable/keymap"
+	"github.com/Layr-Labs/eigenda/litt/disktable/segment"
+	"github.com/Layr-Labs/eigenda/litt/util"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/urfave/cli/v2"
+)
+
+// pruneCommand is the CLI command handler for "litt prune".
+func pruneCommand(ctx *cli.Context) error {
+	logger, err := buildLogger()
+	if err != nil {
+		return err
+	}
+
+	sources, err := sanitizePaths(ctx.StringSlice(srcFlag.Name))
+	if err != nil {
+		return err
+	}
+
+	maxAge := time.Duration(ctx.Uint64(maxAgeFlag.Name)) * time.Second
+
+	return prune(logger, sources, maxAge, time.Now(), ctx.Bool(fsyncFlag.Name))
+}
+
+// prune deletes all segments in all tables that were sealed more than maxAge ago. This is the offline equivalent
+// of the garbage collection performed by a running DB. The DB must not be running while this is called.
+//
+// If the sources are a snapshot, then the hard links that the pruned snapshot files point to are deleted as well.
+func prune(logger logging.Logger, sources []string, maxAge time.Duration, now time.Time, fsync bool) error {
+	release, err := lockSources(logger, sources, fsync)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	tables, err := listTables(sources)
+	if err != nil {
+		return fmt.Errorf("failed to list tables: %w", err)
+	}
+
+	for _, tableName := range tables {
+		err = pruneTable(logger, sources, tableName, maxAge, now, fsync)
+		if err != nil {
+			return fmt.Errorf("failed to prune table %s: %w", tableName, err)
+		}
+	}
+
+	return nil
+}
+
+// pruneTable deletes all segments in a table that were sealed more than maxAge ago.
+func pruneTable(
+	logger logging.Logger,
+	sources []string,
+	tableName string,
+	maxAge time.Duration,
+	now time.Time,
+	fsync bool,
+) error {
+
+	segDirs, err := segmentDirectories(sources, tableName)
+	if err != nil {
+		return err
+	}
+
+	errorMonitor := util.NewErrorMonitor(context.Background(), logger, nil)
+	defer errorMonitor.Shutdown()
+
+	lowestSegmentIndex, highestSegmentIndex, segments, err :=
+		segment.GatherSegmentFiles(logger, errorMonitor, segDirs, now, fsync)
+	if err != nil {
+		return fmt.Errorf("failed to gather segment files: %w", err)
+	}
+	if len(segments) == 0 {
+		return nil
+	}
+
+	kmap, err := openKeymap(logger, sources, tableName)
+	if err != nil {
+		return fmt.Errorf("failed to open keymap: %w", err)
+	}
+	if kmap != nil {
+		defer func() {
+			err := kmap.Stop()
+			if err != nil {
+				logger.Errorf("failed to stop keymap for table %s: %v", tableName, err)
+			}
+		}()
+	}
+
+	deletedCount := 0
+	var deletedBytes uint64
+	for index, seg := range segment.ExistingSegments(segments, lowestSegmentIndex, highestSegmentIndex) {
+		if now.Sub(seg.GetSealTime()) < maxAge {
+			// This segment and all segments after it are too young to be deleted.
+			break
+		}
+		if seg.GetMaxExpiry().After(now) {
+			// This segment contains a value with an extended expiry. Keep it, but allow later segments to be deleted.
+			seg.DetachNextSegment()
+			continue
+		}
+
+		if kmap != nil {
+			keys, err := seg.GetKeys()
+			if err != nil {
+				return fmt.Errorf("failed to get keys for segment %d: %w", index, err)
+			}
+			err = kmap.Delete(keys)
+			if err != nil {
+				return fmt.Errorf("failed to delete keys for segment %d: %w", index, err)
+			}
+		}
+
+		// If this is a snapshot, the segment files are symlinks to hard links that must be deleted as well.
+		hardLinks, err := snapshotHardLinks(seg.GetFilePaths())
+		if err != nil {
+			return fmt.Errorf("failed to find snapshot hard links for segment %d: %w", index, err)
+		}
+
+		deletedCount++
+		deletedBytes += seg.Size()
+
+		seg.Release()
+		err = seg.BlockUntilFullyDeleted()
+		if err != nil {
+			return fmt.Errorf("failed to delete segment %d: %w", index, err)
+		}
+
+		for _, hardLink := range hardLinks {
+			err = os.Remove(hardLink)
+			if err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("failed to delete snapshot hard link %s: %w", hardLink, err)
+			}
+		}
+	}
+
+	logger.Infof("Pruned %d segment(s) (%d bytes) from table %s", deletedCount, deletedBytes, tableName)
+
+	return nil
+}
+
+// snapshotHardLinks returns the hard links that the given segment files point to, if they are snapshot symlinks.
+// Files that are not symlinks into a snapshot hard link directory are ignored.
+func snapshotHardLinks(filePaths []string) ([]string, error) {
+	hardLinks := make([]string, 0)
+	for _, filePath := range filePaths {
+		info, err := os.Lstat(filePath)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to stat %s: %w", filePath, err)
+		}
+		if info.Mode()&os.ModeSymlink == 0 {
+			continue
+		}
+
+		target, err := os.Readlink(filePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read symlink %s: %w", filePath, err)
+		}
+		if !filepath.IsAbs(target) {
+			target = filepath.Join(filepath.Dir(filePath), target)
+		}
+		if filepath.Base(filepath.Dir(target)) != disktable.SnapshotHardLinkDirectory {
+			continue
+		}
+		hardLinks = append(hardLinks, target)
+	}
+	return hardLinks, nil
+}
+
+// openKeymap opens the persistent keymap for a table. Returns nil if the table does not have a keymap that needs
+// to be kept in sync with the segment files (i.e. if there is no keymap on disk, or if the keymap is in-memory).
+func openKeymap(logger logging.Logger, sources []string, tableName string) (keymap.Keymap, error) {
+	keymapDir, err := findKeymapDirectory(sources, tableName)
+	if err != nil {
+		return nil, err
+	}
+	if keymapDir == "" {
+		return nil, nil
+	}
+
+	initialized, err := util.Exists(path.Join(keymapDir, keymap.KeymapInitializedFileName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to check for keymap initialized file: %w", err)
+	}
+	if !initialized {
+		// The keymap will be rebuilt from the key files the next time the DB is started.
+		return nil, nil
+	}
+
+	keymapTypeFile, err := keymap.LoadKeymapTypeFile(keymapDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load keymap type file: %w", err)
+	}
+
+	dataDir := path.Join(keymapDir, keymap.KeymapDataDirectoryName)
+	var kmap keymap.Keymap
+	switch keymapTypeFile.Type() {
+	case keymap.MemKeymapType:
+		return nil, nil
+	case keymap.LevelDBKeymapType:
+		kmap, _, err = keymap.NewLevelDBKeymap(logger, dataDir, false)
+	case keymap.UnsafeLevelDBKeymapType:
+		kmap, _, err = keymap.NewUnsafeLevelDBKeymap(logger, dataDir, false)
+	case keymap.PebbleKeymapType:
+		kmap, _, err = keymap.NewPebbleKeymap(logger, dataDir, false)
+	case keymap.UnsafePebbleKeymapType:
+		kmap, _, err = keymap.NewUnsafePebbleKeymap(logger, dataDir, false)
+	default:
+		return nil, fmt.Errorf("unsupported keymap type: %s", keymapTypeFile.Type())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open keymap: %w", err)
+	}
+
+	return kmap, nil
+}
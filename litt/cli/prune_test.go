@@ -0,0 +1,123 @@
+package main
+
+import (
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/testutils/random"
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/disktable"
+	"github.com/Layr-Labs/eigenda/litt/littbuilder"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrune(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+
+	logger, err := common.NewLogger(common.DefaultConsoleLoggerConfig())
+	require.NoError(t, err)
+
+	roots := buildRoots(t, 3)
+	tables := []string{"table1", "table2"}
+
+	// Write some data that appears to have been written two hours ago.
+	oldClock := func() time.Time {
+		return time.Now().Add(-2 * time.Hour)
+	}
+	oldData := writeTestData(t, rand, roots, tables, oldClock, 50)
+
+	// Write some data with the current time.
+	newData := writeTestData(t, rand, roots, tables, nil, 50)
+
+	// Data younger than the max age should not be pruned.
+	err = prune(logger, roots, 3*time.Hour, time.Now(), false)
+	require.NoError(t, err)
+	for _, tableName := range tables {
+		info, err := getTableInfo(logger, roots, tableName, time.Now(), false)
+		require.NoError(t, err)
+		require.Equal(t, uint64(100), info.keyCount)
+	}
+
+	err = prune(logger, roots, time.Hour, time.Now(), false)
+	require.NoError(t, err)
+
+	for _, tableName := range tables {
+		info, err := getTableInfo(logger, roots, tableName, time.Now(), false)
+		require.NoError(t, err)
+		require.Equal(t, uint64(50), info.keyCount)
+
+		for key := range oldData[tableName] {
+			_, exists, err := get(logger, roots, tableName, []byte(key), false)
+			require.NoError(t, err)
+			require.False(t, exists)
+		}
+		for key, expectedValue := range newData[tableName] {
+			value, exists, err := get(logger, roots, tableName, []byte(key), false)
+			require.NoError(t, err)
+			require.True(t, exists)
+			require.Equal(t, expectedValue, value)
+		}
+	}
+}
+
+func TestPruneSnapshot(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+
+	logger, err := common.NewLogger(common.DefaultConsoleLoggerConfig())
+	require.NoError(t, err)
+
+	roots := buildRoots(t, 2)
+	snapshotDirectory := path.Join(t.TempDir(), "snapshot")
+
+	config, err := litt.DefaultConfig(roots...)
+	require.NoError(t, err)
+	config.Fsync = false
+	config.TargetSegmentFileSize = 1024
+	config.Clock = func() time.Time {
+		return time.Now().Add(-2 * time.Hour)
+	}
+	db, err := littbuilder.NewDB(config)
+	require.NoError(t, err)
+	table, err := db.GetTable("table")
+	require.NoError(t, err)
+	for i := 0; i < 50; i++ {
+		err = table.Put(rand.PrintableBytes(32), rand.PrintableVariableBytes(1, 128))
+		require.NoError(t, err)
+	}
+	err = db.Snapshot(snapshotDirectory)
+	require.NoError(t, err)
+	err = db.Close()
+	require.NoError(t, err)
+
+	countHardLinks := func() int {
+		count := 0
+		for _, root := range roots {
+			entries, err := os.ReadDir(path.Join(root, "table", disktable.SnapshotHardLinkDirectory))
+			if os.IsNotExist(err) {
+				continue
+			}
+			require.NoError(t, err)
+			count += len(entries)
+		}
+		return count
+	}
+	require.NotZero(t, countHardLinks())
+
+	// Pruning the snapshot deletes both the symlinks and the hard links they point to.
+	err = prune(logger, []string{snapshotDirectory}, time.Hour, time.Now(), false)
+	require.NoError(t, err)
+	require.Zero(t, countHardLinks())
+	entries, err := os.ReadDir(path.Join(snapshotDirectory, "table", disktable.SegmentDirectory))
+	require.NoError(t, err)
+	require.Empty(t, entries)
+
+	// The database itself is unaffected.
+	info, err := getTableInfo(logger, roots, "table", time.Now(), false)
+	require.NoError(t, err)
+	require.Equal(t, uint64(50), info.keyCount)
+}
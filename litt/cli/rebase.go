@@ -0,0 +1,229 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path"
+
+	"github.com/Layr-Labs/eigenda/litt/disktable"
+	"github.com/Layr-Labs/eigenda/litt/littbuilder"
+	"github.com/Layr-Labs/eigenda/litt/util"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/urfave/cli/v2"
+)
+
+// rebaseCommand is the CLI command handler for "litt rebase".
+func rebaseCommand(ctx *cli.Context) error {
+	logger, err := buildLogger()
+	if err != nil {
+		return err
+	}
+
+	sources, err := sanitizePaths(ctx.StringSlice(srcFlag.Name))
+	if err != nil {
+		return err
+	}
+
+	destinations, err := sanitizePaths(ctx.StringSlice(dstFlag.Name))
+	if err != nil {
+		return err
+	}
+
+	return rebase(logger, sources, destinations, ctx.Bool(fsyncFlag.Name))
+}
+
+// rebase moves LittDB data from the source directories into the destination directories. Segment files are spread
+// across the destination directories, all other files are moved into the first destination directory. Data in
+// directories that are both a source and a destination is not moved. When finished, source directories that are
+// not also destinations are deleted.
+//
+// This operation is idempotent. If it is interrupted, running it again with the same arguments will finish the job.
+// The DB must not be running while this is called.
+func rebase(logger logging.Logger, sources []string, destinations []string, fsync bool) error {
+	if len(destinations) == 0 {
+		return fmt.Errorf("at least one destination is required")
+	}
+
+	destinationSet := make(map[string]struct{}, len(destinations))
+	for _, destination := range destinations {
+		destinationSet[destination] = struct{}{}
+	}
+
+	// Sources that do not exist are permitted, since they may have been deleted by a prior incomplete rebase.
+	sourcesToMove := make([]string, 0, len(sources))
+	for _, source := range sources {
+		if _, ok := destinationSet[source]; ok {
+			continue
+		}
+		exists, err := util.Exists(source)
+		if err != nil {
+			return fmt.Errorf("failed to check if source %s exists: %w", source, err)
+		}
+		if exists {
+			sourcesToMove = append(sourcesToMove, source)
+		}
+	}
+
+	if len(sourcesToMove) == 0 {
+		logger.Infof("Destinations are a superset of the sources, nothing to rebase.")
+		return nil
+	}
+
+	for _, destination := range destinations {
+		err := util.EnsureDirectoryExists(destination, fsync)
+		if err != nil {
+			return fmt.Errorf("failed to create destination directory %s: %w", destination, err)
+		}
+	}
+
+	release, err := lockSources(logger, append(append([]string{}, sourcesToMove...), destinations...), fsync)
+	if err != nil {
+		return err
+	}
+	lockReleased := false
+	defer func() {
+		if !lockReleased {
+			release()
+		}
+	}()
+
+	for _, source := range sourcesToMove {
+		logger.Infof("Moving data from %s", source)
+		err = moveRoot(source, destinations, fsync)
+		if err != nil {
+			return fmt.Errorf("failed to move data from %s: %w", source, err)
+		}
+	}
+
+	// Source directories will be empty once the lock files and paths files have been removed.
+	release()
+	lockReleased = true
+	for _, source := range sourcesToMove {
+		// The source's record of the DB's paths is out of date, and must not overwrite the one in a destination.
+		err = os.Remove(path.Join(source, littbuilder.PathsFileName))
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove paths file from %s: %w", source, err)
+		}
+		err = os.Remove(source)
+		if err != nil {
+			return fmt.Errorf("failed to remove source directory %s: %w", source, err)
+		}
+	}
+
+	logger.Infof("Rebase complete.")
+	return nil
+}
+
+// moveRoot moves all data out of a single source root directory and into the destination root directories.
+// The lock file and paths file in the source directory are left in place.
+func moveRoot(source string, destinations []string, fsync bool) error {
+	entries, err := os.ReadDir(source)
+	if err != nil {
+		return fmt.Errorf("failed to read directory %s: %w", source, err)
+	}
+
+	for _, entry := range entries {
+		if entry.Name() == util.LockfileName || entry.Name() == littbuilder.PathsFileName {
+			continue
+		}
+
+		entryPath := path.Join(source, entry.Name())
+
+		isTable := false
+		if entry.IsDir() {
+			isTable, err = util.Exists(path.Join(entryPath, disktable.SegmentDirectory))
+			if err != nil {
+				return fmt.Errorf("failed to check for segment directory: %w", err)
+			}
+		}
+
+		if isTable {
+			err = moveTable(source, entry.Name(), destinations, fsync)
+			if err != nil {
+				return fmt.Errorf("failed to move table %s: %w", entry.Name(), err)
+			}
+			continue
+		}
+
+		err = move(entryPath, path.Join(destinations[0], entry.Name()), fsync)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// moveTable moves a single table out of a source root directory and into the destination root directories.
+func moveTable(source string, tableName string, destinations []string, fsync bool) error {
+	tableDir := path.Join(source, tableName)
+	segmentDir := path.Join(tableDir, disktable.SegmentDirectory)
+
+	segmentFiles, err := os.ReadDir(segmentDir)
+	if err != nil {
+		return fmt.Errorf("failed to read directory %s: %w", segmentDir, err)
+	}
+
+	for _, segmentFile := range segmentFiles {
+		// The destination is a deterministic function of the file name. If a rebase is interrupted and restarted,
+		// each file will be moved to the same place it was moved to the first time.
+		destination := destinations[util.Perm64Bytes([]byte(segmentFile.Name()))%uint64(len(destinations))]
+
+		err = move(
+			path.Join(segmentDir, segmentFile.Name()),
+			path.Join(destination, tableName, disktable.SegmentDirectory, segmentFile.Name()),
+			fsync)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = os.Remove(segmentDir)
+	if err != nil {
+		return fmt.Errorf("failed to remove segment directory %s: %w", segmentDir, err)
+	}
+
+	// Move everything else (e.g. the keymap and table metadata) into the first destination.
+	otherFiles, err := os.ReadDir(tableDir)
+	if err != nil {
+		return fmt.Errorf("failed to read directory %s: %w", tableDir, err)
+	}
+	for _, otherFile := range otherFiles {
+		err = move(
+			path.Join(tableDir, otherFile.Name()),
+			path.Join(destinations[0], tableName, otherFile.Name()),
+			fsync)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = os.Remove(tableDir)
+	if err != nil {
+		return fmt.Errorf("failed to remove table directory %s: %w", tableDir, err)
+	}
+
+	return nil
+}
+
+// move moves a file or directory tree from the source to the destination. Once complete, nothing remains
+// at the source path.
+func move(source string, destination string, fsync bool) error {
+	err := util.EnsureParentDirectoryExists(destination, fsync)
+	if err != nil {
+		return fmt.Errorf("failed to create parent directory of %s: %w", destination, err)
+	}
+
+	err = util.RecursiveMove(source, destination, false, fsync)
+	if err != nil {
+		return fmt.Errorf("failed to move %s to %s: %w", source, destination, err)
+	}
+
+	// RecursiveMove leaves behind empty directories when moving a directory tree.
+	err = os.RemoveAll(source)
+	if err != nil {
+		return fmt.Errorf("failed to remove %s: %w", source, err)
+	}
+
+	return nil
+}
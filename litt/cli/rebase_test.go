@@ -0,0 +1,101 @@
+package main
+
+import (
+	"path"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/testutils/random"
+	"github.com/Layr-Labs/eigenda/litt/util"
+	"github.com/stretchr/testify/require"
+)
+
+// verifyData checks that all expected data can be read from a DB stored in the given roots.
+func verifyData(t *testing.T, roots []string, data map[string]map[string][]byte) {
+	logger, err := common.NewLogger(common.DefaultConsoleLoggerConfig())
+	require.NoError(t, err)
+
+	for tableName, pairs := range data {
+		for key, expectedValue := range pairs {
+			value, exists, err := get(logger, roots, tableName, []byte(key), false)
+			require.NoError(t, err)
+			require.True(t, exists)
+			require.Equal(t, expectedValue, value)
+		}
+	}
+}
+
+func TestRebase(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+
+	logger, err := common.NewLogger(common.DefaultConsoleLoggerConfig())
+	require.NoError(t, err)
+
+	roots := buildRoots(t, 5)
+	sources := roots[:3]
+	destinations := roots[2:]
+
+	data := writeTestData(t, rand, sources, []string{"table1", "table2"}, nil, 100)
+
+	err = rebase(logger, sources, destinations, false)
+	require.NoError(t, err)
+
+	// Sources that are not also destinations should be gone.
+	for _, source := range roots[:2] {
+		exists, err := util.Exists(source)
+		require.NoError(t, err)
+		require.False(t, exists)
+	}
+
+	verifyData(t, destinations, data)
+
+	// Segment files should have been distributed across the destinations.
+	for _, destination := range destinations {
+		exists, err := util.Exists(path.Join(destination, "table1", "segments"))
+		require.NoError(t, err)
+		require.True(t, exists)
+	}
+
+	// Rebasing a second time is a no-op.
+	err = rebase(logger, sources, destinations, false)
+	require.NoError(t, err)
+	verifyData(t, destinations, data)
+}
+
+func TestRebaseToSuperset(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+
+	logger, err := common.NewLogger(common.DefaultConsoleLoggerConfig())
+	require.NoError(t, err)
+
+	roots := buildRoots(t, 3)
+	data := writeTestData(t, rand, roots[:2], []string{"table"}, nil, 50)
+
+	err = rebase(logger, roots[:2], roots, false)
+	require.NoError(t, err)
+
+	// Nothing should have been moved into the new root.
+	exists, err := util.Exists(roots[2])
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	verifyData(t, roots, data)
+}
+
+func TestRebaseToSingleDirectory(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+
+	logger, err := common.NewLogger(common.DefaultConsoleLoggerConfig())
+	require.NoError(t, err)
+
+	roots := buildRoots(t, 4)
+	data := writeTestData(t, rand, roots[:3], []string{"table"}, nil, 50)
+
+	err = rebase(logger, roots[:3], roots[3:], false)
+	require.NoError(t, err)
+
+	verifyData(t, roots[3:], data)
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/litt/disktable"
+	"github.com/Layr-Labs/eigenda/litt/disktable/keymap"
+	"github.com/Layr-Labs/eigenda/litt/disktable/segment"
+	"github.com/Layr-Labs/eigenda/litt/util"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/urfave/cli/v2"
+)
+
+// tableInfo contains information about a LittDB table.
+type tableInfo struct {
+	// The number of keys in the table.
+	keyCount uint64
+	// The size of the table's segments on disk, in bytes.
+	size uint64
+	// The index of the oldest segment in the table.
+	lowestSegmentIndex uint32
+	// The index of the newest segment in the table.
+	highestSegmentIndex uint32
+	// The seal time of the oldest segment in the table. Zero if the table has no segments.
+	oldestSegmentSealTime time.Time
+	// The seal time of the newest segment in the table. Zero if the table has no segments.
+	newestSegmentSealTime time.Time
+	// The table's TTL. A TTL of zero means that data is never garbage collected.
+	ttl time.Duration
+	// The type of the keymap used by the table, or empty if no keymap is present on disk.
+	keymapType keymap.KeymapType
+}
+
+// tableInfoCommand is the CLI command handler for "litt table-info".
+func tableInfoCommand(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return fmt.Errorf("table-info requires exactly one argument: <table-name>")
+	}
+	tableName := ctx.Args().First()
+
+	logger, err := buildLogger()
+	if err != nil {
+		return err
+	}
+
+	sources, err := sanitizePaths(ctx.StringSlice(srcFlag.Name))
+	if err != nil {
+		return err
+	}
+
+	info, err := getTableInfo(logger, sources, tableName, time.Now(), true)
+	if err != nil {
+		return err
+	}
+
+	var oldestSegmentAge time.Duration
+	var newestSegmentAge time.Duration
+	var segmentSpan time.Duration
+	if !info.oldestSegmentSealTime.IsZero() {
+		now := time.Now()
+		oldestSegmentAge = now.Sub(info.oldestSegmentSealTime)
+		newestSegmentAge = now.Sub(info.newestSegmentSealTime)
+		segmentSpan = info.newestSegmentSealTime.Sub(info.oldestSegmentSealTime)
+	}
+
+	ttlString := "none"
+	if info.ttl > 0 {
+		ttlString = common.PrettyPrintTime(uint64(info.ttl.Nanoseconds()))
+	}
+
+	logger.Infof("Table:                       %s", tableName)
+	logger.Infof("Key count:                   %d", info.keyCount)
+	logger.Infof("Size:                        %s", common.PrettyPrintBytes(info.size))
+	logger.Infof("TTL:                         %s", ttlString)
+	logger.Infof("Oldest segment age:          %s", common.PrettyPrintTime(uint64(oldestSegmentAge.Nanoseconds())))
+	logger.Infof("Oldest segment seal time:    %s", info.oldestSegmentSealTime.Format(time.RFC3339))
+	logger.Infof("Newest segment age:          %s", common.PrettyPrintTime(uint64(newestSegmentAge.Nanoseconds())))
+	logger.Infof("Newest segment seal time:    %s", info.newestSegmentSealTime.Format(time.RFC3339))
+	logger.Infof("Segment span:                %s", common.PrettyPrintTime(uint64(segmentSpan.Nanoseconds())))
+	logger.Infof("Lowest segment index:        %d", info.lowestSegmentIndex)
+	logger.Infof("Highest segment index:       %d", info.highestSegmentIndex)
+	logger.Infof("Key map type:                %s", info.keymapType)
+
+	return nil
+}
+
+// getTableInfo gathers information about a table stored in the given root directories.
+func getTableInfo(
+	logger logging.Logger,
+	sources []string,
+	tableName string,
+	now time.Time,
+	fsync bool,
+) (*tableInfo, error) {
+
+	release, err := lockSources(logger, sources, fsync)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	err = errIfTableNotFound(sources, tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &tableInfo{}
+
+	info.ttl, err = disktable.ReadTableTTL(tableRoots(sources, tableName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read table TTL: %w", err)
+	}
+
+	keymapDir, err := findKeymapDirectory(sources, tableName)
+	if err != nil {
+		return nil, err
+	}
+	if keymapDir != "" {
+		keymapTypeFile, err := keymap.LoadKeymapTypeFile(keymapDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load keymap type file: %w", err)
+		}
+		info.keymapType = keymapTypeFile.Type()
+	}
+
+	segDirs, err := segmentDirectories(sources, tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	errorMonitor := util.NewErrorMonitor(context.Background(), logger, nil)
+	defer errorMonitor.Shutdown()
+
+	lowestSegmentIndex, highestSegmentIndex, segments, err :=
+		segment.GatherSegmentFilesReadOnly(logger, errorMonitor, segDirs, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to gather segment files: %w", err)
+	}
+
+	if len(segments) == 0 {
+		return info, nil
+	}
+
+	info.lowestSegmentIndex = lowestSegmentIndex
+	info.highestSegmentIndex = highestSegmentIndex
+	info.oldestSegmentSealTime = segments[lowestSegmentIndex].GetSealTime()
+	info.newestSegmentSealTime = segments[highestSegmentIndex].GetSealTime()
+
+	for _, seg := range segments {
+		info.keyCount += uint64(seg.KeyCount())
+		info.size += seg.Size()
+	}
+
+	return info, nil
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/testutils/random"
+	"github.com/Layr-Labs/eigenda/litt/disktable"
+	"github.com/Layr-Labs/eigenda/litt/disktable/keymap"
+	"github.com/Layr-Labs/eigenda/litt/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTableInfo(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+
+	logger, err := common.NewLogger(common.DefaultConsoleLoggerConfig())
+	require.NoError(t, err)
+
+	roots := buildRoots(t, 3)
+	pairCount := rand.Intn(100) + 50
+	writeTestData(t, rand, roots, []string{"table1", "table2"}, nil, pairCount)
+
+	info, err := getTableInfo(logger, roots, "table1", time.Now(), false)
+	require.NoError(t, err)
+
+	require.Equal(t, uint64(pairCount), info.keyCount)
+	require.Greater(t, info.size, uint64(0))
+	require.Less(t, info.lowestSegmentIndex, info.highestSegmentIndex)
+	require.False(t, info.oldestSegmentSealTime.After(info.newestSegmentSealTime))
+	require.Equal(t, time.Duration(0), info.ttl)
+	require.Equal(t, keymap.KeymapType(keymap.LevelDBKeymapType), info.keymapType)
+
+	// Gathering info a second time should not change anything.
+	info2, err := getTableInfo(logger, roots, "table1", time.Now(), false)
+	require.NoError(t, err)
+	require.Equal(t, info, info2)
+
+	// Unknown tables should be rejected.
+	_, err = getTableInfo(logger, roots, "not-a-table", time.Now(), false)
+	require.Error(t, err)
+
+	// If the root containing the table metadata is omitted, the table can not be found.
+	_, err = getTableInfo(logger, roots[1:], "table1", time.Now(), false)
+	require.Error(t, err)
+}
+
+func TestTableInfoDoesNotModifyFiles(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+
+	logger, err := common.NewLogger(common.DefaultConsoleLoggerConfig())
+	require.NoError(t, err)
+
+	roots := buildRoots(t, 2)
+	pairCount := rand.Intn(100) + 50
+	writeTestData(t, rand, roots, []string{"table1"}, nil, pairCount)
+
+	// A swap file left behind by a crash is garbage, which opening the DB would delete.
+	garbageFile := path.Join(roots[0], "table1", disktable.SegmentDirectory, "0"+util.SwapFileExtension)
+	require.NoError(t, os.WriteFile(garbageFile, []byte("garbage"), 0644))
+
+	info, err := getTableInfo(logger, roots, "table1", time.Now(), false)
+	require.NoError(t, err)
+	require.Equal(t, uint64(pairCount), info.keyCount)
+
+	exists, err := util.Exists(garbageFile)
+	require.NoError(t, err)
+	require.True(t, exists)
+}
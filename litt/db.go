@@ -1,5 +1,7 @@
 package litt
 
+import "context"
+
 // DB is a highly specialized key-value store. It is intentionally very feature poor, sacrificing
 // unnecessary features for simplicity, high performance, and low memory usage.
 //
@@ -55,4 +57,51 @@ type DB interface {
 
 	// Destroy deletes all data in the database.
 	Destroy() error
+
+	// Snapshot captures an incremental snapshot of the database in the given directory. Only tables that have been
+	// fetched via GetTable() since the database was started are included. Writes are not blocked while the snapshot
+	// is being taken. Data written before this method is called is included in the snapshot, data written
+	// concurrently with this method may or may not be included.
+	//
+	// Segment files are not copied. Instead, each segment file is hard linked into a directory named "snapshot"
+	// in the root directory that contains it, and the snapshot directory contains symlinks to those hard links.
+	// Calling this method multiple times with the same directory only adds data that is not yet in the snapshot.
+	// The DB never deletes snapshot files, it is the responsibility of the caller to delete both the symlinks and
+	// the hard links when they are no longer needed. Running the "litt prune" CLI command against the snapshot
+	// directory deletes the pruned symlinks along with the hard links they point to.
+	//
+	// A snapshot can be used to build a new database via littbuilder.RestoreSnapshot().
+	Snapshot(directory string) error
+
+	// Backup performs a single incremental backup pass. The sealed segments and metadata of every table that has
+	// been fetched via GetTable() since the database was started are passed to the target, and then the target's
+	// Commit() method is called. Writes are not blocked while the backup is being taken. Only sealed segments are
+	// backed up. The segment currently being written to is not sealed by this method, and so its data is included in
+	// the first backup pass after it is sealed (i.e. when it reaches Config.TargetSegmentFileSize or
+	// Config.MaxSegmentKeyCount, or when the DB is closed).
+	//
+	// If Config.BackupTarget is set, then this method is called automatically once per Config.BackupPeriod.
+	// See the backup package for an S3 backup target.
+	Backup(ctx context.Context, target BackupTarget) error
+
+	// AddPath adds a root directory to the database without restarting it. New segments may be placed in the new
+	// path as soon as this method returns. This is a no-op if the path is already in use.
+	//
+	// The DB's configuration must be updated to include the path before the DB is next started. The DB records the
+	// set of paths in use, and refuses to start if a path that contains data is missing from its configuration.
+	AddPath(path string) error
+
+	// RemovePath removes a root directory from the database without restarting it. No new data is written to the
+	// path once this method returns, and data already in the path is moved to the remaining paths in the background.
+	// Progress is reported via metrics. Any table that has been written to disk in the path but has not yet been
+	// fetched via GetTable() is loaded so that its data can be moved.
+	//
+	// If the path contains a table's metadata or keymap (by default, these are stored in the first path), they are
+	// moved to one of the remaining paths before any segment files are moved. Operations on the table's keymap block
+	// while the keymap is being moved.
+	//
+	// If the DB is stopped before all data has been moved, the path must remain in the DB's configuration
+	// until RemovePath is called again after the next restart. Once all data has been moved, the path should be
+	// removed from the DB's configuration.
+	RemovePath(path string) error
 }
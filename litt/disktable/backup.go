@@ -0,0 +1,56 @@
+package disktable
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/disktable/segment"
+	"github.com/Layr-Labs/eigenda/litt/util"
+)
+
+// Backup passes all sealed segments in the table to the backup target, in index order, followed by the table's
+// metadata. The mutable segment is not sealed (doing so on every backup pass would fill the table with tiny
+// segments), so its data is backed up by the first pass after it is sealed during normal operation. Writes may
+// continue while the backup is being taken. Each sealed segment is reserved for the duration of the backup, and so
+// the garbage collector can't delete segment files while they are being backed up.
+func (d *DiskTable) Backup(ctx context.Context, target litt.BackupTarget) error {
+	if ok, err := d.errorMonitor.IsOk(); !ok {
+		return fmt.Errorf("cannot process Backup() request, DB is in panicked state due to error: %w", err)
+	}
+
+	request := &controlLoopReserveSegmentsRequest{
+		sealedOnly:   true,
+		responseChan: make(chan []*segment.Segment, 1),
+	}
+	err := d.controlLoop.enqueue(request)
+	if err != nil {
+		return fmt.Errorf("failed to send backup request: %w", err)
+	}
+
+	segments, err := util.Await(d.errorMonitor, request.responseChan)
+	if err != nil {
+		return fmt.Errorf("failed to await backup segments: %w", err)
+	}
+	defer func() {
+		for _, seg := range segments {
+			seg.Release()
+		}
+	}()
+
+	segmentIndices := make([]uint32, 0, len(segments))
+	for _, seg := range segments {
+		err = target.BackupSegment(ctx, d.name, seg.SegmentIndex(), seg.GetFilePaths())
+		if err != nil {
+			return fmt.Errorf("failed to back up segment %d: %w", seg.SegmentIndex(), err)
+		}
+		segmentIndices = append(segmentIndices, seg.SegmentIndex())
+	}
+
+	err = target.BackupTable(ctx, d.name, d.metadata.serialize(), segmentIndices)
+	if err != nil {
+		return fmt.Errorf("failed to back up table metadata: %w", err)
+	}
+
+	return nil
+}
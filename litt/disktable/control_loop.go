@@ -3,6 +3,7 @@ package disktable
 import (
 	"fmt"
 	"math/rand"
+	"path"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -105,6 +106,12 @@ type controlLoop struct {
 
 	// garbageCollectionPeriod is the period at which garbage collection is run.
 	garbageCollectionPeriod time.Duration
+
+	// The scrubber verifies the checksums of values in sealed segments. Nil if scrubbing is disabled.
+	scrubber *scrubber
+
+	// scrubPeriod is the period at which the scrubber is run. Ignored if scrubber is nil.
+	scrubPeriod time.Duration
 }
 
 // enqueue enqueues a request to the control loop. Returns an error if the request could not be sent due to the
@@ -120,6 +127,14 @@ func (c *controlLoop) run() {
 	ticker := time.NewTicker(c.garbageCollectionPeriod)
 	defer ticker.Stop()
 
+	// If scrubbing is disabled, scrubTicker is nil and never produces a value.
+	var scrubTicker <-chan time.Time
+	if c.scrubber != nil {
+		t := time.NewTicker(c.scrubPeriod)
+		defer t.Stop()
+		scrubTicker = t.C
+	}
+
 	for {
 		select {
 		case <-c.errorMonitor.ImmediateShutdownRequired():
@@ -138,12 +153,20 @@ func (c *controlLoop) run() {
 			} else if req, ok := message.(*controlLoopGCRequest); ok {
 				c.doGarbageCollection()
 				req.completionChan <- struct{}{}
+			} else if req, ok := message.(*controlLoopReserveSegmentsRequest); ok {
+				c.handleReserveSegmentsRequest(req)
+			} else if req, ok := message.(*controlLoopSetSegmentDirectoriesRequest); ok {
+				c.handleSetSegmentDirectoriesRequest(req)
+			} else if req, ok := message.(*controlLoopReserveSegmentsInDirectoryRequest); ok {
+				c.handleReserveSegmentsInDirectoryRequest(req)
 			} else {
 				c.errorMonitor.Panic(fmt.Errorf("unknown control message type %T", message))
 				return
 			}
 		case <-ticker.C:
 			c.doGarbageCollection()
+		case <-scrubTicker:
+			c.scheduleScrub()
 		}
 	}
 }
@@ -157,18 +180,21 @@ func (c *controlLoop) doGarbageCollection() {
 		return
 	}
 
+	// The number of segments that are old enough to be deleted, but that contain a value with an extended expiry
+	// that has not yet passed.
+	pinnedSegmentCount := uint64(0)
+
 	defer func() {
 		if c.metrics != nil {
 			end := c.clock()
 			delta := end.Sub(start)
 			c.metrics.ReportGarbageCollectionLatency(c.name, delta)
-
+			c.metrics.ReportPinnedSegmentCount(c.name, pinnedSegmentCount)
 		}
 		c.updateCurrentSize()
 	}()
 
-	for index := c.lowestSegmentIndex; index <= c.highestSegmentIndex; index++ {
-		seg := c.segments[index]
+	for index, seg := range segment.ExistingSegments(c.segments, c.lowestSegmentIndex, c.highestSegmentIndex) {
 		if !seg.IsSealed() {
 			// We can't delete an unsealed segment.
 			return
@@ -181,6 +207,14 @@ func (c *controlLoop) doGarbageCollection() {
 			return
 		}
 
+		if seg.GetMaxExpiry().After(start) {
+			// The segment contains a value with an extended expiry. Keep the segment, but allow the segments that
+			// come after it to be deleted.
+			pinnedSegmentCount++
+			seg.DetachNextSegment()
+			continue
+		}
+
 		// Segment is old enough to be deleted.
 		keys, err := seg.GetKeys()
 		if err != nil {
@@ -214,7 +248,10 @@ func (c *controlLoop) doGarbageCollection() {
 		delete(c.segments, index)
 		c.segmentLock.Unlock()
 
-		c.lowestSegmentIndex++
+		// Skip over any gaps left by segments deleted while an earlier segment with an extended expiry was retained.
+		for c.lowestSegmentIndex < c.highestSegmentIndex && c.segments[c.lowestSegmentIndex] == nil {
+			c.lowestSegmentIndex++
+		}
 	}
 }
 
@@ -262,6 +299,16 @@ func (c *controlLoop) handleWriteRequest(req *controlLoopWriteRequest) {
 	for _, kv := range req.values {
 		// Do the write.
 		seg := c.segments[c.highestSegmentIndex]
+		if !req.expiry.IsZero() {
+			// Record the expiry before writing the value, so that the segment can't be deleted before the expiry
+			// even if we crash after the value is written.
+			err := seg.ExtendExpiry(req.expiry)
+			if err != nil {
+				c.errorMonitor.Panic(
+					fmt.Errorf("failed to extend expiry of segment %d: %w", c.highestSegmentIndex, err))
+				return
+			}
+		}
 		keyCount, keyFileSize, err := seg.Write(kv)
 		shardSize := seg.GetMaxShardSize()
 		if err != nil {
@@ -388,8 +435,154 @@ func (c *controlLoop) handleControlLoopSetShardingFactorRequest(req *controlLoop
 	}
 }
 
+// handleReserveSegmentsRequest seals the mutable segment (if it contains any data) and then reserves all sealed
+// segments. Sealing the mutable segment ensures that all data written before the request was made is included in
+// the reserved segments. If the request includes the mutable segment, it is reserved instead of being sealed. If
+// the request is for sealed segments only, the mutable segment is left alone.
+func (c *controlLoop) handleReserveSegmentsRequest(req *controlLoopReserveSegmentsRequest) {
+	if req.sealedOnly {
+		segments, ok := c.reserveSealedSegments()
+		if !ok {
+			return
+		}
+		req.responseChan <- segments
+		return
+	}
+
+	if req.includeMutableSegment {
+		segments, ok := c.reserveSealedSegments()
+		if !ok {
+			return
+		}
+		mutableSegment := c.segments[c.highestSegmentIndex]
+		if !mutableSegment.Reserve() {
+			// This should be impossible, the control loop holds a reservation on all segments in the map.
+			c.errorMonitor.Panic(fmt.Errorf("failed to reserve segment %d", c.highestSegmentIndex))
+			for _, reserved := range segments {
+				reserved.Release()
+			}
+			return
+		}
+		req.responseChan <- append(segments, mutableSegment)
+		return
+	}
+
+	if c.segments[c.highestSegmentIndex].KeyCount() > 0 {
+		err := c.expandSegments()
+		if err != nil {
+			c.errorMonitor.Panic(fmt.Errorf("failed to expand segments: %w", err))
+			return
+		}
+	}
+
+	segments, ok := c.reserveSealedSegments()
+	if !ok {
+		return
+	}
+
+	req.responseChan <- segments
+}
+
+// reserveSealedSegments reserves all sealed segments, in order. It is the caller's responsibility to release the
+// reservations. Returns false if the segments could not be reserved (which should never happen).
+func (c *controlLoop) reserveSealedSegments() ([]*segment.Segment, bool) {
+	segments := make([]*segment.Segment, 0, c.highestSegmentIndex-c.lowestSegmentIndex)
+	for index, seg := range segment.ExistingSegments(c.segments, c.lowestSegmentIndex, c.highestSegmentIndex) {
+		if index == c.highestSegmentIndex {
+			// The highest segment is the mutable segment.
+			break
+		}
+		if !seg.Reserve() {
+			// This should be impossible, the control loop holds a reservation on all segments in the map.
+			c.errorMonitor.Panic(fmt.Errorf("failed to reserve segment %d", index))
+			for _, reserved := range segments {
+				reserved.Release()
+			}
+			return nil, false
+		}
+		segments = append(segments, seg)
+	}
+	return segments, true
+}
+
+// handleSetSegmentDirectoriesRequest changes the set of directories where new segments are created.
+func (c *controlLoop) handleSetSegmentDirectoriesRequest(req *controlLoopSetSegmentDirectoriesRequest) {
+	c.segmentDirectories = req.segmentDirectories
+
+	mutableSegment := c.segments[c.highestSegmentIndex]
+	sealRequired := mutableSegment.KeyCount() > 0
+	if !sealRequired {
+		// Even an empty mutable segment must be sealed if it has files in a directory that is no longer in use.
+		inUse := make(map[string]struct{}, len(c.segmentDirectories))
+		for _, directory := range c.segmentDirectories {
+			inUse[directory] = struct{}{}
+		}
+		for _, filePath := range mutableSegment.GetFilePaths() {
+			if _, ok := inUse[path.Dir(filePath)]; !ok {
+				sealRequired = true
+				break
+			}
+		}
+	}
+
+	if sealRequired {
+		err := c.expandSegments()
+		if err != nil {
+			c.errorMonitor.Panic(fmt.Errorf("failed to expand segments: %w", err))
+			return
+		}
+	}
+
+	req.responseChan <- struct{}{}
+}
+
+// handleReserveSegmentsInDirectoryRequest reserves all sealed segments that have at least one file in the
+// requested directory.
+func (c *controlLoop) handleReserveSegmentsInDirectoryRequest(req *controlLoopReserveSegmentsInDirectoryRequest) {
+	segments, ok := c.reserveSealedSegments()
+	if !ok {
+		return
+	}
+
+	matchingSegments := make([]*segment.Segment, 0)
+	for _, seg := range segments {
+		if seg.HasFilesIn(req.directory) {
+			matchingSegments = append(matchingSegments, seg)
+		} else {
+			seg.Release()
+		}
+	}
+
+	req.responseChan <- matchingSegments
+}
+
+// scheduleScrub hands the sealed segments to the scrubber. If the previous scrub pass has not yet finished,
+// this method does nothing.
+func (c *controlLoop) scheduleScrub() {
+	if c.scrubber.busy.Load() {
+		c.logger.Warnf("table %s: previous scrub is still in progress, skipping scheduled scrub", c.name)
+		return
+	}
+
+	segments, ok := c.reserveSealedSegments()
+	if !ok {
+		return
+	}
+
+	if !c.scrubber.schedule(segments) {
+		for _, seg := range segments {
+			seg.Release()
+		}
+	}
+}
+
 // handleShutdownRequest performs tasks necessary to cleanly shut down the disk table.
 func (c *controlLoop) handleShutdownRequest(req *controlLoopShutdownRequest) {
+	// Stop the scrubber. It may be using the keymap, so it must stop before the keymap is stopped.
+	if c.scrubber != nil {
+		c.scrubber.stop()
+	}
+
 	// Instruct the flush loop to stop.
 	shutdownCompleteChan := make(chan struct{})
 	request := &flushLoopShutdownRequest{
@@ -1,6 +1,11 @@
 package disktable
 
-import "github.com/Layr-Labs/eigenda/litt/types"
+import (
+	"time"
+
+	"github.com/Layr-Labs/eigenda/litt/disktable/segment"
+	"github.com/Layr-Labs/eigenda/litt/types"
+)
 
 // This file contains various messages that can be sent to the disk table's control loop.
 
@@ -25,6 +30,9 @@ type controlLoopWriteRequest struct {
 
 	// values is a slice of key-value pairs to write.
 	values []*types.KVPair
+
+	// If not zero, the values must not be deleted before this time (even if the table's TTL has elapsed).
+	expiry time.Time
 }
 
 // controlLoopSetShardingFactorRequest is a request to set the sharding factor that is sent to the control loop.
@@ -51,3 +59,47 @@ type controlLoopGCRequest struct {
 	// completionChan produces a value when the garbage collection is complete.
 	completionChan chan struct{}
 }
+
+// controlLoopReserveSegmentsRequest is a request to seal the mutable segment and then reserve all sealed segments.
+// Used by operations that need a stable view of all data in the table (e.g. snapshots). If includeMutableSegment
+// is set, the mutable segment is not sealed, and is instead reserved along with the sealed segments. If sealedOnly
+// is set, the mutable segment is neither sealed nor reserved.
+type controlLoopReserveSegmentsRequest struct {
+	controlLoopMessage
+
+	// If true, do not seal the mutable segment. The mutable segment is reserved and is the last segment returned.
+	includeMutableSegment bool
+
+	// If true, do not seal the mutable segment. Only the segments that are already sealed are reserved.
+	sealedOnly bool
+
+	// responseChan produces the reserved segments, in order. Each segment is reserved, and it is the responsibility
+	// of the receiver to release the reservations.
+	responseChan chan []*segment.Segment
+}
+
+// controlLoopSetSegmentDirectoriesRequest is a request to change the set of directories where new segments are
+// created. If the mutable segment contains data or has files in a directory that is no longer in use, the mutable
+// segment is sealed so that the next segment is created in the new set of directories.
+type controlLoopSetSegmentDirectoriesRequest struct {
+	controlLoopMessage
+
+	// segmentDirectories is the new set of segment directories.
+	segmentDirectories []string
+
+	// responseChan produces a value when the request has been handled.
+	responseChan chan struct{}
+}
+
+// controlLoopReserveSegmentsInDirectoryRequest is a request to reserve all sealed segments that have at least one
+// file in a particular directory.
+type controlLoopReserveSegmentsInDirectoryRequest struct {
+	controlLoopMessage
+
+	// directory is the directory to look for segment files in.
+	directory string
+
+	// responseChan produces the matching segments, in order. Each segment is reserved, and it is the responsibility
+	// of the receiver to release the reservations.
+	responseChan chan []*segment.Segment
+}
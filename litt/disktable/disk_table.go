@@ -3,7 +3,6 @@ package disktable
 import (
 	"errors"
 	"fmt"
-	"math"
 	"math/rand"
 	"os"
 	"path"
@@ -22,8 +21,8 @@ import (
 
 var _ litt.ManagedTable = (*DiskTable)(nil)
 
-// segmentDirectory is the directory where segment files are stored, relative to the root directory.
-const segmentDirectory = "segments"
+// SegmentDirectory is the directory where segment files are stored, relative to the table's root directory.
+const SegmentDirectory = "segments"
 
 // keymapReloadBatchSize is the size of the batch used for reloading keys from segments into the keymap.
 const keymapReloadBatchSize = 1024
@@ -47,21 +46,36 @@ type DiskTable struct {
 	// The directories where segment files are stored.
 	segmentDirectories []string
 
+	// Protects roots and segmentDirectories, which may change at runtime via AddRoot() and RemoveRoot().
+	rootsLock sync.Mutex
+
+	// Held by a migration for its entire duration, ensures that only one migration runs at a time.
+	migrationLock sync.Mutex
+
+	// Closed when the table is closed, causing in-progress migrations to stop.
+	migrationStopChannel chan struct{}
+
+	// Tracks in-progress migrations, so that the table can wait for them to stop before shutting down.
+	migrationWaitGroup sync.WaitGroup
+
 	// The table's name.
 	name string
 
 	// The table's metadata.
 	metadata *tableMetadata
 
-	// A map of keys to their addresses.
-	keymap keymap.Keymap
+	// A map of keys to their addresses. Replaced if the keymap is moved to a different root.
+	keymap *movableKeymap
 
-	// The path to the keymap directory.
+	// The path to the keymap directory. Protected by rootsLock, since it changes if the keymap is moved.
 	keymapPath string
 
-	// The type file for the keymap.
+	// The type file for the keymap. Protected by rootsLock, since it changes if the keymap is moved.
 	keymapTypeFile *keymap.KeymapTypeFile
 
+	// Passed to the keymap when it is reopened after being moved.
+	doubleWriteProtection bool
+
 	// unflushedDataCache is a map of keys to their values that may not have been flushed to disk yet. This is used as a
 	// lookup table when data is requested from the table before it has been flushed to disk.
 	unflushedDataCache sync.Map
@@ -84,9 +98,13 @@ type DiskTable struct {
 
 	// Encapsulates metrics for the database.
 	metrics *metrics.LittDBMetrics
+
+	// whether fsync mode is enabled.
+	fsync bool
 }
 
-// NewDiskTable creates a new DiskTable.
+// NewDiskTable creates a new DiskTable. If quarantineCallback is not nil, it is called with the keys that the
+// scrubber quarantines, so that they can be evicted from any caches in front of the table.
 func NewDiskTable(
 	config *litt.Config,
 	name string,
@@ -95,7 +113,8 @@ func NewDiskTable(
 	keymapTypeFile *keymap.KeymapTypeFile,
 	roots []string,
 	reloadKeymap bool,
-	metrics *metrics.LittDBMetrics) (litt.ManagedTable, error) {
+	metrics *metrics.LittDBMetrics,
+	quarantineCallback func(keys [][]byte)) (litt.ManagedTable, error) {
 
 	if config.GCPeriod <= 0 {
 		return nil, errors.New("garbage collection period must be greater than 0")
@@ -118,7 +137,7 @@ func NewDiskTable(
 	// For each root directory, create a segment directory if it doesn't exist.
 	segDirs := make([]string, 0, len(roots))
 	for _, root := range roots {
-		segDir := path.Join(root, segmentDirectory)
+		segDir := path.Join(root, SegmentDirectory)
 		segDirs = append(segDirs, segDir)
 
 		exists, err := util.Exists(segDir)
@@ -134,24 +153,20 @@ func NewDiskTable(
 		}
 	}
 
-	var metadataFilePath string
 	var metadata *tableMetadata
 
 	// Find the table metadata file or create a new one.
-	for _, root := range roots {
-		possibleMetadataPath := metadataPath(root)
-		exists, err := util.Exists(possibleMetadataPath)
+	metadataFilePath, duplicates, err := findMetadataFile(roots)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find metadata file: %w", err)
+	}
+	for _, duplicate := range duplicates {
+		// The metadata file was being moved to a different root when the DB crashed.
+		config.Logger.Warnf("Table metadata file %s is a duplicate of %s, likely due to a crash while moving it",
+			duplicate, metadataFilePath)
+		err = os.Remove(duplicate)
 		if err != nil {
-			return nil, fmt.Errorf("failed to check if metadata file exists: %w", err)
-		}
-		if exists {
-			if metadataFilePath != "" {
-				return nil, fmt.Errorf("multiple metadata files found: %s and %s",
-					metadataFilePath, possibleMetadataPath)
-			}
-
-			// We've found an existing metadata file. Use it.
-			metadataFilePath = possibleMetadataPath
+			return nil, fmt.Errorf("failed to remove duplicate metadata file %s: %w", duplicate, err)
 		}
 	}
 	if metadataFilePath == "" {
@@ -175,17 +190,20 @@ func NewDiskTable(
 	errorMonitor := util.NewErrorMonitor(config.CTX, config.Logger, config.FatalErrorCallback)
 
 	table := &DiskTable{
-		logger:             config.Logger,
-		errorMonitor:       errorMonitor,
-		clock:              config.Clock,
-		roots:              roots,
-		segmentDirectories: segDirs,
-		name:               name,
-		metadata:           metadata,
-		keymap:             keymap,
-		keymapPath:         keymapPath,
-		keymapTypeFile:     keymapTypeFile,
-		metrics:            metrics,
+		logger:                config.Logger,
+		errorMonitor:          errorMonitor,
+		clock:                 config.Clock,
+		roots:                 roots,
+		segmentDirectories:    segDirs,
+		name:                  name,
+		metadata:              metadata,
+		keymap:                newMovableKeymap(keymap),
+		keymapPath:            keymapPath,
+		keymapTypeFile:        keymapTypeFile,
+		doubleWriteProtection: config.DoubleWriteProtection,
+		metrics:               metrics,
+		fsync:                 config.Fsync,
+		migrationStopChannel:  make(chan struct{}),
 	}
 
 	lowestSegmentIndex, highestSegmentIndex, segments, err :=
@@ -286,11 +304,24 @@ func NewDiskTable(
 		metrics:                 metrics,
 		name:                    name,
 		gcBatchSize:             config.GCBatchSize,
-		keymap:                  keymap,
+		keymap:                  table.keymap,
 		flushLoop:               fLoop,
 		garbageCollectionPeriod: config.GCPeriod,
 		immutableSegmentSize:    immutableSegmentSize,
 	}
+	if config.ScrubPeriod > 0 {
+		cLoop.scrubber = newScrubber(
+			config.Logger,
+			errorMonitor,
+			table.keymap,
+			metrics,
+			config.Clock,
+			name,
+			config.QuarantineCorruptedData,
+			quarantineCallback)
+		cLoop.scrubPeriod = config.ScrubPeriod
+		go cLoop.scrubber.run()
+	}
 	cLoop.threadsafeHighestSegmentIndex.Store(highestSegmentIndex)
 	table.controlLoop = cLoop
 	cLoop.updateCurrentSize()
@@ -321,14 +352,14 @@ func (d *DiskTable) reloadKeymap(
 
 	batch := make([]*types.ScopedKey, 0, keymapReloadBatchSize)
 
-	for i := lowestSegmentIndex; i <= highestSegmentIndex; i++ {
-		if !segments[i].IsSealed() {
+	for i, seg := range segment.ExistingSegments(segments, lowestSegmentIndex, highestSegmentIndex) {
+		if !seg.IsSealed() {
 			// ignore unsealed segment, this will have been created in the current session and will not
 			// yet contain any data.
 			continue
 		}
 
-		keys, err := segments[i].GetKeys()
+		keys, err := seg.GetKeys()
 		if err != nil {
 			return fmt.Errorf("failed to get keys from segment %d: %w", i, err)
 		}
@@ -383,6 +414,10 @@ func (d *DiskTable) Close() error {
 		return fmt.Errorf("cannot process Stop() request, DB is in panicked state due to error: %w", err)
 	}
 
+	// Migrations depend on the control loop, so they must be stopped before the control loop is stopped.
+	close(d.migrationStopChannel)
+	d.migrationWaitGroup.Wait()
+
 	d.errorMonitor.Shutdown()
 
 	shutdownCompleteChan := make(chan struct{}, 1)
@@ -441,20 +476,24 @@ func (d *DiskTable) Destroy() error {
 	}
 
 	// destroy the keymap
+	d.rootsLock.Lock()
+	keymapPath := d.keymapPath
+	keymapTypeFile := d.keymapTypeFile
+	d.rootsLock.Unlock()
 	err = d.keymap.Destroy()
 	if err != nil {
 		return fmt.Errorf("failed to destroy keymap: %w", err)
 	}
-	err = d.keymapTypeFile.Delete()
+	err = keymapTypeFile.Delete()
 	if err != nil {
 		return fmt.Errorf("failed to delete keymap type file: %w", err)
 	}
-	exists, err := util.Exists(d.keymapPath)
+	exists, err := util.Exists(keymapPath)
 	if err != nil {
 		return fmt.Errorf("failed to check if keymap directory exists: %w", err)
 	}
 	if exists {
-		err = os.RemoveAll(d.keymapPath)
+		err = os.RemoveAll(keymapPath)
 		if err != nil {
 			return fmt.Errorf("failed to remove keymap directory: %w", err)
 		}
@@ -468,6 +507,15 @@ func (d *DiskTable) Destroy() error {
 
 	// delete the root directories for the table
 	for _, root := range d.roots {
+		exists, err = util.Exists(path.Join(root, SnapshotHardLinkDirectory))
+		if err != nil {
+			return fmt.Errorf("failed to check if snapshot directory exists: %w", err)
+		}
+		if exists {
+			// Snapshot files are never deleted by the DB, so the root directory must be left in place.
+			continue
+		}
+
 		err = os.Remove(root)
 		if err != nil {
 			return fmt.Errorf("failed to remove root directory: %w", err)
@@ -611,6 +659,29 @@ func (d *DiskTable) PutBatch(batch []*types.KVPair) error {
 		return fmt.Errorf("cannot process PutBatch() request, DB is in panicked state due to error: %w", err)
 	}
 
+	return d.putBatch(batch, time.Time{})
+}
+
+func (d *DiskTable) PutWithExpiry(key []byte, value []byte, expiry time.Time) error {
+	if ok, err := d.errorMonitor.IsOk(); !ok {
+		return fmt.Errorf("cannot process PutWithExpiry() request, DB is in panicked state due to error: %w", err)
+	}
+
+	ttl := d.metadata.GetTTL()
+	if ttl > 0 {
+		minimumExpiry := d.clock().Add(ttl)
+		if expiry.Before(minimumExpiry) {
+			return fmt.Errorf("expiry %v is before the minimum expiry %v permitted by the table's TTL: %w",
+				expiry, minimumExpiry, litt.ErrInvalidExpiry)
+		}
+	}
+
+	return d.putBatch([]*types.KVPair{{Key: key, Value: value}}, expiry)
+}
+
+// putBatch sends a batch of values to the control loop to be written. If expiry is not zero, the values will not be
+// deleted before that time.
+func (d *DiskTable) putBatch(batch []*types.KVPair, expiry time.Time) error {
 	if d.metrics != nil {
 		start := d.clock()
 		totalSize := uint64(0)
@@ -625,12 +696,6 @@ func (d *DiskTable) PutBatch(batch []*types.KVPair) error {
 	}
 
 	for _, kv := range batch {
-		if len(kv.Key) > math.MaxUint32 {
-			return fmt.Errorf("key is too large, length must not exceed 2^32 bytes: %d bytes", len(kv.Key))
-		}
-		if len(kv.Value) > math.MaxUint32 {
-			return fmt.Errorf("value is too large, length must not exceed 2^32 bytes: %d bytes", len(kv.Value))
-		}
 		if kv.Key == nil {
 			return fmt.Errorf("nil keys are not supported")
 		}
@@ -643,6 +708,7 @@ func (d *DiskTable) PutBatch(batch []*types.KVPair) error {
 
 	request := &controlLoopWriteRequest{
 		values: batch,
+		expiry: expiry,
 	}
 	err := d.controlLoop.enqueue(request)
 	if err != nil {
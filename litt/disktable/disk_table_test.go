@@ -122,6 +122,7 @@ func buildMemKeyDiskTableSingleShard(
 		keymapTypeFile,
 		roots,
 		true,
+		nil,
 		nil)
 
 	if err != nil {
@@ -178,6 +179,7 @@ func buildMemKeyDiskTableMultiShard(
 		keymapTypeFile,
 		roots,
 		true,
+		nil,
 		nil)
 
 	if err != nil {
@@ -233,6 +235,7 @@ func buildLevelDBKeyDiskTableSingleShard(
 		keymapTypeFile,
 		roots,
 		false,
+		nil,
 		nil)
 
 	if err != nil {
@@ -289,6 +292,7 @@ func buildLevelDBKeyDiskTableMultiShard(
 		keymapTypeFile,
 		roots,
 		false,
+		nil,
 		nil)
 
 	if err != nil {
@@ -1266,8 +1270,8 @@ func truncatedValueFileTest(t *testing.T, tableBuilder *tableBuilder) {
 		offset := key.Address.Offset()
 		valueSize := len(expectedValues[string(key.Key)])
 		// If there are not at least this many bytes remaining in the value file, the value is missing.
-		requiredLength := offset + uint32(valueSize) + 4
-		if requiredLength > uint32(len(valueFileBytes)) {
+		requiredLength := offset + uint64(valueSize) + 8 /* uint64 length */ + 4 /* uint32 checksum */
+		if requiredLength > uint64(len(valueFileBytes)) {
 			missingKeys[string(key.Key)] = struct{}{}
 		}
 	}
@@ -1762,9 +1766,9 @@ func restartWithMultipleStorageDirectoriesTest(t *testing.T, tableBuilder *table
 
 			// Shuffle the table metadata location. This should not cause problems.
 			metadataDir := path.Join(directories[0], "table")
-			mPath := path.Join(metadataDir, tableMetadataFileName)
+			mPath := path.Join(metadataDir, TableMetadataFileName)
 			newMetadataDir := path.Join(directories[rand.Uint32Range(1, uint32(len(directories)))], "table")
-			newMPath := path.Join(newMetadataDir, tableMetadataFileName)
+			newMPath := path.Join(newMetadataDir, TableMetadataFileName)
 			err = os.MkdirAll(newMetadataDir, 0755)
 			require.NoError(t, err)
 			err = os.Rename(mPath, newMPath)
@@ -2309,3 +2313,176 @@ func TestTableSize(t *testing.T) {
 		})
 	}
 }
+
+func extendedExpiryTest(t *testing.T, tableBuilder *tableBuilder) {
+	rand := random.NewTestRandom()
+
+	directory := t.TempDir()
+
+	startTime := rand.Time()
+	var fakeTime atomic.Pointer[time.Time]
+	fakeTime.Store(&startTime)
+	clock := func() time.Time {
+		return *fakeTime.Load()
+	}
+	advanceClock := func(duration time.Duration) {
+		newTime := fakeTime.Load().Add(duration)
+		fakeTime.Store(&newTime)
+	}
+
+	tableName := rand.String(8)
+	table, err := tableBuilder.builder(clock, tableName, []string{directory})
+	require.NoError(t, err)
+
+	ttl := 10 * time.Second
+	err = table.SetTTL(ttl)
+	require.NoError(t, err)
+
+	writeValues := func(count int) map[string][]byte {
+		values := make(map[string][]byte)
+		for i := 0; i < count; i++ {
+			key := rand.PrintableVariableBytes(32, 64)
+			value := rand.PrintableVariableBytes(1, 128)
+			err = table.Put(key, value)
+			require.NoError(t, err)
+			values[string(key)] = value
+		}
+		err = table.Flush()
+		require.NoError(t, err)
+		return values
+	}
+
+	// An expiry before the table's TTL is not permitted.
+	err = table.PutWithExpiry(rand.PrintableBytes(32), rand.PrintableBytes(32), clock().Add(ttl/2))
+	require.ErrorIs(t, err, litt.ErrInvalidExpiry)
+
+	earlyValues := writeValues(50)
+
+	pinnedKey := rand.PrintableBytes(32)
+	pinnedValue := rand.PrintableBytes(32)
+	expiry := clock().Add(10 * ttl)
+	err = table.PutWithExpiry(pinnedKey, pinnedValue, expiry)
+	require.NoError(t, err)
+
+	lateValues := writeValues(50)
+
+	// Advance the clock past the TTL of all values written so far, and write enough new data to seal the segments.
+	advanceClock(2 * ttl)
+	recentValues := writeValues(50)
+	err = table.RunGC()
+	require.NoError(t, err)
+
+	checkPinnedSegments := func() {
+		value, ok, err := table.Get(pinnedKey)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, pinnedValue, value)
+
+		for key, expectedValue := range recentValues {
+			value, ok, err := table.Get([]byte(key))
+			require.NoError(t, err)
+			require.True(t, ok)
+			require.Equal(t, expectedValue, value)
+		}
+
+		countDeleted := func(values map[string][]byte) int {
+			deletedCount := 0
+			for key := range values {
+				ok, err := table.Exists([]byte(key))
+				require.NoError(t, err)
+				if !ok {
+					deletedCount++
+				}
+			}
+			return deletedCount
+		}
+
+		// Values in segments before the pinned segment are deleted. So are values in segments after the pinned
+		// segment, even though the pinned segment has not yet been deleted.
+		require.Greater(t, countDeleted(earlyValues), 0)
+		require.Greater(t, countDeleted(lateValues), 0)
+	}
+	checkPinnedSegments()
+
+	// The table can be restarted while there is a gap between the pinned segment and later segments.
+	err = table.Close()
+	require.NoError(t, err)
+	table, err = tableBuilder.builder(clock, tableName, []string{directory})
+	require.NoError(t, err)
+	err = table.RunGC()
+	require.NoError(t, err)
+	checkPinnedSegments()
+
+	// Once the extended expiry passes, the pinned segment is deleted.
+	advanceClock(10 * ttl)
+	writeValues(50)
+	err = table.RunGC()
+	require.NoError(t, err)
+
+	ok, err := table.Exists(pinnedKey)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	err = table.Close()
+	require.NoError(t, err)
+}
+
+func TestExtendedExpiry(t *testing.T) {
+	t.Parallel()
+	for _, tb := range tableBuilders {
+		t.Run(tb.name, func(t *testing.T) {
+			extendedExpiryTest(t, tb)
+		})
+	}
+}
+
+func iterateWithoutSealingTest(t *testing.T, tableBuilder *tableBuilder) {
+	rand := random.NewTestRandom()
+
+	directory := t.TempDir()
+
+	tableName := rand.String(8)
+	table, err := tableBuilder.builder(time.Now, tableName, []string{directory})
+	require.NoError(t, err)
+
+	// Values are small enough that they all fit in the mutable segment.
+	flushedKey := rand.PrintableBytes(8)
+	flushedValue := rand.PrintableBytes(8)
+	err = table.Put(flushedKey, flushedValue)
+	require.NoError(t, err)
+	err = table.Flush()
+	require.NoError(t, err)
+
+	unflushedKey := rand.PrintableBytes(8)
+	unflushedValue := rand.PrintableBytes(8)
+	err = table.Put(unflushedKey, unflushedValue)
+	require.NoError(t, err)
+
+	segmentIndex := getLatestSegmentIndex(table)
+
+	visited := make(map[string][]byte)
+	err = table.Iterate(func(key []byte, value []byte) error {
+		visited[string(key)] = value
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, map[string][]byte{
+		string(flushedKey):   flushedValue,
+		string(unflushedKey): unflushedValue,
+	}, visited)
+
+	// Iteration should not have sealed the mutable segment.
+	require.Equal(t, segmentIndex, getLatestSegmentIndex(table))
+
+	err = table.Destroy()
+	require.NoError(t, err)
+}
+
+func TestIterateWithoutSealing(t *testing.T) {
+	t.Parallel()
+	for _, tb := range tableBuilders {
+		t.Run(tb.name, func(t *testing.T) {
+			iterateWithoutSealingTest(t, tb)
+		})
+	}
+}
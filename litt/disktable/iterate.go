@@ -0,0 +1,130 @@
+package disktable
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda/litt/disktable/segment"
+	"github.com/Layr-Labs/eigenda/litt/types"
+	"github.com/Layr-Labs/eigenda/litt/util"
+)
+
+// Iterate calls the given function once for each key-value pair in the table. Segments are visited in order,
+// from oldest to newest, followed by values that have not yet been flushed to disk. All data written prior to this
+// call is visited. The mutable segment is not sealed; instead, its flushed keys are read directly, and values that
+// are not yet flushed are taken from a snapshot of the unflushed data cache. Each segment is reserved for the
+// duration of the iteration, so it is safe for garbage collection to run concurrently (segments deleted by the
+// garbage collector are not removed from disk until iteration is finished). Segments that have expired are skipped.
+func (d *DiskTable) Iterate(fn func(key []byte, value []byte) error) error {
+	if ok, err := d.errorMonitor.IsOk(); !ok {
+		return fmt.Errorf("cannot process Iterate() request, DB is in panicked state due to error: %w", err)
+	}
+
+	// The unflushed data must be captured before the segments are reserved. A value that is removed from the
+	// unflushed data cache before this snapshot is taken has already been flushed, and so is covered by the segments.
+	unflushedValues := make(map[string][]byte)
+	d.unflushedDataCache.Range(func(key any, value any) bool {
+		unflushedValues[key.(string)] = value.([]byte)
+		return true
+	})
+
+	request := &controlLoopReserveSegmentsRequest{
+		includeMutableSegment: true,
+		responseChan:          make(chan []*segment.Segment, 1),
+	}
+	err := d.controlLoop.enqueue(request)
+	if err != nil {
+		return fmt.Errorf("failed to send reserve segments request: %w", err)
+	}
+
+	segments, err := util.Await(d.errorMonitor, request.responseChan)
+	if err != nil {
+		return fmt.Errorf("failed to await segments: %w", err)
+	}
+	defer func() {
+		for _, seg := range segments {
+			seg.Release()
+		}
+	}()
+
+	for i, seg := range segments {
+		var keys []*types.ScopedKey
+		if i == len(segments)-1 {
+			// This is the mutable segment (or was, at the time it was reserved). It may be sealed concurrently, so
+			// only its flushed keys are read.
+			keys, err = seg.GetFlushedKeys()
+		} else {
+			if d.isSegmentExpired(seg) {
+				continue
+			}
+			keys, err = seg.GetKeys()
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get keys for segment %d: %w", seg.SegmentIndex(), err)
+		}
+
+		err = d.iterateSegment(seg, keys, unflushedValues, fn)
+		if err != nil {
+			return err
+		}
+	}
+
+	for key, value := range unflushedValues {
+		err = fn([]byte(key), value)
+		if err != nil {
+			return fmt.Errorf("iteration stopped at key %x: %w", key, err)
+		}
+	}
+
+	return nil
+}
+
+// isSegmentExpired returns true if all data in the segment has passed its TTL, i.e. if the segment is eligible
+// for garbage collection. A segment containing a value with an extended expiry is not expired until that expiry
+// passes, and all values in such a segment are visited until then.
+func (d *DiskTable) isSegmentExpired(seg *segment.Segment) bool {
+	ttl := d.metadata.GetTTL()
+	if ttl.Nanoseconds() <= 0 {
+		return false
+	}
+	now := d.clock()
+	if seg.GetMaxExpiry().After(now) {
+		return false
+	}
+	return now.Sub(seg.GetSealTime()) >= ttl
+}
+
+// iterateSegment calls the given function once for each of the given keys in a segment. Keys that are in the
+// unflushed values snapshot are skipped, since they are visited once the segments have all been iterated.
+func (d *DiskTable) iterateSegment(
+	seg *segment.Segment,
+	keys []*types.ScopedKey,
+	unflushedValues map[string][]byte,
+	fn func(key []byte, value []byte) error) error {
+
+	for _, key := range keys {
+		if _, ok := unflushedValues[util.UnsafeBytesToString(key.Key)]; ok {
+			continue
+		}
+
+		value, err := seg.Read(key.Key, key.Address)
+		if err != nil {
+			if errors.Is(err, segment.ErrChecksumMismatch) {
+				// If the scrubber has quarantined this key, it will no longer be present in the keymap.
+				_, exists, keymapErr := d.keymap.Get(key.Key)
+				if keymapErr == nil && !exists {
+					continue
+				}
+			}
+			return fmt.Errorf("failed to read value for key %x in segment %d: %w",
+				key.Key, seg.SegmentIndex(), err)
+		}
+
+		err = fn(key.Key, value)
+		if err != nil {
+			return fmt.Errorf("iteration stopped at key %x: %w", key.Key, err)
+		}
+	}
+
+	return nil
+}
@@ -13,6 +13,11 @@ const KeymapDirectoryName = "keymap"
 // This directory will be created inside the keymap directory.
 const KeymapDataDirectoryName = "data"
 
+// KeymapStagingDirectoryName is the name of the directory a keymap is copied into while it is being moved to a
+// different root. Once the copy is complete, the directory is renamed to KeymapDirectoryName. A staging directory
+// found on startup was left behind by a crash, and is deleted.
+const KeymapStagingDirectoryName = "keymap-staging"
+
 // KeymapInitializedFileName is the name of the file that indicates that the keymap has been initialized.
 // This file contains no data, and serves as a flag that is set when the keymap has been fully initialized.
 const KeymapInitializedFileName = "initialized"
@@ -51,3 +56,18 @@ type Keymap interface {
 
 // BuildKeymap is a function that builds a Keymap.
 type BuildKeymap func(logger logging.Logger, keymapPath string, doubleWriteProtection bool) (Keymap, bool, error)
+
+// keymapBuilders contains builders for all supported keymap types.
+var keymapBuilders = map[KeymapType]BuildKeymap{
+	MemKeymapType:           NewMemKeymap,
+	LevelDBKeymapType:       NewLevelDBKeymap,
+	UnsafeLevelDBKeymapType: NewUnsafeLevelDBKeymap,
+	PebbleKeymapType:        NewPebbleKeymap,
+	UnsafePebbleKeymapType:  NewUnsafePebbleKeymap,
+}
+
+// GetBuilder returns the builder for the given keymap type, or false if the type is not supported.
+func GetBuilder(keymapType KeymapType) (BuildKeymap, bool) {
+	builder, ok := keymapBuilders[keymapType]
+	return builder, ok
+}
@@ -16,6 +16,7 @@ import (
 var builders = []keymapBuilder{
 	buildMemKeymap,
 	buildLevelDBKeymap,
+	buildPebbleKeymap,
 }
 
 type keymapBuilder func(logger logging.Logger, path string) (Keymap, error)
@@ -38,6 +39,15 @@ func buildLevelDBKeymap(logger logging.Logger, path string) (Keymap, error) {
 	return kmap, nil
 }
 
+func buildPebbleKeymap(logger logging.Logger, path string) (Keymap, error) {
+	kmap, _, err := NewUnsafePebbleKeymap(logger, path, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return kmap, nil
+}
+
 func testBasicBehavior(t *testing.T, keymap Keymap) {
 	rand := random.NewTestRandom()
 
@@ -49,7 +59,7 @@ func testBasicBehavior(t *testing.T, keymap Keymap) {
 		if choice < 0.5 {
 			// Write a random value
 			key := []byte(rand.String(32))
-			address := types.Address(rand.Uint64())
+			address := types.NewAddress(rand.Uint32(), rand.Uint64())
 
 			err := keymap.Put([]*types.ScopedKey{{Key: key, Address: address}})
 			require.NoError(t, err)
@@ -78,7 +88,7 @@ func testBasicBehavior(t *testing.T, keymap Keymap) {
 			pairs := make([]*types.ScopedKey, numberToWrite)
 			for i := 0; i < int(numberToWrite); i++ {
 				key := []byte(rand.String(32))
-				address := types.Address(rand.Uint64())
+				address := types.NewAddress(rand.Uint32(), rand.Uint64())
 				pairs[i] = &types.ScopedKey{Key: key, Address: address}
 				expected[string(key)] = address
 			}
@@ -155,7 +165,7 @@ func TestRestart(t *testing.T) {
 		if choice < 0.5 {
 			// Write a random value
 			key := []byte(rand.String(32))
-			address := types.Address(rand.Uint64())
+			address := types.NewAddress(rand.Uint32(), rand.Uint64())
 
 			err := keymap.Put([]*types.ScopedKey{{Key: key, Address: address}})
 			require.NoError(t, err)
@@ -184,7 +194,7 @@ func TestRestart(t *testing.T) {
 			pairs := make([]*types.ScopedKey, numberToWrite)
 			for i := 0; i < int(numberToWrite); i++ {
 				key := []byte(rand.String(32))
-				address := types.Address(rand.Uint64())
+				address := types.NewAddress(rand.Uint32(), rand.Uint64())
 				pairs[i] = &types.ScopedKey{Key: key, Address: address}
 				expected[string(key)] = address
 			}
@@ -230,7 +240,7 @@ func TestRestart(t *testing.T) {
 		if choice < 0.5 {
 			// Write a random value
 			key := []byte(rand.String(32))
-			address := types.Address(rand.Uint64())
+			address := types.NewAddress(rand.Uint32(), rand.Uint64())
 
 			err := keymap.Put([]*types.ScopedKey{{Key: key, Address: address}})
 			require.NoError(t, err)
@@ -259,7 +269,7 @@ func TestRestart(t *testing.T) {
 			pairs := make([]*types.ScopedKey, numberToWrite)
 			for i := 0; i < int(numberToWrite); i++ {
 				key := []byte(rand.String(32))
-				address := types.Address(rand.Uint64())
+				address := types.NewAddress(rand.Uint32(), rand.Uint64())
 				pairs[i] = &types.ScopedKey{Key: key, Address: address}
 				expected[string(key)] = address
 			}
@@ -21,6 +21,26 @@ type KeymapTypeFile struct {
 	keymapType KeymapType
 }
 
+// keymapStorageFormats maps each keymap type to the format of the data it stores on disk. Keymap types that share
+// a storage format (e.g. a keymap and its unsafe variant) can be switched between without rebuilding the keymap.
+// Keymap types that do not store data on disk map to an empty string.
+var keymapStorageFormats = map[KeymapType]string{
+	MemKeymapType:           "",
+	LevelDBKeymapType:       "leveldb",
+	UnsafeLevelDBKeymapType: "leveldb",
+	PebbleKeymapType:        "pebble",
+	UnsafePebbleKeymapType:  "pebble",
+}
+
+// ParseKeymapType parses the name of a keymap type, returning an error if the type is unknown.
+func ParseKeymapType(name string) (KeymapType, error) {
+	keymapType := KeymapType(name)
+	if _, ok := keymapStorageFormats[keymapType]; !ok {
+		return "", fmt.Errorf("unknown keymap type: %s", name)
+	}
+	return keymapType, nil
+}
+
 // KeymapFileExists checks if the keymap type file exists in the target directory.
 func KeymapFileExists(keymapPath string) (bool, error) {
 	return util.Exists(path.Join(keymapPath, KeymapTypeFileName))
@@ -51,16 +71,9 @@ func LoadKeymapTypeFile(keymapPath string) (*KeymapTypeFile, error) {
 		return nil, fmt.Errorf("unable to read keymap type file: %v", err)
 	}
 
-	var keymapType KeymapType
-	switch string(fileContents) {
-	case MemKeymapType:
-		keymapType = MemKeymapType
-	case LevelDBKeymapType:
-		keymapType = LevelDBKeymapType
-	case UnsafeLevelDBKeymapType:
-		keymapType = UnsafeLevelDBKeymapType
-	default:
-		return nil, fmt.Errorf("unknown keymap type: %s", string(fileContents))
+	keymapType, err := ParseKeymapType(string(fileContents))
+	if err != nil {
+		return nil, err
 	}
 
 	return &KeymapTypeFile{
@@ -105,6 +118,37 @@ func (k *KeymapTypeFile) Write() error {
 	return nil
 }
 
+// CanMigrateInPlace returns true if the data currently in the keymap directory can be used by a keymap of the given
+// type. If false, switching to the new type requires the keymap to be deleted and rebuilt from the segment files.
+func (k *KeymapTypeFile) CanMigrateInPlace(newType KeymapType) bool {
+	currentFormat, ok := keymapStorageFormats[k.keymapType]
+	if !ok || currentFormat == "" {
+		return false
+	}
+	newFormat, ok := keymapStorageFormats[newType]
+	if !ok {
+		return false
+	}
+	return currentFormat == newFormat
+}
+
+// MigrateInPlace switches the keymap type without touching the data in the keymap directory. Returns an error if
+// CanMigrateInPlace returns false for the new type.
+func (k *KeymapTypeFile) MigrateInPlace(newType KeymapType) error {
+	if !k.CanMigrateInPlace(newType) {
+		return fmt.Errorf("keymap type %s can not be migrated in place to %s", k.keymapType, newType)
+	}
+
+	filePath := path.Join(k.keymapPath, KeymapTypeFileName)
+	err := util.AtomicWrite(filePath, []byte(newType), true)
+	if err != nil {
+		return fmt.Errorf("unable to write keymap type file: %w", err)
+	}
+	k.keymapType = newType
+
+	return nil
+}
+
 // Delete deletes the keymap type file.
 func (k *KeymapTypeFile) Delete() error {
 	exists, err := util.Exists(path.Join(k.keymapPath, KeymapTypeFileName))
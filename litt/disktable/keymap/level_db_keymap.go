@@ -121,14 +121,14 @@ func (l *LevelDBKeymap) Get(key []byte) (types.Address, bool, error) {
 	addressBytes, err := l.db.Get(key, nil)
 	if err != nil {
 		if errors.Is(err, leveldb.ErrNotFound) {
-			return 0, false, nil
+			return types.Address{}, false, nil
 		}
-		return 0, false, fmt.Errorf("failed to get key from LevelDB: %w", err)
+		return types.Address{}, false, fmt.Errorf("failed to get key from LevelDB: %w", err)
 	}
 
 	address, err := types.DeserializeAddress(addressBytes)
 	if err != nil {
-		return 0, false, fmt.Errorf("failed to deserialize address: %w", err)
+		return types.Address{}, false, fmt.Errorf("failed to deserialize address: %w", err)
 	}
 
 	return address, true, nil
@@ -0,0 +1,200 @@
+package keymap
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync/atomic"
+
+	"github.com/Layr-Labs/eigenda/litt/types"
+	"github.com/Layr-Labs/eigenda/litt/util"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/cockroachdb/pebble"
+)
+
+var _ Keymap = &PebbleKeymap{}
+
+// PebbleKeymap is a keymap that uses Pebble as the underlying storage. Methods on this struct are goroutine safe.
+type PebbleKeymap struct {
+	logger logging.Logger
+	db     *pebble.DB
+	// if true, then return an error if an update would overwrite an existing key
+	doubleWriteProtection bool
+	keymapPath            string
+	alive                 atomic.Bool
+	// This is a "test mode only" flag. Should be true in production use cases or anywhere that data consistency
+	// is critical. Unit tests write lots of little values, and syncing each one is slow, so it may be desirable
+	// to set this to false in some tests.
+	syncWrites bool
+}
+
+var _ BuildKeymap = NewPebbleKeymap
+
+// NewPebbleKeymap creates a new PebbleKeymap instance.
+func NewPebbleKeymap(
+	logger logging.Logger,
+	keymapPath string,
+	doubleWriteProtection bool) (kmap Keymap, requiresReload bool, err error) {
+
+	return newPebbleKeymap(logger, keymapPath, doubleWriteProtection, true)
+}
+
+// NewUnsafePebbleKeymap creates a new PebbleKeymap instance. It does not use sync writes. This makes it faster,
+// but unsafe if data consistency is critical (i.e. production use cases).
+func NewUnsafePebbleKeymap(
+	logger logging.Logger,
+	keymapPath string,
+	doubleWriteProtection bool) (kmap Keymap, requiresReload bool, err error) {
+
+	return newPebbleKeymap(logger, keymapPath, doubleWriteProtection, false)
+}
+
+// newPebbleKeymap creates a new PebbleKeymap instance.
+func newPebbleKeymap(
+	logger logging.Logger,
+	keymapPath string,
+	doubleWriteProtection bool,
+	syncWrites bool) (kmap *PebbleKeymap, requiresReload bool, err error) {
+
+	exists, err := util.Exists(keymapPath)
+	if err != nil {
+		return nil, false, fmt.Errorf("error checking for keymap directory: %w", err)
+	}
+
+	if !exists {
+		err = os.MkdirAll(keymapPath, 0755)
+		if err != nil {
+			return nil, false, fmt.Errorf("error creating keymap directory: %w", err)
+		}
+	}
+	requiresReload = !exists
+
+	options := &pebble.Options{}
+	if logger != nil {
+		options.Logger = logger
+	}
+
+	db, err := pebble.Open(keymapPath, options)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to open Pebble: %w", err)
+	}
+
+	kmap = &PebbleKeymap{
+		logger:                logger,
+		db:                    db,
+		keymapPath:            keymapPath,
+		doubleWriteProtection: doubleWriteProtection,
+		syncWrites:            syncWrites,
+	}
+	kmap.alive.Store(true)
+
+	return kmap, requiresReload, nil
+}
+
+func (p *PebbleKeymap) Put(keys []*types.ScopedKey) error {
+
+	if p.doubleWriteProtection {
+		for _, k := range keys {
+			_, ok, err := p.Get(k.Key)
+			if err != nil {
+				return fmt.Errorf("failed to get key: %w", err)
+			}
+			if ok {
+				return fmt.Errorf("key %s already exists", k.Key)
+			}
+		}
+	}
+
+	batch := p.db.NewBatch()
+	defer func() {
+		_ = batch.Close()
+	}()
+
+	for _, k := range keys {
+		err := batch.Set(k.Key, k.Address.Serialize(), nil)
+		if err != nil {
+			return fmt.Errorf("failed to add key to Pebble batch: %w", err)
+		}
+	}
+
+	writeOptions := pebble.NoSync
+	if p.syncWrites {
+		writeOptions = pebble.Sync
+	}
+
+	err := batch.Commit(writeOptions)
+	if err != nil {
+		return fmt.Errorf("failed to put batch to Pebble: %w", err)
+	}
+	return nil
+}
+
+func (p *PebbleKeymap) Get(key []byte) (types.Address, bool, error) {
+	addressBytes, closer, err := p.db.Get(key)
+	if err != nil {
+		if errors.Is(err, pebble.ErrNotFound) {
+			return types.Address{}, false, nil
+		}
+		return types.Address{}, false, fmt.Errorf("failed to get key from Pebble: %w", err)
+	}
+	// The returned slice is only valid until the closer is closed, so deserialize it first.
+	address, err := types.DeserializeAddress(addressBytes)
+	closeErr := closer.Close()
+	if err != nil {
+		return types.Address{}, false, fmt.Errorf("failed to deserialize address: %w", err)
+	}
+	if closeErr != nil {
+		return types.Address{}, false, fmt.Errorf("failed to release Pebble value: %w", closeErr)
+	}
+
+	return address, true, nil
+}
+
+func (p *PebbleKeymap) Delete(keys []*types.ScopedKey) error {
+	batch := p.db.NewBatch()
+	defer func() {
+		_ = batch.Close()
+	}()
+
+	for _, key := range keys {
+		err := batch.Delete(key.Key, nil)
+		if err != nil {
+			return fmt.Errorf("failed to add key to Pebble batch: %w", err)
+		}
+	}
+
+	err := batch.Commit(pebble.NoSync)
+	if err != nil {
+		return fmt.Errorf("failed to delete keys from Pebble: %w", err)
+	}
+
+	return nil
+}
+
+func (p *PebbleKeymap) Stop() error {
+	alive := p.alive.Swap(false)
+	if !alive {
+		return nil
+	}
+
+	err := p.db.Close()
+	if err != nil {
+		return fmt.Errorf("failed to close Pebble: %w", err)
+	}
+	return nil
+}
+
+func (p *PebbleKeymap) Destroy() error {
+	err := p.Stop()
+	if err != nil {
+		return fmt.Errorf("failed to stop Pebble: %w", err)
+	}
+
+	p.logger.Info(fmt.Sprintf("deleting Pebble keymap at path: %s", p.keymapPath))
+	err = os.RemoveAll(p.keymapPath)
+	if err != nil {
+		return fmt.Errorf("failed to remove Pebble data directory: %w", err)
+	}
+
+	return nil
+}
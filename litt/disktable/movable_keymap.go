@@ -0,0 +1,69 @@
+package disktable
+
+import (
+	"sync"
+
+	"github.com/Layr-Labs/eigenda/litt/disktable/keymap"
+	"github.com/Layr-Labs/eigenda/litt/types"
+)
+
+var _ keymap.Keymap = (*movableKeymap)(nil)
+
+// movableKeymap wraps a table's keymap so that it can be replaced while the table is running, e.g. when the keymap is
+// moved out of a root that is being removed. Operations on the keymap wait while it is being replaced.
+type movableKeymap struct {
+	// The wrapped keymap.
+	keymap keymap.Keymap
+
+	// Held for reading by operations on the keymap, and for writing while the keymap is being replaced.
+	lock sync.RWMutex
+}
+
+// newMovableKeymap wraps a keymap so that it can be replaced.
+func newMovableKeymap(kmap keymap.Keymap) *movableKeymap {
+	return &movableKeymap{
+		keymap: kmap,
+	}
+}
+
+// replace calls the given function with the wrapped keymap, and wraps the keymap it returns instead. No other
+// operation on the keymap runs while the function is running. The function must return the keymap to use from then
+// on even if it fails, which may be the keymap it was given.
+func (m *movableKeymap) replace(fn func(kmap keymap.Keymap) (keymap.Keymap, error)) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	replacement, err := fn(m.keymap)
+	m.keymap = replacement
+	return err
+}
+
+func (m *movableKeymap) Put(pairs []*types.ScopedKey) error {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.keymap.Put(pairs)
+}
+
+func (m *movableKeymap) Get(key []byte) (types.Address, bool, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.keymap.Get(key)
+}
+
+func (m *movableKeymap) Delete(keys []*types.ScopedKey) error {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.keymap.Delete(keys)
+}
+
+func (m *movableKeymap) Stop() error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.keymap.Stop()
+}
+
+func (m *movableKeymap) Destroy() error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.keymap.Destroy()
+}
@@ -0,0 +1,468 @@
+package disktable
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/disktable/keymap"
+	"github.com/Layr-Labs/eigenda/litt/disktable/segment"
+	"github.com/Layr-Labs/eigenda/litt/types"
+	"github.com/Layr-Labs/eigenda/litt/util"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+var _ litt.ManagedTable = (*ReadOnlyTable)(nil)
+
+// ReadOnlyTable provides read access to a table that is owned by another process (i.e. a process that holds the
+// lock on the DB's root directories). A read-only table never modifies files on disk.
+//
+// Only sealed segments are visible. The owner's keymap can not be shared between processes, so a read-only table
+// builds an in-memory keymap from the key files of the sealed segments. The set of visible segments is refreshed
+// once per GC period (and each time RunGC() is called): newly sealed segments are loaded, and segments that the
+// owner has deleted are dropped. Values in a segment that is deleted concurrently with a read are reported as
+// not existing.
+type ReadOnlyTable struct {
+	logger logging.Logger
+
+	// Responsible for handling fatal DB errors.
+	errorMonitor *util.ErrorMonitor
+
+	// The table's name.
+	name string
+
+	// The directories where segment files are stored.
+	segmentDirectories []string
+
+	// A map of keys to their addresses, built from the key files of the loaded segments.
+	keymap keymap.Keymap
+
+	// The currently loaded segments, keyed by segment index.
+	segments map[uint32]*segment.Segment
+
+	// The keys in each loaded segment, keyed by segment index. Used to remove keys from the keymap when the owner
+	// deletes a segment (at which point the key file may no longer be readable).
+	segmentKeys map[uint32][]*types.ScopedKey
+
+	// Protects segments and segmentKeys.
+	lock sync.RWMutex
+
+	// Serializes calls to refresh().
+	refreshLock sync.Mutex
+
+	// The number of keys in the loaded segments.
+	keyCount atomic.Int64
+
+	// The size of the loaded segments, in bytes.
+	size atomic.Uint64
+
+	// Closed when the table is closed, causing the refresh goroutine to exit.
+	stopChannel chan struct{}
+
+	// Closed when the refresh goroutine has exited.
+	stoppedChannel chan struct{}
+
+	// True if the table has been closed.
+	closed atomic.Bool
+}
+
+// NewReadOnlyTable opens a table owned by another process for reading. Returns an error if the table does not exist.
+func NewReadOnlyTable(config *litt.Config, name string, roots []string) (litt.ManagedTable, error) {
+	if config.GCPeriod <= 0 {
+		return nil, errors.New("garbage collection period must be greater than 0")
+	}
+
+	segmentDirectories := make([]string, 0, len(roots))
+	for _, root := range roots {
+		segmentDirectory := path.Join(root, SegmentDirectory)
+		exists, err := util.Exists(segmentDirectory)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check if segment directory exists: %w", err)
+		}
+		if exists {
+			segmentDirectories = append(segmentDirectories, segmentDirectory)
+		}
+	}
+	if len(segmentDirectories) == 0 {
+		return nil, fmt.Errorf("table %s does not exist", name)
+	}
+
+	// The keymap is not shared with the owner, and so there is no need for double write protection.
+	kmap, _, err := keymap.NewMemKeymap(config.Logger, "", false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create keymap: %w", err)
+	}
+
+	table := &ReadOnlyTable{
+		logger:             config.Logger,
+		errorMonitor:       util.NewErrorMonitor(config.CTX, config.Logger, config.FatalErrorCallback),
+		name:               name,
+		segmentDirectories: segmentDirectories,
+		keymap:             kmap,
+		segments:           make(map[uint32]*segment.Segment),
+		segmentKeys:        make(map[uint32][]*types.ScopedKey),
+		stopChannel:        make(chan struct{}),
+		stoppedChannel:     make(chan struct{}),
+	}
+
+	err = table.refresh()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load segments: %w", err)
+	}
+
+	go table.refreshLoop(config.GCPeriod)
+
+	return table, nil
+}
+
+// refreshLoop periodically refreshes the set of loaded segments until the table is closed.
+func (t *ReadOnlyTable) refreshLoop(period time.Duration) {
+	defer close(t.stoppedChannel)
+
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-t.errorMonitor.ImmediateShutdownRequired():
+			t.logger.Infof("context done, shutting down read-only table refresh loop")
+			return
+		case <-t.stopChannel:
+			return
+		case <-ticker.C:
+			err := t.refresh()
+			if err != nil {
+				t.errorMonitor.Panic(fmt.Errorf("failed to refresh read-only table %s: %w", t.name, err))
+				return
+			}
+		}
+	}
+}
+
+// refresh brings the set of loaded segments up to date with the segments on disk. Segments that have been deleted
+// by the owner are dropped, and segments that have been sealed since the last refresh are loaded.
+func (t *ReadOnlyTable) refresh() error {
+	t.refreshLock.Lock()
+	defer t.refreshLock.Unlock()
+
+	indices, err := segment.ListSegmentIndices(t.logger, t.segmentDirectories)
+	if err != nil {
+		return fmt.Errorf("failed to list segments: %w", err)
+	}
+
+	present := make(map[uint32]struct{}, len(indices))
+	for _, index := range indices {
+		present[index] = struct{}{}
+	}
+
+	// Drop segments that have been deleted by the owner.
+	t.lock.RLock()
+	deleted := make([]uint32, 0)
+	for index := range t.segments {
+		if _, ok := present[index]; !ok {
+			deleted = append(deleted, index)
+		}
+	}
+	t.lock.RUnlock()
+
+	for _, index := range deleted {
+		err = t.dropSegment(index)
+		if err != nil {
+			return fmt.Errorf("failed to drop segment %d: %w", index, err)
+		}
+	}
+
+	// Load segments that have been sealed since the last refresh.
+	for _, index := range indices {
+		t.lock.RLock()
+		_, loaded := t.segments[index]
+		t.lock.RUnlock()
+		if loaded {
+			continue
+		}
+
+		seg, keys, err := t.loadSegment(index)
+		if err != nil {
+			if errors.Is(err, segment.ErrSegmentNotSealed) {
+				// Only the owner's most recent segment is unsealed, there is nothing more to load.
+				break
+			}
+			if errors.Is(err, os.ErrNotExist) {
+				// The owner deleted this segment while we were loading it.
+				continue
+			}
+			return fmt.Errorf("failed to load segment %d: %w", index, err)
+		}
+
+		err = t.keymap.Put(keys)
+		if err != nil {
+			return fmt.Errorf("failed to add keys from segment %d to keymap: %w", index, err)
+		}
+
+		t.lock.Lock()
+		t.segments[index] = seg
+		t.segmentKeys[index] = keys
+		t.lock.Unlock()
+
+		t.keyCount.Add(int64(len(keys)))
+		t.size.Add(seg.Size())
+	}
+
+	return nil
+}
+
+// loadSegment loads a sealed segment and its keys from disk.
+func (t *ReadOnlyTable) loadSegment(index uint32) (*segment.Segment, []*types.ScopedKey, error) {
+	seg, err := segment.LoadSegmentReadOnly(t.logger, t.errorMonitor, index, t.segmentDirectories)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keys, err := seg.GetKeys()
+	if err != nil {
+		seg.Release()
+		return nil, nil, fmt.Errorf("failed to get keys: %w", err)
+	}
+
+	return seg, keys, nil
+}
+
+// dropSegment removes a segment and its keys from the table.
+func (t *ReadOnlyTable) dropSegment(index uint32) error {
+	t.lock.Lock()
+	seg := t.segments[index]
+	keys := t.segmentKeys[index]
+	delete(t.segments, index)
+	delete(t.segmentKeys, index)
+	t.lock.Unlock()
+
+	err := t.keymap.Delete(keys)
+	if err != nil {
+		return fmt.Errorf("failed to remove keys from keymap: %w", err)
+	}
+
+	t.keyCount.Add(-int64(len(keys)))
+	t.size.Add(-seg.Size())
+	seg.Release()
+
+	return nil
+}
+
+// getSegment returns the loaded segment with the given index, or false if no such segment is loaded.
+func (t *ReadOnlyTable) getSegment(index uint32) (*segment.Segment, bool) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	seg, ok := t.segments[index]
+	return seg, ok
+}
+
+// read reads a value from a segment. Returns false if the segment has been deleted by the owner.
+func (t *ReadOnlyTable) read(seg *segment.Segment, key []byte, address types.Address) ([]byte, bool, error) {
+	value, err := seg.Read(key, address)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("failed to read value from segment %d: %w", seg.SegmentIndex(), err)
+	}
+	return value, true, nil
+}
+
+func (t *ReadOnlyTable) Name() string {
+	return t.name
+}
+
+func (t *ReadOnlyTable) Put(_ []byte, _ []byte) error {
+	return fmt.Errorf("cannot write to table %s: %w", t.name, litt.ErrReadOnly)
+}
+
+func (t *ReadOnlyTable) PutBatch(_ []*types.KVPair) error {
+	return fmt.Errorf("cannot write to table %s: %w", t.name, litt.ErrReadOnly)
+}
+
+func (t *ReadOnlyTable) PutWithExpiry(_ []byte, _ []byte, _ time.Time) error {
+	return fmt.Errorf("cannot write to table %s: %w", t.name, litt.ErrReadOnly)
+}
+
+func (t *ReadOnlyTable) Get(key []byte) (value []byte, exists bool, err error) {
+	value, exists, _, err = t.CacheAwareGet(key, false)
+	return value, exists, err
+}
+
+func (t *ReadOnlyTable) CacheAwareGet(
+	key []byte,
+	onlyReadFromCache bool,
+) (value []byte, exists bool, hot bool, err error) {
+
+	if ok, err := t.errorMonitor.IsOk(); !ok {
+		return nil, false, false, fmt.Errorf(
+			"cannot process CacheAwareGet() request, DB is in panicked state due to error: %w", err)
+	}
+
+	address, exists, err := t.keymap.Get(key)
+	if err != nil {
+		return nil, false, false, fmt.Errorf("failed to get address: %w", err)
+	}
+	if !exists {
+		return nil, false, false, nil
+	}
+
+	if onlyReadFromCache {
+		// The value exists but we are not allowed to read it from disk.
+		return nil, true, false, nil
+	}
+
+	seg, ok := t.getSegment(address.Index())
+	if !ok {
+		// The segment was dropped after we looked up the address.
+		return nil, false, false, nil
+	}
+
+	value, exists, err = t.read(seg, key, address)
+	if err != nil {
+		return nil, false, false, err
+	}
+	return value, exists, false, nil
+}
+
+// Iterate calls the given function once for each key-value pair in the loaded segments. Segments that the owner
+// deletes during iteration are skipped.
+func (t *ReadOnlyTable) Iterate(fn func(key []byte, value []byte) error) error {
+	if ok, err := t.errorMonitor.IsOk(); !ok {
+		return fmt.Errorf("cannot process Iterate() request, DB is in panicked state due to error: %w", err)
+	}
+
+	t.lock.RLock()
+	segments := make(map[*segment.Segment][]*types.ScopedKey, len(t.segments))
+	for index, seg := range t.segments {
+		segments[seg] = t.segmentKeys[index]
+	}
+	t.lock.RUnlock()
+
+	for seg, keys := range segments {
+		for _, key := range keys {
+			value, exists, err := t.read(seg, key.Key, key.Address)
+			if err != nil {
+				return fmt.Errorf("failed to read value for key %x: %w", key.Key, err)
+			}
+			if !exists {
+				// The owner deleted this segment.
+				break
+			}
+
+			err = fn(key.Key, value)
+			if err != nil {
+				return fmt.Errorf("iteration stopped at key %x: %w", key.Key, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (t *ReadOnlyTable) Exists(key []byte) (exists bool, err error) {
+	_, exists, err = t.keymap.Get(key)
+	if err != nil {
+		return false, fmt.Errorf("failed to get address: %w", err)
+	}
+	return exists, nil
+}
+
+func (t *ReadOnlyTable) Flush() error {
+	return fmt.Errorf("cannot flush table %s: %w", t.name, litt.ErrReadOnly)
+}
+
+func (t *ReadOnlyTable) Size() uint64 {
+	return t.size.Load()
+}
+
+func (t *ReadOnlyTable) KeyCount() uint64 {
+	return uint64(t.keyCount.Load())
+}
+
+func (t *ReadOnlyTable) SetTTL(_ time.Duration) error {
+	return fmt.Errorf("cannot set TTL for table %s: %w", t.name, litt.ErrReadOnly)
+}
+
+func (t *ReadOnlyTable) SetShardingFactor(_ uint32) error {
+	return fmt.Errorf("cannot set sharding factor for table %s: %w", t.name, litt.ErrReadOnly)
+}
+
+func (t *ReadOnlyTable) SetWriteCacheSize(_ uint64) error {
+	// read-only tables have no cache
+	return nil
+}
+
+func (t *ReadOnlyTable) SetReadCacheSize(_ uint64) error {
+	// read-only tables have no cache
+	return nil
+}
+
+func (t *ReadOnlyTable) Close() error {
+	if !t.closed.CompareAndSwap(false, true) {
+		return nil
+	}
+
+	close(t.stopChannel)
+	<-t.stoppedChannel
+
+	t.lock.Lock()
+	for _, seg := range t.segments {
+		seg.Release()
+	}
+	t.segments = make(map[uint32]*segment.Segment)
+	t.segmentKeys = make(map[uint32][]*types.ScopedKey)
+	t.lock.Unlock()
+
+	err := t.keymap.Stop()
+	if err != nil {
+		return fmt.Errorf("failed to stop keymap: %w", err)
+	}
+
+	if ok, err := t.errorMonitor.IsOk(); !ok {
+		return fmt.Errorf("DB is in panicked state due to error: %w", err)
+	}
+
+	return nil
+}
+
+func (t *ReadOnlyTable) Destroy() error {
+	return fmt.Errorf("cannot destroy table %s: %w", t.name, litt.ErrReadOnly)
+}
+
+// RunGC refreshes the set of loaded segments. The owner of the table is responsible for garbage collection, a
+// read-only table only needs to notice which segments the owner has sealed or deleted.
+func (t *ReadOnlyTable) RunGC() error {
+	if ok, err := t.errorMonitor.IsOk(); !ok {
+		return fmt.Errorf("cannot process RunGC() request, DB is in panicked state due to error: %w", err)
+	}
+
+	err := t.refresh()
+	if err != nil {
+		return fmt.Errorf("failed to refresh table %s: %w", t.name, err)
+	}
+	return nil
+}
+
+func (t *ReadOnlyTable) Snapshot(_ string) error {
+	return fmt.Errorf("cannot snapshot table %s: %w", t.name, litt.ErrReadOnly)
+}
+
+func (t *ReadOnlyTable) Backup(_ context.Context, _ litt.BackupTarget) error {
+	return fmt.Errorf("cannot back up table %s: %w", t.name, litt.ErrReadOnly)
+}
+
+func (t *ReadOnlyTable) AddRoot(_ string) error {
+	return fmt.Errorf("cannot add root to table %s: %w", t.name, litt.ErrReadOnly)
+}
+
+func (t *ReadOnlyTable) RemoveRoot(_ string) (func() error, error) {
+	return nil, fmt.Errorf("cannot remove root from table %s: %w", t.name, litt.ErrReadOnly)
+}
@@ -0,0 +1,340 @@
+package disktable
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"slices"
+
+	"github.com/Layr-Labs/eigenda/litt/disktable/keymap"
+	"github.com/Layr-Labs/eigenda/litt/disktable/segment"
+	"github.com/Layr-Labs/eigenda/litt/util"
+)
+
+// AddRoot adds a root directory to the table. If the mutable segment contains data, it is sealed so that the next
+// segment is able to use the new root.
+func (d *DiskTable) AddRoot(root string) error {
+	if ok, err := d.errorMonitor.IsOk(); !ok {
+		return fmt.Errorf("cannot process AddRoot() request, DB is in panicked state due to error: %w", err)
+	}
+
+	d.rootsLock.Lock()
+	defer d.rootsLock.Unlock()
+
+	if slices.Contains(d.roots, root) {
+		return nil
+	}
+
+	segmentDirectory := path.Join(root, SegmentDirectory)
+	err := util.EnsureDirectoryExists(segmentDirectory, d.fsync)
+	if err != nil {
+		return fmt.Errorf("failed to create segment directory %s: %w", segmentDirectory, err)
+	}
+
+	segmentDirectories := append(slices.Clone(d.segmentDirectories), segmentDirectory)
+	err = d.setSegmentDirectories(segmentDirectories)
+	if err != nil {
+		return err
+	}
+
+	d.roots = append(d.roots, root)
+	d.segmentDirectories = segmentDirectories
+	d.logger.Infof("table %s: added root %s", d.name, root)
+
+	return nil
+}
+
+// RemoveRoot stops placing new segments in the given root, and starts migrating data out of the root in the
+// background. If the root contains the table's metadata file or keymap, these are moved to one of the remaining roots
+// before any segment files are moved.
+func (d *DiskTable) RemoveRoot(root string) (func() error, error) {
+	if ok, err := d.errorMonitor.IsOk(); !ok {
+		return nil, fmt.Errorf("cannot process RemoveRoot() request, DB is in panicked state due to error: %w", err)
+	}
+
+	d.rootsLock.Lock()
+	defer d.rootsLock.Unlock()
+
+	rootIndex := slices.Index(d.roots, root)
+	if rootIndex == -1 {
+		return nil, fmt.Errorf("table %s does not use root %s", d.name, root)
+	}
+	if len(d.roots) == 1 {
+		return nil, fmt.Errorf("cannot remove root %s, it is the only root used by table %s", root, d.name)
+	}
+
+	segmentDirectory := path.Join(root, SegmentDirectory)
+	segmentDirectories := slices.DeleteFunc(slices.Clone(d.segmentDirectories), func(directory string) bool {
+		return directory == segmentDirectory
+	})
+
+	err := d.setSegmentDirectories(segmentDirectories)
+	if err != nil {
+		return nil, err
+	}
+
+	d.roots = slices.Delete(slices.Clone(d.roots), rootIndex, rootIndex+1)
+	d.segmentDirectories = segmentDirectories
+	d.logger.Infof("table %s: removed root %s, migrating data to remaining roots", d.name, root)
+
+	errorChan := make(chan error, 1)
+	d.migrationWaitGroup.Add(1)
+	go func() {
+		defer d.migrationWaitGroup.Done()
+		errorChan <- d.migrate(root)
+	}()
+
+	return func() error {
+		err, awaitErr := util.Await(d.errorMonitor, errorChan)
+		if awaitErr != nil {
+			return fmt.Errorf("failed to await migration: %w", awaitErr)
+		}
+		return err
+	}, nil
+}
+
+// setSegmentDirectories tells the control loop to create new segments in the given directories, and blocks until
+// the control loop has done so.
+func (d *DiskTable) setSegmentDirectories(segmentDirectories []string) error {
+	request := &controlLoopSetSegmentDirectoriesRequest{
+		segmentDirectories: segmentDirectories,
+		responseChan:       make(chan struct{}, 1),
+	}
+	err := d.controlLoop.enqueue(request)
+	if err != nil {
+		return fmt.Errorf("failed to send set segment directories request: %w", err)
+	}
+
+	_, err = util.Await(d.errorMonitor, request.responseChan)
+	if err != nil {
+		return fmt.Errorf("failed to await set segment directories request: %w", err)
+	}
+	return nil
+}
+
+// migrate moves the table's metadata file, keymap, and all segment files out of a root that has been removed from the
+// table. Once all files have been moved, the root's table directory is deleted (unless it contains other data, such as
+// snapshot files).
+//
+// Migrations are performed one at a time. A migration does not decide which segments to move until all earlier
+// migrations have finished, so segment files are never moved into a root that is concurrently being removed.
+func (d *DiskTable) migrate(root string) error {
+	d.migrationLock.Lock()
+	defer d.migrationLock.Unlock()
+
+	err := d.moveTableFilesOutOf(root)
+	if err != nil {
+		return err
+	}
+
+	segmentDirectory := path.Join(root, SegmentDirectory)
+
+	request := &controlLoopReserveSegmentsInDirectoryRequest{
+		directory:    segmentDirectory,
+		responseChan: make(chan []*segment.Segment, 1),
+	}
+	err = d.controlLoop.enqueue(request)
+	if err != nil {
+		return fmt.Errorf("failed to send reserve segments request: %w", err)
+	}
+	segments, err := util.Await(d.errorMonitor, request.responseChan)
+	if err != nil {
+		return fmt.Errorf("failed to await segments: %w", err)
+	}
+	defer func() {
+		for _, seg := range segments {
+			seg.Release()
+		}
+	}()
+
+	d.rootsLock.Lock()
+	destinations := slices.Clone(d.segmentDirectories)
+	d.rootsLock.Unlock()
+
+	d.logger.Infof("table %s: migrating %d segment(s) out of %s", d.name, len(segments), root)
+	d.metrics.ReportMigrationSegmentsRemaining(d.name, uint64(len(segments)))
+
+	for i, seg := range segments {
+		select {
+		case <-d.migrationStopChannel:
+			return fmt.Errorf("migration out of %s interrupted, %d segment(s) not moved", root, len(segments)-i)
+		default:
+		}
+
+		bytesMoved, err := seg.MoveFilesOutOf(segmentDirectory, destinations, d.fsync)
+		d.metrics.ReportMigrationBytesMoved(d.name, bytesMoved)
+		if err != nil {
+			d.logger.Errorf("table %s: failed to migrate segment %d out of %s: %v",
+				d.name, seg.SegmentIndex(), root, err)
+			return fmt.Errorf("failed to migrate segment %d: %w", seg.SegmentIndex(), err)
+		}
+		d.metrics.ReportMigrationSegmentsRemaining(d.name, uint64(len(segments)-i-1))
+	}
+
+	err = os.Remove(segmentDirectory)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		// Segments that were already scheduled for deletion when the migration started are not moved, and may
+		// leave files behind for a short time.
+		d.logger.Warnf("table %s: unable to remove %s after migration: %v", d.name, segmentDirectory, err)
+	}
+	err = os.Remove(root)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		// This is expected if the root contains snapshot files, which the DB never deletes.
+		d.logger.Warnf("table %s: unable to remove %s after migration: %v", d.name, root, err)
+	}
+
+	d.logger.Infof("table %s: finished migrating data out of %s", d.name, root)
+	return nil
+}
+
+// moveTableFilesOutOf moves the table's metadata file and keymap out of a root that has been removed from the table,
+// into the first remaining root.
+func (d *DiskTable) moveTableFilesOutOf(root string) error {
+	d.rootsLock.Lock()
+	destination := d.roots[0]
+	keymapPath := d.keymapPath
+	d.rootsLock.Unlock()
+
+	if d.metadata.directory() == root {
+		d.logger.Infof("table %s: moving metadata from %s to %s", d.name, root, destination)
+		err := d.metadata.moveTo(destination)
+		if err != nil {
+			return fmt.Errorf("failed to move metadata for table %s: %w", d.name, err)
+		}
+	}
+
+	if path.Dir(keymapPath) == root {
+		d.logger.Infof("table %s: moving keymap from %s to %s", d.name, root, destination)
+		err := d.moveKeymap(destination)
+		if err != nil {
+			return fmt.Errorf("failed to move keymap for table %s: %w", d.name, err)
+		}
+	}
+
+	return nil
+}
+
+// moveKeymap moves the keymap into the given table directory. Operations on the keymap wait until the move is
+// complete.
+//
+// The keymap is stopped and its files are copied into a staging directory, which is renamed into place once the
+// copy is complete. The old copy's initialized file is deleted before the rest of the old copy, so that a crash at
+// any point leaves behind exactly one initialized copy of the keymap, possibly along with incomplete copies that are
+// deleted the next time the table is loaded.
+func (d *DiskTable) moveKeymap(tableDirectory string) error {
+	d.rootsLock.Lock()
+	oldPath := d.keymapPath
+	keymapType := d.keymapTypeFile.Type()
+	d.rootsLock.Unlock()
+
+	newPath := path.Join(tableDirectory, keymap.KeymapDirectoryName)
+	stagingPath := path.Join(tableDirectory, keymap.KeymapStagingDirectoryName)
+
+	builder, ok := keymap.GetBuilder(keymapType)
+	if !ok {
+		return fmt.Errorf("unsupported keymap type: %v", keymapType)
+	}
+
+	// An in-memory keymap only keeps its type file and initialized file on disk, and doesn't need to be reopened.
+	inMemory := keymapType == keymap.MemKeymapType
+
+	return d.keymap.replace(func(oldKeymap keymap.Keymap) (keymap.Keymap, error) {
+		if !inMemory {
+			err := oldKeymap.Stop()
+			if err != nil {
+				err = fmt.Errorf("failed to stop keymap: %w", err)
+				d.errorMonitor.Panic(err)
+				return oldKeymap, err
+			}
+		}
+
+		copyErr := d.copyKeymap(oldPath, stagingPath, newPath)
+		if copyErr != nil {
+			if inMemory {
+				return oldKeymap, copyErr
+			}
+			// The keymap is still in its original location, so it can be reopened and used as before.
+			reopenedKeymap, _, err := builder(
+				d.logger, path.Join(oldPath, keymap.KeymapDataDirectoryName), d.doubleWriteProtection)
+			if err != nil {
+				err = fmt.Errorf("failed to reopen keymap after failing to move it (%v): %w", copyErr, err)
+				d.errorMonitor.Panic(err)
+				return oldKeymap, err
+			}
+			return reopenedKeymap, copyErr
+		}
+
+		err := os.RemoveAll(oldPath)
+		if err != nil {
+			// The old copy is no longer initialized, and will be deleted the next time the table is loaded.
+			d.logger.Warnf("table %s: unable to remove %s after moving keymap: %v", d.name, oldPath, err)
+		}
+
+		keymapTypeFile, err := keymap.LoadKeymapTypeFile(newPath)
+		if err != nil {
+			err = fmt.Errorf("failed to load keymap type file after moving keymap: %w", err)
+			d.errorMonitor.Panic(err)
+			return oldKeymap, err
+		}
+
+		newKeymap := oldKeymap
+		if !inMemory {
+			var requiresReload bool
+			newKeymap, requiresReload, err = builder(
+				d.logger, path.Join(newPath, keymap.KeymapDataDirectoryName), d.doubleWriteProtection)
+			if err == nil && requiresReload {
+				err = fmt.Errorf("keymap data not found in %s", newPath)
+			}
+			if err != nil {
+				err = fmt.Errorf("failed to open keymap after moving it: %w", err)
+				d.errorMonitor.Panic(err)
+				return oldKeymap, err
+			}
+		}
+
+		d.rootsLock.Lock()
+		d.keymapPath = newPath
+		d.keymapTypeFile = keymapTypeFile
+		d.rootsLock.Unlock()
+
+		return newKeymap, nil
+	})
+}
+
+// copyKeymap copies the keymap directory at oldPath to newPath, via stagingPath, and then deletes the old copy's
+// initialized file. If this returns an error, the keymap at oldPath is left intact and no copy exists at newPath.
+func (d *DiskTable) copyKeymap(oldPath string, stagingPath string, newPath string) error {
+	// A staging directory might have been left behind by an earlier failed move.
+	err := os.RemoveAll(stagingPath)
+	if err != nil {
+		return fmt.Errorf("failed to remove keymap staging directory %s: %w", stagingPath, err)
+	}
+	err = util.ErrIfExists(newPath)
+	if err != nil {
+		return fmt.Errorf("keymap already exists at destination: %w", err)
+	}
+
+	err = util.RecursiveMove(oldPath, stagingPath, true, d.fsync)
+	if err != nil {
+		_ = os.RemoveAll(stagingPath)
+		return fmt.Errorf("failed to copy keymap from %s to %s: %w", oldPath, stagingPath, err)
+	}
+	err = util.AtomicRename(stagingPath, newPath, d.fsync)
+	if err != nil {
+		_ = os.RemoveAll(stagingPath)
+		return fmt.Errorf("failed to rename %s to %s: %w", stagingPath, newPath, err)
+	}
+
+	oldInitializedFile := path.Join(oldPath, keymap.KeymapInitializedFileName)
+	err = os.Remove(oldInitializedFile)
+	if err == nil && d.fsync {
+		err = util.SyncParentPath(oldInitializedFile)
+	}
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		_ = os.RemoveAll(newPath)
+		return fmt.Errorf("failed to remove %s: %w", oldInitializedFile, err)
+	}
+
+	return nil
+}
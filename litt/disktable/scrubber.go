@@ -0,0 +1,205 @@
+package disktable
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/litt/disktable/keymap"
+	"github.com/Layr-Labs/eigenda/litt/disktable/segment"
+	"github.com/Layr-Labs/eigenda/litt/metrics"
+	"github.com/Layr-Labs/eigenda/litt/types"
+	"github.com/Layr-Labs/eigenda/litt/util"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// scrubber runs a goroutine that verifies the values in sealed segments against the checksums recorded when the
+// values were written. The scrubber is managed by the control loop: the control loop periodically reserves the
+// sealed segments and hands them to the scrubber, and stops the scrubber when the table is shut down.
+type scrubber struct {
+	logger logging.Logger
+
+	// Responsible for handling fatal DB errors.
+	errorMonitor *util.ErrorMonitor
+
+	// The keymap for the table. Corrupted keys are removed from the keymap if quarantine is enabled.
+	keymap keymap.Keymap
+
+	// metrics encapsulates metrics for the DB.
+	metrics *metrics.LittDBMetrics
+
+	// provides the current time
+	clock func() time.Time
+
+	// the name of the table
+	name string
+
+	// If true, corrupted keys are removed from the keymap. If false, finding a corrupted key causes the DB to panic.
+	quarantine bool
+
+	// If not nil, called with the keys that were quarantined, after they are removed from the keymap. Used to evict
+	// quarantined keys from any caches that sit in front of the table.
+	quarantineCallback func(keys [][]byte)
+
+	// segmentChannel is used by the control loop to send reserved segments to the scrubber. The scrubber is
+	// responsible for releasing these reservations.
+	segmentChannel chan []*segment.Segment
+
+	// stopChannel is closed when the scrubber should stop.
+	stopChannel chan struct{}
+
+	// stoppedChannel is closed when the scrubber goroutine has exited.
+	stoppedChannel chan struct{}
+
+	// busy is true from the time the control loop schedules a scrub pass until that pass is complete.
+	busy atomic.Bool
+}
+
+// newScrubber creates a new scrubber. The scrubber goroutine is not started until run() is called.
+func newScrubber(
+	logger logging.Logger,
+	errorMonitor *util.ErrorMonitor,
+	keymap keymap.Keymap,
+	metrics *metrics.LittDBMetrics,
+	clock func() time.Time,
+	name string,
+	quarantine bool,
+	quarantineCallback func(keys [][]byte)) *scrubber {
+
+	return &scrubber{
+		logger:             logger,
+		errorMonitor:       errorMonitor,
+		keymap:             keymap,
+		metrics:            metrics,
+		clock:              clock,
+		name:               name,
+		quarantine:         quarantine,
+		quarantineCallback: quarantineCallback,
+		segmentChannel:     make(chan []*segment.Segment, 1),
+		stopChannel:        make(chan struct{}),
+		stoppedChannel:     make(chan struct{}),
+	}
+}
+
+// schedule attempts to start a scrub pass over the given segments. The segments must be reserved. Returns false
+// if a scrub pass is already in progress, in which case the caller retains ownership of the reservations.
+// Should only be called from the control loop.
+func (s *scrubber) schedule(segments []*segment.Segment) bool {
+	if !s.busy.CompareAndSwap(false, true) {
+		return false
+	}
+	// Since busy was false, the channel is guaranteed to be empty.
+	s.segmentChannel <- segments
+	return true
+}
+
+// stop stops the scrubber and blocks until the scrubber goroutine has exited. If a scrub pass is in progress, it
+// is abandoned. Should only be called from the control loop.
+func (s *scrubber) stop() {
+	close(s.stopChannel)
+	<-s.stoppedChannel
+}
+
+// run is the main loop of the scrubber goroutine.
+func (s *scrubber) run() {
+	defer close(s.stoppedChannel)
+
+	for {
+		select {
+		case <-s.errorMonitor.ImmediateShutdownRequired():
+			s.logger.Infof("context done, shutting down disk table scrubber")
+			s.releaseUnscrubbedSegments()
+			return
+		case <-s.stopChannel:
+			s.releaseUnscrubbedSegments()
+			return
+		case segments := <-s.segmentChannel:
+			s.scrub(segments)
+			s.busy.Store(false)
+		}
+	}
+}
+
+// releaseUnscrubbedSegments releases the reservations on any segments that were scheduled but never scrubbed.
+func (s *scrubber) releaseUnscrubbedSegments() {
+	select {
+	case segments := <-s.segmentChannel:
+		for _, seg := range segments {
+			seg.Release()
+		}
+	default:
+	}
+}
+
+// scrub verifies all values in the given segments, then releases the segments.
+func (s *scrubber) scrub(segments []*segment.Segment) {
+	defer func() {
+		for _, seg := range segments {
+			seg.Release()
+		}
+	}()
+
+	start := s.clock()
+	for _, seg := range segments {
+		ok := s.scrubSegment(seg)
+		if !ok {
+			return
+		}
+	}
+
+	s.metrics.ReportScrubLatency(s.name, s.clock().Sub(start))
+}
+
+// scrubSegment verifies all values in a single segment. Returns false if scrubbing should be aborted.
+func (s *scrubber) scrubSegment(seg *segment.Segment) bool {
+	keys, err := seg.GetKeys()
+	if err != nil {
+		s.errorMonitor.Panic(fmt.Errorf("failed to get keys for segment %d: %w", seg.SegmentIndex(), err))
+		return false
+	}
+
+	corruptedKeys := make([]*types.ScopedKey, 0)
+	for _, key := range keys {
+		select {
+		case <-s.stopChannel:
+			return false
+		default:
+		}
+
+		err = seg.VerifyValue(key)
+		if err != nil {
+			s.logger.Errorf("table %s: corrupted value for key %x in segment %d: %v",
+				s.name, key.Key, seg.SegmentIndex(), err)
+			s.metrics.ReportCorruptedKey(s.name)
+			corruptedKeys = append(corruptedKeys, key)
+		}
+	}
+	s.metrics.ReportScrubbedKeys(s.name, uint64(len(keys)))
+
+	if len(corruptedKeys) == 0 {
+		return true
+	}
+
+	if !s.quarantine {
+		s.errorMonitor.Panic(fmt.Errorf("table %s: found %d corrupted value(s) in segment %d",
+			s.name, len(corruptedKeys), seg.SegmentIndex()))
+		return false
+	}
+
+	err = s.keymap.Delete(corruptedKeys)
+	if err != nil {
+		s.errorMonitor.Panic(fmt.Errorf("failed to quarantine corrupted keys: %w", err))
+		return false
+	}
+	if s.quarantineCallback != nil {
+		quarantinedKeys := make([][]byte, 0, len(corruptedKeys))
+		for _, key := range corruptedKeys {
+			quarantinedKeys = append(quarantinedKeys, key.Key)
+		}
+		s.quarantineCallback(quarantinedKeys)
+	}
+	s.logger.Warnf("table %s: quarantined %d corrupted value(s) in segment %d",
+		s.name, len(corruptedKeys), seg.SegmentIndex())
+
+	return true
+}
@@ -1,6 +1,8 @@
 package segment
 
 import (
+	"encoding/binary"
+	"math"
 	"testing"
 
 	"github.com/Layr-Labs/eigenda/common/testutils/random"
@@ -13,9 +15,37 @@ func TestAddress(t *testing.T) {
 	rand := random.NewTestRandom()
 
 	index := rand.Uint32()
-	offset := rand.Uint32()
+	offset := rand.Uint64()
 	address := types.NewAddress(index, offset)
 
 	require.Equal(t, index, address.Index())
 	require.Equal(t, offset, address.Offset())
 }
+
+func TestAddressSerialization(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+
+	// Addresses with 32-bit offsets use the legacy 8 byte format.
+	address := types.NewAddress(rand.Uint32(), uint64(rand.Uint32()))
+	serialized := address.Serialize()
+	require.Len(t, serialized, 8)
+	deserialized, err := types.DeserializeAddress(serialized)
+	require.NoError(t, err)
+	require.Equal(t, address, deserialized)
+
+	// The legacy format packed the index and offset into a single big endian uint64.
+	legacyAddress := uint64(address.Index())<<32 | address.Offset()
+	require.Equal(t, legacyAddress, binary.BigEndian.Uint64(serialized))
+
+	// Addresses with 64-bit offsets use a 12 byte format.
+	address = types.NewAddress(rand.Uint32(), math.MaxUint32+1+rand.Uint64Range(0, math.MaxUint32))
+	serialized = address.Serialize()
+	require.Len(t, serialized, 12)
+	deserialized, err = types.DeserializeAddress(serialized)
+	require.NoError(t, err)
+	require.Equal(t, address, deserialized)
+
+	_, err = types.DeserializeAddress(rand.Bytes(10))
+	require.Error(t, err)
+}
@@ -0,0 +1,19 @@
+package segment
+
+import (
+	"errors"
+	"hash/crc32"
+)
+
+// ErrChecksumMismatch is returned when a value read from disk does not match the checksum that was computed when
+// the value was written. Callers can detect this error with errors.Is().
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
+// checksumTable is the CRC32 table used to compute value checksums. The Castagnoli polynomial is used since it has
+// hardware support on most modern CPUs.
+var checksumTable = crc32.MakeTable(crc32.Castagnoli)
+
+// computeChecksum computes the checksum of a value.
+func computeChecksum(value []byte) uint32 {
+	return crc32.Checksum(value, checksumTable)
+}
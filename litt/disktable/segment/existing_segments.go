@@ -0,0 +1,26 @@
+package segment
+
+import "iter"
+
+// ExistingSegments iterates over the entries of a map keyed by segment index, for the indices in the range
+// [lowestSegmentIndex, highestSegmentIndex], in index order. Indices that are not in the map are skipped. The indices
+// of a table's segments contain such gaps when segments are deleted while an earlier segment with an extended expiry
+// is retained.
+func ExistingSegments[T any](
+	segments map[uint32]T,
+	lowestSegmentIndex uint32,
+	highestSegmentIndex uint32,
+) iter.Seq2[uint32, T] {
+
+	return func(yield func(uint32, T) bool) {
+		for index := uint64(lowestSegmentIndex); index <= uint64(highestSegmentIndex); index++ {
+			value, ok := segments[uint32(index)]
+			if !ok {
+				continue
+			}
+			if !yield(uint32(index), value) {
+				return
+			}
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"encoding/binary"
 	"fmt"
+	"math"
 	"os"
 	"path"
 	"strconv"
@@ -49,11 +50,12 @@ type keyFile struct {
 	swap bool
 }
 
-// newKeyFile creates a new key file.
+// createKeyFile creates a new key file.
 func createKeyFile(
 	logger logging.Logger,
 	index uint32,
 	parentDirectory string,
+	segmentVersion SegmentVersion,
 	swap bool,
 ) (*keyFile, error) {
 
@@ -61,7 +63,7 @@ func createKeyFile(
 		logger:          logger,
 		index:           index,
 		parentDirectory: parentDirectory,
-		segmentVersion:  ValueSizeSegmentVersion,
+		segmentVersion:  segmentVersion,
 		swap:            swap,
 	}
 
@@ -103,7 +105,7 @@ func loadKeyFile(
 		return nil, fmt.Errorf("failed to find key file: %w", err)
 	}
 	if keysPath == "" {
-		return nil, fmt.Errorf("failed to find key file %s", keyFileName)
+		return nil, fmt.Errorf("failed to find key file %s: %w", keyFileName, os.ErrNotExist)
 	}
 	parentDirectory := path.Dir(keysPath)
 
@@ -126,7 +128,7 @@ func loadKeyFile(
 	}
 
 	if !exists {
-		return nil, fmt.Errorf("key file %s does not exist", filePath)
+		return nil, fmt.Errorf("key file %s does not exist: %w", filePath, os.ErrNotExist)
 	}
 
 	return keys, nil
@@ -176,8 +178,12 @@ func (k *keyFile) write(scopedKey *types.ScopedKey) error {
 		return fmt.Errorf("key file is sealed")
 	}
 
+	if k.segmentVersion < LargeValueSegmentVersion {
+		return k.writeLegacy(scopedKey)
+	}
+
 	// Write the length of the key.
-	err := binary.Write(k.writer, binary.BigEndian, uint32(len(scopedKey.Key)))
+	err := binary.Write(k.writer, binary.BigEndian, uint64(len(scopedKey.Key)))
 	if err != nil {
 		return fmt.Errorf("failed to write key length to key file: %v", err)
 	}
@@ -189,7 +195,11 @@ func (k *keyFile) write(scopedKey *types.ScopedKey) error {
 	}
 
 	// Write the address.
-	err = binary.Write(k.writer, binary.BigEndian, scopedKey.Address)
+	err = binary.Write(k.writer, binary.BigEndian, scopedKey.Address.Index())
+	if err != nil {
+		return fmt.Errorf("failed to write address to key file: %v", err)
+	}
+	err = binary.Write(k.writer, binary.BigEndian, scopedKey.Address.Offset())
 	if err != nil {
 		return fmt.Errorf("failed to write address to key file: %v", err)
 	}
@@ -200,15 +210,95 @@ func (k *keyFile) write(scopedKey *types.ScopedKey) error {
 		return fmt.Errorf("failed to write value size to key file: %v", err)
 	}
 
-	k.size += uint64(
-		4 /* uint32 size of key */ +
-			len(scopedKey.Key) +
-			8 /* uint64 address */ +
-			4 /* uint32 size of value */)
+	// Write the checksum of the value.
+	err = binary.Write(k.writer, binary.BigEndian, scopedKey.Checksum)
+	if err != nil {
+		return fmt.Errorf("failed to write checksum to key file: %v", err)
+	}
+
+	k.size += k.entrySize(scopedKey)
 
 	return nil
 }
 
+// writeLegacy writes a key to a key file using a segment version that predates LargeValueSegmentVersion.
+func (k *keyFile) writeLegacy(scopedKey *types.ScopedKey) error {
+	if uint64(len(scopedKey.Key)) > math.MaxUint32 {
+		return fmt.Errorf("key is too large for segment version %d: %d bytes", k.segmentVersion, len(scopedKey.Key))
+	}
+	if scopedKey.Address.Offset() > math.MaxUint32 {
+		return fmt.Errorf("address %s is too large for segment version %d", scopedKey.Address, k.segmentVersion)
+	}
+	if scopedKey.ValueSize > math.MaxUint32 {
+		return fmt.Errorf("value is too large for segment version %d: %d bytes",
+			k.segmentVersion, scopedKey.ValueSize)
+	}
+
+	// Write the length of the key.
+	err := binary.Write(k.writer, binary.BigEndian, uint32(len(scopedKey.Key)))
+	if err != nil {
+		return fmt.Errorf("failed to write key length to key file: %v", err)
+	}
+
+	// Write the key itself.
+	_, err = k.writer.Write(scopedKey.Key)
+	if err != nil {
+		return fmt.Errorf("failed to write key to key file: %v", err)
+	}
+
+	// Write the address.
+	err = binary.Write(k.writer, binary.BigEndian, scopedKey.Address.Index())
+	if err != nil {
+		return fmt.Errorf("failed to write address to key file: %v", err)
+	}
+	err = binary.Write(k.writer, binary.BigEndian, uint32(scopedKey.Address.Offset()))
+	if err != nil {
+		return fmt.Errorf("failed to write address to key file: %v", err)
+	}
+
+	// Write the size of the value.
+	if k.segmentVersion >= ValueSizeSegmentVersion {
+		err = binary.Write(k.writer, binary.BigEndian, uint32(scopedKey.ValueSize))
+		if err != nil {
+			return fmt.Errorf("failed to write value size to key file: %v", err)
+		}
+	}
+
+	// Write the checksum of the value.
+	if k.segmentVersion >= ChecksumSegmentVersion {
+		err = binary.Write(k.writer, binary.BigEndian, scopedKey.Checksum)
+		if err != nil {
+			return fmt.Errorf("failed to write checksum to key file: %v", err)
+		}
+	}
+
+	k.size += k.entrySize(scopedKey)
+
+	return nil
+}
+
+// entrySize returns the number of bytes required to store a key in a key file with this file's segment version.
+func (k *keyFile) entrySize(scopedKey *types.ScopedKey) uint64 {
+	return uint64(len(scopedKey.Key)) + k.entryOverhead()
+}
+
+// entryOverhead returns the number of bytes in each key file entry other than the key itself.
+func (k *keyFile) entryOverhead() uint64 {
+	if k.segmentVersion >= LargeValueSegmentVersion {
+		return 8 /* uint64 size of key */ + 12 /* uint32 index + uint64 offset */ +
+			8 /* uint64 size of value */ + 4 /* uint32 checksum */
+	}
+
+	size := uint64(4 /* uint32 size of key */ + 8 /* uint32 index + uint32 offset */)
+	if k.segmentVersion >= ValueSizeSegmentVersion {
+		size += 4 /* uint32 size of value */
+	}
+	if k.segmentVersion >= ChecksumSegmentVersion {
+		size += 4 /* uint32 checksum */
+	}
+	return size
+}
+
 // getKeyFileIndex returns the index of the key file from the file name. Key file names have the form "X.keys",
 // where X is the segment index.
 func getKeyFileIndex(fileName string) (uint32, error) {
@@ -254,7 +344,13 @@ func (k *keyFile) readKeys() ([]*types.ScopedKey, error) {
 	if k.writer != nil {
 		return nil, fmt.Errorf("key file is not sealed")
 	}
+	return k.readFlushedKeys(math.MaxUint64)
+}
 
+// readFlushedKeys reads the keys in the first flushedSize bytes of the key file. Unlike readKeys, this method may
+// be called before the key file is sealed, as long as flushedSize bytes have been flushed to disk. Data beyond
+// flushedSize (which may be partially written) is ignored.
+func (k *keyFile) readFlushedKeys(flushedSize uint64) ([]*types.ScopedKey, error) {
 	file, err := os.Open(k.path())
 	if err != nil {
 		return nil, fmt.Errorf("failed to open key file: %v", err)
@@ -271,48 +367,75 @@ func (k *keyFile) readKeys() ([]*types.ScopedKey, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to read key file: %v", err)
 	}
+	if uint64(len(keyBytes)) > flushedSize {
+		keyBytes = keyBytes[:flushedSize]
+	}
 	keys := make([]*types.ScopedKey, 0)
 
+	// The number of bytes used to encode the length of the key.
+	keyLengthSize := 4
+	if k.segmentVersion >= LargeValueSegmentVersion {
+		keyLengthSize = 8
+	}
+	// The number of bytes following the key.
+	trailerSize := int(k.entryOverhead()) - keyLengthSize
+
 	index := 0
 	for {
-		// We need at least 4 bytes to read the length of the key.
-		if index+4 > len(keyBytes) { //nolint:staticcheck // QF1006
-			// There are fewer than 4 bytes left in the file.
+		// We need enough bytes to read the length of the key.
+		if index+keyLengthSize > len(keyBytes) {
+			// There are too few bytes left in the file.
 			break
 		}
-		keyLength := int(binary.BigEndian.Uint32(keyBytes[index : index+4]))
+		var keyLength uint64
+		if k.segmentVersion >= LargeValueSegmentVersion {
+			keyLength = binary.BigEndian.Uint64(keyBytes[index : index+8])
+		} else {
+			keyLength = uint64(binary.BigEndian.Uint32(keyBytes[index : index+4]))
+		}
+		index += keyLengthSize
+
+		// We need to read the key, as well as the address, value size, and checksum (if present).
+		if keyLength > uint64(len(keyBytes)-index) || index+int(keyLength)+trailerSize > len(keyBytes) {
+			// There are insufficient bytes left in the file to read the key and the data that follows it.
+			break
+		}
+
+		key := keyBytes[index : index+int(keyLength)]
+		index += int(keyLength)
+
+		segmentIndex := binary.BigEndian.Uint32(keyBytes[index : index+4])
 		index += 4
 
-		if k.segmentVersion < ValueSizeSegmentVersion {
-			// We need to read the key, as well as the 8 byte address.
-			if index+keyLength+8 > len(keyBytes) {
-				// There are insufficient bytes left in the file to read the key and address.
-				break
-			}
+		var offset uint64
+		if k.segmentVersion >= LargeValueSegmentVersion {
+			offset = binary.BigEndian.Uint64(keyBytes[index : index+8])
+			index += 8
 		} else {
-			// We need to read the key, as well as the 8 byte address and 4 byte value size.
-			if index+keyLength+12 > len(keyBytes) {
-				// There are insufficient bytes left in the file to read the key, address, and value size.
-				break
-			}
+			offset = uint64(binary.BigEndian.Uint32(keyBytes[index : index+4]))
+			index += 4
 		}
 
-		key := keyBytes[index : index+keyLength]
-		index += keyLength
-
-		address := types.Address(binary.BigEndian.Uint64(keyBytes[index : index+8]))
-		index += 8
+		var valueSize uint64
+		if k.segmentVersion >= LargeValueSegmentVersion {
+			valueSize = binary.BigEndian.Uint64(keyBytes[index : index+8])
+			index += 8
+		} else if k.segmentVersion >= ValueSizeSegmentVersion {
+			valueSize = uint64(binary.BigEndian.Uint32(keyBytes[index : index+4]))
+			index += 4
+		}
 
-		var valueSize uint32
-		if k.segmentVersion >= ValueSizeSegmentVersion {
-			valueSize = binary.BigEndian.Uint32(keyBytes[index : index+4])
+		var checksum uint32
+		if k.segmentVersion >= ChecksumSegmentVersion {
+			checksum = binary.BigEndian.Uint32(keyBytes[index : index+4])
 			index += 4
 		}
 
 		keys = append(keys, &types.ScopedKey{
 			Key:       key,
-			Address:   address,
+			Address:   types.NewAddress(segmentIndex, offset),
 			ValueSize: valueSize,
+			Checksum:  checksum,
 		})
 	}
 
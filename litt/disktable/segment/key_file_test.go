@@ -1,6 +1,7 @@
 package segment
 
 import (
+	"math"
 	"os"
 	"testing"
 
@@ -24,12 +25,13 @@ func TestReadWriteKeys(t *testing.T) {
 	keys := make([]*types.ScopedKey, keyCount)
 	for i := 0; i < int(keyCount); i++ {
 		key := rand.VariableBytes(1, 100)
-		address := types.Address(rand.Uint64())
-		valueSize := rand.Uint32()
-		keys[i] = &types.ScopedKey{Key: key, Address: address, ValueSize: valueSize}
+		address := types.NewAddress(rand.Uint32(), rand.Uint64())
+		valueSize := rand.Uint64()
+		checksum := rand.Uint32()
+		keys[i] = &types.ScopedKey{Key: key, Address: address, ValueSize: valueSize, Checksum: checksum}
 	}
 
-	file, err := createKeyFile(logger, index, directory, false)
+	file, err := createKeyFile(logger, index, directory, LatestSegmentVersion, false)
 	require.NoError(t, err)
 
 	for _, key := range keys {
@@ -60,7 +62,7 @@ func TestReadWriteKeys(t *testing.T) {
 	}
 
 	// Create a new in-memory instance from the on-disk file and verify that it behaves the same.
-	file2, err := loadKeyFile(logger, index, []string{directory}, ValueSizeSegmentVersion)
+	file2, err := loadKeyFile(logger, index, []string{directory}, LatestSegmentVersion)
 	require.NoError(t, err)
 	require.Equal(t, file.Size(), file2.Size())
 
@@ -82,6 +84,58 @@ func TestReadWriteKeys(t *testing.T) {
 	require.True(t, os.IsNotExist(err))
 }
 
+func TestReadWriteLegacyKeys(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+	logger, err := common.NewLogger(common.DefaultTextLoggerConfig())
+	require.NoError(t, err)
+	directory := t.TempDir()
+
+	index := rand.Uint32()
+
+	keys := make([]*types.ScopedKey, rand.Int32Range(100, 200))
+	for i := range keys {
+		keys[i] = &types.ScopedKey{
+			Key:       rand.VariableBytes(1, 100),
+			Address:   types.NewAddress(index, uint64(rand.Uint32())),
+			ValueSize: uint64(rand.Uint32()),
+			Checksum:  rand.Uint32(),
+		}
+	}
+
+	file, err := createKeyFile(logger, index, directory, ExpirySegmentVersion, false)
+	require.NoError(t, err)
+	for _, key := range keys {
+		err = file.write(key)
+		require.NoError(t, err)
+	}
+
+	// Values and offsets larger than 32 bits can't be written using a legacy segment version.
+	err = file.write(&types.ScopedKey{
+		Key:       rand.VariableBytes(1, 100),
+		Address:   types.NewAddress(index, math.MaxUint32+1),
+		ValueSize: 1,
+	})
+	require.Error(t, err)
+	err = file.write(&types.ScopedKey{
+		Key:       rand.VariableBytes(1, 100),
+		Address:   types.NewAddress(index, 0),
+		ValueSize: math.MaxUint32 + 1,
+	})
+	require.Error(t, err)
+
+	err = file.seal()
+	require.NoError(t, err)
+
+	stat, err := os.Stat(file.path())
+	require.NoError(t, err)
+	require.Equal(t, uint64(stat.Size()), file.Size())
+
+	readKeys, err := file.readKeys()
+	require.NoError(t, err)
+	require.Equal(t, keys, readKeys)
+}
+
 func TestReadingTruncatedKeyFile(t *testing.T) {
 	t.Parallel()
 	rand := random.NewTestRandom()
@@ -95,12 +149,13 @@ func TestReadingTruncatedKeyFile(t *testing.T) {
 	keys := make([]*types.ScopedKey, keyCount)
 	for i := 0; i < int(keyCount); i++ {
 		key := rand.VariableBytes(1, 100)
-		address := types.Address(rand.Uint64())
-		valueSize := rand.Uint32()
-		keys[i] = &types.ScopedKey{Key: key, Address: address, ValueSize: valueSize}
+		address := types.NewAddress(rand.Uint32(), rand.Uint64())
+		valueSize := rand.Uint64()
+		checksum := rand.Uint32()
+		keys[i] = &types.ScopedKey{Key: key, Address: address, ValueSize: valueSize, Checksum: checksum}
 	}
 
-	file, err := createKeyFile(logger, index, directory, false)
+	file, err := createKeyFile(logger, index, directory, LatestSegmentVersion, false)
 	require.NoError(t, err)
 
 	for _, key := range keys {
@@ -174,12 +229,13 @@ func TestSwappingKeyFile(t *testing.T) {
 	keys := make([]*types.ScopedKey, keyCount)
 	for i := 0; i < int(keyCount); i++ {
 		key := rand.VariableBytes(1, 100)
-		address := types.Address(rand.Uint64())
-		valueSize := rand.Uint32()
-		keys[i] = &types.ScopedKey{Key: key, Address: address, ValueSize: valueSize}
+		address := types.NewAddress(rand.Uint32(), rand.Uint64())
+		valueSize := rand.Uint64()
+		checksum := rand.Uint32()
+		keys[i] = &types.ScopedKey{Key: key, Address: address, ValueSize: valueSize, Checksum: checksum}
 	}
 
-	file, err := createKeyFile(logger, index, directory, false)
+	file, err := createKeyFile(logger, index, directory, LatestSegmentVersion, false)
 	require.NoError(t, err)
 
 	for _, key := range keys {
@@ -210,7 +266,7 @@ func TestSwappingKeyFile(t *testing.T) {
 	}
 
 	// Create a new in-memory instance from the on-disk file and verify that it behaves the same.
-	file2, err := loadKeyFile(logger, index, []string{directory}, ValueSizeSegmentVersion)
+	file2, err := loadKeyFile(logger, index, []string{directory}, LatestSegmentVersion)
 	require.NoError(t, err)
 	require.Equal(t, file.Size(), file2.Size())
 
@@ -222,7 +278,7 @@ func TestSwappingKeyFile(t *testing.T) {
 
 	// Create a new version of the key file that only contains the keys at even indices. The intention is to replace
 	// the on-disk file with this new version.
-	swapFile, err := createKeyFile(logger, index, directory, true)
+	swapFile, err := createKeyFile(logger, index, directory, LatestSegmentVersion, true)
 	require.NoError(t, err)
 	for i := 0; i < int(keyCount); i += 2 {
 		err := swapFile.write(keys[i])
@@ -260,7 +316,7 @@ func TestSwappingKeyFile(t *testing.T) {
 	require.Equal(t, actualSize, reportedSize)
 
 	// Verify the contents of the new file. Reload it from disk just to ensure that we aren't "cheating" somehow.
-	file2, err = loadKeyFile(logger, index, []string{directory}, ValueSizeSegmentVersion)
+	file2, err = loadKeyFile(logger, index, []string{directory}, LatestSegmentVersion)
 	require.NoError(t, err)
 	readKeys, err = file2.readKeys()
 	require.NoError(t, err)
@@ -49,6 +49,18 @@ const (
 	// - 4 bytes for keyCount
 	// - and 1 byte for sealed.
 	V2MetadataSize = 37
+
+	// V4MetadataSize is the size of the metadata file at version 4 (aka ExpirySegmentVersion). Version 3 uses
+	// the same metadata format as version 2, and version 5 uses the same metadata format as version 4.
+	// This is a constant, so it's convenient to have it here.
+	// - 4 bytes for version
+	// - 4 bytes for the sharding factor
+	// - 16 bytes for salt
+	// - 8 bytes for lastValueTimestamp
+	// - 4 bytes for keyCount
+	// - 8 bytes for maxExpiry
+	// - and 1 byte for sealed.
+	V4MetadataSize = 45
 )
 
 // metadataFile contains metadata about a segment. This file contains metadata about the data segment, such as
@@ -83,6 +95,12 @@ type metadataFile struct {
 	// This value is encoded in the file.
 	keyCount uint32
 
+	// The latest expiry of any value in the segment that was written with an explicit expiry, in nanoseconds since
+	// the epoch. Zero if no such value has been written to the segment. Unlike lastValueTimestamp, this value is
+	// written to disk as soon as it changes (and not just when the segment is sealed), so that it is not lost if
+	// the process crashes before the segment is sealed. This value is encoded in the file.
+	maxExpiry uint64
+
 	// If true, the segment is sealed and no more data can be written to it. If false, then data can still be written
 	// to this segment. This value is encoded in the file.
 	sealed bool
@@ -131,7 +149,7 @@ func loadMetadataFile(index uint32, parentDirectories []string, fsync bool) (*me
 		return nil, fmt.Errorf("failed to find metadata file: %w", err)
 	}
 	if metadataPath == "" {
-		return nil, fmt.Errorf("failed to find metadata file %s", metadataFileName)
+		return nil, fmt.Errorf("failed to find metadata file %s: %w", metadataFileName, os.ErrNotExist)
 	}
 	parentDirectory := path.Dir(metadataPath)
 
@@ -143,7 +161,7 @@ func loadMetadataFile(index uint32, parentDirectories []string, fsync bool) (*me
 
 	data, err := os.ReadFile(metadataPath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read metadata file %s: %v", metadataPath, err)
+		return nil, fmt.Errorf("failed to read metadata file %s: %w", metadataPath, err)
 	}
 	err = file.deserialize(data)
 	if err != nil {
@@ -172,8 +190,10 @@ func (m *metadataFile) Size() uint64 {
 		return V0MetadataSize
 	case SipHashSegmentVersion:
 		return V1MetadataSize
-	default:
+	case ValueSizeSegmentVersion, ChecksumSegmentVersion:
 		return V2MetadataSize
+	default:
+		return V4MetadataSize
 	}
 }
 
@@ -250,15 +270,46 @@ func (m *metadataFile) serializeV1Legacy() []byte {
 	return data
 }
 
+func (m *metadataFile) serializeV2Legacy() []byte {
+	data := make([]byte, V2MetadataSize)
+
+	// Write the version
+	binary.BigEndian.PutUint32(data[0:4], uint32(m.segmentVersion))
+
+	// Write the sharding factor
+	binary.BigEndian.PutUint32(data[4:8], m.shardingFactor)
+
+	// Write the salt
+	copy(data[8:24], m.salt[:])
+
+	// Write the lastValueTimestamp
+	binary.BigEndian.PutUint64(data[24:32], m.lastValueTimestamp)
+
+	// Write the key count
+	binary.BigEndian.PutUint32(data[32:36], m.keyCount)
+
+	// Write the sealed flag
+	if m.sealed {
+		data[36] = 1
+	} else {
+		data[36] = 0
+	}
+
+	return data
+}
+
 // serialize serializes the metadata file to a byte array.
 func (m *metadataFile) serialize() []byte {
-	if m.segmentVersion == OldHashFunctionSegmentVersion {
+	switch m.segmentVersion {
+	case OldHashFunctionSegmentVersion:
 		return m.serializeV0Legacy()
-	} else if m.segmentVersion == SipHashSegmentVersion {
+	case SipHashSegmentVersion:
 		return m.serializeV1Legacy()
+	case ValueSizeSegmentVersion, ChecksumSegmentVersion:
+		return m.serializeV2Legacy()
 	}
 
-	data := make([]byte, V2MetadataSize)
+	data := make([]byte, V4MetadataSize)
 
 	// Write the version
 	binary.BigEndian.PutUint32(data[0:4], uint32(m.segmentVersion))
@@ -275,11 +326,14 @@ func (m *metadataFile) serialize() []byte {
 	// Write the key count
 	binary.BigEndian.PutUint32(data[32:36], m.keyCount)
 
+	// Write the max expiry
+	binary.BigEndian.PutUint64(data[36:44], m.maxExpiry)
+
 	// Write the sealed flag
 	if m.sealed {
-		data[36] = 1
+		data[44] = 1
 	} else {
-		data[36] = 0
+		data[44] = 0
 	}
 
 	return data
@@ -313,6 +367,21 @@ func (m *metadataFile) deserializeV1Legacy(data []byte) error {
 	return nil
 }
 
+func (m *metadataFile) deserializeV2Legacy(data []byte) error {
+	if len(data) != V2MetadataSize {
+		return fmt.Errorf("metadata file is not the correct size, expected %d, got %d",
+			V2MetadataSize, len(data))
+	}
+
+	m.shardingFactor = binary.BigEndian.Uint32(data[4:8])
+	m.salt = [16]byte(data[8:24])
+	m.lastValueTimestamp = binary.BigEndian.Uint64(data[24:32])
+	m.keyCount = binary.BigEndian.Uint32(data[32:36])
+	m.sealed = data[36] == 1
+
+	return nil
+}
+
 // deserialize deserializes the metadata file from a byte array.
 func (m *metadataFile) deserialize(data []byte) error {
 	if len(data) < 4 {
@@ -324,26 +393,49 @@ func (m *metadataFile) deserialize(data []byte) error {
 		return fmt.Errorf("unsupported serialization version: %d", m.segmentVersion)
 	}
 
-	if m.segmentVersion == OldHashFunctionSegmentVersion {
+	switch m.segmentVersion {
+	case OldHashFunctionSegmentVersion:
 		return m.deserializeV0Legacy(data)
-	} else if m.segmentVersion == SipHashSegmentVersion {
+	case SipHashSegmentVersion:
 		return m.deserializeV1Legacy(data)
+	case ValueSizeSegmentVersion, ChecksumSegmentVersion:
+		return m.deserializeV2Legacy(data)
 	}
 
-	if len(data) != V2MetadataSize {
+	if len(data) != V4MetadataSize {
 		return fmt.Errorf("metadata file is not the correct size, expected %d, got %d",
-			V2MetadataSize, len(data))
+			V4MetadataSize, len(data))
 	}
 
 	m.shardingFactor = binary.BigEndian.Uint32(data[4:8])
 	m.salt = [16]byte(data[8:24])
 	m.lastValueTimestamp = binary.BigEndian.Uint64(data[24:32])
 	m.keyCount = binary.BigEndian.Uint32(data[32:36])
-	m.sealed = data[36] == 1
+	m.maxExpiry = binary.BigEndian.Uint64(data[36:44])
+	m.sealed = data[44] == 1
 
 	return nil
 }
 
+// extendExpiry records that the segment contains a value that must not be deleted before the given expiry (in
+// nanoseconds since the epoch). If this is later than the current max expiry, the metadata file is atomically
+// rewritten. Not permitted for segments using a version before ExpirySegmentVersion.
+func (m *metadataFile) extendExpiry(expiry uint64) error {
+	if m.segmentVersion < ExpirySegmentVersion {
+		return fmt.Errorf("segment version %d does not support explicit expiry", m.segmentVersion)
+	}
+	if expiry <= m.maxExpiry {
+		return nil
+	}
+
+	m.maxExpiry = expiry
+	err := m.write()
+	if err != nil {
+		return fmt.Errorf("failed to write metadata file: %v", err)
+	}
+	return nil
+}
+
 // write atomically writes the metadata file to disk.
 func (m *metadataFile) write() error {
 	err := util.AtomicWrite(m.path(), m.serialize(), m.fsync)
@@ -174,3 +174,50 @@ func TestSealing(t *testing.T) {
 	_, err = os.Stat(filePath)
 	require.True(t, os.IsNotExist(err))
 }
+
+func TestExtendExpiry(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+	directory := t.TempDir()
+
+	salt := ([16]byte)(rand.Bytes(16))
+
+	index := rand.Uint32()
+	m, err := createMetadataFile(index, 1234, salt, directory, false)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), m.maxExpiry)
+
+	expiry := rand.Uint64()
+	err = m.extendExpiry(expiry)
+	require.NoError(t, err)
+	require.Equal(t, expiry, m.maxExpiry)
+
+	// An earlier expiry does not shorten the max expiry.
+	err = m.extendExpiry(expiry - 1)
+	require.NoError(t, err)
+	require.Equal(t, expiry, m.maxExpiry)
+
+	// The max expiry is written to disk before the file is sealed.
+	deserialized, err := loadMetadataFile(index, []string{m.parentDirectory}, false)
+	require.NoError(t, err)
+	require.Equal(t, *m, *deserialized)
+
+	// The max expiry survives sealing.
+	err = m.seal(rand.Time(), 987)
+	require.NoError(t, err)
+	deserialized, err = loadMetadataFile(index, []string{m.parentDirectory}, false)
+	require.NoError(t, err)
+	require.Equal(t, *m, *deserialized)
+	require.Equal(t, expiry, deserialized.maxExpiry)
+
+	// Older segment versions can't record an expiry.
+	legacy := &metadataFile{
+		index:           index + 1,
+		segmentVersion:  ChecksumSegmentVersion,
+		shardingFactor:  1234,
+		salt:            salt,
+		parentDirectory: directory,
+	}
+	err = legacy.extendExpiry(expiry)
+	require.Error(t, err)
+}
@@ -0,0 +1,129 @@
+package segment
+
+import (
+	"fmt"
+	"os"
+	"path"
+
+	"github.com/Layr-Labs/eigenda/litt/util"
+)
+
+// HasFilesIn returns true if any of the segment's files are stored in the given directory.
+func (s *Segment) HasFilesIn(directory string) bool {
+	s.filesLock.RLock()
+	defer s.filesLock.RUnlock()
+
+	if s.metadata.parentDirectory == directory || s.keys.parentDirectory == directory {
+		return true
+	}
+	for _, shard := range s.shards {
+		if shard.parentDirectory == directory {
+			return true
+		}
+	}
+	return false
+}
+
+// MoveFilesOutOf moves all of the segment's files that are stored in the given directory into the destination
+// directories, returning the number of bytes moved. Value files are assigned to destinations in the same round-robin
+// fashion used by CreateSegment, and all other files are moved to the first destination.
+//
+// The segment may continue to be read while its files are being moved. Each file is first copied to a swap file
+// in its destination, then renamed into place. Only then is the segment updated to point at the new location and
+// the original file deleted. If the process crashes part way through, swap files are cleaned up on the next startup.
+// The caller must hold a reservation on the segment for the duration of this call.
+//
+// Only permitted to be called after the segment has been sealed.
+func (s *Segment) MoveFilesOutOf(directory string, destinations []string, fsync bool) (uint64, error) {
+	if !s.metadata.sealed {
+		return 0, fmt.Errorf("segment %d is not sealed, cannot move files", s.index)
+	}
+	if s.readOnly {
+		return 0, fmt.Errorf("segment %d is read-only, cannot move files", s.index)
+	}
+	if len(destinations) == 0 {
+		return 0, fmt.Errorf("no destination directories provided")
+	}
+
+	bytesMoved := uint64(0)
+
+	for shardIndex, shard := range s.shards {
+		if shard.parentDirectory != directory {
+			continue
+		}
+		destination := destinations[(shardIndex+1)%len(destinations)]
+		err := s.moveFile(shard.name(), directory, destination, fsync, func() {
+			shard.parentDirectory = destination
+		})
+		if err != nil {
+			return bytesMoved, fmt.Errorf("failed to move value file for shard %d: %w", shardIndex, err)
+		}
+		bytesMoved += shard.Size()
+	}
+
+	if s.keys.parentDirectory == directory {
+		err := s.moveFile(s.keys.name(), directory, destinations[0], fsync, func() {
+			s.keys.parentDirectory = destinations[0]
+		})
+		if err != nil {
+			return bytesMoved, fmt.Errorf("failed to move key file: %w", err)
+		}
+		bytesMoved += s.keys.Size()
+	}
+
+	// The metadata file is moved last. Until every other file has been moved, the segment is still discoverable
+	// by scanning the original directory.
+	if s.metadata.parentDirectory == directory {
+		err := s.moveFile(s.metadata.name(), directory, destinations[0], fsync, func() {
+			s.metadata.parentDirectory = destinations[0]
+		})
+		if err != nil {
+			return bytesMoved, fmt.Errorf("failed to move metadata file: %w", err)
+		}
+		bytesMoved += s.metadata.Size()
+	}
+
+	return bytesMoved, nil
+}
+
+// moveFile moves a single segment file from the source directory to the destination directory. The updateLocation
+// function is called (while holding the segment's file lock) once the file is present in the destination and
+// before the original file is deleted.
+func (s *Segment) moveFile(
+	fileName string,
+	sourceDirectory string,
+	destinationDirectory string,
+	fsync bool,
+	updateLocation func()) error {
+
+	source := path.Join(sourceDirectory, fileName)
+	destination := path.Join(destinationDirectory, fileName)
+	swapPath := destination + util.SwapFileExtension
+
+	err := util.RecursiveMove(source, swapPath, true, fsync)
+	if err != nil {
+		return fmt.Errorf("failed to copy %s to %s: %w", source, swapPath, err)
+	}
+
+	err = util.AtomicRename(swapPath, destination, fsync)
+	if err != nil {
+		return fmt.Errorf("failed to rename %s to %s: %w", swapPath, destination, err)
+	}
+
+	s.filesLock.Lock()
+	updateLocation()
+	s.filesLock.Unlock()
+
+	err = os.Remove(source)
+	if err != nil {
+		return fmt.Errorf("failed to remove %s: %w", source, err)
+	}
+	if fsync {
+		err = util.SyncPath(sourceDirectory)
+		if err != nil {
+			return fmt.Errorf("failed to sync %s: %w", sourceDirectory, err)
+		}
+	}
+
+	return nil
+}
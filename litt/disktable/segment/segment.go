@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math"
 	"path"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -76,7 +77,9 @@ type Segment struct {
 	// nextSegment is the next segment in the chain (i.e. the segment with index+1). Each segment takes a reservation
 	// on the next segment in the sequence. This reservation is released when the segment is fully deleted. This
 	// ensures that segments are always deleted strictly in sequence. This makes it impossible for a crash to cause
-	// segment X to be missing while segment X-1 is present.
+	// segment X to be missing while segment X-1 is present. The one exception is a segment that contains a value
+	// with an extended expiry (see ExtendExpiry). Such a segment may outlive segments that come after it, in which
+	// case it releases its reservation on the next segment early (see DetachNextSegment).
 	nextSegment *Segment
 
 	// Used as a sanity checker. For each value written to the segment, the segment must eventually return
@@ -85,11 +88,28 @@ type Segment struct {
 	// asserts that this value is zero. This check should never fail, but is a nice safety net.
 	unflushedKeyCount atomic.Int64
 
+	// The number of bytes at the start of the key file that describe values that are durably flushed to disk, i.e.
+	// the key file size as of the most recently completed flush. Allows the keys of a mutable segment to be read
+	// without sealing it.
+	flushedKeyFileSize atomic.Uint64
+
 	// If true, then sync the file system for atomic operations. Should always be true in production, but can
 	// be set to false for tests to save time.
 	fsync bool
+
+	// Protects the locations of the segment's files. Files belonging to a sealed segment may be moved to a different
+	// directory while the segment is in use (see MoveFilesOutOf). Operations that access files by path hold a
+	// read lock, and the file locations are only updated while holding a write lock.
+	filesLock sync.RWMutex
+
+	// If true, this segment was loaded by a process that does not own the DB's files. Read-only segments never
+	// modify or delete files on disk.
+	readOnly bool
 }
 
+// ErrSegmentNotSealed is returned by LoadSegmentReadOnly when the requested segment has not yet been sealed.
+var ErrSegmentNotSealed = errors.New("segment is not sealed")
+
 // CreateSegment creates a new data segment.
 //
 // Note that shardingFactor and salt parameters are ignored if this is not a new segment. Segments loaded from
@@ -135,7 +155,7 @@ func CreateSegment(
 		// By default, put the key file in the first parent directory.
 		keysDirectory = parentDirectories[0]
 	}
-	keys, err := createKeyFile(logger, index, keysDirectory, false)
+	keys, err := createKeyFile(logger, index, keysDirectory, metadata.segmentVersion, false)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open key file: %v", err)
 	}
@@ -150,7 +170,7 @@ func CreateSegment(
 		// use it for value files too.
 		parentDirectory := parentDirectories[int(shard+1)%len(parentDirectories)]
 
-		values, err := createValueFile(logger, index, shard, parentDirectory, fsync)
+		values, err := createValueFile(logger, index, shard, parentDirectory, metadata.segmentVersion, fsync)
 		if err != nil {
 			return nil, fmt.Errorf("failed to open value file: %v", err)
 		}
@@ -227,7 +247,7 @@ func LoadSegment(logger logging.Logger,
 	// Look for the value files. There should be one for each shard.
 	shards := make([]*valueFile, metadata.shardingFactor)
 	for shard := uint32(0); shard < metadata.shardingFactor; shard++ {
-		values, err := loadValueFile(logger, index, shard, parentDirectories)
+		values, err := loadValueFile(logger, index, shard, parentDirectories, metadata.segmentVersion)
 		if err != nil {
 			return nil, fmt.Errorf("failed to open value file: %v", err)
 		}
@@ -261,6 +281,61 @@ func LoadSegment(logger logging.Logger,
 	return segment, nil
 }
 
+// LoadSegmentReadOnly loads an existing sealed segment from disk without modifying any files. This is intended for
+// use by a process that reads a DB owned by another process. Returns an error wrapping ErrSegmentNotSealed if the
+// segment is not sealed, and an error wrapping os.ErrNotExist if any of the segment's files are missing (e.g. if the
+// owning process deleted the segment while it was being loaded). Releasing the last reservation on a read-only
+// segment does not delete its files.
+func LoadSegmentReadOnly(
+	logger logging.Logger,
+	errorMonitor *util.ErrorMonitor,
+	index uint32,
+	parentDirectories []string,
+) (*Segment, error) {
+
+	if len(parentDirectories) == 0 {
+		return nil, errors.New("no parent directories provided")
+	}
+
+	metadata, err := loadMetadataFile(index, parentDirectories, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open metadata file: %w", err)
+	}
+	if !metadata.sealed {
+		return nil, fmt.Errorf("segment %d: %w", index, ErrSegmentNotSealed)
+	}
+
+	keys, err := loadKeyFile(logger, index, parentDirectories, metadata.segmentVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open key file: %w", err)
+	}
+
+	shards := make([]*valueFile, metadata.shardingFactor)
+	for shard := uint32(0); shard < metadata.shardingFactor; shard++ {
+		values, err := loadValueFile(logger, index, shard, parentDirectories, metadata.segmentVersion)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open value file: %w", err)
+		}
+		shards[shard] = values
+	}
+
+	segment := &Segment{
+		logger:          logger,
+		errorMonitor:    errorMonitor,
+		index:           index,
+		metadata:        metadata,
+		keys:            keys,
+		shards:          shards,
+		keyFileSize:     keys.Size(),
+		keyCount:        metadata.keyCount,
+		deletionChannel: make(chan struct{}, 1),
+		readOnly:        true,
+	}
+	segment.reservationCount.Store(1)
+
+	return segment, nil
+}
+
 // SegmentIndex returns the index of the segment.
 func (s *Segment) SegmentIndex() uint32 {
 	return s.index
@@ -284,9 +359,9 @@ func (s *Segment) sealLoadedSegment(now time.Time) error {
 	for _, scopedKey := range scopedKeys {
 		shard := s.GetShard(scopedKey.Key)
 
-		requiredValueFileLength := uint64(scopedKey.Address.Offset()) +
-			4 /* value size uint32 */ +
-			uint64(scopedKey.ValueSize)
+		requiredValueFileLength := scopedKey.Address.Offset() +
+			s.shards[shard].headerSize() +
+			scopedKey.ValueSize
 
 		if s.shards[shard].Size() < requiredValueFileLength {
 			badKeys = append(badKeys, scopedKey)
@@ -300,7 +375,7 @@ func (s *Segment) sealLoadedSegment(now time.Time) error {
 		s.logger.Warnf("segment %d has %d unflushed value(s)",
 			s.index, len(badKeys))
 
-		swapFile, err := createKeyFile(s.logger, s.index, s.keys.parentDirectory, true)
+		swapFile, err := createKeyFile(s.logger, s.index, s.keys.parentDirectory, s.metadata.segmentVersion, true)
 		if err != nil {
 			return fmt.Errorf("failed to create swap key file: %w", err)
 		}
@@ -360,6 +435,21 @@ func (s *Segment) KeyCount() uint32 {
 	return s.keyCount
 }
 
+// GetFilePaths returns the paths of all files that make up this segment. The metadata file is always the last file
+// in the returned list. This method should only be called on sealed segments.
+func (s *Segment) GetFilePaths() []string {
+	s.filesLock.RLock()
+	defer s.filesLock.RUnlock()
+
+	paths := make([]string, 0, len(s.shards)+2)
+	paths = append(paths, s.keys.path())
+	for _, shard := range s.shards {
+		paths = append(paths, shard.path())
+	}
+	paths = append(paths, s.metadata.path())
+	return paths
+}
+
 // lookForFile looks for a file in a list of directories. It returns an error if the file appears
 // in more than one directory, and an empty string if the file is not found. If the file is found and
 // there are no errors, this method returns the path to the file.
@@ -392,6 +482,17 @@ func (s *Segment) SetNextSegment(nextSegment *Segment) {
 	s.nextSegment = nextSegment
 }
 
+// DetachNextSegment releases this segment's reservation on the next segment in the chain, allowing the next segment
+// to be deleted before this one. This is used when this segment must be retained past its normal expiry (i.e. when it
+// holds a value with an extended expiry). Calling this method more than once has no effect.
+func (s *Segment) DetachNextSegment() {
+	if s.nextSegment == nil {
+		return
+	}
+	s.nextSegment.Release()
+	s.nextSegment = nil
+}
+
 // GetShard returns the shard number for a key.
 func (s *Segment) GetShard(key []byte) uint32 {
 	if s.metadata.shardingFactor == 1 {
@@ -420,26 +521,46 @@ func (s *Segment) Write(data *types.KVPair) (keyCount uint32, keyFileSize uint64
 	shard := s.GetShard(data.Key)
 	currentSize := s.shardSizes[shard]
 
-	if currentSize > math.MaxUint32 {
-		// No matter the configuration, we absolutely cannot permit a value to be written if the first byte of the
-		// value would be beyond position 2^32. This is because we only have 32 bits in an address to store the
-		// position of a value's first byte.
-		return 0, 0,
-			fmt.Errorf("value file already contains %d bytes, cannot add a new value", currentSize)
+	if s.metadata.segmentVersion < LargeValueSegmentVersion {
+		if currentSize > math.MaxUint32 {
+			// No matter the configuration, we absolutely cannot permit a value to be written if the first byte of
+			// the value would be beyond position 2^32. This is because this segment version only has 32 bits in an
+			// address to store the position of a value's first byte.
+			return 0, 0,
+				fmt.Errorf("value file already contains %d bytes, cannot add a new value", currentSize)
+		}
+		if uint64(len(data.Key)) > math.MaxUint32 || uint64(len(data.Value)) > math.MaxUint32 {
+			return 0, 0,
+				fmt.Errorf("segment version %d does not support keys or values larger than 2^32 bytes",
+					s.metadata.segmentVersion)
+		}
 	}
 	s.unflushedKeyCount.Add(1)
-	firstByteIndex := uint32(currentSize)
+	firstByteIndex := currentSize
+
+	var checksum uint32
+	if s.metadata.segmentVersion >= ChecksumSegmentVersion {
+		checksum = computeChecksum(data.Value)
+	}
+
+	keyRequest := &types.ScopedKey{
+		Key:       data.Key,
+		Address:   types.NewAddress(s.index, firstByteIndex),
+		ValueSize: uint64(len(data.Value)),
+		Checksum:  checksum,
+	}
 
-	s.shardSizes[shard] += uint64(len(data.Value)) + 4 /* uint32 length */
+	s.shardSizes[shard] += uint64(len(data.Value)) + s.shards[shard].headerSize()
 	if s.shardSizes[shard] > s.maxShardSize {
 		s.maxShardSize = s.shardSizes[shard]
 	}
 	s.keyCount++
-	s.keyFileSize += uint64(len(data.Key)) + 4 /* uint32 length */ + 8 /* uint64 Address */ + 4 /* uint32 ValueSize */
+	s.keyFileSize += s.keys.entrySize(keyRequest)
 
 	// Forward the value to the shard control loop, which asynchronously writes it to the value file.
 	shardRequest := &valueToWrite{
 		value:                  data.Value,
+		checksum:               checksum,
 		expectedFirstByteIndex: firstByteIndex,
 	}
 	err = util.Send(s.errorMonitor, s.shardChannels[shard], shardRequest)
@@ -449,12 +570,6 @@ func (s *Segment) Write(data *types.KVPair) (keyCount uint32, keyFileSize uint64
 	}
 
 	// Forward the value to the key and its address file control loop, which asynchronously writes it to the key file.
-	keyRequest := &types.ScopedKey{
-		Key:       data.Key,
-		Address:   types.NewAddress(s.index, firstByteIndex),
-		ValueSize: uint32(len(data.Value)),
-	}
-
 	err = util.Send(s.errorMonitor, s.keyFileChannel, keyRequest)
 	if err != nil {
 		return 0, 0,
@@ -473,6 +588,9 @@ func (s *Segment) GetMaxShardSize() uint64 {
 //
 // It is only thread safe to read from a segment if the key being read has previously been flushed to disk.
 func (s *Segment) Read(key []byte, dataAddress types.Address) ([]byte, error) {
+	s.filesLock.RLock()
+	defer s.filesLock.RUnlock()
+
 	shard := s.GetShard(key)
 	values := s.shards[shard]
 
@@ -483,12 +601,46 @@ func (s *Segment) Read(key []byte, dataAddress types.Address) ([]byte, error) {
 	return value, nil
 }
 
+// VerifyValue reads the value for a key and checks that it matches the size and checksum recorded in the key file.
+// Returns an error wrapping ErrChecksumMismatch if the value on disk is corrupted. Segments written before checksums
+// were introduced can only be checked for readability and (if recorded) value size.
+//
+// Only permitted to be called after the segment has been sealed.
+func (s *Segment) VerifyValue(scopedKey *types.ScopedKey) error {
+	if !s.metadata.sealed {
+		return fmt.Errorf("segment is not sealed, cannot verify values")
+	}
+
+	value, err := s.Read(scopedKey.Key, scopedKey.Address)
+	if err != nil {
+		return fmt.Errorf("failed to read value for key %x: %w", scopedKey.Key, err)
+	}
+
+	if s.metadata.segmentVersion >= ValueSizeSegmentVersion && uint64(len(value)) != scopedKey.ValueSize {
+		return fmt.Errorf("value for key %x has size %d, expected %d: %w",
+			scopedKey.Key, len(value), scopedKey.ValueSize, ErrChecksumMismatch)
+	}
+
+	if s.metadata.segmentVersion >= ChecksumSegmentVersion {
+		actualChecksum := computeChecksum(value)
+		if actualChecksum != scopedKey.Checksum {
+			return fmt.Errorf("value for key %x has checksum %08x, expected %08x: %w",
+				scopedKey.Key, actualChecksum, scopedKey.Checksum, ErrChecksumMismatch)
+		}
+	}
+
+	return nil
+}
+
 // GetKeys returns all keys in the data segment. Only permitted to be called after the segment has been sealed.
 func (s *Segment) GetKeys() ([]*types.ScopedKey, error) {
 	if !s.metadata.sealed {
 		return nil, fmt.Errorf("segment is not sealed, cannot read keys")
 	}
 
+	s.filesLock.RLock()
+	defer s.filesLock.RUnlock()
+
 	keys, err := s.keys.readKeys()
 	if err != nil {
 		return nil, fmt.Errorf("failed to read keys: %w", err)
@@ -496,6 +648,21 @@ func (s *Segment) GetKeys() ([]*types.ScopedKey, error) {
 	return keys, nil
 }
 
+// GetFlushedKeys returns the keys in the segment whose values have been durably flushed to disk, i.e. the keys
+// written before the most recently completed flush. Unlike GetKeys, this may be called on the mutable segment, and
+// is safe to call concurrently with writes and with the segment being sealed. Only meaningful for a segment created
+// by this process (for a segment loaded from disk, use GetKeys).
+func (s *Segment) GetFlushedKeys() ([]*types.ScopedKey, error) {
+	s.filesLock.RLock()
+	defer s.filesLock.RUnlock()
+
+	keys, err := s.keys.readFlushedKeys(s.flushedKeyFileSize.Load())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keys: %w", err)
+	}
+	return keys, nil
+}
+
 // FlushWaitFunction is a function that waits for a flush operation to complete. It returns the addresses of the data
 // that was flushed, or an error if the flush operation failed.
 type FlushWaitFunction func() ([]*types.ScopedKey, error)
@@ -549,6 +716,7 @@ func (s *Segment) flush(seal bool) (FlushWaitFunction, error) {
 		}
 
 		s.unflushedKeyCount.Add(-int64(len(keyFlushResponse.addresses)))
+		s.flushedKeyFileSize.Store(keyFlushResponse.keyFileSize)
 		return keyFlushResponse.addresses, nil
 	}, nil
 }
@@ -590,6 +758,35 @@ func (s *Segment) GetSealTime() time.Time {
 	return time.Unix(0, int64(s.metadata.lastValueTimestamp))
 }
 
+// ExtendExpiry records that this segment contains a value that must not be deleted before the given expiry. The
+// expiry is durably written to the segment's metadata before this method returns. Only permitted to be called on
+// unsealed segments.
+func (s *Segment) ExtendExpiry(expiry time.Time) error {
+	if s.metadata.sealed {
+		return fmt.Errorf("segment is sealed, cannot extend expiry")
+	}
+
+	err := s.metadata.extendExpiry(uint64(expiry.UnixNano()))
+	if err != nil {
+		return fmt.Errorf("failed to extend expiry of segment %d: %w", s.index, err)
+	}
+	return nil
+}
+
+// GetMaxExpiry returns the latest expiry of any value in this segment that was written with an extended expiry. If
+// the segment contains no such values, this method returns the zero time.
+func (s *Segment) GetMaxExpiry() time.Time {
+	if s.metadata.maxExpiry == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, int64(s.metadata.maxExpiry))
+}
+
+// HasExtendedExpiry returns true if this segment contains at least one value written with an extended expiry.
+func (s *Segment) HasExtendedExpiry() bool {
+	return s.metadata.maxExpiry != 0
+}
+
 // Reserve reserves the segment, preventing it from being deleted. Returns true if the reservation was successful, and
 // false otherwise.
 func (s *Segment) Reserve() bool {
@@ -640,12 +837,20 @@ func (s *Segment) BlockUntilFullyDeleted() error {
 	return nil
 }
 
-// delete deletes the segment from disk.
+// delete deletes the segment from disk. Files belonging to a read-only segment are left untouched.
 func (s *Segment) delete() error {
 	defer func() {
 		s.deletionChannel <- struct{}{}
 	}()
 
+	if s.readOnly {
+		// The files belong to another process, it is responsible for deleting them.
+		if s.nextSegment != nil {
+			s.nextSegment.Release()
+		}
+		return nil
+	}
+
 	err := s.keys.delete()
 	if err != nil {
 		return fmt.Errorf("failed to delete key file, segment %d: %w", s.index, err)
@@ -698,7 +903,7 @@ func (s *Segment) handleShardFlushRequest(shard uint32, request *shardFlushReque
 
 // handleShardWrite applies a single write operation to a shard.
 func (s *Segment) handleShardWrite(shard uint32, data *valueToWrite) {
-	firstByteIndex, err := s.shards[shard].write(data.value)
+	firstByteIndex, err := s.shards[shard].write(data.value, data.checksum)
 	if err != nil {
 		s.errorMonitor.Panic(fmt.Errorf("failed to write value to value file: %w", err))
 	}
@@ -735,7 +940,8 @@ func (s *Segment) handleKeyFileFlushRequest(request *keyFileFlushRequest, unflus
 	}
 
 	request.completionChannel <- &keyFileFlushResponse{
-		addresses: unflushedKeys,
+		addresses:   unflushedKeys,
+		keyFileSize: s.keys.Size(),
 	}
 }
 
@@ -751,7 +957,8 @@ type shardFlushRequest struct {
 // valueToWrite is a message sent to the shard control loop to request that it write a value to the value file.
 type valueToWrite struct {
 	value                  []byte
-	expectedFirstByteIndex uint32
+	checksum               uint32
+	expectedFirstByteIndex uint64
 }
 
 // shardControlLoop is the main loop for performing modifications to a particular shard. Each shard is managed
@@ -794,6 +1001,9 @@ type keyFileFlushRequest struct {
 // key file has been flushed.
 type keyFileFlushResponse struct {
 	addresses []*types.ScopedKey
+
+	// The size of the key file once the flush completed.
+	keyFileSize uint64
 }
 
 // keyFileControlLoop is the main loop for performing modifications to the key file. This goroutine is responsible
@@ -1,10 +1,14 @@
 package segment
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
 	"math"
 	"os"
 	"path"
+	"slices"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/litt/util"
@@ -14,6 +18,12 @@ import (
 // scanDirectories scans directories for segment files and returns a map of metadata, key, and value files.
 // Also returns a list of garbage files that should be deleted. Does not do anything to files with unrecognized
 // extensions.
+//
+// If a segment file is present in more than one directory, the process crashed while moving the file between
+// directories (see MoveFilesOutOf), after the file was renamed into its destination but before the original was
+// deleted. If the copies are byte-for-byte identical, all but the first copy found are treated as garbage. (If the
+// copy that is kept is in a path that is being removed, it is moved again the next time that path is removed.)
+// Copies with different contents are reported as an error.
 func scanDirectories(logger logging.Logger, rootDirectories []string) (
 	metadataFiles map[uint32]string,
 	keyFiles map[uint32]string,
@@ -33,6 +43,9 @@ func scanDirectories(logger logging.Logger, rootDirectories []string) (
 
 	garbageFiles = make([]string, 0)
 
+	// key is the name of a segment file, value is the path of the first copy of that file that was found
+	segmentFileLocations := make(map[string]string)
+
 	for _, rootDirectory := range rootDirectories {
 		files, err := os.ReadDir(rootDirectory)
 		if err != nil {
@@ -51,9 +64,32 @@ func scanDirectories(logger logging.Logger, rootDirectories []string) (
 			var index uint32
 
 			switch extension {
-			case MetadataSwapExtension, KeyFileSwapExtension:
+			case MetadataSwapExtension, KeyFileSwapExtension, util.SwapFileExtension:
+				garbageFiles = append(garbageFiles, filePath)
+				continue
+			case MetadataFileExtension, KeyFileExtension, ValuesFileExtension:
+				originalPath, duplicate := segmentFileLocations[fileName]
+				if !duplicate {
+					segmentFileLocations[fileName] = filePath
+					break
+				}
+				identical, err := filesAreIdentical(originalPath, filePath)
+				if err != nil {
+					return nil, nil, nil, nil, 0, 0,
+						fmt.Errorf("failed to compare %s and %s: %v", originalPath, filePath, err)
+				}
+				if !identical {
+					return nil, nil, nil, nil, 0, 0,
+						fmt.Errorf("file %s found in multiple directories with different contents: %s, %s",
+							fileName, originalPath, filePath)
+				}
+				logger.Warnf("File %s is a duplicate of %s, likely due to a crash while moving it",
+					filePath, originalPath)
 				garbageFiles = append(garbageFiles, filePath)
 				continue
+			}
+
+			switch extension {
 			case MetadataFileExtension:
 				index, err = getMetadataFileIndex(fileName)
 				if err != nil {
@@ -104,15 +140,85 @@ func scanDirectories(logger logging.Logger, rootDirectories []string) (
 		nil
 }
 
+// filesAreIdentical returns true if the two files have exactly the same contents.
+func filesAreIdentical(pathA string, pathB string) (bool, error) {
+	infoA, err := os.Stat(pathA)
+	if err != nil {
+		return false, fmt.Errorf("failed to stat %s: %v", pathA, err)
+	}
+	infoB, err := os.Stat(pathB)
+	if err != nil {
+		return false, fmt.Errorf("failed to stat %s: %v", pathB, err)
+	}
+	if infoA.Size() != infoB.Size() {
+		return false, nil
+	}
+
+	fileA, err := os.Open(pathA)
+	if err != nil {
+		return false, fmt.Errorf("failed to open %s: %v", pathA, err)
+	}
+	defer func() {
+		_ = fileA.Close()
+	}()
+	fileB, err := os.Open(pathB)
+	if err != nil {
+		return false, fmt.Errorf("failed to open %s: %v", pathB, err)
+	}
+	defer func() {
+		_ = fileB.Close()
+	}()
+
+	bufferA := make([]byte, 64*1024)
+	bufferB := make([]byte, 64*1024)
+	for {
+		bytesReadA, errA := io.ReadFull(fileA, bufferA)
+		bytesReadB, errB := io.ReadFull(fileB, bufferB)
+		if !bytes.Equal(bufferA[:bytesReadA], bufferB[:bytesReadB]) {
+			return false, nil
+		}
+		doneA := errA == io.EOF || errA == io.ErrUnexpectedEOF
+		doneB := errB == io.EOF || errB == io.ErrUnexpectedEOF
+		if errA != nil && !doneA {
+			return false, fmt.Errorf("failed to read %s: %v", pathA, errA)
+		}
+		if errB != nil && !doneB {
+			return false, fmt.Errorf("failed to read %s: %v", pathB, errB)
+		}
+		if doneA || doneB {
+			return doneA && doneB, nil
+		}
+	}
+}
+
+// ListSegmentIndices returns the sorted indices of all segments that have a metadata file in the given directories.
+// Unlike GatherSegmentFiles, this function never modifies any files on disk.
+func ListSegmentIndices(logger logging.Logger, rootDirectories []string) ([]uint32, error) {
+	metadataFiles, _, _, _, _, _, err := scanDirectories(logger, rootDirectories)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan directories: %w", err)
+	}
+
+	indices := make([]uint32, 0, len(metadataFiles))
+	for index := range metadataFiles {
+		indices = append(indices, index)
+	}
+	slices.Sort(indices)
+
+	return indices, nil
+}
+
 // diagnoseMissingFile decides what to do with specific missing files. If the segment is either the segment
 // with the lowest index or the segment with the highest index, it is possible for files to be missing due to
-// non-catastrophic reasons (i.e. a crash during cleanup). If the segment is neither the lowest nor highest segment,
-// then missing files signal non-recoverable DB corruption, and an error is returned.
+// non-catastrophic reasons (i.e. a crash during cleanup). The same is true if the segment comes at or after a segment
+// containing a value with an extended expiry, since such segments are permitted to outlive the segments that follow
+// them. Otherwise, missing files signal non-recoverable DB corruption, and an error is returned.
 func diagnoseMissingFile(
 	logger logging.Logger,
 	index uint32,
 	lowestFileIndex uint32,
 	highestFileIndex uint32,
+	followsExtendedExpiry bool,
 	fileType string,
 	damagedSegments map[uint32]struct{}) error {
 
@@ -124,6 +230,11 @@ func diagnoseMissingFile(
 		// This can happen when deleting the oldest segment. Recoverable.
 		logger.Warnf("Missing %s file for first segment %d", fileType, index)
 		damagedSegments[index] = struct{}{}
+	} else if followsExtendedExpiry {
+		// This can happen when deleting a segment that comes after a segment with an extended expiry. Recoverable.
+		logger.Warnf("Missing %s file for segment %d, which follows a segment with an extended expiry",
+			fileType, index)
+		damagedSegments[index] = struct{}{}
 	} else {
 		// Database is missing internal files. Catastrophic failure.
 		return fmt.Errorf("missing %s file for segment %d", fileType, index)
@@ -149,19 +260,53 @@ func lookForMissingFiles(
 	orphanedFiles = make([]string, 0)
 	damagedSegments = make(map[uint32]struct{})
 
-	for segment := lowestSegmentIndex; segment <= highestSegmentIndex; segment++ {
+	// Becomes true once we have seen a segment containing a value with an extended expiry. All segments that come
+	// after such a segment may be deleted while it remains on disk.
+	followsExtendedExpiry := false
 
-		if segment == 0 && len(metadataFiles) == 0 && len(keyFiles) == 0 && len(valueFiles) == 0 {
-			// Special case, only happens when starting a table from scratch.
-			// Files aren't actually missing, so no need to log anything.
-			break
+	// The indices of all segments with at least one file on disk.
+	segmentsWithFiles := make(map[uint32]struct{}, len(metadataFiles))
+	for segment := range metadataFiles {
+		segmentsWithFiles[segment] = struct{}{}
+	}
+	for segment := range keyFiles {
+		segmentsWithFiles[segment] = struct{}{}
+	}
+	for segment, files := range valueFiles {
+		if len(files) > 0 {
+			segmentsWithFiles[segment] = struct{}{}
+		}
+	}
+
+	nextSegment := lowestSegmentIndex
+	for segment := range ExistingSegments(segmentsWithFiles, lowestSegmentIndex, highestSegmentIndex) {
+		if segment != nextSegment && !followsExtendedExpiry {
+			// Segments with no files at all are only expected after a segment with an extended expiry.
+			return nil, nil, fmt.Errorf("missing metadata file for segment %d", nextSegment)
+		}
+		nextSegment = segment + 1
+
+		_, metadataPresent := metadataFiles[segment]
+		_, keysPresent := keyFiles[segment]
+
+		var metadata *metadataFile
+		if metadataPresent {
+			// We need to know the sharding factor to check for missing value files.
+			loadedMetadata, err := loadMetadataFile(segment, []string{path.Dir(metadataFiles[segment])}, fsync)
+			if err != nil {
+				return nil, nil,
+					fmt.Errorf("failed to load metadata file: %v", err)
+			}
+			metadata = loadedMetadata
+			if metadata.maxExpiry != 0 {
+				followsExtendedExpiry = true
+			}
 		}
 
 		potentialOrphans := make([]string, 0)
 		segmentMissingFiles := false
 
 		// Check for missing metadata file.
-		_, metadataPresent := metadataFiles[segment]
 		if metadataPresent {
 			potentialOrphans = append(potentialOrphans, metadataFiles[segment])
 		} else {
@@ -171,6 +316,7 @@ func lookForMissingFiles(
 				segment,
 				lowestSegmentIndex,
 				highestSegmentIndex,
+				followsExtendedExpiry,
 				"metadata",
 				damagedSegments)
 			if err != nil {
@@ -179,7 +325,6 @@ func lookForMissingFiles(
 		}
 
 		// Check for missing key file.
-		_, keysPresent := keyFiles[segment]
 		if keysPresent {
 			potentialOrphans = append(potentialOrphans, keyFiles[segment])
 		} else {
@@ -189,6 +334,7 @@ func lookForMissingFiles(
 				segment,
 				lowestSegmentIndex,
 				highestSegmentIndex,
+				followsExtendedExpiry,
 				"key",
 				damagedSegments)
 			if err != nil {
@@ -203,16 +349,6 @@ func lookForMissingFiles(
 			orphanedFiles = append(orphanedFiles, valueFiles[segment]...)
 		} else {
 
-			// We need to know the sharding factor to check for missing value files.
-			metadataPath := metadataFiles[segment]
-			metadataDirectory := path.Dir(metadataPath)
-
-			metadata, err := loadMetadataFile(segment, []string{metadataDirectory}, fsync)
-			if err != nil {
-				return nil, nil,
-					fmt.Errorf("failed to load metadata file: %v", err)
-			}
-
 			if uint32(len(valueFiles[segment])) > metadata.shardingFactor {
 				return nil, nil,
 					fmt.Errorf("too many value files for segment %d, expected at most %d, got %d",
@@ -236,11 +372,12 @@ func lookForMissingFiles(
 				_, shardPresent := shardsPresent[shard]
 				if !shardPresent {
 					segmentMissingFiles = true
-					err = diagnoseMissingFile(
+					err := diagnoseMissingFile(
 						logger,
 						segment,
 						lowestSegmentIndex,
 						highestSegmentIndex,
+						followsExtendedExpiry,
 						fmt.Sprintf("shard-%d", shard),
 						damagedSegments)
 					if err != nil {
@@ -271,29 +408,24 @@ func deleteOrphanedFiles(logger logging.Logger, orphanedFiles []string) error {
 	return nil
 }
 
-// linkSegments links together adjacent segments via SetNextSegment().
-func linkSegments(lowestSegmentIndex uint32, highestSegmentIndex uint32, segments map[uint32]*Segment) error {
-	if lowestSegmentIndex == highestSegmentIndex {
-		// Only one segment, nothing to link. This is checked explicitly to avoid 0-1 underflow.
-		return nil
+// linkSegments links together adjacent segments via SetNextSegment(). Segment indices may contain gaps if segments
+// were deleted while an earlier segment with an extended expiry was retained, in which case each segment is linked
+// to the next segment that is present.
+func linkSegments(segments map[uint32]*Segment) {
+	indices := make([]uint32, 0, len(segments))
+	for index := range segments {
+		indices = append(indices, index)
 	}
+	slices.Sort(indices)
 
-	for i := lowestSegmentIndex; i < highestSegmentIndex; i++ {
-		first, ok := segments[i]
-		if !ok {
-			return fmt.Errorf("missing segment %d", i)
-		}
-		second, ok := segments[i+1]
-		if !ok {
-			return fmt.Errorf("missing segment %d", i+1)
-		}
-		first.SetNextSegment(second)
+	for i := 0; i+1 < len(indices); i++ {
+		segments[indices[i]].SetNextSegment(segments[indices[i+1]])
 	}
-	return nil
 }
 
-// GatherSegmentFiles scans a directory for segment files and loads them into memory. It also deletes
-// orphaned files and checks for corrupted files. It creates a new mutable segment at the end.
+// GatherSegmentFiles scans a directory for segment files and loads them into memory. It also deletes garbage and
+// orphaned files, checks for corrupted files, and seals the last segment if it was left unsealed. The caller is
+// responsible for creating a new mutable segment after the last one.
 func GatherSegmentFiles(
 	logger logging.Logger,
 	errorMonitor *util.ErrorMonitor,
@@ -301,6 +433,30 @@ func GatherSegmentFiles(
 	now time.Time,
 	fsync bool,
 ) (lowestSegmentIndex uint32, highestSegmentIndex uint32, segments map[uint32]*Segment, err error) {
+	return gatherSegmentFiles(logger, errorMonitor, rootDirectories, now, fsync, false)
+}
+
+// GatherSegmentFilesReadOnly is like GatherSegmentFiles, but never modifies any files on disk. Garbage and orphaned
+// files are left in place, and segments that are not sealed are skipped. This is intended for tools that inspect a
+// DB without repairing it.
+func GatherSegmentFilesReadOnly(
+	logger logging.Logger,
+	errorMonitor *util.ErrorMonitor,
+	rootDirectories []string,
+	now time.Time,
+) (lowestSegmentIndex uint32, highestSegmentIndex uint32, segments map[uint32]*Segment, err error) {
+	return gatherSegmentFiles(logger, errorMonitor, rootDirectories, now, false, true)
+}
+
+// gatherSegmentFiles implements GatherSegmentFiles and GatherSegmentFilesReadOnly.
+func gatherSegmentFiles(
+	logger logging.Logger,
+	errorMonitor *util.ErrorMonitor,
+	rootDirectories []string,
+	now time.Time,
+	fsync bool,
+	readOnly bool,
+) (lowestSegmentIndex uint32, highestSegmentIndex uint32, segments map[uint32]*Segment, err error) {
 
 	// Scan the root directories for segment files.
 	metadataFiles, keyFiles, valueFiles, garbageFiles, highestSegmentIndex, lowestSegmentIndex, err :=
@@ -314,6 +470,10 @@ func GatherSegmentFiles(
 
 	// Delete any garbage files. Ignore files with unrecognized extensions.
 	for _, garbageFile := range garbageFiles {
+		if readOnly {
+			logger.Infof("ignoring garbage file %s", garbageFile)
+			continue
+		}
 		logger.Infof("deleting file %s", garbageFile)
 		err = os.Remove(garbageFile)
 		if err != nil {
@@ -337,10 +497,16 @@ func GatherSegmentFiles(
 	}
 
 	// Clean up any orphaned segment files.
-	err = deleteOrphanedFiles(logger, orphanedFiles)
-	if err != nil {
-		return 0, 0, nil,
-			fmt.Errorf("failed to delete orphaned files: %v", err)
+	if readOnly {
+		for _, orphanedFile := range orphanedFiles {
+			logger.Infof("ignoring orphaned file %s", orphanedFile)
+		}
+	} else {
+		err = deleteOrphanedFiles(logger, orphanedFiles)
+		if err != nil {
+			return 0, 0, nil,
+				fmt.Errorf("failed to delete orphaned files: %v", err)
+		}
 	}
 
 	if len(metadataFiles) > 0 {
@@ -353,8 +519,22 @@ func GatherSegmentFiles(
 		}
 
 		// Load all healthy segments.
-		for i := lowestSegmentIndex; i <= highestSegmentIndex; i++ {
-			segment, err := LoadSegment(logger, errorMonitor, i, rootDirectories, now, fsync)
+		for i := range ExistingSegments(metadataFiles, lowestSegmentIndex, highestSegmentIndex) {
+			if _, ok := damagedSegments[i]; ok {
+				continue
+			}
+
+			var segment *Segment
+			if readOnly {
+				segment, err = LoadSegmentReadOnly(logger, errorMonitor, i, rootDirectories)
+				if errors.Is(err, ErrSegmentNotSealed) {
+					// Sealing the segment would modify it.
+					logger.Infof("skipping unsealed segment %d", i)
+					continue
+				}
+			} else {
+				segment, err = LoadSegment(logger, errorMonitor, i, rootDirectories, now, fsync)
+			}
 			if err != nil {
 				return 0, 0, nil,
 					fmt.Errorf("failed to create segment %d: %v", i, err)
@@ -362,12 +542,18 @@ func GatherSegmentFiles(
 			segments[i] = segment
 		}
 
-		// Stitch together the segments.
-		err = linkSegments(lowestSegmentIndex, highestSegmentIndex, segments)
-		if err != nil {
-			return 0, 0, nil,
-				fmt.Errorf("failed to link segments: %v", err)
+		// If there are gaps in the segment indices, make sure the range starts and ends with a segment that exists.
+		if len(segments) > 0 {
+			for segments[lowestSegmentIndex] == nil {
+				lowestSegmentIndex++
+			}
+			for segments[highestSegmentIndex] == nil {
+				highestSegmentIndex--
+			}
 		}
+
+		// Stitch together the segments.
+		linkSegments(segments)
 	}
 
 	return lowestSegmentIndex, highestSegmentIndex, segments, nil
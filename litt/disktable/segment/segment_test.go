@@ -65,7 +65,7 @@ func TestWriteAndReadSegmentSingleShard(t *testing.T) {
 		value := values[i]
 		expectedValues[string(key)] = value
 
-		expectedLargestShardSize += uint64(len(value)) + 4 /* uint32 length */
+		expectedLargestShardSize += uint64(len(value)) + 8 /* uint64 length */ + 4 /* uint32 checksum */
 
 		_, _, err := seg.Write(&types.KVPair{Key: key, Value: value})
 		largestShardSize := seg.GetMaxShardSize()
@@ -437,3 +437,70 @@ func TestWriteAndReadColdShard(t *testing.T) {
 
 	require.Equal(t, 0, countFilesInDirectory(t, directory))
 }
+
+func TestCorruptedValue(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+	logger, err := common.NewLogger(common.DefaultTextLoggerConfig())
+	require.NoError(t, err)
+	directory := t.TempDir()
+
+	index := rand.Uint32()
+	salt := ([16]byte)(rand.Bytes(16))
+	seg, err := CreateSegment(
+		logger,
+		util.NewErrorMonitor(context.Background(), logger, nil),
+		index,
+		[]string{directory},
+		1,
+		salt,
+		false)
+	require.NoError(t, err)
+
+	valueCount := rand.Int32Range(10, 20)
+	values := make(map[string][]byte)
+	for i := 0; i < int(valueCount); i++ {
+		key := rand.PrintableBytes(32)
+		value := rand.PrintableVariableBytes(1, 100)
+		values[string(key)] = value
+		_, _, err = seg.Write(&types.KVPair{Key: key, Value: value})
+		require.NoError(t, err)
+	}
+
+	_, err = seg.Seal(rand.Time())
+	require.NoError(t, err)
+
+	scopedKeys, err := seg.GetKeys()
+	require.NoError(t, err)
+	require.Equal(t, int(valueCount), len(scopedKeys))
+
+	// Before corruption, all values should pass verification.
+	for _, scopedKey := range scopedKeys {
+		err = seg.VerifyValue(scopedKey)
+		require.NoError(t, err)
+	}
+
+	// Flip a bit in the last byte of a randomly chosen value.
+	corruptedKey := scopedKeys[rand.Intn(len(scopedKeys))]
+	valueFilePath := seg.shards[0].path()
+	fileBytes, err := os.ReadFile(valueFilePath)
+	require.NoError(t, err)
+	corruptedByteIndex := uint64(corruptedKey.Address.Offset()) + seg.shards[0].headerSize() +
+		uint64(corruptedKey.ValueSize) - 1
+	fileBytes[corruptedByteIndex] ^= 1
+	err = os.WriteFile(valueFilePath, fileBytes, 0644)
+	require.NoError(t, err)
+
+	for _, scopedKey := range scopedKeys {
+		value, readErr := seg.Read(scopedKey.Key, scopedKey.Address)
+		verifyErr := seg.VerifyValue(scopedKey)
+		if bytes.Equal(scopedKey.Key, corruptedKey.Key) {
+			require.ErrorIs(t, readErr, ErrChecksumMismatch)
+			require.ErrorIs(t, verifyErr, ErrChecksumMismatch)
+		} else {
+			require.NoError(t, readErr)
+			require.NoError(t, verifyErr)
+			require.Equal(t, values[string(scopedKey.Key)], value)
+		}
+	}
+}
@@ -14,7 +14,21 @@ const (
 	// ValueSizeSegmentVersion adds the length of values to the key file. Previously, only the key and the address were
 	// stored in the key file. It also adds the key count to the segment metadata file.
 	ValueSizeSegmentVersion SegmentVersion = 2
+
+	// ChecksumSegmentVersion adds a CRC32 (Castagnoli) checksum for each value. The checksum is stored both in the
+	// value file (so that values can be verified each time they are read) and in the key file (so that the scrubber
+	// can detect corruption in the value file without trusting the value file's own bookkeeping).
+	ChecksumSegmentVersion SegmentVersion = 3
+
+	// ExpirySegmentVersion adds the maximum expiry of all values in the segment that were written with an explicit
+	// expiry (i.e. via Table.PutWithExpiry) to the segment metadata file.
+	ExpirySegmentVersion SegmentVersion = 4
+
+	// LargeValueSegmentVersion uses 64-bit lengths for keys and values in the key file and value files, and 64-bit
+	// value file offsets in the key file. This permits keys and values larger than 4 GiB, as well as value files
+	// larger than 4 GiB. Earlier versions use 32-bit lengths and offsets.
+	LargeValueSegmentVersion SegmentVersion = 5
 )
 
 // LatestSegmentVersion always refers to the latest version of the segment serialization format.
-const LatestSegmentVersion = ValueSizeSegmentVersion
+const LatestSegmentVersion = LargeValueSegmentVersion
@@ -34,6 +34,9 @@ type valueFile struct {
 	// The parent directory containing this file.
 	parentDirectory string
 
+	// The segment version. Determines serialization format.
+	segmentVersion SegmentVersion
+
 	// The file wrapped by the writer. If the file is sealed, this value is nil.
 	file *os.File
 
@@ -60,6 +63,7 @@ func createValueFile(
 	index uint32,
 	shard uint32,
 	parentDirectory string,
+	segmentVersion SegmentVersion,
 	fsync bool) (*valueFile, error) {
 
 	values := &valueFile{
@@ -67,6 +71,7 @@ func createValueFile(
 		index:           index,
 		shard:           shard,
 		parentDirectory: parentDirectory,
+		segmentVersion:  segmentVersion,
 		fsync:           fsync,
 	}
 
@@ -98,7 +103,8 @@ func loadValueFile(
 	logger logging.Logger,
 	index uint32,
 	shard uint32,
-	parentDirectories []string) (*valueFile, error) {
+	parentDirectories []string,
+	segmentVersion SegmentVersion) (*valueFile, error) {
 
 	valuesFileName := fmt.Sprintf("%d-%d%s", index, shard, ValuesFileExtension)
 	valuesPath, err := lookForFile(parentDirectories, valuesFileName)
@@ -107,7 +113,7 @@ func loadValueFile(
 		return nil, fmt.Errorf("failed to find value file: %v", err)
 	}
 	if valuesPath == "" {
-		return nil, fmt.Errorf("value file %s not found", valuesFileName)
+		return nil, fmt.Errorf("value file %s not found: %w", valuesFileName, os.ErrNotExist)
 	}
 	parentDirectory = path.Dir(valuesPath)
 
@@ -116,6 +122,7 @@ func loadValueFile(
 		index:           index,
 		shard:           shard,
 		parentDirectory: parentDirectory,
+		segmentVersion:  segmentVersion,
 		fsync:           false,
 	}
 
@@ -126,7 +133,7 @@ func loadValueFile(
 	}
 
 	if !exists {
-		return nil, fmt.Errorf("value file %s does not exist", filePath)
+		return nil, fmt.Errorf("value file %s does not exist: %w", filePath, os.ErrNotExist)
 	}
 
 	values.size = uint64(size)
@@ -190,17 +197,30 @@ func (v *valueFile) path() string {
 	return path.Join(v.parentDirectory, v.name())
 }
 
-// read reads a value from the value file.
-func (v *valueFile) read(firstByteIndex uint32) ([]byte, error) {
+// headerSize returns the number of bytes written before each value (i.e. the length prefix and, if present,
+// the checksum).
+func (v *valueFile) headerSize() uint64 {
+	if v.segmentVersion >= LargeValueSegmentVersion {
+		return 8 /* uint64 length */ + 4 /* uint32 checksum */
+	}
+	if v.segmentVersion < ChecksumSegmentVersion {
+		return 4 /* uint32 length */
+	}
+	return 4 /* uint32 length */ + 4 /* uint32 checksum */
+}
+
+// read reads a value from the value file. If this value file contains checksums, the checksum is verified and
+// an error wrapping ErrChecksumMismatch is returned if the value does not match.
+func (v *valueFile) read(firstByteIndex uint64) ([]byte, error) {
 	flushedSize := v.flushedSize.Load()
-	if uint64(firstByteIndex) >= flushedSize {
+	if firstByteIndex >= flushedSize {
 		return nil, fmt.Errorf("index %d is out of bounds (current flushed size is %d)",
 			firstByteIndex, flushedSize)
 	}
 
 	file, err := os.OpenFile(v.path(), os.O_RDONLY, 0644)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open value file: %v", err)
+		return nil, fmt.Errorf("failed to open value file: %w", err)
 	}
 	defer func() {
 		err = file.Close()
@@ -213,12 +233,35 @@ func (v *valueFile) read(firstByteIndex uint32) ([]byte, error) {
 	reader := bufio.NewReader(file)
 
 	// Read the length of the value.
-	var length uint32
-	err = binary.Read(reader, binary.BigEndian, &length)
+	var length uint64
+	if v.segmentVersion >= LargeValueSegmentVersion {
+		err = binary.Read(reader, binary.BigEndian, &length)
+	} else {
+		var legacyLength uint32
+		err = binary.Read(reader, binary.BigEndian, &legacyLength)
+		length = uint64(legacyLength)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to read value length from value file: %v", err)
 	}
 
+	// Read the checksum of the value.
+	var checksum uint32
+	if v.segmentVersion >= ChecksumSegmentVersion {
+		err = binary.Read(reader, binary.BigEndian, &checksum)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read value checksum from value file: %v", err)
+		}
+	}
+
+	// The length is read from disk, so it may be arbitrarily large if the file is corrupted. Compare without
+	// adding to it, since the sum could overflow. firstByteIndex < flushedSize was checked above, and the first
+	// condition ensures that the second subtraction can't underflow.
+	if flushedSize-firstByteIndex < v.headerSize() || length > flushedSize-firstByteIndex-v.headerSize() {
+		return nil, fmt.Errorf("value at index %d with length %d is out of bounds (current flushed size is %d)",
+			firstByteIndex, length, flushedSize)
+	}
+
 	// Read the value itself.
 	value := make([]byte, length)
 	bytesRead, err := io.ReadFull(reader, value)
@@ -226,40 +269,68 @@ func (v *valueFile) read(firstByteIndex uint32) ([]byte, error) {
 		return nil, fmt.Errorf("failed to read value from value file: %v", err)
 	}
 
-	if uint32(bytesRead) != length {
+	if uint64(bytesRead) != length {
 		return nil, fmt.Errorf("failed to read value from value file: read %d bytes, expected %d", bytesRead, length)
 	}
 
+	if v.segmentVersion >= ChecksumSegmentVersion {
+		actualChecksum := computeChecksum(value)
+		if actualChecksum != checksum {
+			return nil, fmt.Errorf("value at index %d in %s: expected checksum %08x, got %08x: %w",
+				firstByteIndex, v.path(), checksum, actualChecksum, ErrChecksumMismatch)
+		}
+	}
+
 	return value, nil
 }
 
-// write writes a value to the value file, returning the index of the first byte written.
-func (v *valueFile) write(value []byte) (uint32, error) {
+// write writes a value to the value file, returning the index of the first byte written. The checksum is ignored
+// if this value file does not contain checksums.
+func (v *valueFile) write(value []byte, checksum uint32) (uint64, error) {
 	if v.writer == nil {
 		return 0, fmt.Errorf("value file is sealed")
 	}
 
-	if v.size > math.MaxUint32 {
-		// No matter what, we can't start a new value if its first byte would be beyond position 2^32.
-		// This is because we only have 32 bits in an address to store the position of a value's first byte.
-		return 0, fmt.Errorf("value file already contains %d bytes, cannot add a new value", v.size)
+	if v.segmentVersion < LargeValueSegmentVersion {
+		if v.size > math.MaxUint32 {
+			// We can't start a new value if its first byte would be beyond position 2^32. This is because this
+			// segment version only has 32 bits in an address to store the position of a value's first byte.
+			return 0, fmt.Errorf("value file already contains %d bytes, cannot add a new value", v.size)
+		}
+		if uint64(len(value)) > math.MaxUint32 {
+			return 0, fmt.Errorf("value is too large for segment version %d: %d bytes",
+				v.segmentVersion, len(value))
+		}
 	}
 
-	firstByteIndex := uint32(v.size)
+	firstByteIndex := v.size
 
 	// First, write the length of the value.
-	err := binary.Write(v.writer, binary.BigEndian, uint32(len(value)))
+	var err error
+	if v.segmentVersion >= LargeValueSegmentVersion {
+		err = binary.Write(v.writer, binary.BigEndian, uint64(len(value)))
+	} else {
+		err = binary.Write(v.writer, binary.BigEndian, uint32(len(value)))
+	}
 	if err != nil {
 		return 0, fmt.Errorf("failed to write value length to value file: %v", err)
 	}
 
+	// Next, write the checksum.
+	if v.segmentVersion >= ChecksumSegmentVersion {
+		err = binary.Write(v.writer, binary.BigEndian, checksum)
+		if err != nil {
+			return 0, fmt.Errorf("failed to write value checksum to value file: %v", err)
+		}
+	}
+
 	// Then, write the value itself.
 	_, err = v.writer.Write(value)
 	if err != nil {
 		return 0, fmt.Errorf("failed to write value to value file: %v", err)
 	}
 
-	v.size += uint64(len(value) + 4)
+	v.size += uint64(len(value)) + v.headerSize()
 
 	return firstByteIndex, nil
 }
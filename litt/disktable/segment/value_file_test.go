@@ -1,6 +1,8 @@
 package segment
 
 import (
+	"encoding/binary"
+	"math"
 	"os"
 	"testing"
 
@@ -23,17 +25,17 @@ func TestWriteThenReadValues(t *testing.T) {
 	expectedFileSize := uint64(0)
 	for i := 0; i < int(valueCount); i++ {
 		values[i] = rand.VariableBytes(1, 100)
-		expectedFileSize += uint64(len(values[i])) + 4 /* length uint32 */
+		expectedFileSize += uint64(len(values[i])) + 8 /* length uint64 */ + 4 /* checksum uint32 */
 	}
 
 	// A map from the first byte index of the value to the value itself.
-	addressMap := make(map[uint32][]byte)
+	addressMap := make(map[uint64][]byte)
 
-	file, err := createValueFile(logger, index, shard, directory, false)
+	file, err := createValueFile(logger, index, shard, directory, LatestSegmentVersion, false)
 	require.NoError(t, err)
 
 	for _, value := range values {
-		address, err := file.write(value)
+		address, err := file.write(value, computeChecksum(value))
 		require.NoError(t, err)
 		addressMap[address] = value
 
@@ -71,7 +73,7 @@ func TestWriteThenReadValues(t *testing.T) {
 	require.Equal(t, actualFileSize, reportedFileSize)
 
 	// Create a new in-memory instance from the on-disk file and verify that it behaves the same.
-	file2, err := loadValueFile(logger, index, shard, []string{directory})
+	file2, err := loadValueFile(logger, index, shard, []string{directory}, LatestSegmentVersion)
 	require.NoError(t, err)
 	require.Equal(t, file.size, file2.size)
 	for key, val := range addressMap {
@@ -108,14 +110,14 @@ func TestReadingTruncatedValueFile(t *testing.T) {
 	}
 
 	// A map from the first byte index of the value to the value itself.
-	addressMap := make(map[uint32][]byte)
+	addressMap := make(map[uint64][]byte)
 
-	file, err := createValueFile(logger, index, shard, directory, false)
+	file, err := createValueFile(logger, index, shard, directory, LatestSegmentVersion, false)
 	require.NoError(t, err)
 
-	var lastAddress uint32
+	var lastAddress uint64
 	for _, value := range values {
-		address, err := file.write(value)
+		address, err := file.write(value, computeChecksum(value))
 		require.NoError(t, err)
 		addressMap[address] = value
 		lastAddress = address
@@ -138,7 +140,7 @@ func TestReadingTruncatedValueFile(t *testing.T) {
 	err = os.WriteFile(filePath, bytes, 0644)
 	require.NoError(t, err)
 
-	file, err = loadValueFile(logger, index, shard, []string{directory})
+	file, err = loadValueFile(logger, index, shard, []string{directory}, LatestSegmentVersion)
 	require.NoError(t, err)
 
 	// We should be able to read all values except for the last one.
@@ -160,7 +162,7 @@ func TestReadingTruncatedValueFile(t *testing.T) {
 	err = os.WriteFile(filePath, bytes, 0644)
 	require.NoError(t, err)
 
-	file, err = loadValueFile(logger, index, shard, []string{directory})
+	file, err = loadValueFile(logger, index, shard, []string{directory}, LatestSegmentVersion)
 	require.NoError(t, err)
 
 	// We should be able to read all values except for the last one.
@@ -185,3 +187,32 @@ func TestReadingTruncatedValueFile(t *testing.T) {
 	_, err = os.Stat(filePath)
 	require.True(t, os.IsNotExist(err))
 }
+
+func TestReadingValueWithCorruptedLength(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+	logger, err := common.NewLogger(common.DefaultTextLoggerConfig())
+	require.NoError(t, err)
+	directory := t.TempDir()
+
+	file, err := createValueFile(logger, rand.Uint32(), rand.Uint32(), directory, LatestSegmentVersion, false)
+	require.NoError(t, err)
+
+	value := rand.VariableBytes(1, 100)
+	address, err := file.write(value, computeChecksum(value))
+	require.NoError(t, err)
+	err = file.seal()
+	require.NoError(t, err)
+
+	// Overwrite the length prefix with a value large enough that adding it to the address would overflow.
+	fileBytes, err := os.ReadFile(file.path())
+	require.NoError(t, err)
+	binary.BigEndian.PutUint64(fileBytes[address:], math.MaxUint64-4)
+	err = os.WriteFile(file.path(), fileBytes, 0644)
+	require.NoError(t, err)
+
+	file, err = loadValueFile(logger, file.index, file.shard, []string{directory}, LatestSegmentVersion)
+	require.NoError(t, err)
+	_, err = file.read(address)
+	require.Error(t, err)
+}
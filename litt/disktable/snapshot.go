@@ -0,0 +1,146 @@
+package disktable
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+
+	"github.com/Layr-Labs/eigenda/litt/disktable/segment"
+	"github.com/Layr-Labs/eigenda/litt/util"
+)
+
+// SnapshotHardLinkDirectory is the name of the directory, relative to each of the table's root directories, where
+// hard links to snapshotted segment files are stored. Hard links protect snapshot data from being deleted by the
+// garbage collector. Since hard links can't span filesystems, there is one of these directories per root.
+const SnapshotHardLinkDirectory = "snapshot"
+
+// SnapshotUpperBoundFileName is the name of the file in a table's snapshot directory that contains the index of the
+// highest segment that has been added to the snapshot.
+const SnapshotUpperBoundFileName = "upper-bound.txt"
+
+// Snapshot adds all data currently in the table to a snapshot in the given directory. Writes may continue while
+// the snapshot is being taken, but data written concurrently with this method may or may not be included.
+//
+// Within the snapshot directory, the table's segment files appear as symlinks in <directory>/<table>/segments.
+// Each symlink points to a hard link in one of the table's roots (<root>/<table>/snapshot). The table's metadata
+// is copied into <directory>/<table>. Snapshots are incremental: if this method is called multiple times with the
+// same directory, only segments that are not already in the snapshot are added.
+//
+// LittDB never deletes snapshot files. It is the responsibility of external tooling to delete both the symlinks
+// and the hard links once they are no longer needed. "litt prune", when run against the snapshot directory, deletes
+// both.
+func (d *DiskTable) Snapshot(directory string) error {
+	if ok, err := d.errorMonitor.IsOk(); !ok {
+		return fmt.Errorf("cannot process Snapshot() request, DB is in panicked state due to error: %w", err)
+	}
+
+	request := &controlLoopReserveSegmentsRequest{
+		responseChan: make(chan []*segment.Segment, 1),
+	}
+	err := d.controlLoop.enqueue(request)
+	if err != nil {
+		return fmt.Errorf("failed to send snapshot request: %w", err)
+	}
+
+	segments, err := util.Await(d.errorMonitor, request.responseChan)
+	if err != nil {
+		return fmt.Errorf("failed to await snapshot segments: %w", err)
+	}
+	defer func() {
+		for _, seg := range segments {
+			seg.Release()
+		}
+	}()
+
+	tableSnapshotDirectory := path.Join(directory, d.name)
+	snapshotSegmentDirectory := path.Join(tableSnapshotDirectory, SegmentDirectory)
+	err = util.EnsureDirectoryExists(snapshotSegmentDirectory, d.fsync)
+	if err != nil {
+		return fmt.Errorf("failed to create snapshot directory %s: %w", snapshotSegmentDirectory, err)
+	}
+
+	for _, seg := range segments {
+		for _, filePath := range seg.GetFilePaths() {
+			err = d.snapshotFile(filePath, snapshotSegmentDirectory)
+			if err != nil {
+				return fmt.Errorf("failed to snapshot segment %d: %w", seg.SegmentIndex(), err)
+			}
+		}
+	}
+
+	if d.fsync {
+		err = util.SyncPath(snapshotSegmentDirectory)
+		if err != nil {
+			return fmt.Errorf("failed to sync snapshot directory: %w", err)
+		}
+	}
+
+	err = util.AtomicWrite(path.Join(tableSnapshotDirectory, TableMetadataFileName), d.metadata.serialize(), d.fsync)
+	if err != nil {
+		return fmt.Errorf("failed to write table metadata to snapshot: %w", err)
+	}
+
+	if len(segments) > 0 {
+		upperBound := segments[len(segments)-1].SegmentIndex()
+		err = util.AtomicWrite(
+			path.Join(tableSnapshotDirectory, SnapshotUpperBoundFileName),
+			[]byte(strconv.FormatUint(uint64(upperBound), 10)),
+			d.fsync)
+		if err != nil {
+			return fmt.Errorf("failed to write snapshot upper bound: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// snapshotFile hard links a segment file into the snapshot directory of the root that contains it, and then creates
+// a symlink to that hard link in the snapshot segment directory. Files that are already in the snapshot are skipped.
+func (d *DiskTable) snapshotFile(filePath string, snapshotSegmentDirectory string) error {
+	fileName := filepath.Base(filePath)
+
+	// filePath is <root>/<table>/segments/<file>, the hard link goes in <root>/<table>/snapshot/<file>
+	tableRoot := filepath.Dir(filepath.Dir(filePath))
+	hardLinkDirectory := path.Join(tableRoot, SnapshotHardLinkDirectory)
+	err := util.EnsureDirectoryExists(hardLinkDirectory, d.fsync)
+	if err != nil {
+		return fmt.Errorf("failed to create hard link directory %s: %w", hardLinkDirectory, err)
+	}
+
+	hardLinkPath := path.Join(hardLinkDirectory, fileName)
+	exists, err := util.Exists(hardLinkPath)
+	if err != nil {
+		return fmt.Errorf("failed to check if hard link %s exists: %w", hardLinkPath, err)
+	}
+	if !exists {
+		err = os.Link(filePath, hardLinkPath)
+		if err != nil {
+			return fmt.Errorf("failed to create hard link %s: %w", hardLinkPath, err)
+		}
+		if d.fsync {
+			err = util.SyncPath(hardLinkDirectory)
+			if err != nil {
+				return fmt.Errorf("failed to sync hard link directory: %w", err)
+			}
+		}
+	}
+
+	symlinkPath := path.Join(snapshotSegmentDirectory, fileName)
+	_, err = os.Lstat(symlinkPath)
+	if err == nil {
+		// The file is already in the snapshot.
+		return nil
+	}
+	if !os.IsNotExist(err) {
+		return fmt.Errorf("failed to check if symlink %s exists: %w", symlinkPath, err)
+	}
+
+	err = os.Symlink(hardLinkPath, symlinkPath)
+	if err != nil {
+		return fmt.Errorf("failed to create symlink %s: %w", symlinkPath, err)
+	}
+
+	return nil
+}
@@ -1,10 +1,12 @@
 package disktable
 
 import (
+	"bytes"
 	"encoding/binary"
 	"fmt"
 	"os"
 	"path"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -15,7 +17,10 @@ import (
 const tableMetadataSerializationVersion = 0
 
 const tableMetadataFileExtension = ".metadata"
-const tableMetadataFileName = "table" + tableMetadataFileExtension
+
+// TableMetadataFileName is the name of the file that contains a table's metadata. This file appears in exactly one
+// of the table's root directories.
+const TableMetadataFileName = "table" + tableMetadataFileExtension
 const tableMetadataSwapFileExtension = ".mswap"
 const tableMetadataSwapFileName = "table" + tableMetadataSwapFileExtension
 
@@ -25,8 +30,12 @@ const tableMetadataSize = 16
 type tableMetadata struct {
 	logger logging.Logger
 
+	// The directory containing the metadata file. Changes if the metadata is moved to a different root.
 	tableDirectory string
 
+	// Protects tableDirectory, and serializes writes to the metadata file.
+	lock sync.Mutex
+
 	// the table's TTL, accessed/modified by concurrent goroutines
 	ttl atomic.Pointer[time.Duration]
 
@@ -99,6 +108,70 @@ func loadTableMetadata(logger logging.Logger, tableDirectory string) (*tableMeta
 	return metadata, nil
 }
 
+// findMetadataFile returns the path to a table's metadata file, searching each of the table's root directories.
+// Returns an empty string if there is no metadata file. A crash while the metadata file was being moved between
+// roots may leave identical copies in several roots, in which case the first is returned, along with the paths of
+// the other copies. Returns an error if the copies are not identical.
+func findMetadataFile(roots []string) (metadataFilePath string, duplicates []string, err error) {
+	var data []byte
+	for _, root := range roots {
+		possiblePath := metadataPath(root)
+		exists, err := util.Exists(possiblePath)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to check if table metadata file exists: %v", err)
+		}
+		if !exists {
+			continue
+		}
+
+		if metadataFilePath == "" {
+			metadataFilePath = possiblePath
+			data, err = os.ReadFile(possiblePath)
+			if err != nil {
+				return "", nil, fmt.Errorf("failed to read table metadata file %s: %v", possiblePath, err)
+			}
+			continue
+		}
+
+		duplicate, err := os.ReadFile(possiblePath)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to read table metadata file %s: %v", possiblePath, err)
+		}
+		if !bytes.Equal(data, duplicate) {
+			return "", nil, fmt.Errorf("multiple metadata files found with different contents: %s and %s",
+				metadataFilePath, possiblePath)
+		}
+		duplicates = append(duplicates, possiblePath)
+	}
+
+	return metadataFilePath, duplicates, nil
+}
+
+// ReadTableTTL reads the TTL stored in a table's metadata file without loading the table. The metadata file is
+// searched for in each of the table's root directories. Returns an error if the metadata file is not found, or if
+// differing copies are found in several root directories. This function never modifies any files on disk.
+func ReadTableTTL(roots []string) (time.Duration, error) {
+	mPath, _, err := findMetadataFile(roots)
+	if err != nil {
+		return 0, err
+	}
+	if mPath == "" {
+		return 0, fmt.Errorf("table metadata file not found in any of %v", roots)
+	}
+
+	data, err := os.ReadFile(mPath)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read table metadata file %s: %v", mPath, err)
+	}
+
+	metadata, err := deserialize(data)
+	if err != nil {
+		return 0, fmt.Errorf("failed to deserialize table metadata: %v", err)
+	}
+
+	return metadata.GetTTL(), nil
+}
+
 // Size returns the size of the table metadata file in bytes.
 func (t *tableMetadata) Size() uint64 {
 	return tableMetadataSize
@@ -134,8 +207,49 @@ func (t *tableMetadata) SetShardingFactor(shardingFactor uint32) error {
 	return nil
 }
 
+// directory returns the directory containing the metadata file.
+func (t *tableMetadata) directory() string {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.tableDirectory
+}
+
+// moveTo moves the metadata file into the given table directory. The file is written to its new location before it
+// is deleted from its old one, so a crash leaves at least one copy behind (see findMetadataFile).
+func (t *tableMetadata) moveTo(tableDirectory string) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if tableDirectory == t.tableDirectory {
+		return nil
+	}
+
+	err := util.AtomicWrite(metadataPath(tableDirectory), t.serialize(), t.fsync)
+	if err != nil {
+		return fmt.Errorf("failed to write table metadata file to %s: %v", tableDirectory, err)
+	}
+
+	oldPath := metadataPath(t.tableDirectory)
+	err = os.Remove(oldPath)
+	if err != nil {
+		return fmt.Errorf("failed to delete table metadata file %s: %v", oldPath, err)
+	}
+	if t.fsync {
+		err = util.SyncParentPath(oldPath)
+		if err != nil {
+			return fmt.Errorf("failed to sync parent directory of %s: %v", oldPath, err)
+		}
+	}
+
+	t.tableDirectory = tableDirectory
+	return nil
+}
+
 // Store atomically stores the table metadata to disk.
 func (t *tableMetadata) write() error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
 	err := util.AtomicWrite(metadataPath(t.tableDirectory), t.serialize(), t.fsync)
 	if err != nil {
 		return fmt.Errorf("failed to write table metadata file: %v", err)
@@ -190,7 +304,7 @@ func deserialize(data []byte) (*tableMetadata, error) {
 
 // delete deletes the table metadata from disk.
 func (t *tableMetadata) delete() error {
-	metadataPath := path.Join(t.tableDirectory, tableMetadataFileName)
+	metadataPath := path.Join(t.directory(), TableMetadataFileName)
 	err := os.Remove(metadataPath)
 	if err != nil {
 		return fmt.Errorf("failed to delete table metadata file %s: %v", metadataPath, err)
@@ -200,7 +314,7 @@ func (t *tableMetadata) delete() error {
 
 // path returns the path to the table metadata file.
 func metadataPath(tableDirectory string) string {
-	return path.Join(tableDirectory, tableMetadataFileName)
+	return path.Join(tableDirectory, TableMetadataFileName)
 }
 
 // swapPath returns the path to the table metadata swap file.
@@ -21,13 +21,6 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
-// keymapBuilders contains builders for all supported keymap types.
-var keymapBuilders = map[keymap.KeymapType]keymap.BuildKeymap{
-	keymap.MemKeymapType:           keymap.NewMemKeymap,
-	keymap.LevelDBKeymapType:       keymap.NewLevelDBKeymap,
-	keymap.UnsafeLevelDBKeymapType: keymap.NewUnsafeLevelDBKeymap,
-}
-
 // cacheWeight is a function that calculates the weight of a cache entry.
 func cacheWeight(key string, value []byte) uint64 {
 	return uint64(len(key) + len(value))
@@ -40,7 +33,7 @@ func buildKeymap(
 	tableName string,
 ) (kmap keymap.Keymap, keymapPath string, keymapTypeFile *keymap.KeymapTypeFile, requiresReload bool, err error) {
 
-	builderForConfiguredType, ok := keymapBuilders[config.KeymapType]
+	builderForConfiguredType, ok := keymap.GetBuilder(config.KeymapType)
 	if !ok {
 		return nil, "", nil, false,
 			fmt.Errorf("unsupported keymap type: %v", config.KeymapType)
@@ -51,57 +44,75 @@ func buildKeymap(
 		potentialKeymapDirectories[i] = path.Join(p, tableName, keymap.KeymapDirectoryName)
 	}
 
+	// A staging directory is left behind if the DB crashed while moving the keymap to a different root. The keymap
+	// in the staging directory may be incomplete, and the original keymap is still intact.
+	for _, p := range config.Paths {
+		stagingDirectory := path.Join(p, tableName, keymap.KeymapStagingDirectoryName)
+		exists, err := util.Exists(stagingDirectory)
+		if err != nil {
+			return nil, "", nil, false,
+				fmt.Errorf("error checking for keymap staging directory: %w", err)
+		}
+		if exists {
+			logger.Warnf("incomplete keymap move detected. Deleting keymap staging directory: %s", stagingDirectory)
+			err = os.RemoveAll(stagingDirectory)
+			if err != nil {
+				return nil, "", nil, false,
+					fmt.Errorf("error deleting keymap staging directory: %w", err)
+			}
+		}
+	}
+
 	// The directory where the keymap data is stored. There are multiple plausible directories, but there
-	// should only be keymap data that exists in at most one of them.
+	// should only be initialized keymap data in at most one of them.
 	var keymapDirectory string
 
-	// If there is no keymap on disk or if the prior keymap was not fully initialized, this will be false. If false,
-	// the keymap will need to be reloaded as a part of the table initialization.
-	var keymapInitialized bool
-
 	for _, directory := range potentialKeymapDirectories {
 		exists, err := util.Exists(directory)
 		if err != nil {
 			return nil, "", nil, false,
 				fmt.Errorf("error checking for keymap type file: %w", err)
 		}
-		if exists {
-			if keymapDirectory != "" {
-				return nil, "", nil, false,
-					fmt.Errorf("multiple keymap directories found: %s and %s", keymapDirectory, directory)
-			}
+		if !exists {
+			continue
+		}
 
-			keymapDirectory = directory
-			keymapTypeFile, err = keymap.LoadKeymapTypeFile(directory)
+		initializedExists, err := util.Exists(path.Join(directory, keymap.KeymapInitializedFileName))
+		if err != nil {
+			return nil, "", nil, false,
+				fmt.Errorf("error checking for keymap initialized file: %w", err)
+		}
+		if !initializedExists {
+			// The keymap has not been fully initialized. This is likely due to a crash during the keymap reloading
+			// process, or during the deletion of the old copy of a keymap that was moved to a different root.
+			logger.Warnf("incomplete keymap initialization detected. Deleting keymap directory: %s", directory)
+			err = os.RemoveAll(directory)
 			if err != nil {
 				return nil, "", nil, false,
-					fmt.Errorf("error loading keymap type file: %w", err)
+					fmt.Errorf("error deleting keymap directory: %w", err)
 			}
+			continue
+		}
 
-			initializedExists, err := util.Exists(path.Join(keymapDirectory, keymap.KeymapInitializedFileName))
+		if keymapDirectory != "" {
+			// The DB crashed while moving the keymap to a different root, after the copy was complete but before
+			// the original was deleted. The two copies are identical.
+			logger.Warnf("keymap in %s is a duplicate of %s, likely due to a crash while moving it. Deleting %s",
+				directory, keymapDirectory, directory)
+			err = os.RemoveAll(directory)
 			if err != nil {
 				return nil, "", nil, false,
-					fmt.Errorf("error checking for keymap initialized file: %w", err)
-			}
-			if initializedExists {
-				keymapInitialized = true
+					fmt.Errorf("error deleting duplicate keymap directory: %w", err)
 			}
+			continue
 		}
-	}
-
-	if keymapTypeFile != nil && !keymapInitialized {
-		// The keymap has not been fully initialized. This is likely due to a crash during the keymap reloading process.
-		logger.Warnf("incomplete keymap initialization detected. Deleting keymap directory: %s",
-			keymapDirectory)
 
-		err := os.RemoveAll(keymapDirectory)
+		keymapDirectory = directory
+		keymapTypeFile, err = keymap.LoadKeymapTypeFile(directory)
 		if err != nil {
 			return nil, "", nil, false,
-				fmt.Errorf("error deleting keymap directory: %w", err)
+				fmt.Errorf("error loading keymap type file: %w", err)
 		}
-
-		keymapTypeFile = nil
-		keymapDirectory = ""
 	}
 
 	newKeymap := false
@@ -129,8 +140,21 @@ func buildKeymap(
 
 	} else {
 		// A previous keymap exists. Check if the keymap type has changed.
-		if config.KeymapType != keymapTypeFile.Type() {
-			// The previously used keymap type is different from the one in the configuration.
+		if config.KeymapType != keymapTypeFile.Type() && keymapTypeFile.CanMigrateInPlace(config.KeymapType) {
+			// The previously used keymap type stores data in the same format as the configured type
+			// (e.g. LevelDBKeymap and UnsafeLevelDBKeymap), so the existing data can be kept.
+			logger.Infof("migrating keymap in %s from %s to %s in place",
+				keymapDirectory, keymapTypeFile.Type(), config.KeymapType)
+			err = keymapTypeFile.MigrateInPlace(config.KeymapType)
+			if err != nil {
+				return nil, "", nil, false,
+					fmt.Errorf("error migrating keymap: %w", err)
+			}
+		} else if config.KeymapType != keymapTypeFile.Type() {
+			// The previously used keymap type is different from the one in the configuration. The keymap
+			// is deleted and rebuilt from the key files.
+			logger.Infof("migrating keymap in %s from %s to %s, keymap will be rebuilt",
+				keymapDirectory, keymapTypeFile.Type(), config.KeymapType)
 
 			keymapTypeFile = nil
 
@@ -204,6 +228,28 @@ func buildTable(
 		tableRoots[i] = path.Join(p, name)
 	}
 
+	writeCache, err := cache.NewCache[string, []byte](
+		config.CacheEvictionPolicy, config.WriteCacheSize, cacheWeight, metrics.GetWriteCacheMetrics())
+	if err != nil {
+		return nil, fmt.Errorf("error creating write cache: %w", err)
+	}
+	writeCache = cache.NewThreadSafeCache(writeCache)
+
+	readCache, err := cache.NewCache[string, []byte](
+		config.CacheEvictionPolicy, config.ReadCacheSize, cacheWeight, metrics.GetReadCacheMetrics())
+	if err != nil {
+		return nil, fmt.Errorf("error creating read cache: %w", err)
+	}
+	readCache = cache.NewThreadSafeCache(readCache)
+
+	// Quarantined keys are no longer in the table, so they must not be served from the caches either.
+	quarantineCallback := func(keys [][]byte) {
+		for _, key := range keys {
+			writeCache.Remove(string(key))
+			readCache.Remove(string(key))
+		}
+	}
+
 	table, err = disktable.NewDiskTable(
 		config,
 		name,
@@ -212,18 +258,13 @@ func buildTable(
 		keymapTypeFile,
 		tableRoots,
 		requiresReload,
-		metrics)
+		metrics,
+		quarantineCallback)
 
 	if err != nil {
 		return nil, fmt.Errorf("error creating table: %w", err)
 	}
 
-	writeCache := cache.NewFIFOCache[string, []byte](config.WriteCacheSize, cacheWeight, metrics.GetWriteCacheMetrics())
-	writeCache = cache.NewThreadSafeCache(writeCache)
-
-	readCache := cache.NewFIFOCache[string, []byte](config.ReadCacheSize, cacheWeight, metrics.GetReadCacheMetrics())
-	readCache = cache.NewThreadSafeCache(readCache)
-
 	cachedTable := tablecache.NewCachedTable(table, writeCache, readCache, metrics)
 
 	return cachedTable, nil
@@ -4,14 +4,19 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"os"
+	"path"
 	"regexp"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/disktable"
 	"github.com/Layr-Labs/eigenda/litt/metrics"
+	"github.com/Layr-Labs/eigenda/litt/util"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 )
 
@@ -59,6 +64,25 @@ type db struct {
 
 	// The HTTP server for metrics. nil if metrics are disabled or if an external party is managing the server.
 	metricsServer *http.Server
+
+	// The DB's configuration. The paths in the configuration are updated by AddPath() and RemovePath(). A removed
+	// path stays in the configuration until all data has been moved out of it. Protected by lock.
+	config *litt.Config
+
+	// Paths that have been removed by RemovePath() but that still contain data that is being moved out. New tables
+	// are not placed in these paths. Protected by lock.
+	removingPaths map[string]struct{}
+
+	// For each root directory, a function that releases the lock file held on that directory. Empty if this DB
+	// does not hold any locks. Protected by lock.
+	releaseLocks map[string]func()
+
+	// If true, the DB was opened in read-only mode, and its paths may not be changed.
+	readOnly bool
+
+	// Ensures that only one backup pass runs at a time, and that the DB is not closed while a backup is in progress.
+	// Must be acquired before lock if both are held.
+	backupLock sync.Mutex
 }
 
 // NewDB creates a new DB instance. After this method is called, the config object should not be modified.
@@ -87,21 +111,153 @@ func NewDB(config *litt.Config) (litt.DB, error) {
 			"Fsync is disabled. Ok for unit tests that need to run fast, NOT OK FOR PRODUCTION USE.")
 	}
 
+	for _, p := range config.Paths {
+		err = util.EnsureDirectoryExists(p, config.Fsync)
+		if err != nil {
+			return nil, fmt.Errorf("error creating root directory %s: %w", p, err)
+		}
+	}
+
+	// Prevent other processes (e.g. other DB instances or the LittDB CLI) from modifying our files.
+	releaseLocks := make(map[string]func(), len(config.Paths))
+	for _, p := range config.Paths {
+		release, err := util.LockDirectories(config.Logger, []string{p}, util.LockfileName, config.Fsync)
+		if err != nil {
+			releaseAll(releaseLocks)
+			return nil, fmt.Errorf("error acquiring locks on root directories: %w", err)
+		}
+		releaseLocks[p] = release
+	}
+
+	err = checkRecordedPaths(config.Logger, config.Paths)
+	if err != nil {
+		releaseAll(releaseLocks)
+		return nil, err
+	}
+	err = writePathsFiles(config.Paths, config.Paths, config.Fsync)
+	if err != nil {
+		releaseAll(releaseLocks)
+		return nil, err
+	}
+
+	var database *db
 	tableBuilder := func(
 		ctx context.Context,
 		logger logging.Logger,
 		name string,
 		metrics *metrics.LittDBMetrics) (litt.ManagedTable, error) {
 
-		return buildTable(config, logger, name, metrics)
+		return buildTable(database.newTableConfig(), logger, name, metrics)
+	}
+
+	database, err = newDB(config, tableBuilder, releaseLocks)
+	if err != nil {
+		releaseAll(releaseLocks)
+		return nil, err
+	}
+
+	if config.BackupTarget != nil {
+		go database.backupLoop(config.BackupTarget, config.BackupPeriod)
 	}
 
-	return NewDBUnsafe(config, tableBuilder)
+	return database, nil
+}
+
+// NewReadOnlyDB opens a DB for reading without taking the locks on its root directories. The DB may be in use by
+// another process at the same time (e.g. a validator node), and that process continues to own the DB's files.
+// Equivalent to calling NewReadOnlyDBFromConfig() with a default config for the given paths.
+func NewReadOnlyDB(paths ...string) (litt.DB, error) {
+	config, err := litt.DefaultConfig(paths...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating config: %w", err)
+	}
+	return NewReadOnlyDBFromConfig(config)
+}
+
+// NewReadOnlyDBFromConfig opens a DB for reading without taking the locks on its root directories.
+//
+// Only data in sealed segments is visible, and the set of visible segments is refreshed once per GC period.
+// Segments deleted by the owning process's garbage collector are dropped. GetTable() returns an error if the
+// table does not exist, and operations that modify data (e.g. Put, Flush, SetTTL) return an error wrapping
+// litt.ErrReadOnly. Configuration that only applies to writers (e.g. TTL, sharding factor, keymap type) is ignored.
+//
+// Metrics are always disabled for a read-only DB, regardless of Config.MetricsEnabled. The process that owns the DB
+// reports its metrics, and a second metrics server or set of collectors would conflict with the owner's. The
+// caller's config is not modified.
+func NewReadOnlyDBFromConfig(config *litt.Config) (litt.DB, error) {
+	var err error
+
+	readOnlyConfig := *config
+	readOnlyConfig.MetricsEnabled = false
+	config = &readOnlyConfig
+
+	if config.Logger == nil {
+		config.Logger, err = buildLogger(config)
+		if err != nil {
+			return nil, fmt.Errorf("error building logger: %w", err)
+		}
+	}
+
+	err = config.SanityCheck()
+	if err != nil {
+		return nil, fmt.Errorf("error checking config: %w", err)
+	}
+
+	err = config.SanitizePaths()
+	if err != nil {
+		return nil, fmt.Errorf("error expanding tildes in config: %w", err)
+	}
+
+	for _, p := range config.Paths {
+		exists, err := util.Exists(p)
+		if err != nil {
+			return nil, fmt.Errorf("error checking root directory %s: %w", p, err)
+		}
+		if !exists {
+			return nil, fmt.Errorf("root directory %s does not exist", p)
+		}
+	}
+
+	tableBuilder := func(
+		ctx context.Context,
+		logger logging.Logger,
+		name string,
+		metrics *metrics.LittDBMetrics) (litt.ManagedTable, error) {
+
+		tableRoots := make([]string, len(config.Paths))
+		for i, p := range config.Paths {
+			tableRoots[i] = path.Join(p, name)
+		}
+		return disktable.NewReadOnlyTable(config, name, tableRoots)
+	}
+
+	database, err := newDB(config, tableBuilder, nil)
+	if err != nil {
+		return nil, err
+	}
+	database.readOnly = true
+
+	return database, nil
 }
 
 // NewDBUnsafe creates a new DB instance with a custom table builder. This is intended for unit test use,
 // and should not be considered a stable API.
 func NewDBUnsafe(config *litt.Config, tableBuilder TableBuilderFunc) (litt.DB, error) {
+	database, err := newDB(config, tableBuilder, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.BackupTarget != nil {
+		go database.backupLoop(config.BackupTarget, config.BackupPeriod)
+	}
+
+	return database, nil
+}
+
+// newDB creates a new DB instance. The functions in releaseLocks (if any) are called when the DB is closed or
+// destroyed.
+func newDB(config *litt.Config, tableBuilder TableBuilderFunc, releaseLocks map[string]func()) (*db, error) {
 	var err error
 
 	if config.Logger == nil {
@@ -127,6 +283,12 @@ func NewDBUnsafe(config *litt.Config, tableBuilder TableBuilderFunc) (litt.DB, e
 		tables:        make(map[string]litt.ManagedTable),
 		metrics:       dbMetrics,
 		metricsServer: metricsServer,
+		config:        config,
+		removingPaths: make(map[string]struct{}),
+		releaseLocks:  releaseLocks,
+	}
+	if database.releaseLocks == nil {
+		database.releaseLocks = make(map[string]func())
 	}
 
 	if config.MetricsEnabled {
@@ -164,6 +326,21 @@ func (d *db) lockFreeSize() uint64 {
 	return size
 }
 
+// newTableConfig returns the configuration to use when building a table. Paths that are being removed are
+// excluded. The caller must hold the lock.
+func (d *db) newTableConfig() *litt.Config {
+	if len(d.removingPaths) == 0 {
+		return d.config
+	}
+
+	config := *d.config
+	config.Paths = slices.DeleteFunc(slices.Clone(d.config.Paths), func(p string) bool {
+		_, removing := d.removingPaths[p]
+		return removing
+	})
+	return &config
+}
+
 // isTableNameValid returns true if the table name is valid.
 func (d *db) isTableNameValid(name string) bool {
 	return tableNameRegex.MatchString(name)
@@ -173,6 +350,11 @@ func (d *db) GetTable(name string) (litt.Table, error) {
 	d.lock.Lock()
 	defer d.lock.Unlock()
 
+	return d.lockFreeGetTable(name)
+}
+
+// lockFreeGetTable gets a table by name, creating one if it does not exist. The caller must hold the lock.
+func (d *db) lockFreeGetTable(name string) (litt.ManagedTable, error) {
 	table, ok := d.tables[name]
 	if !ok {
 		if !d.isTableNameValid(name) {
@@ -217,7 +399,241 @@ func (d *db) DropTable(name string) error {
 	return nil
 }
 
+func (d *db) Snapshot(directory string) error {
+	directory, err := util.SanitizePath(directory)
+	if err != nil {
+		return fmt.Errorf("error sanitizing snapshot directory %s: %w", directory, err)
+	}
+
+	// Don't hold the lock while the snapshot is being taken, the snapshot may take a while to complete.
+	d.lock.Lock()
+	tables := make([]litt.ManagedTable, 0, len(d.tables))
+	for _, table := range d.tables {
+		tables = append(tables, table)
+	}
+	d.lock.Unlock()
+
+	for _, table := range tables {
+		err = table.Snapshot(directory)
+		if err != nil {
+			return fmt.Errorf("error taking snapshot of table %s: %w", table.Name(), err)
+		}
+	}
+
+	return nil
+}
+
+func (d *db) Backup(ctx context.Context, target litt.BackupTarget) error {
+	if d.readOnly {
+		return fmt.Errorf("cannot back up database: %w", litt.ErrReadOnly)
+	}
+
+	d.backupLock.Lock()
+	defer d.backupLock.Unlock()
+
+	if d.stopped.Load() {
+		return fmt.Errorf("cannot back up database, database is stopped")
+	}
+
+	// Don't hold the lock while the backup is being taken, uploading segment files may take a long time.
+	d.lock.Lock()
+	tables := make([]litt.ManagedTable, 0, len(d.tables))
+	for _, table := range d.tables {
+		tables = append(tables, table)
+	}
+	d.lock.Unlock()
+
+	for _, table := range tables {
+		err := table.Backup(ctx, target)
+		if err != nil {
+			return fmt.Errorf("error backing up table %s: %w", table.Name(), err)
+		}
+	}
+
+	err := target.Commit(ctx)
+	if err != nil {
+		return fmt.Errorf("error committing backup: %w", err)
+	}
+
+	return nil
+}
+
+func (d *db) AddPath(p string) error {
+	if d.readOnly {
+		return fmt.Errorf("cannot add path %s: %w", p, litt.ErrReadOnly)
+	}
+
+	p, err := util.SanitizePath(p)
+	if err != nil {
+		return fmt.Errorf("error sanitizing path %s: %w", p, err)
+	}
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if _, removing := d.removingPaths[p]; removing {
+		// The path is being removed. Data that has already been moved out of the path stays where it is.
+		delete(d.removingPaths, p)
+		for name, table := range d.tables {
+			err = table.AddRoot(path.Join(p, name))
+			if err != nil {
+				return fmt.Errorf("error adding path %s to table %s: %w", p, name, err)
+			}
+		}
+		d.logger.Infof("Added path %s", p)
+		return nil
+	}
+
+	if slices.Contains(d.config.Paths, p) {
+		return nil
+	}
+
+	err = util.EnsureDirectoryExists(p, d.config.Fsync)
+	if err != nil {
+		return fmt.Errorf("error creating root directory %s: %w", p, err)
+	}
+
+	release, err := util.LockDirectories(d.logger, []string{p}, util.LockfileName, d.config.Fsync)
+	if err != nil {
+		return fmt.Errorf("error acquiring lock on root directory %s: %w", p, err)
+	}
+	d.releaseLocks[p] = release
+	d.config.Paths = append(slices.Clone(d.config.Paths), p)
+
+	// Record the new path before any data is placed in it.
+	err = writePathsFiles(d.config.Paths, d.config.Paths, d.config.Fsync)
+	if err != nil {
+		return fmt.Errorf("error recording paths: %w", err)
+	}
+
+	for name, table := range d.tables {
+		err = table.AddRoot(path.Join(p, name))
+		if err != nil {
+			return fmt.Errorf("error adding path %s to table %s: %w", p, name, err)
+		}
+	}
+
+	d.logger.Infof("Added path %s", p)
+	return nil
+}
+
+func (d *db) RemovePath(p string) error {
+	if d.readOnly {
+		return fmt.Errorf("cannot remove path %s: %w", p, litt.ErrReadOnly)
+	}
+
+	p, err := util.SanitizePath(p)
+	if err != nil {
+		return fmt.Errorf("error sanitizing path %s: %w", p, err)
+	}
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if !slices.Contains(d.config.Paths, p) {
+		return fmt.Errorf("path %s is not in use", p)
+	}
+	if _, removing := d.removingPaths[p]; removing {
+		return fmt.Errorf("path %s is already being removed", p)
+	}
+	if len(d.config.Paths)-len(d.removingPaths) == 1 {
+		return fmt.Errorf("cannot remove path %s, it is the only path", p)
+	}
+
+	// Tables with data in the path must be loaded so that their data can be moved.
+	entries, err := os.ReadDir(p)
+	if err != nil {
+		return fmt.Errorf("error reading directory %s: %w", p, err)
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() || !d.isTableNameValid(entry.Name()) {
+			continue
+		}
+		isTable, err := util.Exists(path.Join(p, entry.Name(), disktable.SegmentDirectory))
+		if err != nil {
+			return fmt.Errorf("error checking for segment directory: %w", err)
+		}
+		if isTable {
+			_, err = d.lockFreeGetTable(entry.Name())
+			if err != nil {
+				return fmt.Errorf("error loading table %s: %w", entry.Name(), err)
+			}
+		}
+	}
+
+	waitFunctions := make(map[string]func() error, len(d.tables))
+	for name, table := range d.tables {
+		wait, err := table.RemoveRoot(path.Join(p, name))
+		if err != nil {
+			return fmt.Errorf("error removing path %s from table %s: %w", p, name, err)
+		}
+		waitFunctions[name] = wait
+	}
+	d.removingPaths[p] = struct{}{}
+
+	d.logger.Infof("Removed path %s, moving data to remaining paths", p)
+	go d.finishRemovingPath(p, waitFunctions)
+
+	return nil
+}
+
+// finishRemovingPath waits for all tables to move their data out of a removed path, then removes the path from the
+// configuration and releases the lock on the path. If any table fails to move its data, the path stays in the
+// configuration and the lock is held until the DB is closed.
+func (d *db) finishRemovingPath(p string, waitFunctions map[string]func() error) {
+	success := true
+	for name, wait := range waitFunctions {
+		err := wait()
+		if err != nil {
+			d.logger.Errorf("Failed to move data for table %s out of path %s: %v", name, p, err)
+			success = false
+		}
+	}
+	if !success {
+		return
+	}
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if _, removing := d.removingPaths[p]; !removing {
+		// The path was added back while data was being moved out of it.
+		return
+	}
+	delete(d.removingPaths, p)
+	d.config.Paths = slices.DeleteFunc(slices.Clone(d.config.Paths), func(configPath string) bool {
+		return configPath == p
+	})
+
+	err := writePathsFiles(d.config.Paths, d.config.Paths, d.config.Fsync)
+	if err != nil {
+		d.logger.Errorf("Failed to record paths after removing path %s: %v", p, err)
+	}
+	err = os.Remove(path.Join(p, PathsFileName))
+	if err != nil && !os.IsNotExist(err) {
+		d.logger.Warnf("Unable to remove paths file from path %s: %v", p, err)
+	}
+
+	release, ok := d.releaseLocks[p]
+	if ok {
+		release()
+		delete(d.releaseLocks, p)
+	}
+
+	err = os.Remove(p)
+	if err != nil {
+		// This is expected if the path contains snapshot files, which the DB never deletes.
+		d.logger.Warnf("Unable to remove path %s after moving data: %v", p, err)
+	}
+
+	d.logger.Infof("Finished moving data out of path %s", p)
+}
+
 func (d *db) Close() error {
+	// Wait for any in-progress backup to finish before closing the tables.
+	d.backupLock.Lock()
+	defer d.backupLock.Unlock()
+
 	d.lock.Lock()
 	defer d.lock.Unlock()
 
@@ -231,10 +647,15 @@ func (d *db) Close() error {
 		}
 	}
 
+	d.unlock()
+
 	return nil
 }
 
 func (d *db) Destroy() error {
+	d.backupLock.Lock()
+	defer d.backupLock.Unlock()
+
 	d.lock.Lock()
 	defer d.lock.Unlock()
 
@@ -247,9 +668,33 @@ func (d *db) Destroy() error {
 		}
 	}
 
+	if !d.readOnly {
+		for _, p := range d.config.Paths {
+			err := os.Remove(path.Join(p, PathsFileName))
+			if err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("error removing paths file from %s: %w", p, err)
+			}
+		}
+	}
+
+	d.unlock()
+
 	return nil
 }
 
+// unlock releases the lock files held on the DB's root directories, if any.
+func (d *db) unlock() {
+	releaseAll(d.releaseLocks)
+	d.releaseLocks = make(map[string]func())
+}
+
+// releaseAll calls each of the given lock release functions.
+func releaseAll(releaseLocks map[string]func()) {
+	for _, release := range releaseLocks {
+		release()
+	}
+}
+
 // gatherMetrics is a method that periodically collects metrics.
 func (d *db) gatherMetrics(interval time.Duration) {
 	if d.metricsServer != nil {
@@ -280,3 +725,24 @@ func (d *db) gatherMetrics(interval time.Duration) {
 		}
 	}
 }
+
+// backupLoop periodically performs an incremental backup to the given target.
+func (d *db) backupLoop(target litt.BackupTarget, period time.Duration) {
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+
+	for !d.stopped.Load() {
+		select {
+		case <-d.ctx.Done():
+			return
+		case <-ticker.C:
+			if d.stopped.Load() {
+				return
+			}
+			err := d.Backup(d.ctx, target)
+			if err != nil {
+				d.logger.Errorf("error backing up database: %v", err)
+			}
+		}
+	}
+}
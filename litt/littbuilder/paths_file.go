@@ -0,0 +1,129 @@
+package littbuilder
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"slices"
+
+	"github.com/Layr-Labs/eigenda/litt/disktable"
+	"github.com/Layr-Labs/eigenda/litt/disktable/keymap"
+	"github.com/Layr-Labs/eigenda/litt/util"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// PathsFileName is the name of the file that records the full set of root directories used by a DB. A copy of the
+// file is kept in each root directory, and is updated whenever a path is added or removed at runtime. This allows
+// the DB to detect on startup that its configuration is missing a root directory that still contains data.
+const PathsFileName = "litt-paths"
+
+// readPathsFiles returns the union of the paths recorded in the paths files in each of the given root directories.
+// Root directories that do not contain a paths file are skipped.
+func readPathsFiles(roots []string) ([]string, error) {
+	recordedPaths := make([]string, 0)
+	for _, root := range roots {
+		data, err := os.ReadFile(path.Join(root, PathsFileName))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading paths file in %s: %w", root, err)
+		}
+
+		var paths []string
+		err = json.Unmarshal(data, &paths)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing paths file in %s: %w", root, err)
+		}
+		for _, p := range paths {
+			if !slices.Contains(recordedPaths, p) {
+				recordedPaths = append(recordedPaths, p)
+			}
+		}
+	}
+	return recordedPaths, nil
+}
+
+// writePathsFiles records the given set of paths in the paths file in each of the given root directories.
+func writePathsFiles(roots []string, paths []string, fsync bool) error {
+	data, err := json.Marshal(paths)
+	if err != nil {
+		return fmt.Errorf("error serializing paths: %w", err)
+	}
+	for _, root := range roots {
+		err = util.AtomicWrite(path.Join(root, PathsFileName), data, fsync)
+		if err != nil {
+			return fmt.Errorf("error writing paths file in %s: %w", root, err)
+		}
+	}
+	return nil
+}
+
+// checkRecordedPaths returns an error if a path that was in use the last time the DB ran is missing from the
+// configured paths and still contains table data. Missing paths that no longer contain table data (e.g. because
+// their data was moved by the LittDB CLI's rebase command) are permitted.
+func checkRecordedPaths(logger logging.Logger, configuredPaths []string) error {
+	recordedPaths, err := readPathsFiles(configuredPaths)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range recordedPaths {
+		if slices.Contains(configuredPaths, p) {
+			continue
+		}
+
+		hasData, err := containsTableData(p)
+		if err != nil {
+			return fmt.Errorf("error checking path %s for table data: %w", p, err)
+		}
+		if hasData {
+			return fmt.Errorf("path %s was in use the last time the DB ran and still contains table data, "+
+				"but it is not in the configured paths. Add it to the configured paths, or use the LittDB CLI "+
+				"to move its data into the configured paths", p)
+		}
+		logger.Warnf("Path %s was in use the last time the DB ran, but no longer contains table data", p)
+	}
+
+	return nil
+}
+
+// containsTableData returns true if the given root directory contains segment files, a table metadata file, or a
+// keymap for any table. Returns false if the directory does not exist.
+func containsTableData(root string) (bool, error) {
+	entries, err := os.ReadDir(root)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("error reading directory %s: %w", root, err)
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		tableDirectory := path.Join(root, entry.Name())
+
+		segmentFiles, err := os.ReadDir(path.Join(tableDirectory, disktable.SegmentDirectory))
+		if err != nil && !os.IsNotExist(err) {
+			return false, fmt.Errorf("error reading segment directory in %s: %w", tableDirectory, err)
+		}
+		if len(segmentFiles) > 0 {
+			return true, nil
+		}
+
+		for _, fileName := range []string{disktable.TableMetadataFileName, keymap.KeymapDirectoryName} {
+			exists, err := util.Exists(path.Join(tableDirectory, fileName))
+			if err != nil {
+				return false, fmt.Errorf("error checking for %s in %s: %w", fileName, tableDirectory, err)
+			}
+			if exists {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
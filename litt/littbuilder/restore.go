@@ -0,0 +1,232 @@
+package littbuilder
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"sort"
+
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/disktable"
+	"github.com/Layr-Labs/eigenda/litt/util"
+)
+
+// RestoreSource provides the files of a set of tables to Restore(), e.g. a snapshot directory or a remote backup.
+type RestoreSource interface {
+	// String describes the source, for logging.
+	fmt.Stringer
+
+	// GetTables returns the sorted names of the tables that can be restored from the source.
+	GetTables() ([]string, error)
+
+	// GetSegmentFiles returns the names of the segment files of a table.
+	GetSegmentFiles(tableName string) ([]string, error)
+
+	// RestoreSegmentFile writes a segment file of a table to the given destination. The parent directory of the
+	// destination may not yet exist.
+	RestoreSegmentFile(tableName string, fileName string, destination string, fsync bool) error
+
+	// RestoreTableMetadata writes the metadata file of a table to the given destination. The parent directory of
+	// the destination may not yet exist.
+	RestoreTableMetadata(tableName string, destination string, fsync bool) error
+}
+
+// RestoreSnapshot builds a database from a snapshot created by DB.Snapshot(). Segment files are copied out of the
+// snapshot and spread across the root directories described by the config, so the snapshot may live on a different
+// filesystem than the database (or may have been transferred from a different host). Once the files are in place,
+// the keymap for each table is rebuilt from the key files.
+//
+// None of the tables in the snapshot may already exist in the target database. The snapshot is not modified.
+func RestoreSnapshot(config *litt.Config, snapshotDirectory string) error {
+	snapshotDirectory, err := util.SanitizePath(snapshotDirectory)
+	if err != nil {
+		return fmt.Errorf("error sanitizing snapshot directory %s: %w", snapshotDirectory, err)
+	}
+
+	return Restore(config, &snapshotSource{directory: snapshotDirectory})
+}
+
+// Restore builds a database from the tables provided by a RestoreSource. Segment files are spread across the root
+// directories described by the config. Once the files are in place, the keymap for each table is rebuilt from the
+// key files.
+//
+// None of the tables in the source may already exist in the target database.
+func Restore(config *litt.Config, source RestoreSource) error {
+	var err error
+
+	if config.Logger == nil {
+		config.Logger, err = buildLogger(config)
+		if err != nil {
+			return fmt.Errorf("error building logger: %w", err)
+		}
+	}
+
+	err = config.SanityCheck()
+	if err != nil {
+		return fmt.Errorf("error checking config: %w", err)
+	}
+
+	err = config.SanitizePaths()
+	if err != nil {
+		return fmt.Errorf("error expanding tildes in config: %w", err)
+	}
+
+	tables, err := source.GetTables()
+	if err != nil {
+		return fmt.Errorf("error finding tables in %s: %w", source, err)
+	}
+
+	for _, p := range config.Paths {
+		err = util.EnsureDirectoryExists(p, config.Fsync)
+		if err != nil {
+			return fmt.Errorf("error creating root directory %s: %w", p, err)
+		}
+	}
+
+	// Hold the locks while copying files, the DB will acquire its own locks once the files are in place.
+	releaseLocks, err := util.LockDirectories(config.Logger, config.Paths, util.LockfileName, config.Fsync)
+	if err != nil {
+		return fmt.Errorf("error acquiring locks on root directories: %w", err)
+	}
+	for _, table := range tables {
+		err = restoreTable(config, source, table)
+		if err != nil {
+			releaseLocks()
+			return fmt.Errorf("error restoring table %s: %w", table, err)
+		}
+	}
+	releaseLocks()
+
+	// There is no keymap on disk for the restored tables, so loading each table causes its keymap to be rebuilt.
+	database, err := NewDB(config)
+	if err != nil {
+		return fmt.Errorf("error opening restored database: %w", err)
+	}
+	for _, table := range tables {
+		_, err = database.GetTable(table)
+		if err != nil {
+			_ = database.Close()
+			return fmt.Errorf("error loading restored table %s: %w", table, err)
+		}
+	}
+	err = database.Close()
+	if err != nil {
+		return fmt.Errorf("error closing restored database: %w", err)
+	}
+
+	config.Logger.Infof("Restored %d table(s) from %s", len(tables), source)
+
+	return nil
+}
+
+// restoreTable copies the files for a single table out of a restore source and into the DB's root directories.
+func restoreTable(config *litt.Config, source RestoreSource, tableName string) error {
+	for _, root := range config.Paths {
+		exists, err := util.Exists(path.Join(root, tableName))
+		if err != nil {
+			return fmt.Errorf("failed to check if table directory exists: %w", err)
+		}
+		if exists {
+			return fmt.Errorf("table %s already exists in %s", tableName, root)
+		}
+	}
+
+	segmentFiles, err := source.GetSegmentFiles(tableName)
+	if err != nil {
+		return fmt.Errorf("failed to get segment files: %w", err)
+	}
+
+	// Spread the segment files across the roots.
+	for i, segmentFile := range segmentFiles {
+		root := config.Paths[i%len(config.Paths)]
+		err = source.RestoreSegmentFile(
+			tableName,
+			segmentFile,
+			path.Join(root, tableName, disktable.SegmentDirectory, segmentFile),
+			config.Fsync)
+		if err != nil {
+			return fmt.Errorf("failed to restore segment file %s: %w", segmentFile, err)
+		}
+	}
+
+	err = source.RestoreTableMetadata(
+		tableName,
+		path.Join(config.Paths[0], tableName, disktable.TableMetadataFileName),
+		config.Fsync)
+	if err != nil {
+		return fmt.Errorf("failed to restore table metadata: %w", err)
+	}
+
+	return nil
+}
+
+var _ RestoreSource = (*snapshotSource)(nil)
+
+// snapshotSource is a RestoreSource that reads tables from a snapshot directory created by DB.Snapshot().
+type snapshotSource struct {
+	// The root of the snapshot.
+	directory string
+}
+
+func (s *snapshotSource) String() string {
+	return fmt.Sprintf("snapshot %s", s.directory)
+}
+
+func (s *snapshotSource) GetTables() ([]string, error) {
+	return getSnapshotTables(s.directory)
+}
+
+func (s *snapshotSource) GetSegmentFiles(tableName string) ([]string, error) {
+	segmentDirectory := path.Join(s.directory, tableName, disktable.SegmentDirectory)
+	entries, err := os.ReadDir(segmentDirectory)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot segment directory %s: %w", segmentDirectory, err)
+	}
+
+	files := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		files = append(files, entry.Name())
+	}
+	return files, nil
+}
+
+// RestoreSegmentFile copies a segment file out of the snapshot. The files in the snapshot are symlinks,
+// CopyRegularFile copies the data that each link points to.
+func (s *snapshotSource) RestoreSegmentFile(tableName string, fileName string, destination string, fsync bool) error {
+	return util.CopyRegularFile(
+		path.Join(s.directory, tableName, disktable.SegmentDirectory, fileName),
+		destination,
+		fsync)
+}
+
+func (s *snapshotSource) RestoreTableMetadata(tableName string, destination string, fsync bool) error {
+	return util.CopyRegularFile(
+		path.Join(s.directory, tableName, disktable.TableMetadataFileName),
+		destination,
+		fsync)
+}
+
+// getSnapshotTables returns the sorted names of the tables in a snapshot directory.
+func getSnapshotTables(snapshotDirectory string) ([]string, error) {
+	entries, err := os.ReadDir(snapshotDirectory)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot directory %s: %w", snapshotDirectory, err)
+	}
+
+	tables := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		exists, err := util.Exists(path.Join(snapshotDirectory, entry.Name(), disktable.TableMetadataFileName))
+		if err != nil {
+			return nil, fmt.Errorf("failed to check for table metadata: %w", err)
+		}
+		if exists {
+			tables = append(tables, entry.Name())
+		}
+	}
+	sort.Strings(tables)
+
+	return tables, nil
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/cache"
 	"github.com/Layr-Labs/eigenda/litt/disktable/keymap"
 	"github.com/Layr-Labs/eigenda/litt/util"
 	"github.com/Layr-Labs/eigensdk-go/logging"
@@ -38,8 +39,9 @@ type Config struct {
 	// The logger configuration for the database. Ignored if Logger is not nil.
 	LoggerConfig *common.LoggerConfig
 
-	// The type of the keymap. Choices are keymap.MemKeymapType and keymap.LevelDBKeymapType.
-	// Default is keymap.LevelDBKeymapType.
+	// The type of the keymap. Choices are keymap.MemKeymapType, keymap.LevelDBKeymapType, and
+	// keymap.PebbleKeymapType. Default is keymap.LevelDBKeymapType. If the type is changed for a DB with existing
+	// data, the keymap is migrated the next time the DB is started.
 	KeymapType keymap.KeymapType
 
 	// The default TTL for newly created tables (either ones with data on disk or new tables).
@@ -72,6 +74,26 @@ type Config struct {
 	// The size of the keymap deletion batch for garbage collection. The default is 10,000.
 	GCBatchSize uint64
 
+	// The period between scrubber runs. The scrubber periodically reads every value in every sealed segment and
+	// verifies it against the checksum recorded when the value was written. Scrubbing is I/O intensive, and so
+	// this period should be long relative to the time required to read all data in the DB. The default is 0,
+	// which disables the scrubber.
+	ScrubPeriod time.Duration
+
+	// Determines what happens when the scrubber finds a corrupted value. If false (the default), the DB enters
+	// a panicked state and the FatalErrorCallback is called. If true, the corrupted keys are removed from the
+	// keymap (making them unreadable) and the DB continues to operate. Corrupted keys are logged and reported
+	// via metrics regardless of this setting.
+	QuarantineCorruptedData bool
+
+	// If not nil, the DB performs an incremental backup to this target once per BackupPeriod (see DB.Backup()).
+	// Only tables that have been fetched via GetTable() are backed up. Backup errors are logged, and the next
+	// backup pass is attempted once the period elapses again. The default is nil (no continuous backup).
+	BackupTarget BackupTarget
+
+	// The period between backup passes. Ignored if BackupTarget is nil. The default is 10 minutes.
+	BackupPeriod time.Duration
+
 	// The sharding factor for the database. If the sharding factor is greater than 1, then values will be spread
 	// out across multiple files. (Note that individual values will always be written to a single file, but two
 	// different values may be written to different files.) These shard files are spead evenly across the paths
@@ -98,6 +120,10 @@ type Config struct {
 	// individually on each table by calling Table.SetReadCacheSize().
 	ReadCacheSize uint64
 
+	// The eviction policy used by the write and read caches. Choices are cache.FIFOEvictionPolicy,
+	// cache.LRUEvictionPolicy, and cache.S3FIFOEvictionPolicy. Default is cache.FIFOEvictionPolicy.
+	CacheEvictionPolicy cache.EvictionPolicy
+
 	// The time source used by the database. This can be substituted for an artificial time source
 	// for testing purposes. The default is time.Now.
 	Clock func() time.Time
@@ -168,9 +194,11 @@ func DefaultConfigNoPaths() *Config {
 		Clock:                    time.Now,
 		GCPeriod:                 5 * time.Minute,
 		GCBatchSize:              10_000,
+		BackupPeriod:             10 * time.Minute,
 		ShardingFactor:           8,
 		SaltShaker:               saltShaker,
 		KeymapType:               keymap.LevelDBKeymapType,
+		CacheEvictionPolicy:      cache.FIFOEvictionPolicy,
 		ControlChannelSize:       64,
 		TargetSegmentFileSize:    math.MaxUint32,
 		MaxSegmentKeyCount:       50_000,
@@ -233,6 +261,12 @@ func (c *Config) SanityCheck() error {
 	if c.GCPeriod == 0 {
 		return fmt.Errorf("gc period must be at least 1")
 	}
+	if c.BackupTarget != nil && c.BackupPeriod == 0 {
+		return fmt.Errorf("backup period must be at least 1 if a backup target is set")
+	}
+	if _, err := cache.ParseEvictionPolicy(string(c.CacheEvictionPolicy)); err != nil {
+		return fmt.Errorf("invalid cache eviction policy: %w", err)
+	}
 	if c.SaltShaker == nil {
 		return fmt.Errorf("salt shaker cannot be nil")
 	}
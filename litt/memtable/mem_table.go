@@ -1,6 +1,7 @@
 package memtable
 
 import (
+	"context"
 	"fmt"
 	"sync"
 	"sync/atomic"
@@ -39,6 +40,9 @@ type memTable struct {
 	// Keeps track of when data should be deleted.
 	expirationQueue queues.Queue
 
+	// Keys written with PutWithExpiry, mapped to the time before which they may not be deleted.
+	extendedExpiries map[string]time.Time
+
 	// Protects access to data and expirationQueue.
 	//
 	// This implementation could be made with smaller granularity locks to improve multithreaded performance,
@@ -53,11 +57,12 @@ type memTable struct {
 func NewMemTable(config *litt.Config, name string) litt.ManagedTable {
 
 	table := &memTable{
-		clock:           config.Clock,
-		name:            name,
-		ttl:             config.TTL,
-		data:            make(map[string][]byte),
-		expirationQueue: linkedlistqueue.New(),
+		clock:            config.Clock,
+		name:             name,
+		ttl:              config.TTL,
+		data:             make(map[string][]byte),
+		expirationQueue:  linkedlistqueue.New(),
+		extendedExpiries: make(map[string]time.Time),
 	}
 
 	if config.GCPeriod > 0 {
@@ -113,6 +118,33 @@ func (m *memTable) Put(key []byte, value []byte) error {
 	return nil
 }
 
+func (m *memTable) PutWithExpiry(key []byte, value []byte, expiry time.Time) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if m.ttl > 0 {
+		minimumExpiry := m.clock().Add(m.ttl)
+		if expiry.Before(minimumExpiry) {
+			return fmt.Errorf("expiry %v is before the minimum expiry %v permitted by the table's TTL: %w",
+				expiry, minimumExpiry, litt.ErrInvalidExpiry)
+		}
+	}
+
+	stringKey := string(key)
+	_, ok := m.data[stringKey]
+	if ok {
+		return fmt.Errorf("key %x already exists", key)
+	}
+	m.data[stringKey] = value
+	m.expirationQueue.Enqueue(&expirationRecord{
+		creationTime: m.clock(),
+		key:          stringKey,
+	})
+	m.extendedExpiries[stringKey] = expiry
+
+	return nil
+}
+
 func (m *memTable) PutBatch(batch []*types.KVPair) error {
 	for _, kv := range batch {
 		err := m.Put(kv.Key, kv.Value)
@@ -140,6 +172,25 @@ func (m *memTable) CacheAwareGet(key []byte, _ bool) (value []byte, exists bool,
 	return value, true, true, nil
 }
 
+func (m *memTable) Iterate(fn func(key []byte, value []byte) error) error {
+	// Copy the data so that the lock is not held while calling fn (which may want to write to this table).
+	m.lock.RLock()
+	pairs := make([]*types.KVPair, 0, len(m.data))
+	for key, value := range m.data {
+		pairs = append(pairs, &types.KVPair{Key: []byte(key), Value: value})
+	}
+	m.lock.RUnlock()
+
+	for _, pair := range pairs {
+		err := fn(pair.Key, pair.Value)
+		if err != nil {
+			return fmt.Errorf("iteration stopped at key %x: %w", pair.Key, err)
+		}
+	}
+
+	return nil
+}
+
 func (m *memTable) Exists(key []byte) (exists bool, err error) {
 	m.lock.RLock()
 	defer m.lock.RUnlock()
@@ -165,6 +216,7 @@ func (m *memTable) Destroy() error {
 
 	m.data = make(map[string][]byte)
 	m.expirationQueue.Clear()
+	m.extendedExpiries = make(map[string]time.Time)
 
 	return nil
 }
@@ -208,8 +260,40 @@ func (m *memTable) RunGC() error {
 			break
 		}
 		m.expirationQueue.Dequeue()
+		if expiry, ok := m.extendedExpiries[expiration.key]; ok && expiry.After(now) {
+			// This key is deleted once its extended expiry passes.
+			continue
+		}
 		delete(m.data, expiration.key)
+		delete(m.extendedExpiries, expiration.key)
+	}
+
+	for key, expiry := range m.extendedExpiries {
+		if !expiry.After(now) {
+			delete(m.data, key)
+			delete(m.extendedExpiries, key)
+		}
 	}
 
 	return nil
 }
+
+func (m *memTable) Snapshot(directory string) error {
+	return fmt.Errorf("memory tables do not support snapshots")
+}
+
+func (m *memTable) Backup(_ context.Context, _ litt.BackupTarget) error {
+	return fmt.Errorf("memory tables do not support backups")
+}
+
+func (m *memTable) AddRoot(root string) error {
+	// the memory table does not store data on disk
+	return nil
+}
+
+func (m *memTable) RemoveRoot(root string) (func() error, error) {
+	// the memory table does not store data on disk
+	return func() error {
+		return nil
+	}, nil
+}
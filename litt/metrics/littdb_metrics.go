@@ -74,6 +74,25 @@ type LittDBMetrics struct {
 	// The latency of garbage collection operations.1
 	garbageCollectionLatency *prometheus.SummaryVec
 
+	// The number of keys whose values have been verified by the scrubber since startup.
+	scrubbedKeysCounter *prometheus.CounterVec
+
+	// The number of keys the scrubber has found to have corrupted values since startup.
+	corruptedKeysCounter *prometheus.CounterVec
+
+	// The latency of a complete scrub pass over all sealed segments in a table.
+	scrubLatency *prometheus.SummaryVec
+
+	// The number of segments that still need to be moved off of removed paths.
+	migrationSegmentsRemaining *prometheus.GaugeVec
+
+	// The number of bytes moved off of removed paths since startup.
+	migrationBytesMovedCounter *prometheus.CounterVec
+
+	// The number of segments that are past their TTL but are retained because they contain a value with an
+	// extended expiry.
+	pinnedSegmentCount *prometheus.GaugeVec
+
 	// Metrics for the write cache.
 	writeCacheMetrics *cache.CacheMetrics
 
@@ -243,6 +262,62 @@ func NewLittDBMetrics(registry *prometheus.Registry, namespace string) *LittDBMe
 		[]string{"table"},
 	)
 
+	scrubbedKeysCounter := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "scrubbed_keys",
+			Help:      "The number of keys whose values have been verified by the scrubber since startup.",
+		},
+		[]string{"table"},
+	)
+
+	corruptedKeysCounter := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "corrupted_keys",
+			Help:      "The number of keys the scrubber has found to have corrupted values since startup.",
+		},
+		[]string{"table"},
+	)
+
+	scrubLatency := promauto.With(registry).NewSummaryVec(
+		prometheus.SummaryOpts{
+			Namespace:  namespace,
+			Name:       "scrub_latency_ms",
+			Help:       "Reports on the latency of a complete scrub pass over all sealed segments in a table.",
+			Objectives: objectives,
+		},
+		[]string{"table"},
+	)
+
+	migrationSegmentsRemaining := promauto.With(registry).NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "migration_segments_remaining",
+			Help:      "The number of segments that still need to be moved off of removed paths.",
+		},
+		[]string{"table"},
+	)
+
+	pinnedSegmentCount := promauto.With(registry).NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "pinned_segment_count",
+			Help: "The number of segments that are past their TTL but are retained because they contain " +
+				"a value with an extended expiry.",
+		},
+		[]string{"table"},
+	)
+
+	migrationBytesMovedCounter := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "migration_bytes_moved",
+			Help:      "The number of bytes moved off of removed paths since startup.",
+		},
+		[]string{"table"},
+	)
+
 	writeCacheMetrics := cache.NewCacheMetrics(
 		registry,
 		namespace,
@@ -256,24 +331,30 @@ func NewLittDBMetrics(registry *prometheus.Registry, namespace string) *LittDBMe
 	)
 
 	return &LittDBMetrics{
-		tableSizeInBytes:         tableSizeInBytes,
-		tableKeyCount:            tableKeyCount,
-		bytesReadCounter:         bytesReadCounter,
-		keysReadCounter:          keysReadCounter,
-		cacheHitCounter:          cacheHitCounter,
-		cacheMissCounter:         cacheMissCounter,
-		readLatency:              readLatency,
-		cacheMissLatency:         cacheMissLatency,
-		bytesWrittenCounter:      bytesWrittenCounter,
-		keysWrittenCounter:       keysWrittenCounter,
-		writeLatency:             writeLatency,
-		flushCount:               flushCount,
-		flushLatency:             flushLatency,
-		garbageCollectionLatency: garbageCollectionLatency,
-		segmentFlushLatency:      segmentFlushLatency,
-		keymapFlushLatency:       keymapFlushLatency,
-		writeCacheMetrics:        writeCacheMetrics,
-		readCacheMetrics:         readCacheMetrics,
+		tableSizeInBytes:           tableSizeInBytes,
+		tableKeyCount:              tableKeyCount,
+		bytesReadCounter:           bytesReadCounter,
+		keysReadCounter:            keysReadCounter,
+		cacheHitCounter:            cacheHitCounter,
+		cacheMissCounter:           cacheMissCounter,
+		readLatency:                readLatency,
+		cacheMissLatency:           cacheMissLatency,
+		bytesWrittenCounter:        bytesWrittenCounter,
+		keysWrittenCounter:         keysWrittenCounter,
+		writeLatency:               writeLatency,
+		flushCount:                 flushCount,
+		flushLatency:               flushLatency,
+		garbageCollectionLatency:   garbageCollectionLatency,
+		segmentFlushLatency:        segmentFlushLatency,
+		keymapFlushLatency:         keymapFlushLatency,
+		scrubbedKeysCounter:        scrubbedKeysCounter,
+		corruptedKeysCounter:       corruptedKeysCounter,
+		scrubLatency:               scrubLatency,
+		migrationSegmentsRemaining: migrationSegmentsRemaining,
+		migrationBytesMovedCounter: migrationBytesMovedCounter,
+		pinnedSegmentCount:         pinnedSegmentCount,
+		writeCacheMetrics:          writeCacheMetrics,
+		readCacheMetrics:           readCacheMetrics,
 	}
 }
 
@@ -371,6 +452,61 @@ func (m *LittDBMetrics) ReportGarbageCollectionLatency(tableName string, latency
 	m.garbageCollectionLatency.WithLabelValues(tableName).Observe(common.ToMilliseconds(latency))
 }
 
+// ReportScrubbedKeys reports the number of keys whose values have been verified by the scrubber.
+func (m *LittDBMetrics) ReportScrubbedKeys(tableName string, count uint64) {
+	if m == nil {
+		return
+	}
+
+	m.scrubbedKeysCounter.WithLabelValues(tableName).Add(float64(count))
+}
+
+// ReportCorruptedKey reports that the scrubber has found a key with a corrupted value.
+func (m *LittDBMetrics) ReportCorruptedKey(tableName string) {
+	if m == nil {
+		return
+	}
+
+	m.corruptedKeysCounter.WithLabelValues(tableName).Inc()
+}
+
+// ReportScrubLatency reports the latency of a complete scrub pass.
+func (m *LittDBMetrics) ReportScrubLatency(tableName string, latency time.Duration) {
+	if m == nil {
+		return
+	}
+
+	m.scrubLatency.WithLabelValues(tableName).Observe(common.ToMilliseconds(latency))
+}
+
+// ReportMigrationSegmentsRemaining reports the number of segments that still need to be moved off of removed paths.
+func (m *LittDBMetrics) ReportMigrationSegmentsRemaining(tableName string, count uint64) {
+	if m == nil {
+		return
+	}
+
+	m.migrationSegmentsRemaining.WithLabelValues(tableName).Set(float64(count))
+}
+
+// ReportMigrationBytesMoved reports the number of bytes moved off of a removed path.
+func (m *LittDBMetrics) ReportMigrationBytesMoved(tableName string, bytes uint64) {
+	if m == nil {
+		return
+	}
+
+	m.migrationBytesMovedCounter.WithLabelValues(tableName).Add(float64(bytes))
+}
+
+// ReportPinnedSegmentCount reports the number of segments that are past their TTL but are retained because they
+// contain a value with an extended expiry.
+func (m *LittDBMetrics) ReportPinnedSegmentCount(tableName string, count uint64) {
+	if m == nil {
+		return
+	}
+
+	m.pinnedSegmentCount.WithLabelValues(tableName).Set(float64(count))
+}
+
 func (m *LittDBMetrics) GetWriteCacheMetrics() *cache.CacheMetrics {
 	if m == nil {
 		return nil
@@ -1,11 +1,19 @@
 package litt
 
 import (
+	"context"
+	"errors"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/litt/types"
 )
 
+// ErrReadOnly is returned when an operation that modifies data is attempted on a read-only table or DB.
+var ErrReadOnly = errors.New("read-only")
+
+// ErrInvalidExpiry is returned by PutWithExpiry when the requested expiry is earlier than the table's TTL permits.
+var ErrInvalidExpiry = errors.New("invalid expiry")
+
 // Table is a key-value store with a namespace that does not overlap with other tables.
 // Values may be written to the table, but once written, they may not be changed or deleted (except via TTL).
 //
@@ -18,7 +26,7 @@ type Table interface {
 	// Note that when this method returns, data written may not be crash durable on disk
 	// (although the write does have atomicity). In order to ensure crash durability, call Flush().
 	//
-	// The maximum size of the key is 2^32 bytes. The maximum size of the value is 2^32 bytes.
+	// The maximum size of the key is 2^64 bytes. The maximum size of the value is 2^64 bytes.
 	// This database has been optimized under the assumption that values are generally much larger than keys.
 	// This affects performance, but not correctness.
 	//
@@ -30,7 +38,7 @@ type Table interface {
 	// at once. This may improve performance, but it otherwise has identical properties to a sequence of Put calls
 	// (i.e. this method does not atomically write the entire batch).
 	//
-	// The maximum size of a key is 2^32 bytes. The maximum size of a value is 2^32 bytes.
+	// The maximum size of a key is 2^64 bytes. The maximum size of a value is 2^64 bytes.
 	// This database has been optimized under the assumption that values are generally much larger than keys.
 	// This affects performance, but not correctness.
 	//
@@ -38,11 +46,22 @@ type Table interface {
 	// (including the key byte slices and the value byte slices).
 	PutBatch(batch []*types.KVPair) error
 
+	// PutWithExpiry stores a value in the database that will not be deleted before the given expiry, even if the
+	// table's TTL has elapsed. Otherwise identical to Put. The expiry may not be earlier than the time when the value
+	// would normally expire (i.e. now plus the table's TTL), or else an error wrapping ErrInvalidExpiry is returned.
+	//
+	// Data is garbage collected a segment at a time, so a value with an extended expiry keeps every other value in
+	// the same segment on disk (and readable) until the extended expiry passes.
+	//
+	// It is not safe to modify the byte slices passed to this function after the call
+	// (both the key and the value).
+	PutWithExpiry(key []byte, value []byte, expiry time.Time) error
+
 	// Get retrieves a value from the database. The returned boolean indicates whether the key exists in the database
 	// (returns false if the key does not exist). If an error is returned, the value of the other returned values are
 	// undefined.
 	//
-	// The maximum size of a key is 2^32 bytes. The maximum size of a value is 2^32 bytes.
+	// The maximum size of a key is 2^64 bytes. The maximum size of a value is 2^64 bytes.
 	// This database has been optimized under the assumption that values are generally much larger than keys.
 	// This affects performance, but not correctness.
 	//
@@ -58,6 +77,18 @@ type Table interface {
 	// (nil, true, false, nil).
 	CacheAwareGet(key []byte, onlyReadFromCache bool) (value []byte, exists bool, hot bool, err error)
 
+	// Iterate calls the given function once for each key-value pair in the table. Iteration order is unspecified.
+	// If the function returns an error, iteration stops and that error is returned (wrapped) by Iterate.
+	//
+	// Iteration may run concurrently with other operations on the table (including writes and garbage collection).
+	// All data written before Iterate is called will be visited unless it expires during iteration. Data written
+	// during iteration may or may not be visited. Data that has expired but has not yet been garbage collected
+	// is not visited.
+	//
+	// The key and value byte slices passed to the function are NOT safe to mutate, and are not safe to retain
+	// after the function returns unless they are copied.
+	Iterate(fn func(key []byte, value []byte) error) error
+
 	// Exists returns true if the key exists in the database, and false otherwise. This is faster than calling Get.
 	//
 	// It is not safe to modify the key byte slice after it is passed to this method.
@@ -131,4 +162,21 @@ type ManagedTable interface {
 	// This method is intended for use in tests, where it can be useful to force a garbage collection run to occur
 	// at a specific time.
 	RunGC() error
+
+	// Snapshot adds all data currently in the table to a snapshot stored in the given directory. See DB.Snapshot()
+	// for more information.
+	Snapshot(directory string) error
+
+	// Backup passes all sealed segments in the table to the backup target, followed by the table's metadata.
+	// Commit() is not called on the target. See DB.Backup() for more information.
+	Backup(ctx context.Context, target BackupTarget) error
+
+	// AddRoot adds a root directory to the table. New segments may be placed in the new root as soon as this
+	// method returns. This is a no-op if the root is already in use by the table.
+	AddRoot(root string) error
+
+	// RemoveRoot stops placing new data in the given root directory, and starts moving the table's existing data
+	// out of the root in the background. The returned function blocks until all data has been moved out of the root
+	// (returning an error if the move failed or was interrupted), and must be called at most once.
+	RemoveRoot(root string) (wait func() error, err error)
 }
@@ -0,0 +1,302 @@
+package test
+
+import (
+	"context"
+	"path"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/aws/mock"
+	"github.com/Layr-Labs/eigenda/common/testutils/random"
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/backup"
+	"github.com/Layr-Labs/eigenda/litt/littbuilder"
+	"github.com/stretchr/testify/require"
+)
+
+const backupBucket = "litt-backup"
+const backupPrefix = "validator/chunks"
+
+// verifyRestoredData checks that a restored DB contains exactly the expected values.
+func verifyRestoredData(t *testing.T, roots []string, expectedValues map[string]map[string][]byte) {
+	restoredDB, err := littbuilder.NewDB(buildSnapshotTestConfig(t, roots))
+	require.NoError(t, err)
+
+	for tableName, tableValues := range expectedValues {
+		table, err := restoredDB.GetTable(tableName)
+		require.NoError(t, err)
+		require.Equal(t, uint64(len(tableValues)), table.KeyCount())
+
+		for expectedKey, expectedValue := range tableValues {
+			value, ok, err := table.Get([]byte(expectedKey))
+			require.NoError(t, err)
+			require.True(t, ok)
+			require.Equal(t, expectedValue, value)
+		}
+	}
+
+	err = restoredDB.Close()
+	require.NoError(t, err)
+}
+
+func TestBackupAndRestore(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+	ctx := context.Background()
+
+	logger, err := common.NewLogger(common.DefaultTextLoggerConfig())
+	require.NoError(t, err)
+
+	testDirectory := t.TempDir()
+	roots := []string{
+		path.Join(testDirectory, "root0"),
+		path.Join(testDirectory, "root1"),
+	}
+
+	client := mock.NewS3Client()
+	target, err := backup.NewS3Target(ctx, logger, client, backupBucket, backupPrefix)
+	require.NoError(t, err)
+
+	db, err := littbuilder.NewDB(buildSnapshotTestConfig(t, roots))
+	require.NoError(t, err)
+
+	tableNames := []string{"tableA", "tableB"}
+	expectedValues := make(map[string]map[string][]byte)
+	for _, tableName := range tableNames {
+		expectedValues[tableName] = make(map[string][]byte)
+	}
+
+	writeData := func(count int) {
+		for i := 0; i < count; i++ {
+			tableName := tableNames[rand.Intn(len(tableNames))]
+			table, err := db.GetTable(tableName)
+			require.NoError(t, err)
+
+			key := rand.PrintableVariableBytes(32, 64)
+			value := rand.PrintableVariableBytes(1, 128)
+			err = table.Put(key, value)
+			require.NoError(t, err)
+			expectedValues[tableName][string(key)] = value
+		}
+	}
+
+	// Take several incremental backups, each time adding more data.
+	for i := 0; i < 3; i++ {
+		writeData(100)
+		err = db.Backup(ctx, target)
+		require.NoError(t, err)
+	}
+
+	// A backup with no new data should not upload anything.
+	uploadCount := client.Called["UploadObjectFromReader"]
+	manifestUploadCount := client.Called["UploadObject"]
+	err = db.Backup(ctx, target)
+	require.NoError(t, err)
+	require.Equal(t, uploadCount, client.Called["UploadObjectFromReader"])
+	require.Equal(t, manifestUploadCount, client.Called["UploadObject"])
+
+	// A new target should pick up where the old one left off.
+	target, err = backup.NewS3Target(ctx, logger, client, backupBucket, backupPrefix)
+	require.NoError(t, err)
+	err = db.Backup(ctx, target)
+	require.NoError(t, err)
+	require.Equal(t, uploadCount, client.Called["UploadObjectFromReader"])
+
+	// Backups don't seal the mutable segment. Restarting the DB seals it, so that all data written so far is
+	// included in the next backup pass.
+	restartAndBackup := func() {
+		err = db.Close()
+		require.NoError(t, err)
+		db, err = littbuilder.NewDB(buildSnapshotTestConfig(t, roots))
+		require.NoError(t, err)
+		for _, tableName := range tableNames {
+			_, err = db.GetTable(tableName)
+			require.NoError(t, err)
+		}
+		err = db.Backup(ctx, target)
+		require.NoError(t, err)
+	}
+	restartAndBackup()
+
+	// Restore the backup into a new set of roots.
+	restoredRoots := []string{
+		path.Join(testDirectory, "restored0"),
+		path.Join(testDirectory, "restored1"),
+		path.Join(testDirectory, "restored2"),
+	}
+	err = backup.Restore(ctx, buildSnapshotTestConfig(t, restoredRoots), client, backupBucket, backupPrefix)
+	require.NoError(t, err)
+	verifyRestoredData(t, restoredRoots, expectedValues)
+
+	// Restoring on top of existing tables is not permitted.
+	err = backup.Restore(ctx, buildSnapshotTestConfig(t, restoredRoots), client, backupBucket, backupPrefix)
+	require.Error(t, err)
+
+	// Once segments are deleted by the garbage collector, they should also be deleted from the backup.
+	manifest, err := backup.LoadManifest(ctx, client, backupBucket, backupPrefix)
+	require.NoError(t, err)
+	for _, tableName := range tableNames {
+		require.NotEmpty(t, manifest.Tables[tableName].Segments)
+
+		table, err := db.GetTable(tableName)
+		require.NoError(t, err)
+		err = table.SetTTL(time.Nanosecond)
+		require.NoError(t, err)
+		err = table.(litt.ManagedTable).RunGC()
+		require.NoError(t, err)
+		err = table.SetTTL(0)
+		require.NoError(t, err)
+		expectedValues[tableName] = make(map[string][]byte)
+	}
+	err = db.Backup(ctx, target)
+	require.NoError(t, err)
+
+	manifest, err = backup.LoadManifest(ctx, client, backupBucket, backupPrefix)
+	require.NoError(t, err)
+	for _, tableName := range tableNames {
+		require.Empty(t, manifest.Tables[tableName].Segments)
+	}
+	objects, err := client.ListObjects(ctx, backupBucket, backupPrefix)
+	require.NoError(t, err)
+	require.Len(t, objects, 1)
+	require.Equal(t, path.Join(backupPrefix, backup.ManifestFileName), objects[0].Key)
+
+	// Data written after the GC should be backed up as usual.
+	writeData(50)
+	restartAndBackup()
+
+	err = db.Close()
+	require.NoError(t, err)
+
+	restoredRoots = []string{path.Join(testDirectory, "restoredAgain")}
+	err = backup.Restore(ctx, buildSnapshotTestConfig(t, restoredRoots), client, backupBucket, backupPrefix)
+	require.NoError(t, err)
+	verifyRestoredData(t, restoredRoots, expectedValues)
+}
+
+func TestBackupDoesNotSealMutableSegment(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+	ctx := context.Background()
+
+	logger, err := common.NewLogger(common.DefaultTextLoggerConfig())
+	require.NoError(t, err)
+
+	testDirectory := t.TempDir()
+	roots := []string{path.Join(testDirectory, "root")}
+
+	client := mock.NewS3Client()
+	target, err := backup.NewS3Target(ctx, logger, client, backupBucket, backupPrefix)
+	require.NoError(t, err)
+
+	db, err := littbuilder.NewDB(buildSnapshotTestConfig(t, roots))
+	require.NoError(t, err)
+	table, err := db.GetTable("table")
+	require.NoError(t, err)
+
+	// A single small value does not fill the mutable segment.
+	err = table.Put(rand.PrintableBytes(8), rand.PrintableBytes(8))
+	require.NoError(t, err)
+	err = table.Flush()
+	require.NoError(t, err)
+
+	// Repeated backup passes neither seal nor upload the mutable segment.
+	for i := 0; i < 3; i++ {
+		err = db.Backup(ctx, target)
+		require.NoError(t, err)
+	}
+	manifest, err := backup.LoadManifest(ctx, client, backupBucket, backupPrefix)
+	require.NoError(t, err)
+	require.NotEmpty(t, manifest.Tables["table"].Metadata)
+	require.Empty(t, manifest.Tables["table"].Segments)
+	require.Equal(t, 0, client.Called["UploadObjectFromReader"])
+
+	// Once the segment is sealed (here, by restarting the DB), it is backed up.
+	err = db.Close()
+	require.NoError(t, err)
+	db, err = littbuilder.NewDB(buildSnapshotTestConfig(t, roots))
+	require.NoError(t, err)
+	_, err = db.GetTable("table")
+	require.NoError(t, err)
+	err = db.Backup(ctx, target)
+	require.NoError(t, err)
+
+	manifest, err = backup.LoadManifest(ctx, client, backupBucket, backupPrefix)
+	require.NoError(t, err)
+	require.Len(t, manifest.Tables["table"].Segments, 1)
+
+	err = db.Close()
+	require.NoError(t, err)
+}
+
+// commitCountingTarget is a backup target that counts the number of successful commits.
+type commitCountingTarget struct {
+	*backup.S3Target
+	commitCount atomic.Uint64
+}
+
+func (c *commitCountingTarget) Commit(ctx context.Context) error {
+	err := c.S3Target.Commit(ctx)
+	if err == nil {
+		c.commitCount.Add(1)
+	}
+	return err
+}
+
+func TestContinuousBackup(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+	ctx := context.Background()
+
+	logger, err := common.NewLogger(common.DefaultTextLoggerConfig())
+	require.NoError(t, err)
+
+	testDirectory := t.TempDir()
+	roots := []string{path.Join(testDirectory, "root")}
+
+	client := mock.NewS3Client()
+	s3Target, err := backup.NewS3Target(ctx, logger, client, backupBucket, backupPrefix)
+	require.NoError(t, err)
+	target := &commitCountingTarget{S3Target: s3Target}
+
+	config := buildSnapshotTestConfig(t, roots)
+	config.BackupTarget = target
+	config.BackupPeriod = 10 * time.Millisecond
+	db, err := littbuilder.NewDB(config)
+	require.NoError(t, err)
+
+	table, err := db.GetTable("table")
+	require.NoError(t, err)
+	expectedValues := map[string]map[string][]byte{"table": make(map[string][]byte)}
+	for i := 0; i < 100; i++ {
+		key := rand.PrintableVariableBytes(32, 64)
+		value := rand.PrintableVariableBytes(1, 128)
+		err = table.Put(key, value)
+		require.NoError(t, err)
+		expectedValues["table"][string(key)] = value
+	}
+
+	// Closing the DB seals the mutable segment. Wait for a full backup pass to start and finish after the restart.
+	err = db.Close()
+	require.NoError(t, err)
+	db, err = littbuilder.NewDB(config)
+	require.NoError(t, err)
+	_, err = db.GetTable("table")
+	require.NoError(t, err)
+
+	commitCount := target.commitCount.Load()
+	require.Eventually(t, func() bool {
+		return target.commitCount.Load() >= commitCount+2
+	}, 10*time.Second, 10*time.Millisecond)
+
+	// Close waits for any in-progress backup pass, so it is safe to read from the mock client afterward.
+	err = db.Close()
+	require.NoError(t, err)
+
+	restoredRoots := []string{path.Join(testDirectory, "restored")}
+	err = backup.Restore(ctx, buildSnapshotTestConfig(t, restoredRoots), client, backupBucket, backupPrefix)
+	require.NoError(t, err)
+	verifyRestoredData(t, restoredRoots, expectedValues)
+}
@@ -4,6 +4,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/Layr-Labs/eigenda/common/cache"
 	"github.com/Layr-Labs/eigenda/common/testutils/random"
 	"github.com/Layr-Labs/eigenda/litt"
 	"github.com/Layr-Labs/eigenda/litt/littbuilder"
@@ -188,3 +189,67 @@ func TestCache(t *testing.T) {
 	require.NoError(t, err)
 	require.Empty(t, entries)
 }
+
+func TestCacheEvictionPolicies(t *testing.T) {
+	policies := []cache.EvictionPolicy{cache.FIFOEvictionPolicy, cache.LRUEvictionPolicy, cache.S3FIFOEvictionPolicy}
+	for _, policy := range policies {
+		t.Run(string(policy), func(t *testing.T) {
+			rand := random.NewTestRandom()
+
+			config, err := litt.DefaultConfig(t.TempDir())
+			require.NoError(t, err)
+			config.WriteCacheSize = 1000
+			config.ReadCacheSize = 1000
+			config.CacheEvictionPolicy = policy
+			config.Fsync = false
+
+			db, err := littbuilder.NewDB(config)
+			require.NoError(t, err)
+
+			table, err := db.GetTable("test_table")
+			require.NoError(t, err)
+
+			expectedValues := make(map[string][]byte)
+			for i := 0; i < 100; i++ {
+				key := rand.PrintableBytes(32)
+				value := rand.PrintableBytes(int(rand.Uint64Range(1, 50)))
+				expectedValues[string(key)] = value
+				err = table.Put(key, value)
+				require.NoError(t, err)
+			}
+			err = table.Flush()
+			require.NoError(t, err)
+
+			// Read every value twice, so that each eviction policy has a chance to promote values it has seen before.
+			for i := 0; i < 2; i++ {
+				for key, expectedValue := range expectedValues {
+					value, ok, err := table.Get([]byte(key))
+					require.NoError(t, err)
+					require.True(t, ok)
+					require.Equal(t, expectedValue, value)
+				}
+			}
+
+			// The number of hot bytes should not exceed the sizes of the caches.
+			hotBytes := uint64(0)
+			for key := range expectedValues {
+				value, ok, hot, err := table.CacheAwareGet([]byte(key), true)
+				require.NoError(t, err)
+				require.True(t, ok)
+				if hot {
+					hotBytes += uint64(len(key)) + uint64(len(value))
+				}
+			}
+			require.Greater(t, hotBytes, uint64(0))
+			require.LessOrEqual(t, hotBytes, config.WriteCacheSize+config.ReadCacheSize)
+
+			err = db.Destroy()
+			require.NoError(t, err)
+		})
+	}
+
+	config, err := litt.DefaultConfig(t.TempDir())
+	require.NoError(t, err)
+	config.CacheEvictionPolicy = "random"
+	require.Error(t, config.SanityCheck())
+}
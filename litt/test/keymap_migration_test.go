@@ -289,3 +289,74 @@ func TestFailedKeymapMigration(t *testing.T) {
 		require.Equal(t, expectedValue, value)
 	}
 }
+
+// Tests migration between keymap types that store data on disk, both with and without rebuilding the keymap.
+func TestDiskKeymapMigration(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+	directory := t.TempDir()
+
+	config, err := litt.DefaultConfig(directory)
+	require.NoError(t, err)
+	config.ShardingFactor = 4
+	config.TargetSegmentFileSize = 100
+	config.KeymapType = keymap.UnsafeLevelDBKeymapType
+	config.Fsync = false // fsync is too slow for unit test workloads
+	config.DoubleWriteProtection = true
+
+	expectedValues := make(map[string][]byte)
+	keymapDataPath := path.Join(directory, "test", keymap.KeymapDirectoryName, keymap.KeymapDataDirectoryName)
+	sentinelPath := path.Join(keymapDataPath, "sentinel")
+
+	// Starts the DB with the given keymap type, verifies existing data, writes some new data, and shuts down.
+	// Returns true if the keymap data from the previous run was kept.
+	runWithKeymapType := func(keymapType keymap.KeymapType) bool {
+		config.KeymapType = keymapType
+		db, err := littbuilder.NewDB(config)
+		require.NoError(t, err)
+		table, err := db.GetTable("test")
+		require.NoError(t, err)
+
+		sentinelExists, err := util.Exists(sentinelPath)
+		require.NoError(t, err)
+
+		keymapTypeFile, err := keymap.LoadKeymapTypeFile(path.Join(directory, "test", keymap.KeymapDirectoryName))
+		require.NoError(t, err)
+		require.Equal(t, keymapType, keymapTypeFile.Type())
+
+		for expectedKey, expectedValue := range expectedValues {
+			value, ok, err := table.Get([]byte(expectedKey))
+			require.NoError(t, err)
+			require.True(t, ok)
+			require.Equal(t, expectedValue, value)
+		}
+
+		for i := 0; i < 100; i++ {
+			key := rand.PrintableVariableBytes(32, 64)
+			value := rand.PrintableVariableBytes(1, 128)
+			err = table.Put(key, value)
+			require.NoError(t, err)
+			expectedValues[string(key)] = value
+		}
+
+		err = db.Close()
+		require.NoError(t, err)
+
+		// Leave a file in the keymap data directory. It is only still present on the next run if the keymap
+		// data was not rebuilt.
+		err = os.WriteFile(sentinelPath, []byte{}, 0644)
+		require.NoError(t, err)
+
+		return sentinelExists
+	}
+
+	runWithKeymapType(keymap.UnsafeLevelDBKeymapType)
+
+	// Same storage format, the keymap is migrated in place.
+	require.True(t, runWithKeymapType(keymap.LevelDBKeymapType))
+
+	// Different storage format, the keymap is rebuilt.
+	require.False(t, runWithKeymapType(keymap.UnsafePebbleKeymapType))
+	require.True(t, runWithKeymapType(keymap.PebbleKeymapType))
+	require.False(t, runWithKeymapType(keymap.UnsafeLevelDBKeymapType))
+}
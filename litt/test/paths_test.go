@@ -0,0 +1,485 @@
+package test
+
+import (
+	"os"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/testutils/random"
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/disktable"
+	"github.com/Layr-Labs/eigenda/litt/disktable/keymap"
+	"github.com/Layr-Labs/eigenda/litt/disktable/segment"
+	"github.com/Layr-Labs/eigenda/litt/littbuilder"
+	"github.com/Layr-Labs/eigenda/litt/util"
+	"github.com/stretchr/testify/require"
+)
+
+// buildPathsTestConfig builds a config for a DB with small segments spread across multiple roots.
+func buildPathsTestConfig(t *testing.T, roots []string) *litt.Config {
+	config, err := litt.DefaultConfig(roots...)
+	require.NoError(t, err)
+	config.KeymapType = keymap.LevelDBKeymapType
+	config.TargetSegmentFileSize = 100
+	config.ShardingFactor = 4
+	config.Fsync = false
+	config.DoubleWriteProtection = true
+	return config
+}
+
+// countSegmentFiles returns the number of segment files for a table in the given root.
+func countSegmentFiles(t *testing.T, root string, tableName string) int {
+	entries, err := os.ReadDir(path.Join(root, tableName, disktable.SegmentDirectory))
+	if os.IsNotExist(err) {
+		return 0
+	}
+	require.NoError(t, err)
+
+	count := 0
+	for _, entry := range entries {
+		if !strings.HasSuffix(entry.Name(), ".swap") {
+			count++
+		}
+	}
+	return count
+}
+
+func TestAddAndRemovePaths(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+
+	testDirectory := t.TempDir()
+	root0 := path.Join(testDirectory, "root0")
+	root1 := path.Join(testDirectory, "root1")
+	root2 := path.Join(testDirectory, "root2")
+
+	db, err := littbuilder.NewDB(buildPathsTestConfig(t, []string{root0, root1}))
+	require.NoError(t, err)
+	table, err := db.GetTable("table")
+	require.NoError(t, err)
+
+	expectedValues := make(map[string][]byte)
+	writeData := func(count int) {
+		for i := 0; i < count; i++ {
+			key := rand.PrintableBytes(32)
+			value := rand.PrintableVariableBytes(1, 64)
+			err = table.Put(key, value)
+			require.NoError(t, err)
+			expectedValues[string(key)] = value
+		}
+		err = table.Flush()
+		require.NoError(t, err)
+	}
+	checkData := func(table litt.Table) {
+		for key, expectedValue := range expectedValues {
+			value, ok, err := table.Get([]byte(key))
+			require.NoError(t, err)
+			require.True(t, ok)
+			require.Equal(t, expectedValue, value)
+		}
+	}
+
+	writeData(100)
+	require.Equal(t, 0, countSegmentFiles(t, root2, "table"))
+
+	// New segments are placed in an added path.
+	err = db.AddPath(root2)
+	require.NoError(t, err)
+	writeData(100)
+	require.Greater(t, countSegmentFiles(t, root2, "table"), 0)
+	checkData(table)
+
+	// Adding a path twice is a no-op.
+	err = db.AddPath(root2)
+	require.NoError(t, err)
+
+	// Unknown paths can't be removed.
+	err = db.RemovePath(path.Join(testDirectory, "root3"))
+	require.Error(t, err)
+
+	// Data in a removed path is moved in the background. Reads and writes continue while this happens.
+	require.Greater(t, countSegmentFiles(t, root1, "table"), 0)
+	err = db.RemovePath(root1)
+	require.NoError(t, err)
+	writeData(100)
+	checkData(table)
+
+	// A table created while data is being moved is not placed in the removed path.
+	otherTable, err := db.GetTable("other")
+	require.NoError(t, err)
+	err = otherTable.Put(rand.PrintableBytes(32), rand.PrintableBytes(32))
+	require.NoError(t, err)
+	require.Equal(t, 0, countSegmentFiles(t, root1, "other"))
+
+	require.Eventually(t, func() bool {
+		_, err := os.Stat(path.Join(root1, "table", disktable.SegmentDirectory))
+		return os.IsNotExist(err)
+	}, 10*time.Second, 10*time.Millisecond)
+	checkData(table)
+
+	err = db.Close()
+	require.NoError(t, err)
+
+	// The DB can be restarted without the removed path.
+	db, err = littbuilder.NewDB(buildPathsTestConfig(t, []string{root0, root2}))
+	require.NoError(t, err)
+	table, err = db.GetTable("table")
+	require.NoError(t, err)
+	checkData(table)
+
+	err = db.Close()
+	require.NoError(t, err)
+}
+
+func TestRemovePathWithUnloadedTable(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+
+	testDirectory := t.TempDir()
+	root0 := path.Join(testDirectory, "root0")
+	root1 := path.Join(testDirectory, "root1")
+
+	db, err := littbuilder.NewDB(buildPathsTestConfig(t, []string{root0, root1}))
+	require.NoError(t, err)
+	table, err := db.GetTable("table")
+	require.NoError(t, err)
+
+	expectedValues := make(map[string][]byte)
+	for i := 0; i < 100; i++ {
+		key := rand.PrintableBytes(32)
+		value := rand.PrintableVariableBytes(1, 64)
+		err = table.Put(key, value)
+		require.NoError(t, err)
+		expectedValues[string(key)] = value
+	}
+	err = db.Close()
+	require.NoError(t, err)
+
+	// After a restart the table has not been loaded. Removing the path must still move its data.
+	db, err = littbuilder.NewDB(buildPathsTestConfig(t, []string{root0, root1}))
+	require.NoError(t, err)
+	err = db.RemovePath(root1)
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		_, err := os.Stat(root1)
+		return os.IsNotExist(err)
+	}, 10*time.Second, 10*time.Millisecond)
+
+	err = db.Close()
+	require.NoError(t, err)
+
+	db, err = littbuilder.NewDB(buildPathsTestConfig(t, []string{root0}))
+	require.NoError(t, err)
+	table, err = db.GetTable("table")
+	require.NoError(t, err)
+	for key, expectedValue := range expectedValues {
+		value, ok, err := table.Get([]byte(key))
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, expectedValue, value)
+	}
+	err = db.Close()
+	require.NoError(t, err)
+}
+
+func TestRemovePathWithMetadataAndKeymap(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+
+	testDirectory := t.TempDir()
+	root0 := path.Join(testDirectory, "root0")
+	root1 := path.Join(testDirectory, "root1")
+
+	// Table "table" keeps its metadata and keymap in root0.
+	expectedValues := writePathsTestData(t, rand, []string{root0, root1})
+	require.FileExists(t, path.Join(root0, "table", disktable.TableMetadataFileName))
+	require.DirExists(t, path.Join(root0, "table", keymap.KeymapDirectoryName))
+
+	// Table "other" keeps its metadata and keymap in root1.
+	db, err := littbuilder.NewDB(buildPathsTestConfig(t, []string{root1, root0}))
+	require.NoError(t, err)
+	otherTable, err := db.GetTable("other")
+	require.NoError(t, err)
+	otherKey := rand.PrintableBytes(32)
+	otherValue := rand.PrintableBytes(32)
+	err = otherTable.Put(otherKey, otherValue)
+	require.NoError(t, err)
+
+	table, err := db.GetTable("table")
+	require.NoError(t, err)
+	checkData := func(table litt.Table) {
+		for key, expectedValue := range expectedValues {
+			value, ok, err := table.Get([]byte(key))
+			require.NoError(t, err)
+			require.True(t, ok)
+			require.Equal(t, expectedValue, value)
+		}
+	}
+
+	// The metadata and keymap are moved out of the removed path along with the segment files.
+	err = db.RemovePath(root0)
+	require.NoError(t, err)
+	for i := 0; i < 100; i++ {
+		key := rand.PrintableBytes(32)
+		value := rand.PrintableVariableBytes(1, 64)
+		err = table.Put(key, value)
+		require.NoError(t, err)
+		expectedValues[string(key)] = value
+	}
+	err = table.Flush()
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		_, err := os.Stat(root0)
+		return os.IsNotExist(err)
+	}, 10*time.Second, 10*time.Millisecond)
+	checkData(table)
+
+	require.FileExists(t, path.Join(root1, "table", disktable.TableMetadataFileName))
+	require.FileExists(t, path.Join(root1, "table", keymap.KeymapDirectoryName, keymap.KeymapInitializedFileName))
+	require.NoDirExists(t, path.Join(root1, "table", keymap.KeymapStagingDirectoryName))
+
+	// Changes to the table's metadata are written to its new location.
+	err = table.SetTTL(time.Hour)
+	require.NoError(t, err)
+	ttl, err := disktable.ReadTableTTL([]string{path.Join(root1, "table")})
+	require.NoError(t, err)
+	require.Equal(t, time.Hour, ttl)
+
+	err = db.Close()
+	require.NoError(t, err)
+
+	// The DB can be restarted without the removed path.
+	db, err = littbuilder.NewDB(buildPathsTestConfig(t, []string{root1}))
+	require.NoError(t, err)
+	table, err = db.GetTable("table")
+	require.NoError(t, err)
+	checkData(table)
+	otherTable, err = db.GetTable("other")
+	require.NoError(t, err)
+	value, ok, err := otherTable.Get(otherKey)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, otherValue, value)
+
+	err = db.Close()
+	require.NoError(t, err)
+}
+
+func TestInterruptedKeymapAndMetadataMove(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+
+	testDirectory := t.TempDir()
+	root0 := path.Join(testDirectory, "root0")
+	root1 := path.Join(testDirectory, "root1")
+	roots := []string{root0, root1}
+
+	expectedValues := writePathsTestData(t, rand, roots)
+
+	// Simulate a crash while the keymap and metadata were being moved from root0 to root1, after both had been
+	// copied to root1 but before the originals were deleted.
+	err := util.RecursiveMove(
+		path.Join(root0, "table", keymap.KeymapDirectoryName),
+		path.Join(root1, "table", keymap.KeymapDirectoryName),
+		true,
+		false)
+	require.NoError(t, err)
+	err = util.RecursiveMove(
+		path.Join(root0, "table", keymap.KeymapDirectoryName),
+		path.Join(root1, "table", keymap.KeymapStagingDirectoryName),
+		true,
+		false)
+	require.NoError(t, err)
+	err = os.Remove(path.Join(root0, "table", keymap.KeymapDirectoryName, keymap.KeymapInitializedFileName))
+	require.NoError(t, err)
+	err = util.CopyRegularFile(
+		path.Join(root0, "table", disktable.TableMetadataFileName),
+		path.Join(root1, "table", disktable.TableMetadataFileName),
+		false)
+	require.NoError(t, err)
+
+	db, err := littbuilder.NewDB(buildPathsTestConfig(t, roots))
+	require.NoError(t, err)
+	table, err := db.GetTable("table")
+	require.NoError(t, err)
+	for key, expectedValue := range expectedValues {
+		value, ok, err := table.Get([]byte(key))
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, expectedValue, value)
+	}
+	err = db.Close()
+	require.NoError(t, err)
+
+	// Only the initialized copy of the keymap and a single copy of the metadata remain.
+	require.NoDirExists(t, path.Join(root0, "table", keymap.KeymapDirectoryName))
+	require.DirExists(t, path.Join(root1, "table", keymap.KeymapDirectoryName))
+	require.NoDirExists(t, path.Join(root1, "table", keymap.KeymapStagingDirectoryName))
+	require.FileExists(t, path.Join(root0, "table", disktable.TableMetadataFileName))
+	require.NoFileExists(t, path.Join(root1, "table", disktable.TableMetadataFileName))
+}
+
+func TestAddedPathMissingFromConfig(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+
+	testDirectory := t.TempDir()
+	root0 := path.Join(testDirectory, "root0")
+	root1 := path.Join(testDirectory, "root1")
+
+	db, err := littbuilder.NewDB(buildPathsTestConfig(t, []string{root0}))
+	require.NoError(t, err)
+	err = db.AddPath(root1)
+	require.NoError(t, err)
+	table, err := db.GetTable("table")
+	require.NoError(t, err)
+	for i := 0; i < 100; i++ {
+		err = table.Put(rand.PrintableBytes(32), rand.PrintableVariableBytes(1, 64))
+		require.NoError(t, err)
+	}
+	err = db.Close()
+	require.NoError(t, err)
+	require.Greater(t, countSegmentFiles(t, root1, "table"), 0)
+
+	// The path added at runtime contains data, so the DB can't be started without it.
+	_, err = littbuilder.NewDB(buildPathsTestConfig(t, []string{root0}))
+	require.Error(t, err)
+
+	db, err = littbuilder.NewDB(buildPathsTestConfig(t, []string{root0, root1}))
+	require.NoError(t, err)
+	err = db.Close()
+	require.NoError(t, err)
+
+	// A path that no longer contains any data may be dropped from the configuration.
+	err = os.RemoveAll(root1)
+	require.NoError(t, err)
+	db, err = littbuilder.NewDB(buildPathsTestConfig(t, []string{root0}))
+	require.NoError(t, err)
+	err = db.Close()
+	require.NoError(t, err)
+}
+
+// writePathsTestData writes data to a table spread across two roots and closes the DB. Returns the data written.
+func writePathsTestData(t *testing.T, rand *random.TestRandom, roots []string) map[string][]byte {
+	db, err := littbuilder.NewDB(buildPathsTestConfig(t, roots))
+	require.NoError(t, err)
+	table, err := db.GetTable("table")
+	require.NoError(t, err)
+
+	expectedValues := make(map[string][]byte)
+	for i := 0; i < 100; i++ {
+		key := rand.PrintableBytes(32)
+		value := rand.PrintableVariableBytes(1, 64)
+		err = table.Put(key, value)
+		require.NoError(t, err)
+		expectedValues[string(key)] = value
+	}
+	err = db.Close()
+	require.NoError(t, err)
+
+	return expectedValues
+}
+
+// duplicateSegmentFile copies a non-empty segment file with the given extension from one of two roots into the
+// other, simulating a crash while the file was being moved between the roots. Returns the name of the copied file.
+func duplicateSegmentFile(t *testing.T, roots []string, extension string) string {
+	for i, sourceRoot := range roots {
+		sourceDirectory := path.Join(sourceRoot, "table", disktable.SegmentDirectory)
+		destinationDirectory := path.Join(roots[(i+1)%len(roots)], "table", disktable.SegmentDirectory)
+
+		entries, err := os.ReadDir(sourceDirectory)
+		require.NoError(t, err)
+		for _, entry := range entries {
+			if !strings.HasSuffix(entry.Name(), extension) {
+				continue
+			}
+			fileBytes, err := os.ReadFile(path.Join(sourceDirectory, entry.Name()))
+			require.NoError(t, err)
+			if len(fileBytes) == 0 {
+				continue
+			}
+			err = os.WriteFile(path.Join(destinationDirectory, entry.Name()), fileBytes, 0644)
+			require.NoError(t, err)
+			return entry.Name()
+		}
+	}
+	require.Fail(t, "no segment file found")
+	return ""
+}
+
+func TestDuplicateSegmentFileAfterCrash(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+
+	testDirectory := t.TempDir()
+	root0 := path.Join(testDirectory, "root0")
+	root1 := path.Join(testDirectory, "root1")
+	roots := []string{root0, root1}
+
+	expectedValues := writePathsTestData(t, rand, roots)
+
+	// Simulate crashes after each type of file was renamed into its destination, but before the original was deleted.
+	duplicates := []string{
+		duplicateSegmentFile(t, roots, segment.ValuesFileExtension),
+		duplicateSegmentFile(t, roots, segment.KeyFileExtension),
+		duplicateSegmentFile(t, roots, segment.MetadataFileExtension),
+	}
+
+	db, err := littbuilder.NewDB(buildPathsTestConfig(t, roots))
+	require.NoError(t, err)
+	table, err := db.GetTable("table")
+	require.NoError(t, err)
+	for key, expectedValue := range expectedValues {
+		value, ok, err := table.Get([]byte(key))
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, expectedValue, value)
+	}
+	err = db.Close()
+	require.NoError(t, err)
+
+	// Only one copy of each duplicated file remains.
+	for _, fileName := range duplicates {
+		copies := 0
+		for _, root := range roots {
+			_, err := os.Stat(path.Join(root, "table", disktable.SegmentDirectory, fileName))
+			if err == nil {
+				copies++
+			} else {
+				require.True(t, os.IsNotExist(err))
+			}
+		}
+		require.Equal(t, 1, copies, fileName)
+	}
+}
+
+func TestConflictingDuplicateSegmentFile(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+
+	testDirectory := t.TempDir()
+	root0 := path.Join(testDirectory, "root0")
+	root1 := path.Join(testDirectory, "root1")
+	roots := []string{root0, root1}
+
+	writePathsTestData(t, rand, roots)
+
+	// A duplicate with different contents can't be resolved automatically.
+	fileName := duplicateSegmentFile(t, roots, segment.ValuesFileExtension)
+	filePath := path.Join(root0, "table", disktable.SegmentDirectory, fileName)
+	fileBytes, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	fileBytes[len(fileBytes)-1] ^= 1
+	err = os.WriteFile(filePath, fileBytes, 0644)
+	require.NoError(t, err)
+
+	db, err := littbuilder.NewDB(buildPathsTestConfig(t, roots))
+	require.NoError(t, err)
+	_, err = db.GetTable("table")
+	require.Error(t, err)
+	_ = db.Close()
+}
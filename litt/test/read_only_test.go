@@ -0,0 +1,144 @@
+package test
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/testutils/random"
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/disktable/keymap"
+	"github.com/Layr-Labs/eigenda/litt/littbuilder"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+// buildReadOnlyTestConfig builds a config for a DB with small segments spread across multiple roots.
+func buildReadOnlyTestConfig(t *testing.T, roots []string) *litt.Config {
+	config, err := litt.DefaultConfig(roots...)
+	require.NoError(t, err)
+	config.KeymapType = keymap.LevelDBKeymapType
+	config.TargetSegmentFileSize = 100
+	config.ShardingFactor = 2
+	config.Fsync = false
+	config.DoubleWriteProtection = true
+	return config
+}
+
+func TestReadOnlyDB(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+
+	testDirectory := t.TempDir()
+	roots := []string{path.Join(testDirectory, "root0"), path.Join(testDirectory, "root1")}
+
+	// The writer reports metrics to the registry.
+	registry := prometheus.NewRegistry()
+	writerConfig := buildReadOnlyTestConfig(t, roots)
+	writerConfig.MetricsEnabled = true
+	writerConfig.MetricsRegistry = registry
+	db, err := littbuilder.NewDB(writerConfig)
+	require.NoError(t, err)
+	table, err := db.GetTable("table")
+	require.NoError(t, err)
+
+	expectedValues := make(map[string][]byte)
+	writeData := func(count int) {
+		for i := 0; i < count; i++ {
+			key := rand.PrintableBytes(32)
+			value := rand.PrintableVariableBytes(1, 64)
+			err = table.Put(key, value)
+			require.NoError(t, err)
+			expectedValues[string(key)] = value
+		}
+		err = table.Flush()
+		require.NoError(t, err)
+	}
+
+	// checkData verifies that all data visible to the reader is correct, and returns the number of visible keys.
+	checkData := func(readerTable litt.Table) int {
+		visible := 0
+		for key, expectedValue := range expectedValues {
+			value, ok, err := readerTable.Get([]byte(key))
+			require.NoError(t, err)
+			if ok {
+				require.Equal(t, expectedValue, value)
+				visible++
+			}
+		}
+		return visible
+	}
+
+	writeData(100)
+
+	// The reader does not take the locks on the root directories, so it can be opened while the writer is live.
+	readerConfig := buildReadOnlyTestConfig(t, roots)
+	readerConfig.GCPeriod = time.Hour
+	// Metrics are never reported by a reader, even if enabled in its config, so the reader's metrics don't conflict
+	// with the writer's.
+	readerConfig.MetricsEnabled = true
+	readerConfig.MetricsRegistry = registry
+	reader, err := littbuilder.NewReadOnlyDBFromConfig(readerConfig)
+	require.NoError(t, err)
+	require.True(t, readerConfig.MetricsEnabled)
+
+	_, err = reader.GetTable("nonexistent")
+	require.Error(t, err)
+
+	readerTable, err := reader.GetTable("table")
+	require.NoError(t, err)
+
+	// Data in sealed segments is visible. Data in the writer's mutable segment is not.
+	visible := checkData(readerTable)
+	require.Greater(t, visible, 0)
+	require.LessOrEqual(t, visible, len(expectedValues))
+	require.Equal(t, uint64(visible), readerTable.KeyCount())
+
+	// Operations that modify data are rejected.
+	err = readerTable.Put(rand.PrintableBytes(32), rand.PrintableBytes(32))
+	require.ErrorIs(t, err, litt.ErrReadOnly)
+	err = readerTable.Flush()
+	require.ErrorIs(t, err, litt.ErrReadOnly)
+	err = readerTable.SetTTL(time.Second)
+	require.ErrorIs(t, err, litt.ErrReadOnly)
+	err = reader.DropTable("table")
+	require.ErrorIs(t, err, litt.ErrReadOnly)
+
+	// Segments sealed by the writer become visible after a refresh.
+	writeData(100)
+	err = readerTable.(litt.ManagedTable).RunGC()
+	require.NoError(t, err)
+	require.Greater(t, checkData(readerTable), visible)
+
+	// Segments deleted by the writer's garbage collector disappear after a refresh. Reads that race with deletion
+	// report missing values rather than errors.
+	err = table.SetTTL(time.Nanosecond)
+	require.NoError(t, err)
+	err = table.(litt.ManagedTable).RunGC()
+	require.NoError(t, err)
+	checkData(readerTable)
+	require.Eventually(t, func() bool {
+		err = readerTable.(litt.ManagedTable).RunGC()
+		require.NoError(t, err)
+		return checkData(readerTable) == 0
+	}, 10*time.Second, 10*time.Millisecond)
+	require.Equal(t, uint64(0), readerTable.KeyCount())
+
+	err = reader.Close()
+	require.NoError(t, err)
+
+	// The writer is unaffected by the reader.
+	table, err = db.GetTable("table")
+	require.NoError(t, err)
+	key := rand.PrintableBytes(32)
+	value := rand.PrintableBytes(32)
+	err = table.Put(key, value)
+	require.NoError(t, err)
+	readValue, ok, err := table.Get(key)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, value, readValue)
+
+	err = db.Close()
+	require.NoError(t, err)
+}
@@ -0,0 +1,223 @@
+package test
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/testutils/random"
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/disktable"
+	"github.com/Layr-Labs/eigenda/litt/disktable/keymap"
+	"github.com/Layr-Labs/eigenda/litt/disktable/segment"
+	"github.com/Layr-Labs/eigenda/litt/littbuilder"
+	"github.com/stretchr/testify/require"
+)
+
+// buildScrubberTestConfig builds a config for scrubber tests.
+func buildScrubberTestConfig(t *testing.T, root string) *litt.Config {
+	config, err := litt.DefaultConfig(root)
+	require.NoError(t, err)
+	config.KeymapType = keymap.MemKeymapType
+	config.TargetSegmentFileSize = 100
+	config.ShardingFactor = 1
+	config.Fsync = false
+	config.DoubleWriteProtection = true
+	return config
+}
+
+// writeScrubberTestData writes random data to a table and closes the DB. Returns the data that was written.
+func writeScrubberTestData(t *testing.T, rand *random.TestRandom, root string) map[string][]byte {
+	db, err := littbuilder.NewDB(buildScrubberTestConfig(t, root))
+	require.NoError(t, err)
+	table, err := db.GetTable("table")
+	require.NoError(t, err)
+
+	expectedValues := make(map[string][]byte)
+	for i := 0; i < 100; i++ {
+		key := rand.PrintableBytes(32)
+		value := rand.PrintableVariableBytes(1, 64)
+		err = table.Put(key, value)
+		require.NoError(t, err)
+		expectedValues[string(key)] = value
+	}
+
+	err = db.Close()
+	require.NoError(t, err)
+
+	return expectedValues
+}
+
+// corruptValueFile flips a bit in the last byte of a non-empty value file. The last byte of a value file always
+// belongs to the last value written to that file.
+func corruptValueFile(t *testing.T, root string) {
+	segmentDirectory := path.Join(root, "table", disktable.SegmentDirectory)
+	valueFiles, err := filepath.Glob(path.Join(segmentDirectory, "*"+segment.ValuesFileExtension))
+	require.NoError(t, err)
+
+	for _, valueFile := range valueFiles {
+		fileBytes, err := os.ReadFile(valueFile)
+		require.NoError(t, err)
+		if len(fileBytes) == 0 {
+			continue
+		}
+		fileBytes[len(fileBytes)-1] ^= 1
+		err = os.WriteFile(valueFile, fileBytes, 0644)
+		require.NoError(t, err)
+		return
+	}
+	require.Fail(t, "no non-empty value file found")
+}
+
+func TestScrubberFatalError(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+	root := t.TempDir()
+
+	expectedValues := writeScrubberTestData(t, rand, root)
+	corruptValueFile(t, root)
+
+	fatalError := atomic.Pointer[error]{}
+	config := buildScrubberTestConfig(t, root)
+	config.ScrubPeriod = 10 * time.Millisecond
+	config.FatalErrorCallback = func(err error) {
+		fatalError.Store(&err)
+	}
+
+	db, err := littbuilder.NewDB(config)
+	require.NoError(t, err)
+	table, err := db.GetTable("table")
+	require.NoError(t, err)
+
+	// Exactly one value should fail its checksum when read.
+	corruptedCount := 0
+	for key, expectedValue := range expectedValues {
+		value, ok, err := table.Get([]byte(key))
+		if err != nil {
+			require.ErrorIs(t, err, segment.ErrChecksumMismatch)
+			corruptedCount++
+			continue
+		}
+		require.True(t, ok)
+		require.Equal(t, expectedValue, value)
+	}
+	require.Equal(t, 1, corruptedCount)
+
+	// The scrubber should eventually find the corrupted value and trigger the fatal error callback.
+	require.Eventually(t, func() bool {
+		return fatalError.Load() != nil
+	}, 10*time.Second, 10*time.Millisecond)
+
+	err = db.Close()
+	require.Error(t, err)
+}
+
+func TestScrubberQuarantine(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+	root := t.TempDir()
+
+	expectedValues := writeScrubberTestData(t, rand, root)
+	corruptValueFile(t, root)
+
+	config := buildScrubberTestConfig(t, root)
+	config.ScrubPeriod = 10 * time.Millisecond
+	config.QuarantineCorruptedData = true
+	config.FatalErrorCallback = func(err error) {
+		require.Fail(t, "unexpected fatal error", err)
+	}
+
+	db, err := littbuilder.NewDB(config)
+	require.NoError(t, err)
+	table, err := db.GetTable("table")
+	require.NoError(t, err)
+
+	// The scrubber should eventually remove the corrupted key from the table.
+	require.Eventually(t, func() bool {
+		missingCount := 0
+		for key := range expectedValues {
+			_, ok, err := table.Get([]byte(key))
+			if err != nil {
+				// The corrupted key has not yet been quarantined.
+				return false
+			}
+			if !ok {
+				missingCount++
+			}
+		}
+		return missingCount == 1
+	}, 10*time.Second, 10*time.Millisecond)
+
+	// All other values should be unaffected.
+	for key, expectedValue := range expectedValues {
+		value, ok, err := table.Get([]byte(key))
+		require.NoError(t, err)
+		if ok {
+			require.Equal(t, expectedValue, value)
+		}
+	}
+
+	err = db.Close()
+	require.NoError(t, err)
+}
+
+func TestScrubberQuarantineEvictsCachedValues(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+	root := t.TempDir()
+
+	config := buildScrubberTestConfig(t, root)
+	config.WriteCacheSize = 1024 * 1024
+	config.ReadCacheSize = 1024 * 1024
+	config.ScrubPeriod = 10 * time.Millisecond
+	config.QuarantineCorruptedData = true
+	config.FatalErrorCallback = func(err error) {
+		require.Fail(t, "unexpected fatal error", err)
+	}
+
+	db, err := littbuilder.NewDB(config)
+	require.NoError(t, err)
+	table, err := db.GetTable("table")
+	require.NoError(t, err)
+
+	expectedValues := make(map[string][]byte)
+	for i := 0; i < 100; i++ {
+		key := rand.PrintableBytes(32)
+		value := rand.PrintableVariableBytes(1, 64)
+		err = table.Put(key, value)
+		require.NoError(t, err)
+		expectedValues[string(key)] = value
+	}
+	err = table.Flush()
+	require.NoError(t, err)
+
+	// Every value is in the cache, so reads don't touch the corrupted file.
+	corruptValueFile(t, root)
+
+	// The corrupted key should eventually be removed from the caches as well as from the table.
+	require.Eventually(t, func() bool {
+		missingCount := 0
+		for key := range expectedValues {
+			_, ok, err := table.Get([]byte(key))
+			require.NoError(t, err)
+			if !ok {
+				missingCount++
+			}
+		}
+		return missingCount == 1
+	}, 10*time.Second, 10*time.Millisecond)
+
+	for key, expectedValue := range expectedValues {
+		value, ok, err := table.Get([]byte(key))
+		require.NoError(t, err)
+		if ok {
+			require.Equal(t, expectedValue, value)
+		}
+	}
+
+	err = db.Close()
+	require.NoError(t, err)
+}
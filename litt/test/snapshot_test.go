@@ -0,0 +1,208 @@
+package test
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/testutils/random"
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/disktable"
+	"github.com/Layr-Labs/eigenda/litt/disktable/keymap"
+	"github.com/Layr-Labs/eigenda/litt/littbuilder"
+	"github.com/Layr-Labs/eigenda/litt/util"
+	"github.com/stretchr/testify/require"
+)
+
+// buildSnapshotTestConfig builds a config for a DB with data spread across multiple roots.
+func buildSnapshotTestConfig(t *testing.T, roots []string) *litt.Config {
+	config, err := litt.DefaultConfig(roots...)
+	require.NoError(t, err)
+	config.KeymapType = keymap.UnsafeLevelDBKeymapType
+	config.TargetSegmentFileSize = 100
+	config.ShardingFactor = 4
+	config.Fsync = false
+	config.DoubleWriteProtection = true
+	return config
+}
+
+func TestSnapshotAndRestore(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+
+	testDirectory := t.TempDir()
+	roots := []string{
+		path.Join(testDirectory, "root0"),
+		path.Join(testDirectory, "root1"),
+		path.Join(testDirectory, "root2"),
+	}
+	snapshotDirectory := path.Join(testDirectory, "snapshot")
+
+	db, err := littbuilder.NewDB(buildSnapshotTestConfig(t, roots))
+	require.NoError(t, err)
+
+	tableNames := []string{"tableA", "tableB"}
+	expectedValues := make(map[string]map[string][]byte)
+	for _, tableName := range tableNames {
+		expectedValues[tableName] = make(map[string][]byte)
+	}
+
+	writeData := func(count int) {
+		for i := 0; i < count; i++ {
+			tableName := tableNames[rand.Intn(len(tableNames))]
+			table, err := db.GetTable(tableName)
+			require.NoError(t, err)
+
+			key := rand.PrintableVariableBytes(32, 64)
+			value := rand.PrintableVariableBytes(1, 128)
+			err = table.Put(key, value)
+			require.NoError(t, err)
+			expectedValues[tableName][string(key)] = value
+		}
+	}
+
+	// Take several incremental snapshots, each time adding more data.
+	for i := 0; i < 3; i++ {
+		writeData(100)
+		err = db.Snapshot(snapshotDirectory)
+		require.NoError(t, err)
+	}
+
+	// Data written after the last snapshot should not be restored.
+	table, err := db.GetTable(tableNames[0])
+	require.NoError(t, err)
+	unsnapshottedKey := rand.PrintableBytes(32)
+	err = table.Put(unsnapshottedKey, rand.PrintableBytes(32))
+	require.NoError(t, err)
+
+	// Setting a TTL and running GC deletes segments from the DB, but should not affect the snapshot.
+	for _, tableName := range tableNames {
+		table, err := db.GetTable(tableName)
+		require.NoError(t, err)
+		err = table.SetTTL(time.Nanosecond)
+		require.NoError(t, err)
+		err = table.(litt.ManagedTable).RunGC()
+		require.NoError(t, err)
+	}
+
+	err = db.Close()
+	require.NoError(t, err)
+
+	for _, tableName := range tableNames {
+		exists, err := util.Exists(path.Join(snapshotDirectory, tableName, disktable.SnapshotUpperBoundFileName))
+		require.NoError(t, err)
+		require.True(t, exists)
+	}
+
+	// Restore the snapshot into a new set of roots.
+	restoredRoots := []string{
+		path.Join(testDirectory, "restored0"),
+		path.Join(testDirectory, "restored1"),
+	}
+	err = littbuilder.RestoreSnapshot(buildSnapshotTestConfig(t, restoredRoots), snapshotDirectory)
+	require.NoError(t, err)
+
+	restoredDB, err := littbuilder.NewDB(buildSnapshotTestConfig(t, restoredRoots))
+	require.NoError(t, err)
+
+	for tableName, tableValues := range expectedValues {
+		table, err := restoredDB.GetTable(tableName)
+		require.NoError(t, err)
+		require.Equal(t, uint64(len(tableValues)), table.KeyCount())
+
+		for expectedKey, expectedValue := range tableValues {
+			value, ok, err := table.Get([]byte(expectedKey))
+			require.NoError(t, err)
+			require.True(t, ok)
+			require.Equal(t, expectedValue, value)
+		}
+	}
+
+	table, err = restoredDB.GetTable(tableNames[0])
+	require.NoError(t, err)
+	_, ok, err := table.Get(unsnapshottedKey)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	// The restored DB should be writable.
+	key := rand.PrintableBytes(32)
+	value := rand.PrintableBytes(32)
+	err = table.Put(key, value)
+	require.NoError(t, err)
+	readValue, ok, err := table.Get(key)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, value, readValue)
+
+	err = restoredDB.Close()
+	require.NoError(t, err)
+
+	// Restoring a second time into the same roots should fail, since the tables already exist.
+	err = littbuilder.RestoreSnapshot(buildSnapshotTestConfig(t, restoredRoots), snapshotDirectory)
+	require.Error(t, err)
+}
+
+func TestSnapshotWhileWriting(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+
+	testDirectory := t.TempDir()
+	roots := []string{path.Join(testDirectory, "root0"), path.Join(testDirectory, "root1")}
+	snapshotDirectory := path.Join(testDirectory, "snapshot")
+
+	db, err := littbuilder.NewDB(buildSnapshotTestConfig(t, roots))
+	require.NoError(t, err)
+	table, err := db.GetTable("table")
+	require.NoError(t, err)
+
+	// Write data on a background goroutine while snapshots are being taken.
+	done := make(chan struct{})
+	writeErrors := make(chan error, 1)
+	go func() {
+		defer close(writeErrors)
+		for i := 0; ; i++ {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			err := table.Put([]byte(fmt.Sprintf("key-%d", i)), rand.PrintableBytes(64))
+			if err != nil {
+				writeErrors <- err
+				return
+			}
+		}
+	}()
+
+	for i := 0; i < 10; i++ {
+		err = db.Snapshot(snapshotDirectory)
+		require.NoError(t, err)
+	}
+	close(done)
+	require.NoError(t, <-writeErrors)
+
+	err = db.Close()
+	require.NoError(t, err)
+
+	// Every file in the snapshot should be a symlink to a hard link in one of the roots.
+	entries, err := os.ReadDir(path.Join(snapshotDirectory, "table", disktable.SegmentDirectory))
+	require.NoError(t, err)
+	require.NotEmpty(t, entries)
+	for _, entry := range entries {
+		require.Equal(t, os.ModeSymlink, entry.Type())
+	}
+
+	restoredRoots := []string{path.Join(testDirectory, "restored")}
+	err = littbuilder.RestoreSnapshot(buildSnapshotTestConfig(t, restoredRoots), snapshotDirectory)
+	require.NoError(t, err)
+
+	restoredDB, err := littbuilder.NewDB(buildSnapshotTestConfig(t, restoredRoots))
+	require.NoError(t, err)
+	restoredTable, err := restoredDB.GetTable("table")
+	require.NoError(t, err)
+	require.Greater(t, restoredTable.KeyCount(), uint64(0))
+	err = restoredDB.Close()
+	require.NoError(t, err)
+}
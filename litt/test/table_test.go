@@ -1,6 +1,7 @@
 package test
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -154,6 +155,7 @@ func buildMemKeyDiskTable(
 		keymapTypeFile,
 		[]string{segmentsPath},
 		true,
+		nil,
 		nil)
 
 	if err != nil {
@@ -205,6 +207,7 @@ func buildLevelDBKeyDiskTable(
 		keymapTypeFile,
 		[]string{segmentsPath},
 		true,
+		nil,
 		nil)
 
 	if err != nil {
@@ -368,6 +371,90 @@ func TestRandomTableOperations(t *testing.T) {
 	}
 }
 
+func iterationTest(t *testing.T, tableBuilder *tableBuilder) {
+	rand := random.NewTestRandom()
+
+	directory := t.TempDir()
+
+	tableName := rand.String(8)
+	table, err := tableBuilder.builder(time.Now, tableName, directory)
+	require.NoError(t, err)
+
+	expectedValues := make(map[string][]byte)
+	for i := 0; i < 500; i++ {
+		key := rand.PrintableVariableBytes(32, 64)
+		value := rand.PrintableVariableBytes(1, 128)
+		err = table.Put(key, value)
+		require.NoError(t, err)
+		expectedValues[string(key)] = value
+
+		// Some data is flushed, some data is not. Iteration should visit both.
+		if rand.BoolWithProbability(0.1) {
+			err = table.Flush()
+			require.NoError(t, err)
+		}
+	}
+
+	// Write to the table while iterating. Values written during iteration may or may not be visited,
+	// but all values written before iteration started must be visited exactly once.
+	visited := make(map[string]int)
+	newValues := make(map[string][]byte)
+	err = table.Iterate(func(key []byte, value []byte) error {
+		visited[string(key)]++
+
+		if expectedValue, ok := expectedValues[string(key)]; ok {
+			require.Equal(t, expectedValue, value)
+		} else {
+			require.Equal(t, newValues[string(key)], value)
+		}
+
+		if rand.BoolWithProbability(0.1) {
+			newKey := rand.PrintableVariableBytes(32, 64)
+			newValue := rand.PrintableVariableBytes(1, 128)
+			err := table.Put(newKey, newValue)
+			require.NoError(t, err)
+			newValues[string(newKey)] = newValue
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	for key := range expectedValues {
+		require.Equal(t, 1, visited[key])
+	}
+	for key, count := range visited {
+		require.Equal(t, 1, count)
+		_, isExpected := expectedValues[key]
+		_, isNew := newValues[key]
+		require.True(t, isExpected || isNew)
+	}
+
+	// Returning an error from the function should stop iteration.
+	stopError := errors.New("stop")
+	count := 0
+	err = table.Iterate(func(key []byte, value []byte) error {
+		count++
+		if count == 10 {
+			return stopError
+		}
+		return nil
+	})
+	require.ErrorIs(t, err, stopError)
+	require.Equal(t, 10, count)
+
+	err = table.Destroy()
+	require.NoError(t, err)
+}
+
+func TestIteration(t *testing.T) {
+	t.Parallel()
+	for _, tb := range tableBuilders {
+		t.Run(tb.name, func(t *testing.T) {
+			iterationTest(t, tb)
+		})
+	}
+}
+
 func garbageCollectionTest(t *testing.T, tableBuilder *tableBuilder) {
 	rand := random.NewTestRandom()
 
@@ -536,6 +623,95 @@ func TestGarbageCollection(t *testing.T) {
 	}
 }
 
+func extendedExpiryTest(t *testing.T, tableBuilder *tableBuilder) {
+	rand := random.NewTestRandom()
+
+	directory := t.TempDir()
+
+	startTime := rand.Time()
+	var fakeTime atomic.Pointer[time.Time]
+	fakeTime.Store(&startTime)
+	clock := func() time.Time {
+		return *fakeTime.Load()
+	}
+	advanceClock := func(duration time.Duration) {
+		newTime := fakeTime.Load().Add(duration)
+		fakeTime.Store(&newTime)
+	}
+
+	table, err := tableBuilder.builder(clock, rand.String(8), directory)
+	require.NoError(t, err)
+
+	ttl := 10 * time.Second
+	err = table.SetTTL(ttl)
+	require.NoError(t, err)
+
+	// Expiries before the table's TTL are rejected.
+	err = table.PutWithExpiry(rand.PrintableBytes(32), rand.PrintableBytes(32), clock().Add(ttl-time.Second))
+	require.ErrorIs(t, err, litt.ErrInvalidExpiry)
+
+	pinnedKey := rand.PrintableBytes(32)
+	pinnedValue := rand.PrintableBytes(32)
+	err = table.PutWithExpiry(pinnedKey, pinnedValue, clock().Add(3*ttl))
+	require.NoError(t, err)
+
+	writeValues := func() [][]byte {
+		keys := make([][]byte, 0)
+		for i := 0; i < 20; i++ {
+			key := rand.PrintableBytes(32)
+			err = table.Put(key, rand.PrintableVariableBytes(1, 128))
+			require.NoError(t, err)
+			keys = append(keys, key)
+		}
+		err = table.Flush()
+		require.NoError(t, err)
+		return keys
+	}
+
+	// After the TTL passes, ordinary values are deleted but the pinned value is not.
+	expiredKeys := writeValues()
+	advanceClock(2 * ttl)
+	writeValues()
+	testutils.AssertEventuallyTrue(t, func() bool {
+		// Values in the same segment as the pinned value (or in the segment sealed most recently) are retained.
+		deletedCount := 0
+		for _, key := range expiredKeys {
+			exists, err := table.Exists(key)
+			require.NoError(t, err)
+			if !exists {
+				deletedCount++
+			}
+		}
+		return deletedCount > 0
+	}, time.Second)
+
+	value, ok, err := table.Get(pinnedKey)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, pinnedValue, value)
+
+	// After the extended expiry passes, the pinned value is deleted.
+	advanceClock(2 * ttl)
+	writeValues()
+	testutils.AssertEventuallyTrue(t, func() bool {
+		exists, err := table.Exists(pinnedKey)
+		require.NoError(t, err)
+		return !exists
+	}, time.Second)
+
+	err = table.Destroy()
+	require.NoError(t, err)
+}
+
+func TestExtendedExpiry(t *testing.T) {
+	t.Parallel()
+	for _, tb := range noCacheTableBuilders {
+		t.Run(tb.name, func(t *testing.T) {
+			extendedExpiryTest(t, tb)
+		})
+	}
+}
+
 func TestInvalidTableName(t *testing.T) {
 	t.Parallel()
 	directory := t.TempDir()
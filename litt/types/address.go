@@ -3,33 +3,48 @@ package types
 import (
 	"encoding/binary"
 	"fmt"
+	"math"
 )
 
-// Address describes the location of data on disk.
-// The first 4 bytes are the file ID, and the second 4 bytes are the offset of the data within the file.
-type Address uint64
+// Address describes the location of data on disk. An address is made up of the index of the segment containing the
+// data, and the offset of the data within the segment's value file.
+type Address struct {
+	// The index of the segment containing the data.
+	index uint32
+	// The offset of the first byte of the data within the value file.
+	offset uint64
+}
 
 // NewAddress creates a new address
-func NewAddress(index uint32, offset uint32) Address {
-	return Address(uint64(index)<<32 | uint64(offset))
+func NewAddress(index uint32, offset uint64) Address {
+	return Address{
+		index:  index,
+		offset: offset,
+	}
 }
 
-// DeserializeAddress converts a byte slice to an address.
+// DeserializeAddress converts a byte slice to an address. Addresses with offsets that fit into 32 bits are
+// serialized into 8 bytes (4 bytes for the index and 4 bytes for the offset), all other addresses are serialized into
+// 12 bytes (4 bytes for the index and 8 bytes for the offset).
 func DeserializeAddress(bytes []byte) (Address, error) {
-	if len(bytes) != 8 {
-		return 0, fmt.Errorf("invalid address length: %d", len(bytes))
+	switch len(bytes) {
+	case 8:
+		return NewAddress(binary.BigEndian.Uint32(bytes[0:4]), uint64(binary.BigEndian.Uint32(bytes[4:8]))), nil
+	case 12:
+		return NewAddress(binary.BigEndian.Uint32(bytes[0:4]), binary.BigEndian.Uint64(bytes[4:12])), nil
+	default:
+		return Address{}, fmt.Errorf("invalid address length: %d", len(bytes))
 	}
-	return Address(binary.BigEndian.Uint64(bytes)), nil
 }
 
 // Index returns the file index of the value address.
 func (a Address) Index() uint32 {
-	return uint32(a >> 32)
+	return a.index
 }
 
 // Offset returns the offset of the value address.
-func (a Address) Offset() uint32 {
-	return uint32(a)
+func (a Address) Offset() uint64 {
+	return a.offset
 }
 
 // String returns a string representation of the address.
@@ -37,9 +52,18 @@ func (a Address) String() string {
 	return fmt.Sprintf("(%d:%d)", a.Index(), a.Offset())
 }
 
-// Serialize converts the address to a byte slice.
+// Serialize converts the address to a byte slice. Addresses with offsets that fit into 32 bits use the original
+// 8 byte format, so that keymaps written before 64-bit offsets were introduced remain readable.
 func (a Address) Serialize() []byte {
-	bytes := make([]byte, 8)
-	binary.BigEndian.PutUint64(bytes, uint64(a))
+	if a.offset <= math.MaxUint32 {
+		bytes := make([]byte, 8)
+		binary.BigEndian.PutUint32(bytes[0:4], a.index)
+		binary.BigEndian.PutUint32(bytes[4:8], uint32(a.offset))
+		return bytes
+	}
+
+	bytes := make([]byte, 12)
+	binary.BigEndian.PutUint32(bytes[0:4], a.index)
+	binary.BigEndian.PutUint64(bytes[4:12], a.offset)
 	return bytes
 }
@@ -7,5 +7,8 @@ type ScopedKey struct {
 	// The location where the value associated with the key is stored.
 	Address Address
 	// The length of the value associated with the key.
-	ValueSize uint32
+	ValueSize uint64
+	// The checksum of the value associated with the key. Always zero for segments written before checksums
+	// were introduced.
+	Checksum uint32
 }
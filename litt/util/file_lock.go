@@ -13,6 +13,10 @@ import (
 	"github.com/Layr-Labs/eigensdk-go/logging"
 )
 
+// LockfileName is the name of the lock file that LittDB (and the LittDB CLI) writes into each root directory it
+// operates on. The presence of this file prevents multiple processes from modifying the same file tree concurrently.
+const LockfileName = "litt.lock"
+
 // FileLock represents a file-based lock
 type FileLock struct {
 	logger logging.Logger
@@ -46,3 +46,36 @@ func VerifyStoreChunksRequest(key gethcommon.Address, request *grpc.StoreChunksR
 	}
 	return requestHash, nil
 }
+
+// SignGetChunksRequest signs the given GetChunksRequest with the given private key. Does not
+// write the signature into the request.
+func SignGetChunksRequest(key *ecdsa.PrivateKey, request *grpc.GetChunksRequest) ([]byte, error) {
+	requestHash, err := hashing.HashValidatorGetChunksRequest(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash request: %w", err)
+	}
+
+	signature, err := crypto.Sign(requestHash, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign request: %w", err)
+	}
+
+	return signature, nil
+}
+
+// RecoverGetChunksRequestSigner returns the address of the key that signed the given GetChunksRequest, along with
+// the hash of the request. Any key is accepted, it is up to the caller to decide what to do with the signer.
+func RecoverGetChunksRequestSigner(request *grpc.GetChunksRequest) (gethcommon.Address, []byte, error) {
+	requestHash, err := hashing.HashValidatorGetChunksRequest(request)
+	if err != nil {
+		return gethcommon.Address{}, nil, fmt.Errorf("failed to hash request: %w", err)
+	}
+
+	signingPublicKey, err := crypto.SigToPub(requestHash, request.GetSignature())
+	if err != nil {
+		return gethcommon.Address{}, nil,
+			fmt.Errorf("failed to recover public key from signature %x: %w", request.GetSignature(), err)
+	}
+
+	return crypto.PubkeyToAddress(*signingPublicKey), requestHash, nil
+}
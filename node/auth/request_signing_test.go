@@ -3,6 +3,7 @@ package auth
 import (
 	"testing"
 
+	grpc "github.com/Layr-Labs/eigenda/api/grpc/validator"
 	"github.com/Layr-Labs/eigenda/api/hashing"
 	"github.com/Layr-Labs/eigenda/common/testutils/random"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -203,3 +204,55 @@ func TestRequestSigning(t *testing.T) {
 	_, err = VerifyStoreChunksRequest(publicAddress, request)
 	require.Error(t, err)
 }
+
+func TestGetChunksRequestSigning(t *testing.T) {
+	rand := random.NewTestRandom()
+
+	public, private, err := rand.ECDSA()
+	require.NoError(t, err)
+	publicAddress := crypto.PubkeyToAddress(*public)
+
+	request := &grpc.GetChunksRequest{
+		BlobKey:  rand.Bytes(32),
+		QuorumId: 1,
+		ChunkRequest: &grpc.GetChunksRequest_ByIndex{
+			ByIndex: &grpc.ChunkRequestByIndex{ChunkIndices: []uint32{1, 2, 3}},
+		},
+		Timestamp: rand.Uint32(),
+	}
+
+	signature, err := SignGetChunksRequest(private, request)
+	require.NoError(t, err)
+	request.Signature = signature
+
+	signer, hash, err := RecoverGetChunksRequestSigner(request)
+	require.NoError(t, err)
+	require.Equal(t, publicAddress, signer)
+	expectedHash, err := hashing.HashValidatorGetChunksRequest(request)
+	require.NoError(t, err)
+	require.Equal(t, expectedHash, hash)
+
+	// Changing the selected chunks should change the hash, and therefore the recovered signer.
+	request.ChunkRequest = &grpc.GetChunksRequest_ByRange{
+		ByRange: &grpc.ChunkRequestByRange{StartIndex: 1, EndIndex: 4},
+	}
+	signer, otherHash, err := RecoverGetChunksRequestSigner(request)
+	require.NoError(t, err)
+	require.NotEqual(t, hash, otherHash)
+	require.NotEqual(t, publicAddress, signer)
+
+	// Changing the timestamp should change the recovered signer.
+	request.ChunkRequest = nil
+	signature, err = SignGetChunksRequest(private, request)
+	require.NoError(t, err)
+	request.Signature = signature
+	request.Timestamp++
+	signer, _, err = RecoverGetChunksRequestSigner(request)
+	require.NoError(t, err)
+	require.NotEqual(t, publicAddress, signer)
+
+	// A malformed signature should be rejected.
+	request.Signature = rand.Bytes(10)
+	_, _, err = RecoverGetChunksRequestSigner(request)
+	require.Error(t, err)
+}
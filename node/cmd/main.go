@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/common/geth"
@@ -123,6 +125,51 @@ func NodeMain(ctx *cli.Context) error {
 		}
 	}
 	err = nodegrpc.RunServers(server, serverV2, config, logger)
+	if err != nil {
+		return err
+	}
+
+	if config.EnableV2 {
+		go handleSignals(node)
+	}
+
+	return nil
+}
 
-	return err
+// handleSignals enters maintenance mode on SIGUSR1 and exits it on SIGUSR2. On SIGINT or SIGTERM, the node enters
+// maintenance mode, waits for in-flight StoreChunks requests to finish, and then exits.
+func handleSignals(n *node.Node) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGUSR1, syscall.SIGUSR2, syscall.SIGINT, syscall.SIGTERM)
+
+	for sig := range signals {
+		switch sig {
+		case syscall.SIGUSR1:
+			go func() {
+				ctx, cancel := context.WithTimeout(context.Background(), n.Config.MaintenanceDrainTimeout)
+				defer cancel()
+				err := n.EnterMaintenance(ctx)
+				if err != nil {
+					n.Logger.Warn("in-flight StoreChunks requests did not finish", "err", err)
+				}
+			}()
+		case syscall.SIGUSR2:
+			n.ExitMaintenance()
+		default:
+			n.Logger.Info("Received shutdown signal", "signal", sig)
+			ctx, cancel := context.WithTimeout(context.Background(), n.Config.MaintenanceDrainTimeout)
+			err := n.EnterMaintenance(ctx)
+			cancel()
+			if err != nil {
+				n.Logger.Warn("in-flight StoreChunks requests did not finish before shutdown", "err", err)
+			}
+			if n.ValidatorStore != nil {
+				err = n.ValidatorStore.Stop()
+				if err != nil {
+					n.Logger.Error("failed to stop validator store", "err", err)
+				}
+			}
+			os.Exit(0)
+		}
+	}
 }
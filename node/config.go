@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/cache"
 	"github.com/Layr-Labs/eigenda/common/geth"
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/encoding/kzg"
@@ -17,6 +18,7 @@ import (
 	blssignerTypes "github.com/Layr-Labs/eigensdk-go/signer/bls/types"
 
 	"github.com/ethereum/go-ethereum/accounts/keystore"
+	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 
 	"github.com/urfave/cli"
@@ -49,6 +51,7 @@ type Config struct {
 	V2RetrievalPort                 string
 	InternalV2DispersalPort         string
 	InternalV2RetrievalPort         string
+	V2OperatorPort                  string
 	EnableNodeApi                   bool
 	NodeApiPort                     string
 	EnableMetrics                   bool
@@ -95,6 +98,7 @@ type Config struct {
 
 	OnchainStateRefreshInterval time.Duration
 	ChunkDownloadTimeout        time.Duration
+	RelayFailureBackoff         time.Duration
 	GRPCMsgSizeLimitV2          int
 
 	PprofHttpPort string
@@ -110,6 +114,33 @@ type Config struct {
 	// The size of the pool where chunks are downloaded from the relay network.
 	DownloadPoolSize int
 
+	// If true, then chunks that can't be downloaded from any relay are recovered from other validators (v2 only).
+	EnableValidatorChunkRecovery bool
+	// The maximum number of blobs in a batch whose chunks are recovered from other validators in parallel.
+	ValidatorChunkRecoveryParallelism int
+
+	// The period between audits of the data of recently signed batches (v2 only). If zero, data is not audited.
+	DataAuditPeriod time.Duration
+	// The number of recently signed batches checked by each audit.
+	DataAuditBatchesPerAudit int
+	// The maximum number of recently signed batches remembered for auditing.
+	DataAuditMaxTrackedBatches int
+
+	// The expected time between blocks, used to convert lengths of time measured in blocks (such as how long data
+	// must be stored) into durations.
+	BlockTime time.Duration
+
+	// The length of time to keep records of StoreChunks requests in the signing history (v2 only). If zero, no
+	// signing history is kept.
+	SigningHistoryRetention time.Duration
+
+	// If true, the node starts in maintenance mode and rejects StoreChunks requests until maintenance mode is
+	// exited (v2 only).
+	StartInMaintenance bool
+	// The maximum time to wait for in-flight StoreChunks requests to finish when entering maintenance mode, and
+	// when shutting down.
+	MaintenanceDrainTimeout time.Duration
+
 	// A special test only setting. If true, then littDB will throw an error if the same data is written twice.
 	LittDBDoubleWriteProtection bool
 
@@ -131,6 +162,9 @@ type Config struct {
 	// this config value overrides the LittDBReadCacheSizeFraction value.
 	LittDBReadCacheSizeGB float64
 
+	// The eviction policy of the littDB read and write caches. If empty, the FIFO eviction policy is used.
+	LittDBCacheEvictionPolicy cache.EvictionPolicy
+
 	// The list of paths to the littDB storage directories. Data is spread across these directories.
 	// Directories do not need to be on the same filesystem.
 	LittDBStoragePaths []string
@@ -151,6 +185,37 @@ type Config struct {
 	// Unit is in megabytes.
 	GetChunksColdBurstLimitMB float64
 
+	// The maximum age of a signed GetChunks request in the past that the node will accept.
+	GetChunksRequestMaxPastAge time.Duration
+
+	// The maximum age of a signed GetChunks request in the future that the node will accept.
+	GetChunksRequestMaxFutureAge time.Duration
+
+	// The rate limit for the number of bytes served by the GetChunks API to a single unsigned requester, keyed by
+	// client IP. Unit is in megabytes per second. If zero, then unsigned requesters are not individually rate limited.
+	GetChunksAnonymousRateLimitMB float64
+
+	// The burst limit for the number of bytes served by the GetChunks API to a single unsigned requester.
+	// Unit is in megabytes.
+	GetChunksAnonymousBurstLimitMB float64
+
+	// The rate limit for the number of bytes served by the GetChunks API to a single authenticated requester, keyed
+	// by signer address. Unit is in megabytes per second. If zero, then authenticated requesters are not
+	// individually rate limited.
+	GetChunksAuthenticatedRateLimitMB float64
+
+	// The burst limit for the number of bytes served by the GetChunks API to a single authenticated requester.
+	// Unit is in megabytes.
+	GetChunksAuthenticatedBurstLimitMB float64
+
+	// The signer addresses whose signed GetChunks requests are authenticated. Anyone can create a signing key, so
+	// signed requests from other addresses are treated like unsigned requests (i.e. keyed by client IP).
+	GetChunksAuthenticatedRequesters []gethcommon.Address
+
+	// The maximum number of GetChunks requesters to track. If zero, then requesters are not tracked, which
+	// disables per-requester rate limits and metrics.
+	GetChunksRequesterCacheSize int
+
 	// Defines a safety buffer for the garbage collector. If non-zero, then the garbage collector will be instructed
 	// to aggressively garbage collect so as to keep this amount of memory free. Useful for preventing kubernetes
 	// from OOM-killing the process.
@@ -330,6 +395,27 @@ func NewConfig(ctx *cli.Context) (*Config, error) {
 		}
 	}
 
+	v2OperatorPort := ctx.GlobalString(flags.V2OperatorPortFlag.Name)
+	if v2OperatorPort != "" {
+		if err := core.ValidatePort(v2OperatorPort); err != nil {
+			return nil, fmt.Errorf("invalid v2 operator port: %s", v2OperatorPort)
+		}
+	}
+
+	blockTime := ctx.GlobalDuration(flags.BlockTimeFlag.Name)
+	if blockTime <= 0 {
+		return nil, fmt.Errorf("the %s flag must be positive, got %v", flags.BlockTimeFlag.Name, blockTime)
+	}
+
+	authenticatedRequesterStrings := ctx.GlobalStringSlice(flags.GetChunksAuthenticatedRequestersFlag.Name)
+	authenticatedRequesters := make([]gethcommon.Address, 0, len(authenticatedRequesterStrings))
+	for _, requester := range authenticatedRequesterStrings {
+		if !gethcommon.IsHexAddress(requester) {
+			return nil, fmt.Errorf("invalid GetChunks authenticated requester address: %s", requester)
+		}
+		authenticatedRequesters = append(authenticatedRequesters, gethcommon.HexToAddress(requester))
+	}
+
 	return &Config{
 		Hostname:                            ctx.GlobalString(flags.HostnameFlag.Name),
 		DispersalPort:                       dispersalPort,
@@ -340,6 +426,7 @@ func NewConfig(ctx *cli.Context) (*Config, error) {
 		V2RetrievalPort:                     v2RetrievalPort,
 		InternalV2DispersalPort:             internalV2DispersalPort,
 		InternalV2RetrievalPort:             internalV2RetrievalPort,
+		V2OperatorPort:                      v2OperatorPort,
 		EnableNodeApi:                       ctx.GlobalBool(flags.EnableNodeApiFlag.Name),
 		NodeApiPort:                         ctx.GlobalString(flags.NodeApiPortFlag.Name),
 		EnableMetrics:                       ctx.GlobalBool(flags.EnableMetricsFlag.Name),
@@ -378,6 +465,16 @@ func NewConfig(ctx *cli.Context) (*Config, error) {
 		EnableV1:                            v1Enabled,
 		OnchainStateRefreshInterval:         ctx.GlobalDuration(flags.OnchainStateRefreshIntervalFlag.Name),
 		ChunkDownloadTimeout:                ctx.GlobalDuration(flags.ChunkDownloadTimeoutFlag.Name),
+		RelayFailureBackoff:                 ctx.GlobalDuration(flags.RelayFailureBackoffFlag.Name),
+		EnableValidatorChunkRecovery:        ctx.GlobalBool(flags.EnableValidatorChunkRecoveryFlag.Name),
+		ValidatorChunkRecoveryParallelism:   ctx.GlobalInt(flags.ValidatorChunkRecoveryParallelismFlag.Name),
+		DataAuditPeriod:                     ctx.GlobalDuration(flags.DataAuditPeriodFlag.Name),
+		DataAuditBatchesPerAudit:            ctx.GlobalInt(flags.DataAuditBatchesPerAuditFlag.Name),
+		DataAuditMaxTrackedBatches:          ctx.GlobalInt(flags.DataAuditMaxTrackedBatchesFlag.Name),
+		BlockTime:                           blockTime,
+		SigningHistoryRetention:             ctx.GlobalDuration(flags.SigningHistoryRetentionFlag.Name),
+		StartInMaintenance:                  ctx.GlobalBool(flags.StartInMaintenanceFlag.Name),
+		MaintenanceDrainTimeout:             ctx.GlobalDuration(flags.MaintenanceDrainTimeoutFlag.Name),
 		GRPCMsgSizeLimitV2:                  ctx.GlobalInt(flags.GRPCMsgSizeLimitV2Flag.Name),
 		PprofHttpPort:                       ctx.GlobalString(flags.PprofHttpPort.Name),
 		EnablePprof:                         ctx.GlobalBool(flags.EnablePprof.Name),
@@ -390,12 +487,21 @@ func NewConfig(ctx *cli.Context) (*Config, error) {
 		LittDBWriteCacheSizeFraction:        ctx.GlobalFloat64(flags.LittDBWriteCacheSizeFractionFlag.Name),
 		LittDBReadCacheSizeGB:               ctx.GlobalFloat64(flags.LittDBReadCacheSizeGBFlag.Name),
 		LittDBReadCacheSizeFraction:         ctx.GlobalFloat64(flags.LittDBReadCacheSizeFractionFlag.Name),
+		LittDBCacheEvictionPolicy:           cache.EvictionPolicy(ctx.GlobalString(flags.LittDBCacheEvictionPolicyFlag.Name)),
 		LittDBStoragePaths:                  ctx.GlobalStringSlice(flags.LittDBStoragePathsFlag.Name),
 		DownloadPoolSize:                    ctx.GlobalInt(flags.DownloadPoolSizeFlag.Name),
 		GetChunksHotCacheReadLimitMB:        ctx.GlobalFloat64(flags.GetChunksHotCacheReadLimitMBFlag.Name),
 		GetChunksHotBurstLimitMB:            ctx.GlobalFloat64(flags.GetChunksHotBurstLimitMBFlag.Name),
 		GetChunksColdCacheReadLimitMB:       ctx.GlobalFloat64(flags.GetChunksColdCacheReadLimitMBFlag.Name),
 		GetChunksColdBurstLimitMB:           ctx.GlobalFloat64(flags.GetChunksColdBurstLimitMBFlag.Name),
+		GetChunksRequestMaxPastAge:          ctx.GlobalDuration(flags.GetChunksRequestMaxPastAgeFlag.Name),
+		GetChunksRequestMaxFutureAge:        ctx.GlobalDuration(flags.GetChunksRequestMaxFutureAgeFlag.Name),
+		GetChunksAnonymousRateLimitMB:       ctx.GlobalFloat64(flags.GetChunksAnonymousRateLimitMBFlag.Name),
+		GetChunksAnonymousBurstLimitMB:      ctx.GlobalFloat64(flags.GetChunksAnonymousBurstLimitMBFlag.Name),
+		GetChunksAuthenticatedRateLimitMB:   ctx.GlobalFloat64(flags.GetChunksAuthenticatedRateLimitMBFlag.Name),
+		GetChunksAuthenticatedBurstLimitMB:  ctx.GlobalFloat64(flags.GetChunksAuthenticatedBurstLimitMBFlag.Name),
+		GetChunksAuthenticatedRequesters:    authenticatedRequesters,
+		GetChunksRequesterCacheSize:         ctx.GlobalInt(flags.GetChunksRequesterCacheSizeFlag.Name),
 		GCSafetyBufferSizeGB:                ctx.GlobalFloat64(flags.GCSafetyBufferSizeGBFlag.Name),
 	}, nil
 }
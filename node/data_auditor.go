@@ -0,0 +1,691 @@
+package node
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	commonpb "github.com/Layr-Labs/eigenda/api/grpc/common/v2"
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/Layr-Labs/eigensdk-go/nodeapi"
+	"github.com/gammazero/workerpool"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// The ID of the data auditor service, as reported by the node API.
+	dataAuditorServiceID = "data-auditor"
+	// The name of the littDB table where the data auditor persists the batches it tracks.
+	dataAuditTableName = "data_audit"
+	// The length of a data audit key: the time the batch was signed, followed by the batch header hash.
+	dataAuditKeyLength = 40
+)
+
+// AuditProblem describes what is wrong with a bundle found by the DataAuditor.
+type AuditProblem string
+
+const (
+	// AuditProblemMissing means that the bundle is not in the ValidatorStore.
+	AuditProblemMissing AuditProblem = "missing"
+	// AuditProblemCorrupt means that the bundle is in the ValidatorStore, but can't be deserialized.
+	AuditProblemCorrupt AuditProblem = "corrupt"
+	// AuditProblemInvalid means that the bundle can be deserialized, but its frames don't match the blob's
+	// commitments.
+	AuditProblemInvalid AuditProblem = "invalid"
+)
+
+// AuditFinding describes a bundle that the DataAuditor found to be missing or damaged.
+type AuditFinding struct {
+	// The hash of the batch header of the batch containing the blob.
+	BatchHeaderHash [32]byte
+	// The key of the blob whose bundle is missing or damaged.
+	BlobKey corev2.BlobKey
+	// What is wrong with the bundle.
+	Problem AuditProblem
+	// True if the bundle was downloaded again and stored.
+	Repaired bool
+	// If the bundle was not repaired, the reason why. Nil if the bundle was repaired. A bundle that was repaired
+	// recently is not repaired again until its backoff expires.
+	RepairError error
+}
+
+// AuditReport describes the outcome of a single audit.
+type AuditReport struct {
+	// The time when the audit started.
+	Time time.Time
+	// The number of batches that were audited.
+	BatchesAudited int
+	// The number of bundles that were audited.
+	BundlesAudited int
+	// The bundles that were found to be missing or damaged.
+	Findings []*AuditFinding
+}
+
+// Unrepaired returns the number of findings that could not be repaired.
+func (r *AuditReport) Unrepaired() int {
+	count := 0
+	for _, finding := range r.Findings {
+		if !finding.Repaired {
+			count++
+		}
+	}
+	return count
+}
+
+// auditedBatch is a batch that this node has signed, and is therefore responsible for storing.
+type auditedBatch struct {
+	batch           *corev2.Batch
+	batchHeaderHash [32]byte
+	signedAt        time.Time
+}
+
+// repairAttempts tracks the repairs of a single bundle, so that repairs of a bundle that keeps getting damaged are
+// backed off.
+type repairAttempts struct {
+	// The number of times the bundle has been repaired.
+	count int
+	// The time of the most recent repair.
+	last time.Time
+	// The earliest time the bundle may be repaired again.
+	next time.Time
+}
+
+// DataAuditor periodically checks that the bundles of recently signed batches are still present and intact in the
+// ValidatorStore. Bundles that are missing, can't be deserialized, or don't match their blob's commitments are
+// downloaded again from the relays. Findings are reported through metrics, the node API, and LatestReport.
+//
+// The auditor keeps a uniform random sample of the batches signed within the audit window (see RecordSignedBatch),
+// and each audit checks a random subset of that sample. The sample is persisted, so that batches signed before a
+// restart are still audited.
+type DataAuditor struct {
+	node   *Node
+	logger logging.Logger
+
+	// The table where tracked batches are persisted. If nil, tracked batches are only kept in memory.
+	table litt.Table
+
+	// The period between audits.
+	period time.Duration
+	// The number of batches checked by each audit.
+	batchesPerAudit int
+	// The maximum number of signed batches that are remembered for auditing.
+	maxTrackedBatches int
+	// The length of time after a batch is signed that the node is required to store its data. Batches older than
+	// this are not audited.
+	window time.Duration
+
+	// The source of time, replaceable for testing.
+	timeSource func() time.Time
+
+	// Protects the fields below.
+	lock sync.Mutex
+	// The batches that may be audited.
+	batches []*auditedBatch
+	// An estimate of the number of signed batches that are still within the audit window, used for reservoir
+	// sampling.
+	recorded int
+	// The report from the most recent audit, or nil if no audit has completed yet.
+	latestReport *AuditReport
+	// Used to sample batches.
+	random *rand.Rand
+	// The repairs of recently repaired bundles, keyed by blob key.
+	repairs map[corev2.BlobKey]*repairAttempts
+}
+
+// NewDataAuditor creates a new DataAuditor. Batches are persisted in the given table, which may be nil, and batches
+// persisted by a previous instance are loaded from it. The auditor does not run until Start is called.
+func NewDataAuditor(
+	node *Node,
+	logger logging.Logger,
+	table litt.Table,
+	period time.Duration,
+	batchesPerAudit int,
+	maxTrackedBatches int,
+	window time.Duration,
+	timeSource func() time.Time) (*DataAuditor, error) {
+
+	if period <= 0 {
+		return nil, fmt.Errorf("audit period must be positive, got %v", period)
+	}
+	if batchesPerAudit <= 0 {
+		return nil, fmt.Errorf("batches per audit must be positive, got %d", batchesPerAudit)
+	}
+	if maxTrackedBatches <= 0 {
+		return nil, fmt.Errorf("max tracked batches must be positive, got %d", maxTrackedBatches)
+	}
+	if window <= 0 {
+		return nil, fmt.Errorf("audit window must be positive, got %v", window)
+	}
+
+	auditor := &DataAuditor{
+		node:              node,
+		logger:            logger.With("component", "DataAuditor"),
+		table:             table,
+		period:            period,
+		batchesPerAudit:   batchesPerAudit,
+		maxTrackedBatches: maxTrackedBatches,
+		window:            window,
+		timeSource:        timeSource,
+		batches:           make([]*auditedBatch, 0),
+		random:            rand.New(rand.NewSource(timeSource().UnixNano())),
+		repairs:           make(map[corev2.BlobKey]*repairAttempts),
+	}
+
+	if table != nil {
+		// Batches older than the window are never audited, so there is no need to keep them any longer.
+		err := table.SetTTL(window)
+		if err != nil {
+			return nil, fmt.Errorf("failed to set TTL for data audit table: %w", err)
+		}
+		err = auditor.loadBatches()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load tracked batches: %w", err)
+		}
+	}
+
+	return auditor, nil
+}
+
+// loadBatches loads the batches persisted in the table that are still within the audit window. Since persisted
+// batches that were replaced in the sample can't be deleted, the table may hold more than maxTrackedBatches batches,
+// in which case a random subset of them is kept.
+func (a *DataAuditor) loadBatches() error {
+	now := a.timeSource()
+	batches := make([]*auditedBatch, 0)
+	err := a.table.Iterate(func(key []byte, value []byte) error {
+		batch, err := decodeAuditedBatch(key, value)
+		if err != nil {
+			return err
+		}
+		if now.Sub(batch.signedAt) < a.window {
+			batches = append(batches, batch)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to iterate data audit table: %w", err)
+	}
+
+	a.random.Shuffle(len(batches), func(i, j int) {
+		batches[i], batches[j] = batches[j], batches[i]
+	})
+	a.recorded = len(batches)
+	if len(batches) > a.maxTrackedBatches {
+		batches = batches[:a.maxTrackedBatches]
+	}
+	a.batches = batches
+
+	a.logger.Info("loaded tracked batches", "count", len(batches))
+	return nil
+}
+
+// encodeAuditedBatch serializes a tracked batch into a key and value for the data audit table.
+func encodeAuditedBatch(batch *auditedBatch) ([]byte, []byte, error) {
+	key := make([]byte, dataAuditKeyLength)
+	binary.BigEndian.PutUint64(key[0:8], uint64(batch.signedAt.UnixNano()))
+	copy(key[8:], batch.batchHeaderHash[:])
+
+	batchProto, err := batch.batch.ToProtobuf()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to convert batch to protobuf: %w", err)
+	}
+	value, err := proto.Marshal(batchProto)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to serialize batch: %w", err)
+	}
+	return key, value, nil
+}
+
+// decodeAuditedBatch deserializes a tracked batch from a key and value in the data audit table.
+func decodeAuditedBatch(key []byte, value []byte) (*auditedBatch, error) {
+	if len(key) != dataAuditKeyLength {
+		return nil, fmt.Errorf("invalid data audit key length %d", len(key))
+	}
+
+	batchProto := &commonpb.Batch{}
+	err := proto.Unmarshal(value, batchProto)
+	if err != nil {
+		return nil, fmt.Errorf("failed to deserialize batch: %w", err)
+	}
+	batch, err := corev2.BatchFromProtobuf(batchProto)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert batch from protobuf: %w", err)
+	}
+
+	entry := &auditedBatch{
+		batch:    batch,
+		signedAt: time.Unix(0, int64(binary.BigEndian.Uint64(key[0:8]))),
+	}
+	copy(entry.batchHeaderHash[:], key[8:])
+	return entry, nil
+}
+
+// Start runs audits periodically until the context is cancelled.
+func (a *DataAuditor) Start(ctx context.Context) {
+	if a.node.NodeApi != nil {
+		a.node.NodeApi.RegisterNewService(
+			dataAuditorServiceID,
+			"Data Auditor",
+			"Checks that the data of recently signed batches is still stored and intact",
+			nodeapi.ServiceStatusUp)
+	}
+
+	ticker := time.NewTicker(a.period)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			a.Audit(ctx)
+		}
+	}
+}
+
+// RecordSignedBatch records that this node has signed the given batch, making it eligible for auditing. Once
+// maxTrackedBatches batches are tracked, each newly signed batch replaces a random tracked batch with a probability
+// chosen so that the tracked batches remain a uniform sample of the batches signed within the window. Batches that
+// are added to the sample are persisted.
+func (a *DataAuditor) RecordSignedBatch(batch *corev2.Batch, batchHeaderHash [32]byte) {
+	entry := &auditedBatch{
+		batch:           batch,
+		batchHeaderHash: batchHeaderHash,
+		signedAt:        a.timeSource(),
+	}
+
+	if !a.addToSample(entry) || a.table == nil {
+		return
+	}
+
+	key, value, err := encodeAuditedBatch(entry)
+	if err == nil {
+		// The table is not flushed here. Unflushed batches are written when the database is flushed or closed, and
+		// losing a few of them in a crash only makes the sample slightly smaller.
+		err = a.table.Put(key, value)
+	}
+	if err != nil {
+		a.logger.Warn("failed to persist tracked batch",
+			"batchHeaderHash", fmt.Sprintf("%x", batchHeaderHash), "err", err)
+	}
+}
+
+// addToSample adds a batch to the sample of tracked batches using reservoir sampling. Returns true if the batch was
+// added.
+func (a *DataAuditor) addToSample(entry *auditedBatch) bool {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	a.recorded++
+	if len(a.batches) < a.maxTrackedBatches {
+		a.batches = append(a.batches, entry)
+		return true
+	}
+
+	index := a.random.Intn(a.recorded)
+	if index < len(a.batches) {
+		a.batches[index] = entry
+		return true
+	}
+	return false
+}
+
+// LatestReport returns the report from the most recent audit, or nil if no audit has completed yet.
+func (a *DataAuditor) LatestReport() *AuditReport {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	return a.latestReport
+}
+
+// sampleBatches discards batches that have left the audit window, and returns a random sample of the remaining ones.
+func (a *DataAuditor) sampleBatches(now time.Time) []*auditedBatch {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	live := make([]*auditedBatch, 0, len(a.batches))
+	for _, batch := range a.batches {
+		if now.Sub(batch.signedAt) < a.window {
+			live = append(live, batch)
+		}
+	}
+	if len(live) < len(a.batches) {
+		// Batches are only ever removed here, so scale down the count of recorded batches to match. This keeps the
+		// replacement probability in RecordSignedBatch in line with the number of batches still in the window.
+		a.recorded = a.recorded * len(live) / len(a.batches)
+		if a.recorded < len(live) {
+			a.recorded = len(live)
+		}
+	}
+	a.batches = live
+
+	for blobKey, attempts := range a.repairs {
+		if now.Sub(attempts.last) >= a.window {
+			delete(a.repairs, blobKey)
+		}
+	}
+
+	a.random.Shuffle(len(live), func(i, j int) {
+		live[i], live[j] = live[j], live[i]
+	})
+	count := a.batchesPerAudit
+	if count > len(live) {
+		count = len(live)
+	}
+	sample := make([]*auditedBatch, count)
+	copy(sample, live[:count])
+	return sample
+}
+
+// Audit checks a random sample of the recently signed batches, and repairs any bundles that are missing or damaged.
+func (a *DataAuditor) Audit(ctx context.Context) *AuditReport {
+	report := &AuditReport{
+		Time:     a.timeSource(),
+		Findings: make([]*AuditFinding, 0),
+	}
+
+	sample := a.sampleBatches(report.Time)
+	for _, batch := range sample {
+		bundlesAudited, findings, err := a.auditBatch(ctx, batch, report.Time)
+		if err != nil {
+			// Don't let one bad batch (e.g. an operator state lookup failure) prevent the other batches from being
+			// audited.
+			a.logger.Warn("failed to audit batch",
+				"batchHeaderHash", fmt.Sprintf("%x", batch.batchHeaderHash), "err", err)
+			continue
+		}
+		report.BatchesAudited++
+		report.BundlesAudited += bundlesAudited
+		report.Findings = append(report.Findings, findings...)
+	}
+
+	for _, finding := range report.Findings {
+		if finding.Repaired {
+			a.logger.Warn("repaired damaged bundle",
+				"batchHeaderHash", fmt.Sprintf("%x", finding.BatchHeaderHash),
+				"blobKey", finding.BlobKey.Hex(),
+				"problem", finding.Problem)
+		} else {
+			a.logger.Error("failed to repair damaged bundle",
+				"batchHeaderHash", fmt.Sprintf("%x", finding.BatchHeaderHash),
+				"blobKey", finding.BlobKey.Hex(),
+				"problem", finding.Problem,
+				"err", finding.RepairError)
+		}
+	}
+
+	a.reportResults(report)
+
+	a.lock.Lock()
+	a.latestReport = report
+	a.lock.Unlock()
+
+	return report
+}
+
+// reportResults publishes the results of an audit to metrics and to the node API. Results are reported as the status
+// of the data auditor service only, leaving the node's overall health to the components that own it.
+func (a *DataAuditor) reportResults(report *AuditReport) {
+	if a.node.Metrics != nil {
+		a.node.Metrics.RecordAudit(report)
+	}
+
+	if a.node.NodeApi != nil {
+		status := nodeapi.ServiceStatusUp
+		if report.Unrepaired() > 0 {
+			status = nodeapi.ServiceStatusDown
+		}
+		err := a.node.NodeApi.UpdateServiceStatus(dataAuditorServiceID, status)
+		if err != nil {
+			a.logger.Warn("failed to update data auditor service status", "err", err)
+		}
+	}
+}
+
+// startRepair records an attempt to repair the bundle of the given blob, and returns false if the bundle was
+// repaired too recently to be repaired again. The delay before a bundle may be repaired again starts at the audit
+// period and doubles with each repair, up to the audit window, so that a bundle that keeps getting damaged (e.g. by a
+// failing disk) doesn't cause a download on every audit.
+func (a *DataAuditor) startRepair(blobKey corev2.BlobKey, now time.Time) bool {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	attempts, ok := a.repairs[blobKey]
+	if !ok {
+		attempts = &repairAttempts{}
+		a.repairs[blobKey] = attempts
+	} else if now.Before(attempts.next) {
+		return false
+	}
+
+	delay := a.period
+	for i := 0; i < attempts.count && delay < a.window; i++ {
+		delay *= 2
+	}
+	if delay > a.window {
+		delay = a.window
+	}
+
+	attempts.count++
+	attempts.last = now
+	attempts.next = now.Add(delay)
+	return true
+}
+
+// auditBatch checks each bundle in the batch that is assigned to this node, and repairs the ones that are missing or
+// damaged. Returns the number of bundles checked.
+func (a *DataAuditor) auditBatch(
+	ctx context.Context,
+	batch *auditedBatch,
+	now time.Time) (int, []*AuditFinding, error) {
+
+	operatorState, err := a.getOperatorState(ctx, batch.batch)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to get operator state: %w", err)
+	}
+
+	blobVersionParams := a.node.BlobVersionParams.Load()
+	if blobVersionParams == nil {
+		return 0, nil, fmt.Errorf("blob version params is nil")
+	}
+
+	// Certificates of blobs whose bundles are missing or damaged, and what's wrong with them.
+	damaged := make([]*corev2.BlobCertificate, 0)
+	findings := make([]*AuditFinding, 0)
+	// Bundles that were read and deserialized successfully, still to be verified against their commitments.
+	readable := make([]*corev2.BlobShard, 0, len(batch.batch.BlobCertificates))
+
+	for _, cert := range batch.batch.BlobCertificates {
+		blobParams, ok := blobVersionParams.Get(cert.BlobHeader.BlobVersion)
+		if !ok {
+			return 0, nil, fmt.Errorf("blob version %d not found", cert.BlobHeader.BlobVersion)
+		}
+		_, err = corev2.GetAssignmentForBlob(operatorState, blobParams, cert.BlobHeader.QuorumNumbers, a.node.Config.ID)
+		if err != nil {
+			// This node stores nothing for blobs it isn't assigned chunks of.
+			continue
+		}
+
+		blobKey, err := cert.BlobHeader.BlobKey()
+		if err != nil {
+			return 0, nil, fmt.Errorf("failed to get blob key: %w", err)
+		}
+		// The current sampling scheme will store the same chunks for all quorums, so we always use quorum 0 as the
+		// quorum key in storage.
+		bundleKey, err := BundleKey(blobKey, core.QuorumID(0))
+		if err != nil {
+			return 0, nil, fmt.Errorf("failed to get bundle key: %w", err)
+		}
+
+		data, exists, err := a.node.ValidatorStore.AuditBundle(bundleKey)
+		if err != nil {
+			return 0, nil, fmt.Errorf("failed to read bundle for blob %s: %w", blobKey.Hex(), err)
+		}
+
+		var problem AuditProblem
+		if !exists {
+			problem = AuditProblemMissing
+		} else {
+			bundle, err := new(core.Bundle).Deserialize(data)
+			if err != nil {
+				problem = AuditProblemCorrupt
+			} else {
+				readable = append(readable, &corev2.BlobShard{BlobCertificate: cert, Bundle: bundle})
+				continue
+			}
+		}
+
+		damaged = append(damaged, cert)
+		findings = append(findings, &AuditFinding{
+			BatchHeaderHash: batch.batchHeaderHash,
+			BlobKey:         blobKey,
+			Problem:         problem,
+		})
+	}
+
+	bundlesAudited := len(damaged) + len(readable)
+
+	invalid, err := a.findInvalidBundles(ctx, readable, blobVersionParams, operatorState)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to verify bundles: %w", err)
+	}
+	for _, shard := range invalid {
+		blobKey, err := shard.BlobHeader.BlobKey()
+		if err != nil {
+			return 0, nil, fmt.Errorf("failed to get blob key: %w", err)
+		}
+		damaged = append(damaged, shard.BlobCertificate)
+		findings = append(findings, &AuditFinding{
+			BatchHeaderHash: batch.batchHeaderHash,
+			BlobKey:         blobKey,
+			Problem:         AuditProblemInvalid,
+		})
+	}
+
+	// Each finding corresponds to the damaged certificate at the same index.
+	toRepair := make([]*corev2.BlobCertificate, 0, len(damaged))
+	repairing := make([]*AuditFinding, 0, len(findings))
+	for i, finding := range findings {
+		if !a.startRepair(finding.BlobKey, now) {
+			finding.RepairError = fmt.Errorf("bundle was repaired recently, repair deferred")
+			continue
+		}
+		toRepair = append(toRepair, damaged[i])
+		repairing = append(repairing, finding)
+	}
+
+	if len(toRepair) > 0 {
+		repairErr := a.repair(ctx, batch.batch.BatchHeader, toRepair, blobVersionParams, operatorState)
+		for _, finding := range repairing {
+			finding.Repaired = repairErr == nil
+			finding.RepairError = repairErr
+		}
+	}
+
+	return bundlesAudited, findings, nil
+}
+
+// findInvalidBundles verifies the frames of each bundle against its blob's commitments, and returns the ones that
+// fail. Bundles are verified together first, and only verified one at a time if that fails, since a damaged bundle
+// is expected to be rare.
+func (a *DataAuditor) findInvalidBundles(
+	ctx context.Context,
+	shards []*corev2.BlobShard,
+	blobVersionParams *corev2.BlobVersionParameterMap,
+	operatorState *core.OperatorState) ([]*corev2.BlobShard, error) {
+
+	if len(shards) == 0 {
+		return nil, nil
+	}
+
+	pool := workerpool.New(a.node.Config.NumBatchValidators)
+	defer pool.StopWait()
+
+	err := a.node.ValidatorV2.ValidateBlobs(ctx, shards, blobVersionParams, pool, operatorState)
+	if err == nil {
+		return nil, nil
+	}
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	invalid := make([]*corev2.BlobShard, 0)
+	for _, shard := range shards {
+		err = a.node.ValidatorV2.ValidateBlobs(
+			ctx, []*corev2.BlobShard{shard}, blobVersionParams, pool, operatorState)
+		if err != nil {
+			invalid = append(invalid, shard)
+		}
+	}
+	return invalid, nil
+}
+
+// repair downloads the bundles for the given blobs again, verifies them, and stores them.
+func (a *DataAuditor) repair(
+	ctx context.Context,
+	batchHeader *corev2.BatchHeader,
+	certs []*corev2.BlobCertificate,
+	blobVersionParams *corev2.BlobVersionParameterMap,
+	operatorState *core.OperatorState) error {
+
+	repairBatch := &corev2.Batch{
+		BatchHeader:      batchHeader,
+		BlobCertificates: certs,
+	}
+
+	blobShards, rawBundles, err := a.node.DownloadBundles(ctx, repairBatch, operatorState, nil)
+	if err != nil {
+		return fmt.Errorf("failed to download bundles: %w", err)
+	}
+
+	pool := workerpool.New(a.node.Config.NumBatchValidators)
+	defer pool.StopWait()
+	err = a.node.ValidatorV2.ValidateBlobs(ctx, blobShards, blobVersionParams, pool, operatorState)
+	if err != nil {
+		return fmt.Errorf("downloaded bundles are invalid: %w", err)
+	}
+
+	bundles := make([]*BundleToStore, 0, len(rawBundles))
+	for _, rawBundle := range rawBundles {
+		blobKey, err := rawBundle.BlobCertificate.BlobHeader.BlobKey()
+		if err != nil {
+			return fmt.Errorf("failed to get blob key: %w", err)
+		}
+		bundleKey, err := BundleKey(blobKey, core.QuorumID(0))
+		if err != nil {
+			return fmt.Errorf("failed to get bundle key: %w", err)
+		}
+		bundles = append(bundles, &BundleToStore{
+			BundleKey:    bundleKey,
+			BundleBytes:  rawBundle.Bundle,
+			ChunkIndices: rawBundle.ChunkIndices,
+		})
+	}
+
+	err = a.node.ValidatorStore.RepairBundles(bundles)
+	if err != nil {
+		return fmt.Errorf("failed to store repaired bundles: %w", err)
+	}
+	return nil
+}
+
+// getOperatorState fetches the operator state at the batch's reference block, for the quorums used by the batch.
+func (a *DataAuditor) getOperatorState(ctx context.Context, batch *corev2.Batch) (*core.OperatorState, error) {
+	quorums := make(map[core.QuorumID]struct{})
+	for _, cert := range batch.BlobCertificates {
+		for _, quorum := range cert.BlobHeader.QuorumNumbers {
+			quorums[quorum] = struct{}{}
+		}
+	}
+	quorumList := make([]core.QuorumID, 0, len(quorums))
+	for quorum := range quorums {
+		quorumList = append(quorumList, quorum)
+	}
+
+	return a.node.ChainState.GetOperatorState(ctx, uint(batch.BatchHeader.ReferenceBlockNumber), quorumList)
+}
@@ -0,0 +1,204 @@
+package node_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	coremockv2 "github.com/Layr-Labs/eigenda/core/mock/v2"
+	v2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/memtable"
+	"github.com/Layr-Labs/eigenda/node"
+	nodemock "github.com/Layr-Labs/eigenda/node/mock"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataAuditorRepairsDamagedBundles(t *testing.T) {
+	c := newComponents(t, op0)
+	c.node.RelayClient.Store(c.relayClient)
+	store := nodemock.NewMockStoreV2()
+	c.node.ValidatorStore = store
+	validator := coremockv2.NewMockShardValidator()
+	validator.On("ValidateBlobs").Return(nil)
+	c.node.ValidatorV2 = validator
+	ctx := context.Background()
+
+	blobKeys, batch, bundles := nodemock.MockBatch(t)
+	batchHeaderHash, err := batch.BatchHeader.Hash()
+	require.NoError(t, err)
+
+	bundleKeys := make([][]byte, len(blobKeys))
+	for i, blobKey := range blobKeys {
+		bundleKeys[i], err = node.BundleKey(blobKey, core.QuorumID(0))
+		require.NoError(t, err)
+	}
+	bundle00Bytes, err := bundles[0][0].Serialize()
+	require.NoError(t, err)
+	bundle10Bytes, err := bundles[1][0].Serialize()
+	require.NoError(t, err)
+	bundle20Bytes, err := bundles[2][0].Serialize()
+	require.NoError(t, err)
+
+	// The first bundle is intact, the second is missing, and the third can't be deserialized.
+	store.On("AuditBundle", bundleKeys[0]).Return(bundle00Bytes, true, nil)
+	store.On("AuditBundle", bundleKeys[1]).Return(nil, false, nil)
+	store.On("AuditBundle", bundleKeys[2]).Return([]byte{1, 2, 3}, true, nil)
+
+	c.relayClient.On("GetChunksByIndex", mock.Anything, v2.RelayKey(0), mock.Anything).
+		Return([][]byte{bundle20Bytes}, nil)
+	c.relayClient.On("GetChunksByIndex", mock.Anything, v2.RelayKey(1), mock.Anything).
+		Return([][]byte{bundle10Bytes}, nil)
+
+	var repaired []*node.BundleToStore
+	store.On("RepairBundles", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		repaired = args.Get(0).([]*node.BundleToStore)
+	})
+
+	now := time.Now()
+	auditor, err := node.NewDataAuditor(c.node, c.node.Logger, nil, time.Minute, 10, 10, time.Hour,
+		func() time.Time { return now })
+	require.NoError(t, err)
+	auditor.RecordSignedBatch(batch, batchHeaderHash)
+
+	report := auditor.Audit(ctx)
+	require.Equal(t, 1, report.BatchesAudited)
+	require.Equal(t, 3, report.BundlesAudited)
+	require.Len(t, report.Findings, 2)
+	require.Equal(t, blobKeys[1], report.Findings[0].BlobKey)
+	require.Equal(t, node.AuditProblemMissing, report.Findings[0].Problem)
+	require.Equal(t, blobKeys[2], report.Findings[1].BlobKey)
+	require.Equal(t, node.AuditProblemCorrupt, report.Findings[1].Problem)
+	for _, finding := range report.Findings {
+		require.Equal(t, batchHeaderHash, finding.BatchHeaderHash)
+		require.True(t, finding.Repaired)
+		require.NoError(t, finding.RepairError)
+	}
+	require.Equal(t, 0, report.Unrepaired())
+	require.Equal(t, report, auditor.LatestReport())
+
+	require.Len(t, repaired, 2)
+	require.Equal(t, bundleKeys[1], repaired[0].BundleKey)
+	require.Equal(t, bundle10Bytes, repaired[0].BundleBytes)
+	require.Equal(t, bundleKeys[2], repaired[1].BundleKey)
+	require.Equal(t, bundle20Bytes, repaired[1].BundleBytes)
+
+	// Bundles that were just repaired are not repaired again until their backoff expires.
+	repaired = nil
+	report = auditor.Audit(ctx)
+	require.Len(t, report.Findings, 2)
+	for _, finding := range report.Findings {
+		require.False(t, finding.Repaired)
+		require.Error(t, finding.RepairError)
+	}
+	require.Nil(t, repaired)
+
+	// Once the backoff expires, bundles that are damaged again are repaired again.
+	now = now.Add(time.Minute)
+	report = auditor.Audit(ctx)
+	require.Len(t, report.Findings, 2)
+	require.Equal(t, 0, report.Unrepaired())
+	require.Len(t, repaired, 2)
+
+	// The backoff doubles with each repair.
+	repaired = nil
+	now = now.Add(time.Minute)
+	report = auditor.Audit(ctx)
+	require.Equal(t, 2, report.Unrepaired())
+	require.Nil(t, repaired)
+	now = now.Add(time.Minute)
+	report = auditor.Audit(ctx)
+	require.Equal(t, 0, report.Unrepaired())
+	require.Len(t, repaired, 2)
+
+	// Once the batch leaves the audit window, it is no longer audited.
+	now = now.Add(2 * time.Hour)
+	report = auditor.Audit(ctx)
+	require.Equal(t, 0, report.BatchesAudited)
+	require.Empty(t, report.Findings)
+}
+
+func TestDataAuditorRepairFailure(t *testing.T) {
+	c := newComponents(t, op0)
+	c.node.RelayClient.Store(c.relayClient)
+	store := nodemock.NewMockStoreV2()
+	c.node.ValidatorStore = store
+	validator := coremockv2.NewMockShardValidator()
+	validator.On("ValidateBlobs").Return(nil)
+	c.node.ValidatorV2 = validator
+	ctx := context.Background()
+
+	blobKeys, batch, _ := nodemock.MockBatch(t)
+	batchHeaderHash, err := batch.BatchHeader.Hash()
+	require.NoError(t, err)
+
+	// Every bundle is missing, and no relay can serve them.
+	store.On("AuditBundle", mock.Anything).Return(nil, false, nil)
+	c.relayClient.On("GetChunksByIndex", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, errors.New("relay unavailable"))
+
+	auditor, err := node.NewDataAuditor(c.node, c.node.Logger, nil, time.Minute, 10, 10, time.Hour, time.Now)
+	require.NoError(t, err)
+	auditor.RecordSignedBatch(batch, batchHeaderHash)
+
+	report := auditor.Audit(ctx)
+	require.Equal(t, 1, report.BatchesAudited)
+	require.Len(t, report.Findings, len(blobKeys))
+	for _, finding := range report.Findings {
+		require.Equal(t, node.AuditProblemMissing, finding.Problem)
+		require.False(t, finding.Repaired)
+		require.Error(t, finding.RepairError)
+	}
+	require.Equal(t, len(blobKeys), report.Unrepaired())
+	store.AssertNotCalled(t, "RepairBundles", mock.Anything)
+}
+
+func TestDataAuditorPersistsTrackedBatches(t *testing.T) {
+	c := newComponents(t, op0)
+	store := nodemock.NewMockStoreV2()
+	c.node.ValidatorStore = store
+	validator := coremockv2.NewMockShardValidator()
+	validator.On("ValidateBlobs").Return(nil)
+	c.node.ValidatorV2 = validator
+	ctx := context.Background()
+
+	blobKeys, batch, bundles := nodemock.MockBatch(t)
+	batchHeaderHash, err := batch.BatchHeader.Hash()
+	require.NoError(t, err)
+
+	// Every bundle is intact.
+	for i, blobKey := range blobKeys {
+		bundleKey, err := node.BundleKey(blobKey, core.QuorumID(0))
+		require.NoError(t, err)
+		bundleBytes, err := bundles[i][0].Serialize()
+		require.NoError(t, err)
+		store.On("AuditBundle", bundleKey).Return(bundleBytes, true, nil)
+	}
+
+	table := memtable.NewMemTable(litt.DefaultConfigNoPaths(), "data_audit")
+	now := time.Now()
+	timeSource := func() time.Time { return now }
+
+	auditor, err := node.NewDataAuditor(c.node, c.node.Logger, table, time.Minute, 10, 10, time.Hour, timeSource)
+	require.NoError(t, err)
+	auditor.RecordSignedBatch(batch, batchHeaderHash)
+
+	// A new auditor, e.g. after a restart, audits the batches tracked by the previous one.
+	now = now.Add(time.Minute)
+	auditor, err = node.NewDataAuditor(c.node, c.node.Logger, table, time.Minute, 10, 10, time.Hour, timeSource)
+	require.NoError(t, err)
+	report := auditor.Audit(ctx)
+	require.Equal(t, 1, report.BatchesAudited)
+	require.Equal(t, len(blobKeys), report.BundlesAudited)
+	require.Empty(t, report.Findings)
+
+	// Batches that left the audit window while the auditor was not running are not loaded.
+	now = now.Add(2 * time.Hour)
+	auditor, err = node.NewDataAuditor(c.node, c.node.Logger, table, time.Minute, 10, 10, time.Hour, timeSource)
+	require.NoError(t, err)
+	report = auditor.Audit(ctx)
+	require.Equal(t, 0, report.BatchesAudited)
+}
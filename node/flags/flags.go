@@ -79,6 +79,12 @@ var (
 		Required: false,
 		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "INTERNAL_V2_RETRIEVAL_PORT"),
 	}
+	V2OperatorPortFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "v2-operator-port"),
+		Usage:    "Port at which node listens for v2 operator calls, such as GetSigningHistory. This port should only be reachable by the node's operator. If not set, the operator service is disabled",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "V2_OPERATOR_PORT"),
+	}
 	EnableNodeApiFlag = cli.BoolFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "enable-node-api"),
 		Usage:    "enable node-api to serve eigenlayer-cli node-api calls",
@@ -270,6 +276,74 @@ var (
 		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "CHUNK_DOWNLOAD_TIMEOUT"),
 		Value:    20 * time.Second,
 	}
+	RelayFailureBackoffFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "relay-failure-backoff"),
+		Usage:    "After a chunk download from a relay fails, the relay is avoided for this long unless no other relay can serve the chunks (default: 1m)",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "RELAY_FAILURE_BACKOFF"),
+		Value:    1 * time.Minute,
+	}
+	EnableValidatorChunkRecoveryFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "enable-validator-chunk-recovery"),
+		Usage:    "If set, chunks that can't be downloaded from any relay are recovered from other validators. This flag is only relevant in v2 (default: false)",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "ENABLE_VALIDATOR_CHUNK_RECOVERY"),
+	}
+	ValidatorChunkRecoveryParallelismFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "validator-chunk-recovery-parallelism"),
+		Usage:    "The maximum number of blobs in a batch whose chunks are recovered from other validators in parallel. This flag is only relevant in v2 (default: 4)",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "VALIDATOR_CHUNK_RECOVERY_PARALLELISM"),
+		Value:    4,
+	}
+	DataAuditPeriodFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "data-audit-period"),
+		Usage:    "The period between audits that check that the data of recently signed batches is still stored and intact, and repair it if not. If 0, data is not audited. This flag is only relevant in v2 (default: 10m)",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "DATA_AUDIT_PERIOD"),
+		Value:    10 * time.Minute,
+	}
+	DataAuditBatchesPerAuditFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "data-audit-batches-per-audit"),
+		Usage:    "The number of recently signed batches checked by each data audit. This flag is only relevant in v2 (default: 4)",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "DATA_AUDIT_BATCHES_PER_AUDIT"),
+		Value:    4,
+	}
+	DataAuditMaxTrackedBatchesFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "data-audit-max-tracked-batches"),
+		Usage:    "The maximum number of recently signed batches remembered for data audits. This flag is only relevant in v2 (default: 1024)",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "DATA_AUDIT_MAX_TRACKED_BATCHES"),
+		Value:    1024,
+	}
+	BlockTimeFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "block-time"),
+		Usage:    "The expected time between blocks, used to convert lengths of time measured in blocks (such as how long data must be stored) into durations (default: 12s)",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "BLOCK_TIME"),
+		Value:    12 * time.Second,
+	}
+	SigningHistoryRetentionFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "signing-history-retention"),
+		Usage:    "The length of time to keep records of StoreChunks requests, which can be queried with the GetSigningHistory RPC on the v2 operator port. If 0, no signing history is kept. This flag is only relevant in v2 (default: 336h)",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "SIGNING_HISTORY_RETENTION"),
+		Value:    14 * 24 * time.Hour,
+	}
+	StartInMaintenanceFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "start-in-maintenance"),
+		Usage:    "Start the node in maintenance mode, in which it rejects new StoreChunks requests but keeps serving GetChunks requests. Send SIGUSR2 to exit maintenance mode, and SIGUSR1 to enter it again. This flag is only relevant in v2",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "START_IN_MAINTENANCE"),
+	}
+	MaintenanceDrainTimeoutFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "maintenance-drain-timeout"),
+		Usage:    "The maximum time to wait for in-flight StoreChunks requests to finish when entering maintenance mode or shutting down. This flag is only relevant in v2 (default: 2m)",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "MAINTENANCE_DRAIN_TIMEOUT"),
+		Value:    2 * time.Minute,
+	}
 	GRPCMsgSizeLimitV2Flag = cli.IntFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "grpc-msg-size-limit-v2"),
 		Usage:    "The maximum message size in bytes the V2 dispersal endpoint can receive from the client. This flag is only relevant in v2 (default: 1MB)",
@@ -439,6 +513,13 @@ var (
 		Value:    0.05,
 		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "LITT_DB_READ_CACHE_SIZE_FRACTION"),
 	}
+	LittDBCacheEvictionPolicyFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "litt-db-cache-eviction-policy"),
+		Usage:    "The eviction policy of the LittDB read and write caches, one of 'fifo', 'lru', or 's3fifo'.",
+		Required: false,
+		Value:    "fifo",
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "LITT_DB_CACHE_EVICTION_POLICY"),
+	}
 	LittDBStoragePathsFlag = cli.StringSliceFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "litt-db-storage-paths"),
 		Usage:    "Comma separated list of paths to store the LittDB data files. If not provided, falls back to NODE_DB_PATH with '/chunk_v2_litt' suffix.",
@@ -480,6 +561,67 @@ var (
 		Value:    32,
 		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "GET_CHUNKS_COLD_BURST_LIMIT_MB"),
 	}
+	GetChunksRequestMaxPastAgeFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "get-chunks-request-max-past-age"),
+		Usage:    "The maximum age of a signed GetChunks request in the past that the node will accept.",
+		Required: false,
+		Value:    5 * time.Minute,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "GET_CHUNKS_REQUEST_MAX_PAST_AGE"),
+	}
+	GetChunksRequestMaxFutureAgeFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "get-chunks-request-max-future-age"),
+		Usage:    "The maximum age of a signed GetChunks request in the future that the node will accept.",
+		Required: false,
+		Value:    5 * time.Minute,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "GET_CHUNKS_REQUEST_MAX_FUTURE_AGE"),
+	}
+	GetChunksAnonymousRateLimitMBFlag = cli.Float64Flag{
+		Name: common.PrefixFlag(FlagPrefix, "get-chunks-anonymous-rate-limit-mb"),
+		Usage: "The rate limit for GetChunks() calls from a single unsigned requester (keyed by client IP), " +
+			"unit is MB/s. If 0, unsigned requesters are not individually rate limited.",
+		Required: false,
+		Value:    8,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "GET_CHUNKS_ANONYMOUS_RATE_LIMIT_MB"),
+	}
+	GetChunksAnonymousBurstLimitMBFlag = cli.Float64Flag{
+		Name: common.PrefixFlag(FlagPrefix, "get-chunks-anonymous-burst-limit-mb"),
+		Usage: "The burst limit for GetChunks() calls from a single unsigned requester, unit is MB. " +
+			"Should be at least as large as the largest bundle served by the node.",
+		Required: false,
+		Value:    32,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "GET_CHUNKS_ANONYMOUS_BURST_LIMIT_MB"),
+	}
+	GetChunksAuthenticatedRateLimitMBFlag = cli.Float64Flag{
+		Name: common.PrefixFlag(FlagPrefix, "get-chunks-authenticated-rate-limit-mb"),
+		Usage: "The rate limit for GetChunks() calls from a single authenticated requester (keyed by signer " +
+			"address), unit is MB/s. If 0, authenticated requesters are not individually rate limited.",
+		Required: false,
+		Value:    64,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "GET_CHUNKS_AUTHENTICATED_RATE_LIMIT_MB"),
+	}
+	GetChunksAuthenticatedBurstLimitMBFlag = cli.Float64Flag{
+		Name: common.PrefixFlag(FlagPrefix, "get-chunks-authenticated-burst-limit-mb"),
+		Usage: "The burst limit for GetChunks() calls from a single authenticated requester, unit is MB. " +
+			"Should be at least as large as the largest bundle served by the node.",
+		Required: false,
+		Value:    128,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "GET_CHUNKS_AUTHENTICATED_BURST_LIMIT_MB"),
+	}
+	GetChunksAuthenticatedRequestersFlag = cli.StringSliceFlag{
+		Name: common.PrefixFlag(FlagPrefix, "get-chunks-authenticated-requesters"),
+		Usage: "The signer addresses whose signed GetChunks() calls are authenticated. Signed calls from other " +
+			"addresses are rate limited like unsigned calls, keyed by client IP.",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "GET_CHUNKS_AUTHENTICATED_REQUESTERS"),
+	}
+	GetChunksRequesterCacheSizeFlag = cli.IntFlag{
+		Name: common.PrefixFlag(FlagPrefix, "get-chunks-requester-cache-size"),
+		Usage: "The maximum number of GetChunks() requesters to track for rate limiting and metrics. " +
+			"If 0, per-requester rate limits and metrics are disabled.",
+		Required: false,
+		Value:    1024,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "GET_CHUNKS_REQUESTER_CACHE_SIZE"),
+	}
 	GCSafetyBufferSizeGBFlag = cli.IntFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "gc-safety-buffer-size-gb"),
 		Usage:    "The size of the safety buffer for garbage collection in gigabytes.",
@@ -580,6 +722,7 @@ var optionalFlags = []cli.Flag{
 	InternalRetrievalPortFlag,
 	InternalV2DispersalPortFlag,
 	InternalV2RetrievalPortFlag,
+	V2OperatorPortFlag,
 	ClientIPHeaderFlag,
 	ChurnerUseSecureGRPC,
 	EcdsaKeyFileFlag,
@@ -596,6 +739,16 @@ var optionalFlags = []cli.Flag{
 	V2RetrievalPortFlag,
 	OnchainStateRefreshIntervalFlag,
 	ChunkDownloadTimeoutFlag,
+	RelayFailureBackoffFlag,
+	EnableValidatorChunkRecoveryFlag,
+	ValidatorChunkRecoveryParallelismFlag,
+	DataAuditPeriodFlag,
+	DataAuditBatchesPerAuditFlag,
+	DataAuditMaxTrackedBatchesFlag,
+	BlockTimeFlag,
+	SigningHistoryRetentionFlag,
+	StartInMaintenanceFlag,
+	MaintenanceDrainTimeoutFlag,
 	GRPCMsgSizeLimitV2Flag,
 	PprofHttpPort,
 	EnablePprof,
@@ -614,11 +767,20 @@ var optionalFlags = []cli.Flag{
 	LittDBReadCacheSizeGBFlag,
 	LittDBWriteCacheSizeFractionFlag,
 	LittDBReadCacheSizeFractionFlag,
+	LittDBCacheEvictionPolicyFlag,
 	LittDBStoragePathsFlag,
 	GetChunksHotCacheReadLimitMBFlag,
 	GetChunksHotBurstLimitMBFlag,
 	GetChunksColdCacheReadLimitMBFlag,
 	GetChunksColdBurstLimitMBFlag,
+	GetChunksRequestMaxPastAgeFlag,
+	GetChunksRequestMaxFutureAgeFlag,
+	GetChunksAnonymousRateLimitMBFlag,
+	GetChunksAnonymousBurstLimitMBFlag,
+	GetChunksAuthenticatedRateLimitMBFlag,
+	GetChunksAuthenticatedBurstLimitMBFlag,
+	GetChunksAuthenticatedRequestersFlag,
+	GetChunksRequesterCacheSizeFlag,
 	GCSafetyBufferSizeGBFlag,
 	EigenDADirectoryFlag,
 	BlsOperatorStateRetrieverFlag,
@@ -1,6 +1,7 @@
 package grpc
 
 import (
+	"strconv"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/common"
@@ -25,6 +26,9 @@ type MetricsV2 struct {
 	getChunksLatency  *prometheus.SummaryVec
 	getChunksDataSize *prometheus.GaugeVec
 
+	getChunksRequesterBytes       *prometheus.CounterVec
+	getChunksRequesterRateLimited *prometheus.CounterVec
+
 	storeChunksStageTimer *common.StageTimer
 }
 
@@ -70,6 +74,25 @@ func NewV2Metrics(logger logging.Logger, registry *prometheus.Registry) (*Metric
 		[]string{},
 	)
 
+	getChunksRequesterBytes := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "get_chunks_requester_bytes",
+			Help:      "The number of bytes served by GetChunks() RPC calls, per authenticated requester.",
+		},
+		[]string{"requester", "authenticated"},
+	)
+
+	getChunksRequesterRateLimited := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "get_chunks_requester_rate_limited_count",
+			Help: "The number of GetChunks() RPC calls rejected by the per-requester rate limiter, " +
+				"per authenticated requester.",
+		},
+		[]string{"requester", "authenticated"},
+	)
+
 	storeChunksStageTimer := common.NewStageTimer(registry, namespace, "store_chunks", false)
 
 	return &MetricsV2{
@@ -79,7 +102,10 @@ func NewV2Metrics(logger logging.Logger, registry *prometheus.Registry) (*Metric
 		storeChunksRequestSize: storeChunksRequestSize,
 		getChunksLatency:       getChunksLatency,
 		getChunksDataSize:      getChunksDataSize,
-		storeChunksStageTimer:  storeChunksStageTimer,
+
+		getChunksRequesterBytes:       getChunksRequesterBytes,
+		getChunksRequesterRateLimited: getChunksRequesterRateLimited,
+		storeChunksStageTimer:         storeChunksStageTimer,
 	}, nil
 }
 
@@ -104,3 +130,37 @@ func (m *MetricsV2) ReportGetChunksLatency(latency time.Duration) {
 func (m *MetricsV2) ReportGetChunksDataSize(size int) {
 	m.getChunksDataSize.WithLabelValues().Set(float64(size))
 }
+
+func (m *MetricsV2) ReportGetChunksRequesterBytes(requester string, authenticated bool, size int) {
+	m.getChunksRequesterBytes.WithLabelValues(
+		requesterLabel(requester, authenticated), strconv.FormatBool(authenticated)).Add(float64(size))
+}
+
+func (m *MetricsV2) ReportGetChunksRequesterRateLimited(requester string, authenticated bool) {
+	m.getChunksRequesterRateLimited.WithLabelValues(
+		requesterLabel(requester, authenticated), strconv.FormatBool(authenticated)).Inc()
+}
+
+// RemoveGetChunksRequester removes the per-requester metrics for the given requester. Called when the requester
+// is no longer being tracked, so that the number of label values stays bounded. Anonymous requesters share a
+// single label, which is never removed.
+func (m *MetricsV2) RemoveGetChunksRequester(requester string, authenticated bool) {
+	if !authenticated {
+		return
+	}
+	m.getChunksRequesterBytes.DeleteLabelValues(requester, strconv.FormatBool(authenticated))
+	m.getChunksRequesterRateLimited.DeleteLabelValues(requester, strconv.FormatBool(authenticated))
+}
+
+// anonymousRequesterLabel is the requester label shared by all anonymous GetChunks requesters.
+const anonymousRequesterLabel = "anonymous"
+
+// requesterLabel returns the requester label for a GetChunks requester. Only authenticated requesters (i.e. the
+// configured allowlist) get their own label. Anonymous requesters are identified by IP address, which an attacker
+// can vary freely, so they share a single label.
+func requesterLabel(requester string, authenticated bool) string {
+	if authenticated {
+		return requester
+	}
+	return anonymousRequesterLabel
+}
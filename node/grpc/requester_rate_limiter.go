@@ -0,0 +1,113 @@
+package grpc
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"golang.org/x/time/rate"
+)
+
+// requesterQuota describes the token bucket given to each GetChunks requester of a particular kind.
+type requesterQuota struct {
+	// The rate at which bytes may be served to a single requester, in bytes per second. If zero, then requesters
+	// of this kind are not rate limited.
+	bytesPerSecond float64
+	// The maximum number of bytes that may be served to a single requester in a burst.
+	burstBytes int
+}
+
+// requesterKey identifies a GetChunks requester. Signed requests are keyed by the address of the signer, unsigned
+// requests are keyed by the client's IP address.
+type requesterKey struct {
+	id            string
+	authenticated bool
+}
+
+// requesterRateLimiter keeps a token bucket for each recent GetChunks requester, so that a single requester can't
+// starve everyone else of the node's read bandwidth. Buckets for requesters that haven't been seen recently are
+// evicted, which resets their quota.
+type requesterRateLimiter struct {
+	anonymousQuota     requesterQuota
+	authenticatedQuota requesterQuota
+
+	// A cache of token buckets, keyed by requester.
+	limiters *lru.Cache[requesterKey, *rate.Limiter]
+
+	// Protects the check-then-create sequence in getLimiter.
+	lock sync.Mutex
+
+	// The source of time, replaceable for testing.
+	now func() time.Time
+}
+
+// newRequesterRateLimiter creates a new requesterRateLimiter. Token buckets are kept for at most cacheSize requesters.
+// onEvict, if not nil, is called whenever a requester's token bucket is evicted from the cache.
+func newRequesterRateLimiter(
+	anonymousQuota requesterQuota,
+	authenticatedQuota requesterQuota,
+	cacheSize int,
+	onEvict func(requester string, authenticated bool),
+	now func() time.Time) (*requesterRateLimiter, error) {
+
+	limiters, err := lru.NewWithEvict(cacheSize, func(key requesterKey, _ *rate.Limiter) {
+		if onEvict != nil {
+			onEvict(key.id, key.authenticated)
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create requester cache: %w", err)
+	}
+
+	return &requesterRateLimiter{
+		anonymousQuota:     anonymousQuota,
+		authenticatedQuota: authenticatedQuota,
+		limiters:           limiters,
+		now:                now,
+	}, nil
+}
+
+// getLimiter returns the token bucket for the given requester, or nil if requesters of this kind are not rate limited.
+// Requesters are tracked even if they are not rate limited, so that evictions can be used to bound the set of
+// requesters that are reported in metrics.
+func (l *requesterRateLimiter) getLimiter(requester string, authenticated bool) *rate.Limiter {
+	key := requesterKey{id: requester, authenticated: authenticated}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	limiter, ok := l.limiters.Get(key)
+	if !ok {
+		quota := l.anonymousQuota
+		if authenticated {
+			quota = l.authenticatedQuota
+		}
+		if quota.bytesPerSecond > 0 {
+			limiter = rate.NewLimiter(rate.Limit(quota.bytesPerSecond), quota.burstBytes)
+		}
+		l.limiters.Add(key, limiter)
+	}
+	return limiter
+}
+
+// Allow reports whether the requester currently has quota remaining. Since the size of a GetChunks response is not
+// known until the data has been read, a requester is allowed to proceed as long as its bucket is not empty, and the
+// bytes actually served are debited afterward with Debit.
+func (l *requesterRateLimiter) Allow(requester string, authenticated bool) bool {
+	limiter := l.getLimiter(requester, authenticated)
+	if limiter == nil {
+		return true
+	}
+	return limiter.TokensAt(l.now()) > 0
+}
+
+// Debit removes the given number of bytes from the requester's bucket. This may cause the bucket to go negative,
+// in which case the requester is throttled until the deficit has been paid back.
+func (l *requesterRateLimiter) Debit(requester string, authenticated bool, size int) {
+	limiter := l.getLimiter(requester, authenticated)
+	if limiter == nil {
+		return
+	}
+	limiter.ReserveN(l.now(), size)
+}
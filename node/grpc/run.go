@@ -149,5 +149,35 @@ func RunServers(serverV1 *Server, serverV2 *ServerV2, config *node.Config, logge
 		}
 	}()
 
+	// v2 Operator service. It is served on its own port so that operator-only RPCs aren't reachable through the
+	// public dispersal and retrieval ports.
+	go func() {
+		if !config.EnableV2 || config.V2OperatorPort == "" {
+			logger.Info("v2 operator port is not configured, skipping v2 operator server startup")
+			return
+		}
+		for {
+			addr := fmt.Sprintf("%s:%s", localhost, config.V2OperatorPort)
+			listener, err := net.Listen("tcp", addr)
+			if err != nil {
+				logger.Fatalf("Could not start tcp listener: %v", err)
+			}
+			gs := grpc.NewServer(serverV2.metrics.GetGRPCServerOption())
+
+			// Register reflection service on gRPC server
+			// This makes "grpcurl -plaintext localhost:9000 list" command work
+			reflection.Register(gs)
+
+			validator.RegisterOperatorServer(gs, serverV2)
+
+			healthcheck.RegisterHealthServer("node.v2.Operator", gs)
+
+			logger.Info("v2 operator enabled on port", config.V2OperatorPort, "address", listener.Addr().String(), "GRPC Listening")
+			if err := gs.Serve(listener); err != nil {
+				logger.Error("operator v2 server failed; restarting.", "err", err)
+			}
+		}
+	}()
+
 	return nil
 }
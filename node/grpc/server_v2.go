@@ -20,14 +20,19 @@ import (
 	"github.com/Layr-Labs/eigenda/node"
 	"github.com/Layr-Labs/eigenda/node/auth"
 	"github.com/Layr-Labs/eigensdk-go/logging"
+	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/shirou/gopsutil/mem"
 )
 
+// The maximum number of records returned by a single GetSigningHistory call.
+const maxSigningHistoryRecords uint32 = 1000
+
 // ServerV2 implements the Node v2 proto APIs.
 type ServerV2 struct {
 	pb.UnimplementedDispersalServer
 	pb.UnimplementedRetrievalServer
+	pb.UnimplementedOperatorServer
 
 	config             *node.Config
 	node               *node.Node
@@ -37,6 +42,13 @@ type ServerV2 struct {
 	chunkAuthenticator auth.RequestAuthenticator
 	blobAuthenticator  corev2.BlobRequestAuthenticator
 	replayGuardian     replay.ReplayGuardian
+
+	// Rejects replayed signed GetChunks requests.
+	getChunksReplayGuardian replay.ReplayGuardian
+	// Limits the rate at which each requester may read chunks. Nil if per-requester tracking is disabled.
+	requesterRateLimiter *requesterRateLimiter
+	// The signer addresses whose signed GetChunks requests are authenticated.
+	authenticatedRequesters map[gethcommon.Address]struct{}
 }
 
 // NewServerV2 creates a new Server instance with the provided parameters.
@@ -75,6 +87,34 @@ func NewServerV2(
 		time.Now,
 		config.StoreChunksRequestMaxPastAge,
 		config.StoreChunksRequestMaxFutureAge)
+	getChunksReplayGuardian := replay.NewReplayGuardian(
+		time.Now,
+		config.GetChunksRequestMaxPastAge,
+		config.GetChunksRequestMaxFutureAge)
+
+	var requesterLimiter *requesterRateLimiter
+	if config.GetChunksRequesterCacheSize > 0 {
+		requesterLimiter, err = newRequesterRateLimiter(
+			requesterQuota{
+				bytesPerSecond: config.GetChunksAnonymousRateLimitMB * 1024 * 1024,
+				burstBytes:     int(config.GetChunksAnonymousBurstLimitMB * 1024 * 1024),
+			},
+			requesterQuota{
+				bytesPerSecond: config.GetChunksAuthenticatedRateLimitMB * 1024 * 1024,
+				burstBytes:     int(config.GetChunksAuthenticatedBurstLimitMB * 1024 * 1024),
+			},
+			config.GetChunksRequesterCacheSize,
+			metrics.RemoveGetChunksRequester,
+			time.Now)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create requester rate limiter: %w", err)
+		}
+	}
+
+	authenticatedRequesters := make(map[gethcommon.Address]struct{}, len(config.GetChunksAuthenticatedRequesters))
+	for _, requester := range config.GetChunksAuthenticatedRequesters {
+		authenticatedRequesters[requester] = struct{}{}
+	}
 
 	return &ServerV2{
 		config:             config,
@@ -85,12 +125,16 @@ func NewServerV2(
 		chunkAuthenticator: chunkAuthenticator,
 		blobAuthenticator:  blobAuthenticator,
 		replayGuardian:     replayGuardian,
+
+		getChunksReplayGuardian: getChunksReplayGuardian,
+		requesterRateLimiter:    requesterLimiter,
+		authenticatedRequesters: authenticatedRequesters,
 	}, nil
 }
 
 func (s *ServerV2) GetNodeInfo(ctx context.Context, in *pb.GetNodeInfoRequest) (*pb.GetNodeInfoReply, error) {
 	if s.config.DisableNodeInfoResources {
-		return &pb.GetNodeInfoReply{Semver: node.SemVer}, nil
+		return &pb.GetNodeInfoReply{Semver: node.SemVer, Maintenance: s.node.Maintenance.Enabled()}, nil
 	}
 
 	memBytes := uint64(0)
@@ -100,15 +144,45 @@ func (s *ServerV2) GetNodeInfo(ctx context.Context, in *pb.GetNodeInfoRequest) (
 	}
 
 	return &pb.GetNodeInfoReply{
-		Semver:   node.SemVer,
-		Os:       runtime.GOOS,
-		Arch:     runtime.GOARCH,
-		NumCpu:   uint32(runtime.GOMAXPROCS(0)),
-		MemBytes: memBytes,
+		Semver:      node.SemVer,
+		Os:          runtime.GOOS,
+		Arch:        runtime.GOARCH,
+		NumCpu:      uint32(runtime.GOMAXPROCS(0)),
+		MemBytes:    memBytes,
+		Maintenance: s.node.Maintenance.Enabled(),
 	}, nil
 }
 
 func (s *ServerV2) StoreChunks(ctx context.Context, in *pb.StoreChunksRequest) (*pb.StoreChunksReply, error) {
+	record := &node.SigningRecord{
+		Time:        time.Now(),
+		DisperserID: in.GetDisperserID(),
+	}
+
+	reply, err := s.storeChunks(ctx, in, record)
+
+	if s.node.SigningHistory != nil {
+		record.Latency = time.Since(record.Time)
+		record.Signed = err == nil
+		if err != nil {
+			record.Error = err.Error()
+		}
+		recordErr := s.node.SigningHistory.Record(record)
+		if recordErr != nil {
+			s.logger.Warn("failed to record StoreChunks request in signing history", "err", recordErr)
+		}
+	}
+
+	return reply, err
+}
+
+// storeChunks handles a StoreChunks request. Information about the batch is added to the record as it becomes
+// available.
+func (s *ServerV2) storeChunks(
+	ctx context.Context,
+	in *pb.StoreChunksRequest,
+	record *node.SigningRecord) (*pb.StoreChunksReply, error) {
+
 	if !s.config.EnableV2 {
 		return nil, api.NewErrorInvalidArg("v2 API is disabled")
 	}
@@ -117,6 +191,11 @@ func (s *ServerV2) StoreChunks(ctx context.Context, in *pb.StoreChunksRequest) (
 		return nil, api.NewErrorInternal("missing bls signer")
 	}
 
+	if !s.node.Maintenance.StartRequest() {
+		return nil, api.NewErrorValidatorMaintenance()
+	}
+	defer s.node.Maintenance.FinishRequest()
+
 	probe := s.metrics.GetStoreChunksProbe()
 	defer probe.End()
 
@@ -134,6 +213,17 @@ func (s *ServerV2) StoreChunks(ctx context.Context, in *pb.StoreChunksRequest) (
 		return nil, api.NewErrorInvalidArg(fmt.Sprintf("failed to serialize batch header hash: %v", err))
 	}
 
+	record.BatchHeaderHash = batchHeaderHash[:]
+	record.ReferenceBlockNumber = batch.BatchHeader.ReferenceBlockNumber
+	record.BlobKeys = make([]corev2.BlobKey, 0, len(batch.BlobCertificates))
+	for _, cert := range batch.BlobCertificates {
+		blobKey, err := cert.BlobHeader.BlobKey()
+		if err != nil {
+			return nil, api.NewErrorInvalidArg(fmt.Sprintf("failed to get blob key: %v", err))
+		}
+		record.BlobKeys = append(record.BlobKeys, blobKey)
+	}
+
 	// If the disperser is blacklisted and the blob authenticator is not nil, return an error
 	// we don't want to blacklist the disperser if the blob authenticator is nil since that indicated v1
 	if s.node.BlacklistStore.IsBlacklisted(ctx, in.DisperserID) && s.config.EnableV2 {
@@ -212,6 +302,10 @@ func (s *ServerV2) StoreChunks(ctx context.Context, in *pb.StoreChunksRequest) (
 		return nil, api.NewErrorInternal(fmt.Sprintf("failed to sign batch: %v", err))
 	}
 
+	if s.node.DataAuditor != nil {
+		s.node.DataAuditor.RecordSignedBatch(batch, batchHeaderHash)
+	}
+
 	return &pb.StoreChunksReply{
 		Signature: sig,
 	}, nil
@@ -243,8 +337,9 @@ func (s *ServerV2) validateAndStoreChunks(
 		}
 
 		batchData = append(batchData, &node.BundleToStore{
-			BundleKey:   bundleKey,
-			BundleBytes: bundle.Bundle,
+			BundleKey:    bundleKey,
+			BundleBytes:  bundle.Bundle,
+			ChunkIndices: bundle.ChunkIndices,
 		})
 	}
 
@@ -307,6 +402,58 @@ func (s *ServerV2) validateStoreChunksRequest(req *pb.StoreChunksRequest) (*core
 	return batch, nil
 }
 
+func (s *ServerV2) GetSigningHistory(
+	ctx context.Context,
+	in *pb.GetSigningHistoryRequest) (*pb.GetSigningHistoryReply, error) {
+
+	if !s.config.EnableV2 {
+		return nil, api.NewErrorInvalidArg("v2 API is disabled")
+	}
+
+	if s.node.SigningHistory == nil {
+		return nil, api.NewErrorUnimplemented()
+	}
+
+	limit := maxSigningHistoryRecords
+	if in.GetLimit() > 0 && in.GetLimit() < maxSigningHistoryRecords {
+		limit = in.GetLimit()
+	}
+
+	start := time.Unix(0, int64(in.GetStartTime()))
+	var end time.Time
+	if in.GetEndTime() > 0 {
+		end = time.Unix(0, int64(in.GetEndTime()))
+	}
+
+	records, truncated, err := s.node.SigningHistory.Query(start, end, in.GetBatchHeaderHash(), int(limit))
+	if err != nil {
+		return nil, api.NewErrorInternal(fmt.Sprintf("failed to query signing history: %v", err))
+	}
+
+	reply := &pb.GetSigningHistoryReply{
+		Records:   make([]*pb.SigningRecord, 0, len(records)),
+		Truncated: truncated,
+	}
+	for _, record := range records {
+		blobKeys := make([][]byte, len(record.BlobKeys))
+		for i, blobKey := range record.BlobKeys {
+			blobKeys[i] = blobKey[:]
+		}
+		reply.Records = append(reply.Records, &pb.SigningRecord{
+			Timestamp:            uint64(record.Time.UnixNano()),
+			BatchHeaderHash:      record.BatchHeaderHash,
+			ReferenceBlockNumber: record.ReferenceBlockNumber,
+			BlobKeys:             blobKeys,
+			Latency:              uint64(record.Latency.Nanoseconds()),
+			Signed:               record.Signed,
+			Error:                record.Error,
+			DisperserId:          record.DisperserID,
+		})
+	}
+
+	return reply, nil
+}
+
 func (s *ServerV2) GetChunks(ctx context.Context, in *pb.GetChunksRequest) (*pb.GetChunksReply, error) {
 	start := time.Now()
 
@@ -331,22 +478,51 @@ func (s *ServerV2) GetChunks(ctx context.Context, in *pb.GetChunksRequest) (*pb.
 		return nil, api.NewErrorInvalidArg(fmt.Sprintf("failed to get bundle key: %v", err))
 	}
 
-	bundleData, err := s.node.ValidatorStore.GetBundleData(bundleKey)
+	var selectChunks node.ChunkSelector
+	if in.GetByIndex() != nil {
+		selectChunks = selectChunksByIndex(in.GetByIndex())
+	} else if in.GetByRange() != nil {
+		if in.GetByRange().GetStartIndex() > in.GetByRange().GetEndIndex() {
+			return nil, api.NewErrorInvalidArg(
+				"invalid chunk range, start index must be less than or equal to end index")
+		}
+		selectChunks = selectChunksByRange(in.GetByRange())
+	}
+
+	requester, authenticated, err := s.identifyGetChunksRequester(ctx, in)
 	if err != nil {
-		return nil, api.NewErrorInternal(fmt.Sprintf("failed to get chunks: %v", err))
+		return nil, err
+	}
+
+	if s.requesterRateLimiter != nil && !s.requesterRateLimiter.Allow(requester, authenticated) {
+		s.metrics.ReportGetChunksRequesterRateLimited(requester, authenticated)
+		return nil, api.NewErrorResourceExhausted(
+			fmt.Sprintf("read rate limit exhausted for requester %s", requester))
 	}
 
-	chunks, _, err := node.DecodeChunks(bundleData)
+	chunks, err := s.node.ValidatorStore.GetChunks(bundleKey, selectChunks)
+	if errors.Is(err, node.ErrInvalidChunkSelection) {
+		return nil, api.NewErrorInvalidArg(fmt.Sprintf("failed to get chunks: %v", err))
+	}
+	if errors.Is(err, node.ErrChunkIndicesUnavailable) {
+		return nil, api.NewErrorNotFound(
+			fmt.Sprintf("failed to get chunks: %v, only requests for all chunks are supported for this blob", err))
+	}
 	if err != nil {
-		return nil, api.NewErrorInternal(fmt.Sprintf("failed to decode chunks: %v", err))
+		return nil, api.NewErrorInternal(fmt.Sprintf("failed to get chunks: %v", err))
 	}
 
 	size := 0
-	if len(chunks) > 0 {
-		size = len(chunks[0]) * len(chunks)
+	for _, chunk := range chunks {
+		size += len(chunk)
 	}
 	s.metrics.ReportGetChunksDataSize(size)
 
+	if s.requesterRateLimiter != nil {
+		s.requesterRateLimiter.Debit(requester, authenticated, size)
+		s.metrics.ReportGetChunksRequesterBytes(requester, authenticated, size)
+	}
+
 	s.metrics.ReportGetChunksLatency(time.Since(start))
 
 	return &pb.GetChunksReply{
@@ -355,6 +531,97 @@ func (s *ServerV2) GetChunks(ctx context.Context, in *pb.GetChunksRequest) (*pb.
 	}, nil
 }
 
+// identifyGetChunksRequester determines who sent a GetChunks request. Requests signed by one of the configured
+// authenticated requesters are identified by the address of the signer, and are considered authenticated. Anyone can
+// create a signing key, so all other requests (including requests signed by other keys) are identified by the
+// client's IP address. A request with a signature that can't be verified is rejected rather than being treated as
+// unsigned.
+func (s *ServerV2) identifyGetChunksRequester(
+	ctx context.Context,
+	in *pb.GetChunksRequest) (requester string, authenticated bool, err error) {
+
+	if len(in.GetSignature()) > 0 {
+		signer, hash, err := auth.RecoverGetChunksRequestSigner(in)
+		if err != nil {
+			return "", false, api.NewErrorInvalidArg(fmt.Sprintf("failed to verify request signature: %v", err))
+		}
+
+		if _, ok := s.authenticatedRequesters[signer]; ok {
+			timestamp := time.Unix(int64(in.GetTimestamp()), 0)
+			err = s.getChunksReplayGuardian.VerifyRequest(hash, timestamp)
+			if err != nil {
+				return "", false, api.NewErrorInvalidArg(fmt.Sprintf("failed to verify request: %v", err))
+			}
+
+			return signer.Hex(), true, nil
+		}
+	}
+
+	if s.requesterRateLimiter == nil {
+		// Unsigned requesters only need to be identified if they are being tracked.
+		return "", false, nil
+	}
+
+	clientAddress, err := common.GetClientAddress(ctx, s.config.ClientIPHeader, 1, true)
+	if err != nil {
+		return "", false, api.NewErrorInvalidArg(fmt.Sprintf("failed to get client address: %v", err))
+	}
+
+	return clientAddress, false, nil
+}
+
+// selectChunksByIndex returns a ChunkSelector that selects the chunks with the requested global chunk indices, in the
+// requested order. Selecting a chunk that is not assigned to this node is an error.
+func selectChunksByIndex(request *pb.ChunkRequestByIndex) node.ChunkSelector {
+	return func(chunkIndices []uint32, chunks [][]byte) ([][]byte, error) {
+		positions := chunkPositions(chunkIndices)
+		selected := make([][]byte, 0, len(request.GetChunkIndices()))
+		for _, index := range request.GetChunkIndices() {
+			position, ok := positions[index]
+			if !ok {
+				return nil, fmt.Errorf("%w: chunk %d is not assigned to this node",
+					node.ErrInvalidChunkSelection, index)
+			}
+			selected = append(selected, chunks[position])
+		}
+		return selected, nil
+	}
+}
+
+// selectChunksByRange returns a ChunkSelector that selects the chunks with global chunk indices in the requested
+// range, in index order. Every chunk in the range must be assigned to this node.
+func selectChunksByRange(request *pb.ChunkRequestByRange) node.ChunkSelector {
+	return func(chunkIndices []uint32, chunks [][]byte) ([][]byte, error) {
+		startIndex := request.GetStartIndex()
+		endIndex := request.GetEndIndex()
+		if uint64(endIndex-startIndex) > uint64(len(chunkIndices)) {
+			return nil, fmt.Errorf("%w: chunk range %d-%d is larger than the %d chunks assigned to this node",
+				node.ErrInvalidChunkSelection, startIndex, endIndex, len(chunkIndices))
+		}
+
+		positions := chunkPositions(chunkIndices)
+		selected := make([][]byte, 0, endIndex-startIndex)
+		for index := startIndex; index < endIndex; index++ {
+			position, ok := positions[index]
+			if !ok {
+				return nil, fmt.Errorf("%w: chunk %d in range %d-%d is not assigned to this node",
+					node.ErrInvalidChunkSelection, index, startIndex, endIndex)
+			}
+			selected = append(selected, chunks[position])
+		}
+		return selected, nil
+	}
+}
+
+// chunkPositions maps the global index of each chunk in a bundle to the chunk's position in the bundle.
+func chunkPositions(chunkIndices []uint32) map[uint32]int {
+	positions := make(map[uint32]int, len(chunkIndices))
+	for position, index := range chunkIndices {
+		positions[index] = position
+	}
+	return positions
+}
+
 // validateDispersalRequest validates the DisperseBlobRequest and returns the blob header
 // Differences between this and the DispersalServerV2 are:
 // - Takes *corev2.BlobCertificate instead of DisperseBlobRequest
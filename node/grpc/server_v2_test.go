@@ -3,12 +3,14 @@ package grpc_test
 import (
 	"context"
 	"errors"
+	"net"
 	"os"
 	"strings"
 	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/Layr-Labs/eigenda/api"
 	"github.com/Layr-Labs/eigenda/api/clients/v2/relay"
 	coreeth "github.com/Layr-Labs/eigenda/core/eth"
 	"github.com/gammazero/workerpool"
@@ -19,21 +21,28 @@ import (
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/kvstore"
 	commonmock "github.com/Layr-Labs/eigenda/common/mock"
+	"github.com/Layr-Labs/eigenda/common/testutils/random"
 	"github.com/Layr-Labs/eigenda/core"
 	coremock "github.com/Layr-Labs/eigenda/core/mock"
 	coremockv2 "github.com/Layr-Labs/eigenda/core/mock/v2"
 	v2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/memtable"
 	"github.com/Layr-Labs/eigenda/node"
+	"github.com/Layr-Labs/eigenda/node/auth"
 	"github.com/Layr-Labs/eigenda/node/grpc"
 	nodemock "github.com/Layr-Labs/eigenda/node/mock"
 	"github.com/Layr-Labs/eigensdk-go/metrics"
 	blssigner "github.com/Layr-Labs/eigensdk-go/signer/bls"
 	blssignerTypes "github.com/Layr-Labs/eigensdk-go/signer/bls/types"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 )
 
@@ -216,20 +225,39 @@ func TestV2StoreChunksSuccess(t *testing.T) {
 	require.NoError(t, err)
 	bundles20Bytes, err := bundles[2][0].Serialize()
 	require.NoError(t, err)
+	// The global chunk indices requested from the relays, keyed by bundle key.
+	requestedIndices := make(map[string][]uint32)
+	recordRequestedIndices := func(requests []*relay.ChunkRequestByIndex) {
+		for _, request := range requests {
+			bundleKey, err := node.BundleKey(request.BlobKey, 0)
+			require.NoError(t, err)
+			requestedIndices[string(bundleKey)] = request.Indices
+		}
+	}
 	c.relayClient.On("GetChunksByIndex", mock.Anything, v2.RelayKey(0), mock.Anything).Return([][]byte{bundles00Bytes, bundles20Bytes}, nil).Run(func(args mock.Arguments) {
 		requests := args.Get(2).([]*relay.ChunkRequestByIndex)
 		require.Len(t, requests, 2)
 		require.Equal(t, blobKeys[0], requests[0].BlobKey)
 		require.Equal(t, blobKeys[2], requests[1].BlobKey)
+		recordRequestedIndices(requests)
 	})
 	c.relayClient.On("GetChunksByIndex", mock.Anything, v2.RelayKey(1), mock.Anything).Return([][]byte{bundles10Bytes}, nil).Run(func(args mock.Arguments) {
 		requests := args.Get(2).([]*relay.ChunkRequestByIndex)
 		require.Len(t, requests, 1)
 		require.Equal(t, blobKeys[1], requests[0].BlobKey)
+		recordRequestedIndices(requests)
 	})
 	c.blacklistStore.On("IsBlacklisted", mock.Anything, mock.Anything).Return(false)
 	c.blacklistStore.On("GetByDisperserID", mock.Anything, mock.Anything).Return(nil, nil)
-	c.store.On("StoreBatch", mock.Anything, mock.Anything).Return(nil, nil)
+	c.store.On("StoreBatch", mock.Anything, mock.Anything).Return(nil, nil).Run(func(args mock.Arguments) {
+		// Each bundle is stored along with the global indices of its chunks, so GetChunks can select from them.
+		batchData := args.Get(0).([]*node.BundleToStore)
+		require.Len(t, batchData, len(requestedIndices))
+		for _, bundle := range batchData {
+			require.NotEmpty(t, bundle.ChunkIndices)
+			require.Equal(t, requestedIndices[string(bundle.BundleKey)], bundle.ChunkIndices)
+		}
+	})
 	reply, err := c.server.StoreChunks(context.Background(), &validator.StoreChunksRequest{
 		DisperserID: 0,
 		Signature:   ecdsaSig,
@@ -426,6 +454,145 @@ func TestV2StoreChunksStorageFailure(t *testing.T) {
 	requireErrorStatusAndMsg(t, err, codes.Internal, "failed to store batch")
 }
 
+func TestV2StoreChunksMaintenance(t *testing.T) {
+	config := makeConfig(t)
+	config.EnableV2 = true
+	c := newTestComponents(t, config)
+
+	_, batch, _ := nodemock.MockBatch(t)
+	batchProto, err := batch.ToProtobuf()
+	require.NoError(t, err)
+	c.blacklistStore.On("IsBlacklisted", mock.Anything, mock.Anything).Return(false)
+	c.blacklistStore.On("GetByDisperserID", mock.Anything, mock.Anything).Return(nil, nil)
+	c.validator.On("ValidateBlobs", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	c.validator.On("ValidateBatchHeader", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	relayErr := errors.New("error")
+	c.relayClient.On("GetChunksByIndex", mock.Anything, mock.Anything, mock.Anything).Return([][]byte{}, relayErr)
+
+	err = c.node.EnterMaintenance(context.Background())
+	require.NoError(t, err)
+
+	info, err := c.server.GetNodeInfo(context.Background(), &validator.GetNodeInfoRequest{})
+	require.NoError(t, err)
+	require.True(t, info.GetMaintenance())
+
+	// New batches are rejected with a retryable status before any work is done.
+	_, err = c.server.StoreChunks(context.Background(), &validator.StoreChunksRequest{
+		DisperserID: 0,
+		Signature:   ecdsaSig,
+		Batch:       batchProto,
+	})
+	requireErrorStatus(t, err, codes.Unavailable)
+	require.True(t, api.IsErrorValidatorMaintenance(err))
+	c.relayClient.AssertNotCalled(t, "GetChunksByIndex", mock.Anything, mock.Anything, mock.Anything)
+
+	c.node.ExitMaintenance()
+
+	info, err = c.server.GetNodeInfo(context.Background(), &validator.GetNodeInfoRequest{})
+	require.NoError(t, err)
+	require.False(t, info.GetMaintenance())
+
+	_, err = c.server.StoreChunks(context.Background(), &validator.StoreChunksRequest{
+		DisperserID: 0,
+		Signature:   ecdsaSig,
+		Batch:       batchProto,
+	})
+	requireErrorStatus(t, err, codes.Internal)
+	require.False(t, api.IsErrorValidatorMaintenance(err))
+}
+
+func TestV2SigningHistory(t *testing.T) {
+	config := makeConfig(t)
+	config.EnableV2 = true
+	c := newTestComponents(t, config)
+
+	// Without a signing history, the RPC is not available.
+	_, err := c.server.GetSigningHistory(context.Background(), &validator.GetSigningHistoryRequest{})
+	requireErrorStatus(t, err, codes.Unimplemented)
+
+	signingHistory, err := node.NewSigningHistory(
+		c.node.Logger, memtable.NewMemTable(litt.DefaultConfigNoPaths(), "signing_history"), time.Hour)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, signingHistory.Stop())
+	}()
+	c.node.SigningHistory = signingHistory
+
+	blobKeys, batch, _ := nodemock.MockBatch(t)
+	batchProto, err := batch.ToProtobuf()
+	require.NoError(t, err)
+	batchHeaderHash, err := batch.BatchHeader.Hash()
+	require.NoError(t, err)
+	c.blacklistStore.On("IsBlacklisted", mock.Anything, mock.Anything).Return(false)
+	c.blacklistStore.On("GetByDisperserID", mock.Anything, mock.Anything).Return(nil, nil)
+	c.validator.On("ValidateBlobs", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	c.validator.On("ValidateBatchHeader", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	relayErr := errors.New("error")
+	c.relayClient.On("GetChunksByIndex", mock.Anything, mock.Anything, mock.Anything).Return([][]byte{}, relayErr)
+
+	start := time.Now()
+	_, err = c.server.StoreChunks(context.Background(), &validator.StoreChunksRequest{
+		DisperserID: 0,
+		Signature:   ecdsaSig,
+		Batch:       batchProto,
+	})
+	requireErrorStatus(t, err, codes.Internal)
+
+	// Requests that can't be parsed are recorded too.
+	_, err = c.server.StoreChunks(context.Background(), &validator.StoreChunksRequest{
+		DisperserID: 0,
+		Signature:   ecdsaSig,
+	})
+	requireErrorStatus(t, err, codes.InvalidArgument)
+
+	reply, err := c.server.GetSigningHistory(context.Background(), &validator.GetSigningHistoryRequest{
+		StartTime: uint64(start.UnixNano()),
+	})
+	require.NoError(t, err)
+	require.False(t, reply.GetTruncated())
+	require.Len(t, reply.GetRecords(), 2)
+
+	record := reply.GetRecords()[0]
+	require.Equal(t, batchHeaderHash[:], record.GetBatchHeaderHash())
+	require.Equal(t, batch.BatchHeader.ReferenceBlockNumber, record.GetReferenceBlockNumber())
+	require.Len(t, record.GetBlobKeys(), len(blobKeys))
+	for i, blobKey := range blobKeys {
+		require.Equal(t, blobKey[:], record.GetBlobKeys()[i])
+	}
+	require.False(t, record.GetSigned())
+	require.NotEmpty(t, record.GetError())
+	require.GreaterOrEqual(t, record.GetTimestamp(), uint64(start.UnixNano()))
+
+	record = reply.GetRecords()[1]
+	require.Empty(t, record.GetBatchHeaderHash())
+	require.False(t, record.GetSigned())
+	require.NotEmpty(t, record.GetError())
+
+	// Filter by batch, with a limit.
+	reply, err = c.server.GetSigningHistory(context.Background(), &validator.GetSigningHistoryRequest{
+		BatchHeaderHash: batchHeaderHash[:],
+		Limit:           1,
+	})
+	require.NoError(t, err)
+	require.False(t, reply.GetTruncated())
+	require.Len(t, reply.GetRecords(), 1)
+	require.Equal(t, batchHeaderHash[:], reply.GetRecords()[0].GetBatchHeaderHash())
+
+	reply, err = c.server.GetSigningHistory(context.Background(), &validator.GetSigningHistoryRequest{
+		Limit: 1,
+	})
+	require.NoError(t, err)
+	require.True(t, reply.GetTruncated())
+	require.Len(t, reply.GetRecords(), 1)
+
+	// Nothing was received before the test started.
+	reply, err = c.server.GetSigningHistory(context.Background(), &validator.GetSigningHistoryRequest{
+		EndTime: uint64(start.UnixNano()),
+	})
+	require.NoError(t, err)
+	require.Empty(t, reply.GetRecords())
+}
+
 func TestV2StoreChunksLittDBValidationFailure(t *testing.T) {
 	config := makeConfig(t)
 	config.EnableV2 = true
@@ -537,6 +704,148 @@ func TestV2GetChunksInputValidation(t *testing.T) {
 	requireErrorStatus(t, err, codes.InvalidArgument)
 }
 
+func TestV2GetChunksSubset(t *testing.T) {
+	config := makeConfig(t)
+	config.EnableV2 = true
+	c := newTestComponents(t, config)
+	ctx := context.Background()
+
+	// The node is assigned global chunks 7, 8, 9, 20 and 21 of the blob, stored in that order.
+	chunks := [][]byte{{7}, {8}, {9}, {20}, {21}}
+	c.store.On("GetChunks", mock.Anything).Return(chunks, nil, []uint32{7, 8, 9, 20, 21})
+	bk := [32]byte{1}
+
+	reply, err := c.server.GetChunks(ctx, &validator.GetChunksRequest{BlobKey: bk[:]})
+	require.NoError(t, err)
+	require.Equal(t, chunks, reply.GetChunks())
+
+	reply, err = c.server.GetChunks(ctx, &validator.GetChunksRequest{
+		BlobKey: bk[:],
+		ChunkRequest: &validator.GetChunksRequest_ByIndex{
+			ByIndex: &validator.ChunkRequestByIndex{ChunkIndices: []uint32{20, 7, 21}},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{{20}, {7}, {21}}, reply.GetChunks())
+
+	reply, err = c.server.GetChunks(ctx, &validator.GetChunksRequest{
+		BlobKey: bk[:],
+		ChunkRequest: &validator.GetChunksRequest_ByRange{
+			ByRange: &validator.ChunkRequestByRange{StartIndex: 8, EndIndex: 10},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{{8}, {9}}, reply.GetChunks())
+
+	// Positions in the node's bundle are not accepted in place of global chunk indices.
+	_, err = c.server.GetChunks(ctx, &validator.GetChunksRequest{
+		BlobKey: bk[:],
+		ChunkRequest: &validator.GetChunksRequest_ByIndex{
+			ByIndex: &validator.ChunkRequestByIndex{ChunkIndices: []uint32{0}},
+		},
+	})
+	requireErrorStatus(t, err, codes.InvalidArgument)
+
+	// Every chunk in a range must be assigned to the node.
+	_, err = c.server.GetChunks(ctx, &validator.GetChunksRequest{
+		BlobKey: bk[:],
+		ChunkRequest: &validator.GetChunksRequest_ByRange{
+			ByRange: &validator.ChunkRequestByRange{StartIndex: 9, EndIndex: 11},
+		},
+	})
+	requireErrorStatus(t, err, codes.InvalidArgument)
+
+	_, err = c.server.GetChunks(ctx, &validator.GetChunksRequest{
+		BlobKey: bk[:],
+		ChunkRequest: &validator.GetChunksRequest_ByRange{
+			ByRange: &validator.ChunkRequestByRange{StartIndex: 0, EndIndex: 0xFFFFFFFF},
+		},
+	})
+	requireErrorStatus(t, err, codes.InvalidArgument)
+
+	_, err = c.server.GetChunks(ctx, &validator.GetChunksRequest{
+		BlobKey: bk[:],
+		ChunkRequest: &validator.GetChunksRequest_ByRange{
+			ByRange: &validator.ChunkRequestByRange{StartIndex: 3, EndIndex: 2},
+		},
+	})
+	requireErrorStatus(t, err, codes.InvalidArgument)
+}
+
+func TestV2GetChunksRequesterRateLimit(t *testing.T) {
+	config := makeConfig(t)
+	config.EnableV2 = true
+	config.GetChunksRequesterCacheSize = 2
+	// 1 byte per second with a burst of 8 bytes, expressed in MB.
+	config.GetChunksAnonymousRateLimitMB = 1.0 / (1 << 20)
+	config.GetChunksAnonymousBurstLimitMB = 8.0 / (1 << 20)
+	// Authenticated requesters are not individually rate limited.
+	config.GetChunksAuthenticatedRateLimitMB = 0
+	config.GetChunksRequestMaxPastAge = time.Minute
+	config.GetChunksRequestMaxFutureAge = time.Minute
+	rand := random.NewTestRandom()
+	_, authenticatedKey, err := rand.ECDSA()
+	require.NoError(t, err)
+	_, unknownKey, err := rand.ECDSA()
+	require.NoError(t, err)
+	config.GetChunksAuthenticatedRequesters = []gethcommon.Address{crypto.PubkeyToAddress(authenticatedKey.PublicKey)}
+	c := newTestComponents(t, config)
+
+	chunks := [][]byte{{0}, {1}, {2}, {3}, {4}}
+	c.store.On("GetChunks", mock.Anything).Return(chunks, nil)
+	bk := [32]byte{1}
+
+	ctxA := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP("1.2.3.4"), Port: 1234},
+	})
+	ctxB := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP("5.6.7.8"), Port: 1234},
+	})
+
+	// The first two requests fit in the burst, and leave the bucket in debt.
+	_, err = c.server.GetChunks(ctxA, &validator.GetChunksRequest{BlobKey: bk[:]})
+	require.NoError(t, err)
+	_, err = c.server.GetChunks(ctxA, &validator.GetChunksRequest{BlobKey: bk[:]})
+	require.NoError(t, err)
+	_, err = c.server.GetChunks(ctxA, &validator.GetChunksRequest{BlobKey: bk[:]})
+	requireErrorStatus(t, err, codes.ResourceExhausted)
+
+	// A request signed by a key that isn't an authenticated requester is keyed by the address, like an unsigned one.
+	unknownRequest := &validator.GetChunksRequest{
+		BlobKey:   bk[:],
+		Timestamp: uint32(time.Now().Unix()),
+	}
+	unknownRequest.Signature, err = auth.SignGetChunksRequest(unknownKey, unknownRequest)
+	require.NoError(t, err)
+	_, err = c.server.GetChunks(ctxA, unknownRequest)
+	requireErrorStatus(t, err, codes.ResourceExhausted)
+
+	// A request signed by an authenticated requester from the same address is keyed by its signer, not by the address.
+	signedRequest := &validator.GetChunksRequest{
+		BlobKey:   bk[:],
+		Timestamp: uint32(time.Now().Unix()),
+	}
+	signedRequest.Signature, err = auth.SignGetChunksRequest(authenticatedKey, signedRequest)
+	require.NoError(t, err)
+	_, err = c.server.GetChunks(ctxA, signedRequest)
+	require.NoError(t, err)
+
+	// Replaying a signed request is not allowed.
+	_, err = c.server.GetChunks(ctxA, signedRequest)
+	requireErrorStatus(t, err, codes.InvalidArgument)
+
+	// A request with a malformed signature is rejected.
+	_, err = c.server.GetChunks(ctxA, &validator.GetChunksRequest{BlobKey: bk[:], Signature: []byte{1, 2, 3}})
+	requireErrorStatus(t, err, codes.InvalidArgument)
+
+	// Other addresses have their own quota. Since only two requesters are tracked at a time, this
+	// evicts the least recently seen requester (the first address), which resets its quota.
+	_, err = c.server.GetChunks(ctxB, &validator.GetChunksRequest{BlobKey: bk[:]})
+	require.NoError(t, err)
+	_, err = c.server.GetChunks(ctxA, &validator.GetChunksRequest{BlobKey: bk[:]})
+	require.NoError(t, err)
+}
+
 func requireErrorStatus(t *testing.T, err error, code codes.Code) {
 	require.Error(t, err)
 	s, ok := status.FromError(err)
@@ -0,0 +1,131 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/Layr-Labs/eigensdk-go/nodeapi"
+)
+
+// The ID of the node API service that reports whether the node is accepting new batches.
+const storeChunksServiceID = "store-chunks"
+
+// MaintenanceMode tracks whether the node is in maintenance mode, and the StoreChunks requests that are in flight.
+// While in maintenance mode the node rejects new StoreChunks requests, but continues to serve GetChunks requests.
+// This allows a node to be taken out of service without losing the batches it is already processing.
+//
+// The zero value is ready to use, and is not in maintenance mode.
+type MaintenanceMode struct {
+	lock sync.Mutex
+
+	// True if the node is in maintenance mode.
+	enabled bool
+
+	// The number of StoreChunks requests that are in flight.
+	inFlight int
+
+	// Closed when the number of in-flight requests drops to zero. Nil if there are no requests in flight.
+	idle chan struct{}
+}
+
+// StartRequest registers a new StoreChunks request. Returns false if the node is in maintenance mode, in which case
+// the request must be rejected. If true is returned, FinishRequest must be called once the request is complete.
+func (m *MaintenanceMode) StartRequest() bool {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if m.enabled {
+		return false
+	}
+	if m.inFlight == 0 {
+		m.idle = make(chan struct{})
+	}
+	m.inFlight++
+	return true
+}
+
+// FinishRequest marks a StoreChunks request started with StartRequest as complete.
+func (m *MaintenanceMode) FinishRequest() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.inFlight--
+	if m.inFlight == 0 {
+		close(m.idle)
+		m.idle = nil
+	}
+}
+
+// Enter puts the node into maintenance mode, and then waits for StoreChunks requests that are in flight to finish.
+// Batches are flushed to disk before StoreChunks returns, so once Enter returns without error all data the node
+// has signed for is durable. If the context is cancelled before in-flight requests finish, an error is returned
+// and the node remains in maintenance mode.
+func (m *MaintenanceMode) Enter(ctx context.Context) error {
+	m.lock.Lock()
+	m.enabled = true
+	idle := m.idle
+	inFlight := m.inFlight
+	m.lock.Unlock()
+
+	if idle == nil {
+		return nil
+	}
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("timed out waiting for %d in-flight StoreChunks requests to finish: %w",
+			inFlight, ctx.Err())
+	}
+}
+
+// Exit takes the node out of maintenance mode.
+func (m *MaintenanceMode) Exit() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.enabled = false
+}
+
+// Enabled returns true if the node is in maintenance mode.
+func (m *MaintenanceMode) Enabled() bool {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	return m.enabled
+}
+
+// EnterMaintenance puts the node into maintenance mode and waits for in-flight StoreChunks requests to finish.
+// See MaintenanceMode.Enter.
+func (n *Node) EnterMaintenance(ctx context.Context) error {
+	n.Logger.Info("Entering maintenance mode, new StoreChunks requests will be rejected")
+	n.updateStoreChunksServiceStatus(nodeapi.ServiceStatusDown)
+
+	err := n.Maintenance.Enter(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to drain StoreChunks requests: %w", err)
+	}
+
+	n.Logger.Info("Entered maintenance mode, all in-flight StoreChunks requests have finished")
+	return nil
+}
+
+// ExitMaintenance takes the node out of maintenance mode.
+func (n *Node) ExitMaintenance() {
+	n.Maintenance.Exit()
+	n.updateStoreChunksServiceStatus(nodeapi.ServiceStatusUp)
+	n.Logger.Info("Exited maintenance mode, accepting StoreChunks requests")
+}
+
+// updateStoreChunksServiceStatus reports whether the node is accepting new batches via the node API.
+func (n *Node) updateStoreChunksServiceStatus(status nodeapi.ServiceStatus) {
+	if n.NodeApi == nil {
+		return
+	}
+	err := n.NodeApi.UpdateServiceStatus(storeChunksServiceID, status)
+	if err != nil {
+		n.Logger.Warn("failed to update store chunks service status", "err", err)
+	}
+}
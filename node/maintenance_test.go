@@ -0,0 +1,66 @@
+package node_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/node"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaintenanceModeDrainsInFlightRequests(t *testing.T) {
+	maintenance := &node.MaintenanceMode{}
+	require.False(t, maintenance.Enabled())
+
+	require.True(t, maintenance.StartRequest())
+	require.True(t, maintenance.StartRequest())
+
+	entered := make(chan error, 1)
+	go func() {
+		entered <- maintenance.Enter(context.Background())
+	}()
+
+	// New requests are rejected as soon as maintenance mode is entered, even while draining.
+	require.Eventually(t, maintenance.Enabled, time.Second, time.Millisecond)
+	require.False(t, maintenance.StartRequest())
+
+	maintenance.FinishRequest()
+	select {
+	case <-entered:
+		require.Fail(t, "maintenance mode entered with a request still in flight")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	maintenance.FinishRequest()
+	select {
+	case err := <-entered:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		require.Fail(t, "timed out waiting for in-flight requests to drain")
+	}
+
+	maintenance.Exit()
+	require.False(t, maintenance.Enabled())
+	require.True(t, maintenance.StartRequest())
+	maintenance.FinishRequest()
+
+	// With nothing in flight, entering maintenance mode returns immediately.
+	require.NoError(t, maintenance.Enter(context.Background()))
+	require.True(t, maintenance.Enabled())
+}
+
+func TestMaintenanceModeDrainTimeout(t *testing.T) {
+	maintenance := &node.MaintenanceMode{}
+	require.True(t, maintenance.StartRequest())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := maintenance.Enter(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// The node stays in maintenance mode even though the drain timed out.
+	require.True(t, maintenance.Enabled())
+	require.False(t, maintenance.StartRequest())
+	maintenance.FinishRequest()
+}
@@ -48,6 +48,12 @@ type Metrics struct {
 	ReachabilityGauge *prometheus.GaugeVec
 	// The throughput (bytes per second) at which the data is written to database.
 	DBWriteThroughput prometheus.Gauge
+	// Accumulated number of bundles checked by the data auditor.
+	AccuAuditedBundles prometheus.Counter
+	// Accumulated number of damaged bundles found by the data auditor, by problem and repair status.
+	AccuAuditFindings *prometheus.CounterVec
+	// The number of damaged bundles that could not be repaired in the most recent audit.
+	AuditUnrepairedBundles prometheus.Gauge
 
 	registry *prometheus.Registry
 	// socketAddr is the address at which the metrics server will be listening.
@@ -157,6 +163,30 @@ func NewMetrics(eigenMetrics eigenmetrics.Metrics, reg *prometheus.Registry, log
 				Help:      "the throughput (bytes per second) at which the data is written to database",
 			},
 		),
+		AccuAuditedBundles: promauto.With(reg).NewCounter(
+			prometheus.CounterOpts{
+				Namespace: Namespace,
+				Name:      "eigenda_audited_bundles_total",
+				Help:      "the total number of bundles checked by the data auditor",
+			},
+		),
+		// The "problem" label has values: missing, corrupt, invalid.
+		// The "status" label has values: repaired, unrepaired.
+		AccuAuditFindings: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: Namespace,
+				Name:      "eigenda_audit_findings_total",
+				Help:      "the total number of missing or damaged bundles found by the data auditor",
+			},
+			[]string{"problem", "status"},
+		),
+		AuditUnrepairedBundles: promauto.With(reg).NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: Namespace,
+				Name:      "audit_unrepaired_bundles",
+				Help:      "the number of damaged bundles that could not be repaired in the most recent audit",
+			},
+		),
 
 		EigenMetrics:           eigenMetrics,
 		logger:                 logger.With("component", "NodeMetrics"),
@@ -223,6 +253,18 @@ func (g *Metrics) RecordStoreChunksStage(stage string, dataSize uint64, latency
 	g.ObserveLatency("StoreChunks", stage, float64(latency.Milliseconds()))
 }
 
+func (g *Metrics) RecordAudit(report *AuditReport) {
+	g.AccuAuditedBundles.Add(float64(report.BundlesAudited))
+	for _, finding := range report.Findings {
+		status := "repaired"
+		if !finding.Repaired {
+			status = "unrepaired"
+		}
+		g.AccuAuditFindings.WithLabelValues(string(finding.Problem), status).Inc()
+	}
+	g.AuditUnrepairedBundles.Set(float64(report.Unrepaired()))
+}
+
 func (g *Metrics) collectOnchainMetrics() {
 	ticker := time.NewTicker(time.Duration(g.onchainMetricsInterval) * time.Second)
 	defer ticker.Stop()
@@ -1,7 +1,10 @@
 package mock
 
 import (
+	"fmt"
+
 	"github.com/Layr-Labs/eigenda/common/kvstore"
+	"github.com/Layr-Labs/eigenda/litt"
 	"github.com/Layr-Labs/eigenda/node"
 	"github.com/stretchr/testify/mock"
 )
@@ -38,6 +41,43 @@ func (m *MockStoreV2) GetBundleData(bundleKey []byte) ([]byte, error) {
 	return args.Get(0).([]byte), args.Error(1)
 }
 
+func (m *MockStoreV2) GetChunks(bundleKey []byte, selectChunks node.ChunkSelector) ([][]byte, error) {
+	args := m.Called(bundleKey)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	chunks := args.Get(0).([][]byte)
+	if selectChunks != nil && args.Error(1) == nil {
+		// The global chunk indices may optionally be provided as a third return value.
+		if len(args) < 3 {
+			return nil, fmt.Errorf("failed to select chunks: %w", node.ErrChunkIndicesUnavailable)
+		}
+		return selectChunks(args.Get(2).([]uint32), chunks)
+	}
+	return chunks, args.Error(1)
+}
+
+func (m *MockStoreV2) AuditBundle(bundleKey []byte) ([]byte, bool, error) {
+	args := m.Called(bundleKey)
+	if args.Get(0) == nil {
+		return nil, args.Bool(1), args.Error(2)
+	}
+	return args.Get(0).([]byte), args.Bool(1), args.Error(2)
+}
+
+func (m *MockStoreV2) RepairBundles(bundles []*node.BundleToStore) error {
+	args := m.Called(bundles)
+	return args.Error(0)
+}
+
+func (m *MockStoreV2) SigningHistory() *node.SigningHistory {
+	return nil
+}
+
+func (m *MockStoreV2) DataAuditTable() litt.Table {
+	return nil
+}
+
 func (m *MockStoreV2) Stop() error {
 	return nil
 }
@@ -18,8 +18,11 @@ import (
 	"time"
 
 	"github.com/Layr-Labs/eigenda/api/clients/v2/relay"
+	validatorclient "github.com/Layr-Labs/eigenda/api/clients/v2/validator"
 	"github.com/Layr-Labs/eigenda/common/pprof"
 	"github.com/Layr-Labs/eigenda/common/pubip"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/encoding/kzg/prover"
 	"github.com/Layr-Labs/eigenda/encoding/kzg/verifier"
 	"github.com/prometheus/client_golang/prometheus"
 
@@ -82,6 +85,27 @@ type Node struct {
 
 	RelayClient atomic.Value
 
+	// PeerValidatorClient downloads chunks from other validators. It is used to recover the chunks assigned to this
+	// node for a blob when they can't be downloaded from any relay. If nil, chunks are only downloaded from relays.
+	PeerValidatorClient validatorclient.ValidatorClient
+	// ChunkProver re-derives the chunks assigned to this node from a blob recovered from other validators. Must be
+	// set if PeerValidatorClient is set.
+	ChunkProver encoding.Prover
+
+	// SigningHistory records the StoreChunks requests handled by the node. If nil, no history is kept.
+	SigningHistory *SigningHistory
+
+	// Maintenance tracks whether the node is in maintenance mode, in which case it stops accepting new batches.
+	Maintenance MaintenanceMode
+
+	// DataAuditor checks that the data of recently signed batches is still stored and intact. If nil, data is
+	// not audited.
+	DataAuditor *DataAuditor
+
+	// relayHealth tracks the health and latency of relays, and is used to choose which relay to download
+	// chunks from.
+	relayHealth relayHealthTracker
+
 	mu            sync.Mutex
 	CurrentSocket string
 
@@ -282,12 +306,12 @@ func NewNode(
 	var blobVersionParams *corev2.BlobVersionParameterMap
 	if config.EnableV2 {
 		ctx := context.Background()
-		// 12s per block
-		ttl := time.Duration(blockStaleMeasure+storeDurationBlocks) * 12 * time.Second
+		ttl := time.Duration(blockStaleMeasure+storeDurationBlocks) * config.BlockTime
 		n.ValidatorStore, err = NewValidatorStore(logger, config, time.Now, ttl, reg)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create new store v2: %w", err)
 		}
+		n.SigningHistory = n.ValidatorStore.SigningHistory()
 
 		blobParams, err := tx.GetAllVersionedBlobParams(ctx)
 		if err != nil {
@@ -314,6 +338,32 @@ func NewNode(
 
 		n.RelayClient.Store(relayClient)
 
+		if config.EnableValidatorChunkRecovery {
+			n.ChunkProver, err = prover.NewProver(&config.EncoderConfig, nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create prover for chunk recovery: %w", err)
+			}
+			n.PeerValidatorClient = validatorclient.NewValidatorClient(
+				logger, tx, cst, v, validatorclient.DefaultClientConfig(), nil)
+		}
+
+		if config.DataAuditPeriod > 0 {
+			// Only audit batches within the window the node is required to store them for.
+			auditWindow := time.Duration(storeDurationBlocks) * config.BlockTime
+			n.DataAuditor, err = NewDataAuditor(
+				n,
+				logger,
+				n.ValidatorStore.DataAuditTable(),
+				config.DataAuditPeriod,
+				config.DataAuditBatchesPerAudit,
+				config.DataAuditMaxTrackedBatches,
+				auditWindow,
+				time.Now)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create data auditor: %w", err)
+			}
+		}
+
 		blockNumber, err := tx.GetCurrentBlockNumber(ctx)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get block number: %w", err)
@@ -356,6 +406,23 @@ func (n *Node) Start(ctx context.Context) error {
 			_ = n.RefreshOnchainState(ctx)
 		}()
 		go n.checkNodeReachability(v2CheckPath)
+		if n.NodeApi != nil {
+			n.NodeApi.RegisterNewService(
+				storeChunksServiceID,
+				"StoreChunks",
+				"Accepts new batches from dispersers, down while the node is in maintenance mode",
+				nodeapi.ServiceStatusUp)
+		}
+		if n.Config.StartInMaintenance {
+			// No requests can be in flight yet, so this returns immediately.
+			err := n.EnterMaintenance(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to enter maintenance mode: %w", err)
+			}
+		}
+		if n.DataAuditor != nil {
+			go n.DataAuditor.Start(ctx)
+		}
 	}
 
 	// Build the socket based on the hostname/IP provided in the CLI
@@ -6,7 +6,7 @@ package node
 import (
 	"context"
 	"fmt"
-	"math/rand"
+	"time"
 
 	"github.com/Layr-Labs/eigenda/api/clients/v2/relay"
 	"github.com/Layr-Labs/eigenda/common"
@@ -15,25 +15,47 @@ import (
 	"github.com/gammazero/workerpool"
 )
 
-type requestMetadata struct {
+// blobDownload tracks the download of the chunks assigned to this node for a single blob.
+type blobDownload struct {
 	blobShardIndex int
-	assignment     corev2.Assignment
+	blobKey        corev2.BlobKey
+	chunkRequest   *relay.ChunkRequestByIndex
+	// The relays that the blob's chunks may be downloaded from.
+	relayKeys []corev2.RelayKey
+	// The relays that have already been asked for the blob's chunks.
+	triedRelays map[corev2.RelayKey]struct{}
+	// The parameters of the blob's version, needed to recover the blob's chunks from other validators.
+	blobParams *core.BlobVersionParameters
+	// The most recent error encountered while downloading the blob's chunks.
+	err error
 }
 type relayRequest struct {
 	chunkRequests []*relay.ChunkRequestByIndex
-	metadata      []*requestMetadata
+	downloads     []*blobDownload
 }
 type response struct {
-	metadata []*requestMetadata
-	bundles  [][]byte
-	err      error
+	relayKey  corev2.RelayKey
+	downloads []*blobDownload
+	bundles   [][]byte
+	latency   time.Duration
+	err       error
 }
 
 type RawBundle struct {
 	BlobCertificate *corev2.BlobCertificate
 	Bundle          []byte
+	// The global indices of the chunks in the bundle, i.e. the indices of this node's chunk assignment for the blob.
+	ChunkIndices []uint32
 }
 
+// DownloadBundles downloads the chunks assigned to this node for each blob in the batch. The chunks for each blob
+// are requested from one of the relays listed in the blob's certificate. If a request fails, then the chunks for
+// the affected blobs are requested again from other relays listed in their certificates.
+//
+// If every relay for a blob has failed and PeerValidatorClient is set, then the chunks for that blob are recovered
+// from other validators (see recoverBundlesFromValidators). Otherwise, an error is returned once every relay for some
+// blob has failed. The given operator state is only used to find this node's chunk assignments, and does not need
+// to contain operator sockets.
 func (n *Node) DownloadBundles(
 	ctx context.Context,
 	batch *corev2.Batch,
@@ -56,7 +78,7 @@ func (n *Node) DownloadBundles(
 
 	blobShards := make([]*corev2.BlobShard, len(batch.BlobCertificates))
 	rawBundles := make([]*RawBundle, len(batch.BlobCertificates))
-	requests := make(map[corev2.RelayKey]*relayRequest)
+	pending := make([]*blobDownload, 0, len(batch.BlobCertificates))
 	for i, cert := range batch.BlobCertificates {
 		blobKey, err := cert.BlobHeader.BlobKey()
 		if err != nil {
@@ -72,8 +94,6 @@ func (n *Node) DownloadBundles(
 		rawBundles[i] = &RawBundle{
 			BlobCertificate: cert,
 		}
-		relayIndex := rand.Intn(len(cert.RelayKeys))
-		relayKey := cert.RelayKeys[relayIndex]
 
 		blobParams, ok := blobVersionParams.Get(cert.BlobHeader.BlobVersion)
 		if !ok {
@@ -86,28 +106,233 @@ func (n *Node) DownloadBundles(
 			n.Logger.Errorf("failed to get assignment: %v", err)
 			continue
 		}
+		rawBundles[i].ChunkIndices = assgn.Indices
 
-		req, ok := requests[relayKey]
-		if !ok {
-			req = &relayRequest{
-				chunkRequests: make([]*relay.ChunkRequestByIndex, 0),
-				metadata:      make([]*requestMetadata, 0),
+		pending = append(pending, &blobDownload{
+			blobShardIndex: i,
+			blobKey:        blobKey,
+			chunkRequest: &relay.ChunkRequestByIndex{
+				BlobKey: blobKey,
+				Indices: assgn.Indices,
+			},
+			relayKeys:   cert.RelayKeys,
+			triedRelays: make(map[corev2.RelayKey]struct{}),
+			blobParams:  blobParams,
+		})
+	}
+
+	// Blobs whose chunks could not be downloaded from any relay.
+	exhausted := make([]*blobDownload, 0)
+
+	// Each iteration requests the chunks for all blobs that have not yet been downloaded. Blobs whose chunks could
+	// not be downloaded are retried in the next iteration using a relay that has not yet been tried for that blob.
+	for len(pending) > 0 {
+		probe.SetStage("download")
+
+		requests := make(map[corev2.RelayKey]*relayRequest)
+		now := time.Now()
+		for _, download := range pending {
+			relayKey, ok := n.relayHealth.chooseRelay(
+				download.relayKeys, download.triedRelays, n.Config.RelayFailureBackoff, now)
+			if !ok {
+				if n.PeerValidatorClient == nil {
+					return nil, nil, fmt.Errorf("failed to get chunks for blob %s from all %d relay(s): %w",
+						download.blobKey.Hex(), len(download.relayKeys), download.err)
+				}
+				exhausted = append(exhausted, download)
+				continue
+			}
+			download.triedRelays[relayKey] = struct{}{}
+
+			req, ok := requests[relayKey]
+			if !ok {
+				req = &relayRequest{
+					chunkRequests: make([]*relay.ChunkRequestByIndex, 0),
+					downloads:     make([]*blobDownload, 0),
+				}
+				requests[relayKey] = req
 			}
-			requests[relayKey] = req
+			// Chunks from one blob are requested to the same relay
+			req.chunkRequests = append(req.chunkRequests, download.chunkRequest)
+			req.downloads = append(req.downloads, download)
 		}
-		// Chunks from one blob are requested to the same relay
-		req.chunkRequests = append(req.chunkRequests, &relay.ChunkRequestByIndex{
-			BlobKey: blobKey,
-			Indices: assgn.Indices,
-		})
-		req.metadata = append(req.metadata, &requestMetadata{
-			blobShardIndex: i,
-			assignment:     assgn,
+
+		responses := n.requestChunksFromRelays(ctx, relayClient, requests)
+
+		probe.SetStage("deserialize")
+
+		pending = make([]*blobDownload, 0)
+		for _, resp := range responses {
+			if resp.err == nil && len(resp.bundles) != len(resp.downloads) {
+				resp.err = fmt.Errorf("number of bundles and metadata do not match (%d != %d)",
+					len(resp.bundles), len(resp.downloads))
+			}
+			if resp.err != nil {
+				n.Logger.Warnf("failed to get chunks for %d blob(s) from relay %d: %v",
+					len(resp.downloads), resp.relayKey, resp.err)
+				n.relayHealth.recordFailure(resp.relayKey, time.Now())
+				for _, download := range resp.downloads {
+					download.err = resp.err
+				}
+				pending = append(pending, resp.downloads...)
+				continue
+			}
+
+			relayHealthy := true
+			for j, bundle := range resp.bundles {
+				download := resp.downloads[j]
+				deserializedBundle, err := new(core.Bundle).Deserialize(bundle)
+				if err != nil {
+					n.Logger.Warnf("failed to deserialize bundle for blob %s from relay %d: %v",
+						download.blobKey.Hex(), resp.relayKey, err)
+					download.err = fmt.Errorf("failed to deserialize bundle: %w", err)
+					pending = append(pending, download)
+					relayHealthy = false
+					continue
+				}
+				blobShards[download.blobShardIndex].Bundle = deserializedBundle
+				rawBundles[download.blobShardIndex].Bundle = bundle
+			}
+
+			if relayHealthy {
+				n.relayHealth.recordSuccess(resp.relayKey, resp.latency)
+			} else {
+				n.relayHealth.recordFailure(resp.relayKey, time.Now())
+			}
+		}
+
+		if len(pending) > 0 && ctx.Err() != nil {
+			return nil, nil, fmt.Errorf("failed to get chunks from relays: %w", ctx.Err())
+		}
+	}
+
+	if len(exhausted) > 0 {
+		probe.SetStage("recover_from_validators")
+		err := n.recoverBundlesFromValidators(ctx, batch, exhausted, blobShards, rawBundles)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return blobShards, rawBundles, nil
+}
+
+// recoverBundlesFromValidators recovers the chunks of each blob that could not be downloaded from any relay, with at
+// most Config.ValidatorChunkRecoveryParallelism blobs recovered at a time. Operator sockets are needed to contact
+// other validators, so the operator state with sockets is only fetched once recovery is needed.
+func (n *Node) recoverBundlesFromValidators(
+	ctx context.Context,
+	batch *corev2.Batch,
+	exhausted []*blobDownload,
+	blobShards []*corev2.BlobShard,
+	rawBundles []*RawBundle,
+) error {
+
+	quorums := make(map[core.QuorumID]struct{})
+	for _, download := range exhausted {
+		for _, quorum := range blobShards[download.blobShardIndex].BlobCertificate.BlobHeader.QuorumNumbers {
+			quorums[quorum] = struct{}{}
+		}
+	}
+	quorumList := make([]core.QuorumID, 0, len(quorums))
+	for quorum := range quorums {
+		quorumList = append(quorumList, quorum)
+	}
+
+	operatorState, err := n.ChainState.GetOperatorStateWithSocket(
+		ctx, uint(batch.BatchHeader.ReferenceBlockNumber), quorumList)
+	if err != nil {
+		return fmt.Errorf("failed to get operator state with sockets: %w", err)
+	}
+
+	// Each task writes to a different index of blobShards, rawBundles, and errs.
+	errs := make([]error, len(exhausted))
+	pool := workerpool.New(n.Config.ValidatorChunkRecoveryParallelism)
+	for i, download := range exhausted {
+		pool.Submit(func() {
+			n.Logger.Warnf(
+				"failed to get chunks for blob %s from all %d relay(s), recovering them from other validators",
+				download.blobKey.Hex(), len(download.relayKeys))
+
+			cert := blobShards[download.blobShardIndex].BlobCertificate
+			bundle, rawBundle, err := n.recoverBundleFromValidators(ctx, cert, download, operatorState)
+			if err != nil {
+				errs[i] = fmt.Errorf(
+					"failed to get chunks for blob %s from all %d relay(s) (%v), and failed to recover them "+
+						"from other validators: %w", download.blobKey.Hex(), len(download.relayKeys), download.err, err)
+				return
+			}
+			blobShards[download.blobShardIndex].Bundle = bundle
+			rawBundles[download.blobShardIndex].Bundle = rawBundle
 		})
+	}
+	pool.StopWait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recoverBundleFromValidators recovers the chunks assigned to this node for a blob without the help of a relay.
+// Enough chunks to reconstruct the blob are downloaded from other validators, and then this node's chunks are
+// re-derived from the blob with the prover. The recovered chunks are not trusted, they are validated against the
+// blob's commitments along with the rest of the batch.
+func (n *Node) recoverBundleFromValidators(
+	ctx context.Context,
+	cert *corev2.BlobCertificate,
+	download *blobDownload,
+	operatorState *core.OperatorState,
+) (core.Bundle, []byte, error) {
+
+	if n.ChunkProver == nil {
+		return nil, nil, fmt.Errorf("chunk prover is not set")
+	}
+
+	blobHeader, err := cert.BlobHeader.GetBlobHeaderWithHashedPayment()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get blob header: %w", err)
+	}
+
+	blob, err := n.PeerValidatorClient.GetBlobWithOperatorState(ctx, blobHeader, operatorState, download.blobParams)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get blob from validators: %w", err)
+	}
+
+	encodingParams, err := corev2.GetEncodingParams(cert.BlobHeader.BlobCommitments.Length, download.blobParams)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get encoding params: %w", err)
+	}
 
+	frames, err := n.ChunkProver.GetFrames(blob, encodingParams)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get frames: %w", err)
 	}
 
-	probe.SetStage("download")
+	bundle := make(core.Bundle, 0, len(download.chunkRequest.Indices))
+	for _, index := range download.chunkRequest.Indices {
+		if int(index) >= len(frames) {
+			return nil, nil, fmt.Errorf("chunk index %d out of range, blob has %d chunks", index, len(frames))
+		}
+		bundle = append(bundle, frames[index])
+	}
+
+	rawBundle, err := bundle.Serialize()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to serialize bundle: %w", err)
+	}
+
+	return bundle, rawBundle, nil
+}
+
+// requestChunksFromRelays sends each request to its relay in parallel, and waits for all responses.
+func (n *Node) requestChunksFromRelays(
+	ctx context.Context,
+	relayClient relay.RelayClient,
+	requests map[corev2.RelayKey]*relayRequest,
+) []response {
 
 	bundleChan := make(chan response, len(requests))
 	for relayKey := range requests {
@@ -116,20 +341,14 @@ func (n *Node) DownloadBundles(
 		n.DownloadPool.Submit(func() {
 			ctxTimeout, cancel := context.WithTimeout(ctx, n.Config.ChunkDownloadTimeout)
 			defer cancel()
+			start := time.Now()
 			bundles, err := relayClient.GetChunksByIndex(ctxTimeout, relayKey, req.chunkRequests)
-			if err != nil {
-				n.Logger.Errorf("failed to get chunks from relays: %v", err)
-				bundleChan <- response{
-					metadata: nil,
-					bundles:  nil,
-					err:      err,
-				}
-				return
-			}
 			bundleChan <- response{
-				metadata: req.metadata,
-				bundles:  bundles,
-				err:      nil,
+				relayKey:  relayKey,
+				downloads: req.downloads,
+				bundles:   bundles,
+				latency:   time.Since(start),
+				err:       err,
 			}
 		})
 	}
@@ -138,34 +357,7 @@ func (n *Node) DownloadBundles(
 	for i := 0; i < len(requests); i++ {
 		responses[i] = <-bundleChan
 	}
-
-	probe.SetStage("deserialize")
-
-	var err error
-	for i := 0; i < len(requests); i++ {
-		resp := responses[i]
-		if resp.err != nil {
-			// TODO (cody-littley) this is flaky, and will fail if any relay fails. We should retry failures
-			return nil, nil, fmt.Errorf("failed to get chunks from relays: %v", resp.err)
-		}
-
-		if len(resp.bundles) != len(resp.metadata) {
-			return nil, nil, fmt.Errorf("number of bundles and metadata do not match (%d != %d)",
-				len(resp.bundles), len(resp.metadata))
-		}
-
-		for j, bundle := range resp.bundles {
-			metadata := resp.metadata[j]
-			blobShards[metadata.blobShardIndex].Bundle, err = new(core.Bundle).Deserialize(bundle)
-			if err != nil {
-				return nil, nil, fmt.Errorf("failed to deserialize bundle: %v", err)
-			}
-			rawBundles[metadata.blobShardIndex].Bundle = bundle
-
-		}
-	}
-
-	return blobShards, rawBundles, nil
+	return responses
 }
 
 func (n *Node) ValidateBatchV2(
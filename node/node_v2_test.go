@@ -10,9 +10,13 @@ import (
 	"github.com/Layr-Labs/eigenda/api/clients/v2/relay"
 	"github.com/docker/go-units"
 
+	clientsmock "github.com/Layr-Labs/eigenda/api/clients/v2/mock"
 	"github.com/Layr-Labs/eigenda/core"
 	v2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/encoding"
+	encmock "github.com/Layr-Labs/eigenda/encoding/mock"
 	nodemock "github.com/Layr-Labs/eigenda/node/mock"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -88,6 +92,125 @@ func TestDownloadBundlesFail(t *testing.T) {
 	require.Nil(t, rawBundles)
 }
 
+func TestDownloadBundlesRelayFailover(t *testing.T) {
+	c := newComponents(t, op0)
+	c.node.RelayClient.Store(c.relayClient)
+	c.node.Config.RelayFailureBackoff = time.Hour
+	ctx := context.Background()
+	blobKeys, batch, bundles := nodemock.MockBatch(t)
+
+	// Blob 1 can be served by relay 1 or relay 2, but relay 1 is broken.
+	batch.BlobCertificates[1].RelayKeys = []v2.RelayKey{1, 2}
+
+	bundles00Bytes, err := bundles[0][0].Serialize()
+	require.NoError(t, err)
+	bundles10Bytes, err := bundles[1][0].Serialize()
+	require.NoError(t, err)
+	bundles20Bytes, err := bundles[2][0].Serialize()
+	require.NoError(t, err)
+	c.relayClient.On("GetChunksByIndex", mock.Anything, v2.RelayKey(0), mock.Anything).Return([][]byte{bundles00Bytes, bundles20Bytes}, nil)
+	c.relayClient.On("GetChunksByIndex", mock.Anything, v2.RelayKey(1), mock.Anything).Return(nil, fmt.Errorf("relay server error"))
+	c.relayClient.On("GetChunksByIndex", mock.Anything, v2.RelayKey(2), mock.Anything).Return([][]byte{bundles10Bytes}, nil).Run(func(args mock.Arguments) {
+		requests := args.Get(2).([]*relay.ChunkRequestByIndex)
+		require.Len(t, requests, 1)
+		require.Equal(t, blobKeys[1], requests[0].BlobKey)
+	})
+
+	state, err := c.node.ChainState.GetOperatorStateByOperator(ctx, uint(10), op0)
+	require.NoError(t, err)
+
+	relayCalls := func(relayKey v2.RelayKey) int {
+		count := 0
+		for _, call := range c.relayClient.Calls {
+			if call.Method == "GetChunksByIndex" && call.Arguments.Get(1).(v2.RelayKey) == relayKey {
+				count++
+			}
+		}
+		return count
+	}
+
+	for i := 0; i < 10; i++ {
+		blobShards, rawBundles, err := c.node.DownloadBundles(ctx, batch, state, nil)
+		require.NoError(t, err)
+		require.Len(t, blobShards, 3)
+		require.Len(t, rawBundles, 3)
+		for j := range blobShards {
+			require.NotNil(t, blobShards[j].Bundle)
+			require.NotNil(t, rawBundles[j].Bundle)
+		}
+		require.Equal(t, bundles10Bytes, rawBundles[1].Bundle)
+	}
+
+	// Once relay 1 has failed, it should be avoided until the backoff period has elapsed.
+	require.LessOrEqual(t, relayCalls(1), 1)
+	require.Equal(t, 10, relayCalls(2))
+}
+
+func TestDownloadBundlesRecoverFromValidators(t *testing.T) {
+	c := newComponents(t, op0)
+	c.node.RelayClient.Store(c.relayClient)
+	ctx := context.Background()
+	_, batch, bundles := nodemock.MockBatch(t)
+	cert := batch.BlobCertificates[1]
+	cert.BlobHeader.BlobCommitments.Length = 16
+
+	// The only relay for blob 1 is broken.
+	bundles00Bytes, err := bundles[0][0].Serialize()
+	require.NoError(t, err)
+	bundles20Bytes, err := bundles[2][0].Serialize()
+	require.NoError(t, err)
+	c.relayClient.On("GetChunksByIndex", mock.Anything, v2.RelayKey(0), mock.Anything).Return([][]byte{bundles00Bytes, bundles20Bytes}, nil)
+	c.relayClient.On("GetChunksByIndex", mock.Anything, v2.RelayKey(1), mock.Anything).Return(nil, fmt.Errorf("relay server error"))
+
+	state, err := c.node.ChainState.GetOperatorStateByOperator(ctx, uint(10), op0)
+	require.NoError(t, err)
+
+	// Without a peer validator client, the chunks can't be recovered.
+	_, _, err = c.node.DownloadBundles(ctx, batch, state, nil)
+	require.Error(t, err)
+
+	blob := []byte("blob data")
+	encodingParams, err := v2.GetEncodingParams(cert.BlobHeader.BlobCommitments.Length, blobParams)
+	require.NoError(t, err)
+	frames := make([]*encoding.Frame, encodingParams.NumChunks)
+	for i := range frames {
+		frames[i] = &encoding.Frame{
+			Proof:  bundles[1][0][0].Proof,
+			Coeffs: []encoding.Symbol{fr.NewElement(uint64(i))},
+		}
+	}
+	assignment, err := v2.GetAssignmentForBlob(state, blobParams, cert.BlobHeader.QuorumNumbers, op0)
+	require.NoError(t, err)
+	expectedBundle := make(core.Bundle, 0, len(assignment.Indices))
+	for _, index := range assignment.Indices {
+		expectedBundle = append(expectedBundle, frames[index])
+	}
+
+	peerClient := &clientsmock.MockRetrievalClient{}
+	peerClient.On("GetBlobWithOperatorState", mock.Anything, mock.Anything, mock.Anything, blobParams).Return(blob, nil)
+	prover := &encmock.MockEncoder{}
+	prover.On("GetFrames", blob, encodingParams).Return(frames, nil)
+	c.node.PeerValidatorClient = peerClient
+	c.node.ChunkProver = prover
+
+	blobShards, rawBundles, err := c.node.DownloadBundles(ctx, batch, state, nil)
+	require.NoError(t, err)
+	require.Len(t, blobShards, 3)
+	require.Len(t, rawBundles, 3)
+	require.Equal(t, expectedBundle, blobShards[1].Bundle)
+	expectedBundleBytes, err := expectedBundle.Serialize()
+	require.NoError(t, err)
+	require.Equal(t, expectedBundleBytes, rawBundles[1].Bundle)
+	peerClient.AssertNumberOfCalls(t, "GetBlobWithOperatorState", 1)
+
+	// If the blob can't be recovered from other validators either, an error is returned.
+	peerClient = &clientsmock.MockRetrievalClient{}
+	peerClient.On("GetBlobWithOperatorState", mock.Anything, mock.Anything, mock.Anything, blobParams).Return(nil, fmt.Errorf("not enough chunks"))
+	c.node.PeerValidatorClient = peerClient
+	_, _, err = c.node.DownloadBundles(ctx, batch, state, nil)
+	require.Error(t, err)
+}
+
 func TestDownloadBundlesOnlyParticipatingQuorums(t *testing.T) {
 	// Operator 3 is not participating in quorum 2, so it should only download bundles for quorums 0 and 1
 	c := newComponents(t, op3)
@@ -0,0 +1,123 @@
+package node
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+)
+
+// relayLatencyWeight is the weight given to the most recent latency sample when updating a relay's average latency.
+const relayLatencyWeight = 0.2
+
+// relayHealth describes the recent behavior of a single relay.
+type relayHealth struct {
+	// The number of requests to the relay that have failed since the last successful request.
+	consecutiveFailures int
+	// The time of the most recent failed request.
+	lastFailure time.Time
+	// An exponential moving average of the latency of successful requests. Zero if no request has succeeded yet.
+	averageLatency time.Duration
+}
+
+// relayHealthTracker tracks the health and latency of relays, so that chunk downloads can avoid relays that are
+// known to be failing and prefer relays that respond quickly. The zero value is ready to use, and all methods
+// are thread safe.
+type relayHealthTracker struct {
+	lock   sync.Mutex
+	relays map[corev2.RelayKey]*relayHealth
+}
+
+// getHealth returns the health of a relay, creating an entry if one does not exist. The caller must hold the lock.
+func (t *relayHealthTracker) getHealth(relayKey corev2.RelayKey) *relayHealth {
+	if t.relays == nil {
+		t.relays = make(map[corev2.RelayKey]*relayHealth)
+	}
+	health, ok := t.relays[relayKey]
+	if !ok {
+		health = &relayHealth{}
+		t.relays[relayKey] = health
+	}
+	return health
+}
+
+// recordSuccess records a successful request to a relay.
+func (t *relayHealthTracker) recordSuccess(relayKey corev2.RelayKey, latency time.Duration) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	health := t.getHealth(relayKey)
+	health.consecutiveFailures = 0
+	if health.averageLatency == 0 {
+		health.averageLatency = latency
+	} else {
+		health.averageLatency = time.Duration(
+			relayLatencyWeight*float64(latency) + (1-relayLatencyWeight)*float64(health.averageLatency))
+	}
+}
+
+// recordFailure records a failed request to a relay.
+func (t *relayHealthTracker) recordFailure(relayKey corev2.RelayKey, now time.Time) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	health := t.getHealth(relayKey)
+	health.consecutiveFailures++
+	health.lastFailure = now
+}
+
+// chooseRelay selects one of the candidate relays, skipping relays in the excluded set. Returns false if every
+// candidate is excluded.
+//
+// A relay that has failed within the last backoff period is only chosen if all other candidates have also recently
+// failed, in which case the relay with the fewest consecutive failures is chosen. Otherwise, two healthy relays are
+// picked at random and the one with the lower average latency is chosen. This spreads load across relays while
+// still favoring fast ones. Relays that have not yet been measured are favored, so that they get measured.
+func (t *relayHealthTracker) chooseRelay(
+	candidates []corev2.RelayKey,
+	excluded map[corev2.RelayKey]struct{},
+	backoff time.Duration,
+	now time.Time,
+) (corev2.RelayKey, bool) {
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	healthy := make([]corev2.RelayKey, 0, len(candidates))
+	var leastFailing *corev2.RelayKey
+	leastFailures := 0
+	for _, candidate := range candidates {
+		if _, ok := excluded[candidate]; ok {
+			continue
+		}
+		health := t.getHealth(candidate)
+		if health.consecutiveFailures == 0 || now.Sub(health.lastFailure) >= backoff {
+			healthy = append(healthy, candidate)
+			continue
+		}
+		if leastFailing == nil || health.consecutiveFailures < leastFailures {
+			relayKey := candidate
+			leastFailing = &relayKey
+			leastFailures = health.consecutiveFailures
+		}
+	}
+
+	switch len(healthy) {
+	case 0:
+		if leastFailing == nil {
+			return 0, false
+		}
+		return *leastFailing, true
+	case 1:
+		return healthy[0], true
+	}
+
+	choices := rand.Perm(len(healthy))
+	first := healthy[choices[0]]
+	second := healthy[choices[1]]
+	if t.relays[second].averageLatency < t.relays[first].averageLatency {
+		return second, true
+	}
+	return first, true
+}
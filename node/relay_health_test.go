@@ -0,0 +1,53 @@
+package node
+
+import (
+	"testing"
+	"time"
+
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelayHealthTracker(t *testing.T) {
+	tracker := relayHealthTracker{}
+	now := time.Now()
+	backoff := time.Minute
+	candidates := []corev2.RelayKey{1, 2, 3}
+
+	// Excluded relays are never chosen.
+	for i := 0; i < 100; i++ {
+		relayKey, ok := tracker.chooseRelay(candidates, map[corev2.RelayKey]struct{}{1: {}, 2: {}}, backoff, now)
+		require.True(t, ok)
+		require.Equal(t, corev2.RelayKey(3), relayKey)
+	}
+	_, ok := tracker.chooseRelay(candidates, map[corev2.RelayKey]struct{}{1: {}, 2: {}, 3: {}}, backoff, now)
+	require.False(t, ok)
+
+	// Relays that recently failed are avoided.
+	tracker.recordFailure(1, now)
+	tracker.recordFailure(2, now)
+	for i := 0; i < 100; i++ {
+		relayKey, ok := tracker.chooseRelay(candidates, nil, backoff, now)
+		require.True(t, ok)
+		require.Equal(t, corev2.RelayKey(3), relayKey)
+	}
+
+	// If every relay has recently failed, the one with the fewest consecutive failures is chosen.
+	tracker.recordFailure(2, now)
+	tracker.recordFailure(3, now)
+	tracker.recordFailure(3, now)
+	relayKey, ok := tracker.chooseRelay(candidates, nil, backoff, now)
+	require.True(t, ok)
+	require.Equal(t, corev2.RelayKey(1), relayKey)
+
+	// Once the backoff has elapsed, failed relays may be chosen again. Faster relays are preferred.
+	later := now.Add(backoff)
+	tracker.recordSuccess(1, time.Millisecond)
+	tracker.recordSuccess(2, time.Second)
+	tracker.recordSuccess(3, time.Second)
+	for i := 0; i < 100; i++ {
+		relayKey, ok := tracker.chooseRelay([]corev2.RelayKey{1, 2}, nil, backoff, later)
+		require.True(t, ok)
+		require.Equal(t, corev2.RelayKey(1), relayKey)
+	}
+}
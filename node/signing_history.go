@@ -0,0 +1,390 @@
+package node
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+const (
+	// The name of the littDB table containing the signing history.
+	signingHistoryTableName = "signing_history"
+	// The length of a signing history key: the index of the time bucket containing the time the request was
+	// received, followed by a sequence number within the bucket.
+	signingHistoryKeyLength = 16
+	// The length of time covered by a single signing history bucket. Queries look up each bucket in their time range,
+	// so buckets must be long enough that a query over the whole retention period doesn't visit too many of them.
+	signingHistoryBucketDuration = time.Minute
+)
+
+// SigningRecord describes a single StoreChunks request handled by the node, and whether the node signed the batch.
+type SigningRecord struct {
+	// The time when the request was received.
+	Time time.Time
+	// The hash of the header of the batch the node was asked to sign. Nil if the request was too malformed for the
+	// batch header hash to be computed.
+	BatchHeaderHash []byte
+	// The reference block number of the batch.
+	ReferenceBlockNumber uint64
+	// The keys of the blobs in the batch.
+	BlobKeys []corev2.BlobKey
+	// The time the node spent handling the request.
+	Latency time.Duration
+	// The ID of the disperser that sent the request.
+	DisperserID uint32
+	// True if the node signed the batch.
+	Signed bool
+	// If the node did not sign the batch, the reason why.
+	Error string
+}
+
+// signingRecordJSON is the serialized form of a SigningRecord. Blob keys are stored as hex strings.
+type signingRecordJSON struct {
+	Time                 time.Time
+	BatchHeaderHash      []byte
+	ReferenceBlockNumber uint64
+	BlobKeys             []string
+	Latency              time.Duration
+	DisperserID          uint32
+	Signed               bool
+	Error                string
+}
+
+// MarshalJSON encodes the record as JSON, with blob keys encoded as hex strings.
+func (r *SigningRecord) MarshalJSON() ([]byte, error) {
+	var blobKeys []string
+	for _, blobKey := range r.BlobKeys {
+		blobKeys = append(blobKeys, blobKey.Hex())
+	}
+	return json.Marshal(&signingRecordJSON{
+		Time:                 r.Time,
+		BatchHeaderHash:      r.BatchHeaderHash,
+		ReferenceBlockNumber: r.ReferenceBlockNumber,
+		BlobKeys:             blobKeys,
+		Latency:              r.Latency,
+		DisperserID:          r.DisperserID,
+		Signed:               r.Signed,
+		Error:                r.Error,
+	})
+}
+
+// UnmarshalJSON decodes a record encoded by MarshalJSON.
+func (r *SigningRecord) UnmarshalJSON(data []byte) error {
+	serialized := &signingRecordJSON{}
+	err := json.Unmarshal(data, serialized)
+	if err != nil {
+		return err
+	}
+
+	var blobKeys []corev2.BlobKey
+	for _, hexKey := range serialized.BlobKeys {
+		blobKey, err := corev2.HexToBlobKey(hexKey)
+		if err != nil {
+			return fmt.Errorf("invalid blob key %q: %w", hexKey, err)
+		}
+		blobKeys = append(blobKeys, blobKey)
+	}
+
+	*r = SigningRecord{
+		Time:                 serialized.Time,
+		BatchHeaderHash:      serialized.BatchHeaderHash,
+		ReferenceBlockNumber: serialized.ReferenceBlockNumber,
+		BlobKeys:             blobKeys,
+		Latency:              serialized.Latency,
+		DisperserID:          serialized.DisperserID,
+		Signed:               serialized.Signed,
+		Error:                serialized.Error,
+	}
+	return nil
+}
+
+// SigningHistory is a durable record of the StoreChunks requests handled by the node. Records are stored in a littDB
+// table next to the chunk data, and are deleted once they are older than the table's TTL.
+//
+// Records are keyed by the time bucket they were received in and their sequence number within the bucket, so that
+// queries can look up the buckets in their time range one at a time instead of scanning the whole table. The next
+// sequence number of each bucket within the retention period is kept in memory (and rebuilt from the table on
+// startup), so that queries read every allocated sequence number. A bucket may have gaps, e.g. if a write failed or
+// garbage collection deleted the bucket's earlier records first, and queries skip them. The table is flushed in the
+// background, so that recording a request doesn't wait for a disk sync. Records that were not yet flushed may be lost
+// in a crash.
+type SigningHistory struct {
+	logger logging.Logger
+	table  litt.Table
+
+	// The length of time records are kept for.
+	retention time.Duration
+
+	// Protects the fields below.
+	lock sync.Mutex
+	// The next sequence number of each bucket within the retention period that contains records. Buckets that are
+	// missing contain no records.
+	sequences map[uint64]uint64
+	// The latest bucket any record has been written to.
+	latestBucket uint64
+
+	// Signals the background goroutine that the table needs to be flushed.
+	flushRequests chan struct{}
+	// Closed to stop the background goroutine.
+	stop chan struct{}
+	// Closed once the background goroutine has stopped.
+	stopped chan struct{}
+	// Makes Stop idempotent.
+	stopOnce sync.Once
+}
+
+// NewSigningHistory creates a new SigningHistory backed by the given table. Records are kept for the given retention
+// period. Stop must be called to flush the last records once the history is no longer needed.
+func NewSigningHistory(
+	logger logging.Logger,
+	table litt.Table,
+	retention time.Duration) (*SigningHistory, error) {
+
+	if retention <= 0 {
+		return nil, fmt.Errorf("signing history retention must be positive, got %v", retention)
+	}
+	err := table.SetTTL(retention)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set TTL for signing history table: %w", err)
+	}
+
+	h := &SigningHistory{
+		logger:        logger.With("component", "SigningHistory"),
+		table:         table,
+		retention:     retention,
+		sequences:     make(map[uint64]uint64),
+		latestBucket:  signingHistoryBucket(time.Now()),
+		flushRequests: make(chan struct{}, 1),
+		stop:          make(chan struct{}),
+		stopped:       make(chan struct{}),
+	}
+
+	err = h.loadSequences()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load signing history sequence numbers: %w", err)
+	}
+
+	go h.flushLoop()
+
+	return h, nil
+}
+
+// signingHistoryBucket returns the index of the bucket containing the given time.
+func signingHistoryBucket(t time.Time) uint64 {
+	return uint64(t.UnixNano() / int64(signingHistoryBucketDuration))
+}
+
+// signingHistoryKey returns the key of the record with the given sequence number in the given bucket.
+func signingHistoryKey(bucket uint64, sequence uint64) []byte {
+	key := make([]byte, signingHistoryKeyLength)
+	binary.BigEndian.PutUint64(key[0:8], bucket)
+	binary.BigEndian.PutUint64(key[8:16], sequence)
+	return key
+}
+
+// loadSequences rebuilds the next sequence number of each bucket from the records in the table.
+func (h *SigningHistory) loadSequences() error {
+	return h.table.Iterate(func(key []byte, _ []byte) error {
+		if len(key) != signingHistoryKeyLength {
+			return fmt.Errorf("invalid signing record key length %d", len(key))
+		}
+		bucket := binary.BigEndian.Uint64(key[0:8])
+		sequence := binary.BigEndian.Uint64(key[8:16])
+		if sequence+1 > h.sequences[bucket] {
+			h.sequences[bucket] = sequence + 1
+		}
+		if bucket > h.latestBucket {
+			h.latestBucket = bucket
+		}
+		return nil
+	})
+}
+
+// oldestBucket returns the oldest bucket that may contain records within the retention period.
+func (h *SigningHistory) oldestBucket() uint64 {
+	return signingHistoryBucket(time.Now().Add(-h.retention))
+}
+
+// Record adds a record to the signing history. The record is written to the table, but the table is flushed in the
+// background.
+func (h *SigningHistory) Record(record *SigningRecord) error {
+	value, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to serialize signing record: %w", err)
+	}
+
+	bucket := signingHistoryBucket(record.Time)
+	if bucket < h.oldestBucket() {
+		// Queries never look this far back, and the bucket's sequence numbers are no longer tracked.
+		h.logger.Warn("dropping signing record older than the retention period", "time", record.Time)
+		return nil
+	}
+	sequence := h.nextSequence(bucket)
+
+	err = h.table.Put(signingHistoryKey(bucket, sequence), value)
+	if err != nil {
+		h.releaseSequence(bucket, sequence)
+		return fmt.Errorf("failed to store signing record: %w", err)
+	}
+
+	select {
+	case h.flushRequests <- struct{}{}:
+	default:
+		// A flush is already pending, and will include this record.
+	}
+	return nil
+}
+
+// nextSequence allocates the sequence number of a new record in the given bucket.
+func (h *SigningHistory) nextSequence(bucket uint64) uint64 {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	sequence := h.sequences[bucket]
+	h.sequences[bucket] = sequence + 1
+
+	if bucket > h.latestBucket {
+		h.latestBucket = bucket
+	}
+	oldestBucket := h.oldestBucket()
+	for tracked := range h.sequences {
+		if tracked < oldestBucket {
+			delete(h.sequences, tracked)
+		}
+	}
+
+	return sequence
+}
+
+// releaseSequence gives back a sequence number whose record could not be written, if no later sequence number in the
+// bucket has been allocated since. Otherwise the sequence number is left as a gap, which queries skip.
+func (h *SigningHistory) releaseSequence(bucket uint64, sequence uint64) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if h.sequences[bucket] == sequence+1 {
+		h.sequences[bucket] = sequence
+	}
+}
+
+// flushLoop flushes the table whenever records have been written, until Stop is called.
+func (h *SigningHistory) flushLoop() {
+	defer close(h.stopped)
+	for {
+		select {
+		case <-h.stop:
+			return
+		case <-h.flushRequests:
+			err := h.table.Flush()
+			if err != nil {
+				h.logger.Warn("failed to flush signing history", "err", err)
+			}
+		}
+	}
+}
+
+// Stop stops flushing the table in the background, and flushes any records that have not yet been flushed.
+func (h *SigningHistory) Stop() error {
+	h.stopOnce.Do(func() {
+		close(h.stop)
+	})
+	<-h.stopped
+
+	err := h.table.Flush()
+	if err != nil {
+		return fmt.Errorf("failed to flush signing history: %w", err)
+	}
+	return nil
+}
+
+// Query returns the records of requests received at or after start and before end, in the order they were received.
+// If end is the zero time, there is no upper bound. If batchHeaderHash is not empty, only records for that batch are
+// returned. At most limit records are returned, and the returned boolean is true if more records matched. Buckets are
+// read in time order, and reading stops as soon as more than limit records have matched.
+func (h *SigningHistory) Query(
+	start time.Time,
+	end time.Time,
+	batchHeaderHash []byte,
+	limit int) ([]*SigningRecord, bool, error) {
+
+	// Records older than the retention period have been deleted, so there is no need to look for them.
+	earliest := time.Now().Add(-h.retention)
+	if start.Before(earliest) {
+		start = earliest
+	}
+
+	h.lock.Lock()
+	lastBucket := h.latestBucket
+	h.lock.Unlock()
+	if !end.IsZero() {
+		endBucket := signingHistoryBucket(end)
+		if endBucket < lastBucket {
+			lastBucket = endBucket
+		}
+	}
+
+	matches := make([]*SigningRecord, 0)
+	for bucket := signingHistoryBucket(start); bucket <= lastBucket && len(matches) <= limit; bucket++ {
+		records, err := h.readBucket(bucket)
+		if err != nil {
+			return nil, false, err
+		}
+
+		for _, record := range records {
+			if record.Time.Before(start) || (!end.IsZero() && !record.Time.Before(end)) {
+				continue
+			}
+			if len(batchHeaderHash) > 0 && !bytes.Equal(batchHeaderHash, record.BatchHeaderHash) {
+				continue
+			}
+			matches = append(matches, record)
+		}
+	}
+
+	truncated := false
+	if len(matches) > limit {
+		matches = matches[:limit]
+		truncated = true
+	}
+	return matches, truncated, nil
+}
+
+// readBucket returns the records in a bucket, in the order they were received. Every sequence number allocated in the
+// bucket is read, and sequence numbers without a record are skipped.
+func (h *SigningHistory) readBucket(bucket uint64) ([]*SigningRecord, error) {
+	h.lock.Lock()
+	sequenceCount := h.sequences[bucket]
+	h.lock.Unlock()
+
+	records := make([]*SigningRecord, 0)
+	for sequence := uint64(0); sequence < sequenceCount; sequence++ {
+		value, exists, err := h.table.Get(signingHistoryKey(bucket, sequence))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read signing record: %w", err)
+		}
+		if !exists {
+			continue
+		}
+
+		record := &SigningRecord{}
+		err = json.Unmarshal(value, record)
+		if err != nil {
+			return nil, fmt.Errorf("failed to deserialize signing record: %w", err)
+		}
+		records = append(records, record)
+	}
+
+	// Requests that take longer to handle may be recorded after requests that were received later.
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Time.Before(records[j].Time)
+	})
+	return records, nil
+}
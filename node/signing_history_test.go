@@ -0,0 +1,71 @@
+package node
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/memtable"
+	"github.com/stretchr/testify/require"
+)
+
+// failingPutTable is a table whose Put fails while fail is set.
+type failingPutTable struct {
+	litt.Table
+	fail bool
+}
+
+func (t *failingPutTable) Put(key []byte, value []byte) error {
+	if t.fail {
+		return errors.New("put failed")
+	}
+	return t.Table.Put(key, value)
+}
+
+func TestSigningHistorySkipsGaps(t *testing.T) {
+	logger, err := common.NewLogger(common.DefaultTextLoggerConfig())
+	require.NoError(t, err)
+
+	now := time.Now()
+	bucket := signingHistoryBucket(now)
+
+	// The first record of the bucket was deleted (e.g. by garbage collection) before the node restarted.
+	table := memtable.NewMemTable(litt.DefaultConfigNoPaths(), signingHistoryTableName)
+	for sequence := uint64(1); sequence < 3; sequence++ {
+		value, err := json.Marshal(&SigningRecord{Time: now, DisperserID: uint32(sequence)})
+		require.NoError(t, err)
+		require.NoError(t, table.Put(signingHistoryKey(bucket, sequence), value))
+	}
+
+	failingTable := &failingPutTable{Table: table}
+	history, err := NewSigningHistory(logger, failingTable, time.Hour)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, history.Stop())
+	}()
+
+	// A failed write gives its sequence number back, and new records don't overwrite the existing ones.
+	failingTable.fail = true
+	require.Error(t, history.Record(&SigningRecord{Time: now, DisperserID: 100}))
+	failingTable.fail = false
+	require.NoError(t, history.Record(&SigningRecord{Time: now, DisperserID: 3}))
+
+	records, truncated, err := history.Query(now.Add(-time.Minute), time.Time{}, nil, 10)
+	require.NoError(t, err)
+	require.False(t, truncated)
+	disperserIDs := make([]uint32, 0, len(records))
+	for _, record := range records {
+		disperserIDs = append(disperserIDs, record.DisperserID)
+	}
+	require.Equal(t, []uint32{1, 2, 3}, disperserIDs)
+
+	exists, err := table.Exists(signingHistoryKey(bucket, 3))
+	require.NoError(t, err)
+	require.True(t, exists)
+	exists, err = table.Exists(signingHistoryKey(bucket, 4))
+	require.NoError(t, err)
+	require.False(t, exists)
+}
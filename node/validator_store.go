@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"crypto/rand"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -24,6 +25,12 @@ import (
 const (
 	// The name of the littDB table containing chunk data.
 	chunksTableName = "chunks"
+	// The name of the littDB table containing bundles that replace damaged bundles in the chunks table.
+	repairedChunksTableName = "repaired_chunks"
+	// The name of the littDB table containing the global chunk indices of the chunks in each bundle.
+	chunkIndicesTableName = "chunk_indices"
+	// The maximum number of times a single bundle may be repaired. Each repair adds a lookup to reads of the bundle.
+	maxBundleRepairs = 16
 	// The metrics prefix for littDB.
 	littDBMetricsPrefix = "node_littdb"
 )
@@ -34,6 +41,9 @@ type BundleToStore struct {
 	BundleKey []byte
 	// The binary bundle bytes.
 	BundleBytes []byte
+	// The global indices of the chunks in the bundle, in the order the chunks are stored in the bundle (i.e. the
+	// indices of the node's chunk assignment for the blob). Not recorded if nil.
+	ChunkIndices []uint32
 }
 
 // ValidatorStore encapsulates the database for storing batches of chunk data for the V2 validator node.
@@ -46,10 +56,49 @@ type ValidatorStore interface {
 	// The returned chunks are encoded in bundle format.
 	GetBundleData(bundleKey []byte) ([]byte, error)
 
+	// GetChunks returns the chunks of a blob with the given bundle key, decoded from the bundle format. If selectChunks
+	// is not nil, then it is used to choose which of the blob's chunks to return, and an error wrapping
+	// ErrChunkIndicesUnavailable is returned if the global indices of the blob's chunks were not recorded. The whole
+	// bundle is read, so the size of the whole bundle counts against the read rate limits regardless of which chunks
+	// are selected.
+	GetChunks(bundleKey []byte, selectChunks ChunkSelector) ([][]byte, error)
+
+	// AuditBundle returns the bundle with the given bundle key, for the purpose of checking that it is intact.
+	// Unlike GetBundleData, the read does not count against the GetChunks read rate limits, and a missing bundle is
+	// reported by returning false rather than an error.
+	AuditBundle(bundleKey []byte) (data []byte, exists bool, err error)
+
+	// RepairBundles stores bundles that replace missing or damaged bundles. Since stored data can't be overwritten,
+	// a bundle that replaces a damaged one is stored separately, and is returned instead of the damaged bundle by
+	// subsequent reads. A bundle may be repaired again if its replacement is damaged too, up to maxBundleRepairs
+	// times; the most recent repair is the one returned by reads.
+	RepairBundles(bundles []*BundleToStore) error
+
+	// SigningHistory returns the record of the StoreChunks requests handled by the node, which is stored in the same
+	// database as the chunks. Returns nil if the signing history is disabled.
+	SigningHistory() *SigningHistory
+
+	// DataAuditTable returns the table where the DataAuditor persists the batches it tracks, which is stored in the
+	// same database as the chunks.
+	DataAuditTable() litt.Table
+
 	// Stop stops the store.
 	Stop() error
 }
 
+// ChunkSelector chooses a subset of the chunks in a bundle. It is given all chunks in the bundle, in the order they
+// are stored, along with the global index of each chunk, and returns the chunks that should be returned to the
+// caller. If the requested chunks are not in the bundle, the returned error should wrap ErrInvalidChunkSelection.
+type ChunkSelector func(chunkIndices []uint32, chunks [][]byte) ([][]byte, error)
+
+// ErrInvalidChunkSelection is returned (wrapped) by GetChunks when a ChunkSelector requests chunks that are not in
+// the bundle, i.e. when the request itself is invalid.
+var ErrInvalidChunkSelection = errors.New("invalid chunk selection")
+
+// ErrChunkIndicesUnavailable is returned (wrapped) by GetChunks when chunks are selected from a bundle whose global
+// chunk indices were not recorded, e.g. because it was stored by an older version of the node.
+var ErrChunkIndicesUnavailable = errors.New("chunk indices unavailable")
+
 type validatorStore struct {
 	logger     logging.Logger
 	timeSource func() time.Time
@@ -60,6 +109,18 @@ type validatorStore struct {
 	// The table where chunks are stored in the littDB database.
 	chunkTable litt.Table
 
+	// The table where bundles that replace damaged bundles in chunkTable are stored. Checked before chunkTable.
+	repairedChunkTable litt.Table
+
+	// The table where the global chunk indices of each bundle are stored, keyed by bundle key.
+	chunkIndicesTable litt.Table
+
+	// The record of StoreChunks requests handled by the node. Nil if disabled.
+	signingHistory *SigningHistory
+
+	// The table where the DataAuditor persists the batches it tracks.
+	dataAuditTable litt.Table
+
 	// The length of time to store data in the database.
 	ttl time.Duration
 
@@ -114,6 +175,9 @@ func NewValidatorStore(
 	littConfig.MetricsNamespace = littDBMetricsPrefix
 	littConfig.Logger = logger
 	littConfig.DoubleWriteProtection = config.LittDBDoubleWriteProtection
+	if config.LittDBCacheEvictionPolicy != "" {
+		littConfig.CacheEvictionPolicy = config.LittDBCacheEvictionPolicy
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to create new litt config: %w", err)
 	}
@@ -128,6 +192,33 @@ func NewValidatorStore(
 		return nil, fmt.Errorf("failed to get chunks table: %w", err)
 	}
 
+	repairedChunkTable, err := littDB.GetTable(repairedChunksTableName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repaired chunks table: %w", err)
+	}
+
+	chunkIndicesTable, err := littDB.GetTable(chunkIndicesTableName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chunk indices table: %w", err)
+	}
+
+	var signingHistory *SigningHistory
+	if config.SigningHistoryRetention > 0 {
+		signingHistoryTable, err := littDB.GetTable(signingHistoryTableName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get signing history table: %w", err)
+		}
+		signingHistory, err = NewSigningHistory(logger, signingHistoryTable, config.SigningHistoryRetention)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create signing history: %w", err)
+		}
+	}
+
+	dataAuditTable, err := littDB.GetTable(dataAuditTableName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data audit table: %w", err)
+	}
+
 	maxMemory, err := memory.GetMaximumAvailableMemory()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get maximum available memory: %w", err)
@@ -177,6 +268,16 @@ func NewValidatorStore(
 		return nil, fmt.Errorf("failed to set TTL for chunks table: %w", err)
 	}
 
+	err = repairedChunkTable.SetTTL(ttl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set TTL for repaired chunks table: %w", err)
+	}
+
+	err = chunkIndicesTable.SetTTL(ttl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set TTL for chunk indices table: %w", err)
+	}
+
 	salt := [16]byte{}
 	_, err = rand.Read(salt[:])
 	if err != nil {
@@ -195,6 +296,10 @@ func NewValidatorStore(
 		timeSource:           timeSource,
 		littDB:               littDB,
 		chunkTable:           chunkTable,
+		repairedChunkTable:   repairedChunkTable,
+		chunkIndicesTable:    chunkIndicesTable,
+		signingHistory:       signingHistory,
+		dataAuditTable:       dataAuditTable,
 		ttl:                  ttl,
 		duplicateRequestLock: common.NewIndexLock(1024),
 		duplicateRequestSalt: salt,
@@ -216,6 +321,7 @@ func (s *validatorStore) StoreBatch(batchData []*BundleToStore) (uint64, error)
 	for _, batchDatum := range batchData {
 		bundleKeyBytes := batchDatum.BundleKey
 		bundleData := batchDatum.BundleBytes
+		chunkIndices := batchDatum.ChunkIndices
 
 		go func() {
 			// Grab a lock on the hash of the blob. This protects against duplicate writes of the same blob.
@@ -224,6 +330,12 @@ func (s *validatorStore) StoreBatch(batchData []*BundleToStore) (uint64, error)
 			s.duplicateRequestLock.Lock(lockIndex)
 			defer s.duplicateRequestLock.Unlock(lockIndex)
 
+			err := s.putChunkIndices(bundleKeyBytes, chunkIndices)
+			if err != nil {
+				writeCompleteChan <- err
+				return
+			}
+
 			exists, err := s.chunkTable.Exists(bundleKeyBytes[:])
 			if err != nil {
 				writeCompleteChan <- fmt.Errorf("failed to check existence: %v", err)
@@ -260,7 +372,12 @@ func (s *validatorStore) StoreBatch(batchData []*BundleToStore) (uint64, error)
 		return 0, fmt.Errorf("failed to write data")
 	}
 
-	err := s.chunkTable.Flush()
+	err := s.chunkIndicesTable.Flush()
+	if err != nil {
+		return 0, fmt.Errorf("failed to flush chunk indices table: %v", err)
+	}
+
+	err = s.chunkTable.Flush()
 	if err != nil {
 		return 0, fmt.Errorf("failed to flush chunk table: %v", err)
 	}
@@ -268,10 +385,53 @@ func (s *validatorStore) StoreBatch(batchData []*BundleToStore) (uint64, error)
 	return size, nil
 }
 
+// putChunkIndices records the global chunk indices of a bundle, unless they are nil or have already been recorded.
+// The caller must hold the bundle's duplicate request lock.
+func (s *validatorStore) putChunkIndices(bundleKey []byte, chunkIndices []uint32) error {
+	if chunkIndices == nil {
+		return nil
+	}
+
+	exists, err := s.chunkIndicesTable.Exists(bundleKey)
+	if err != nil {
+		return fmt.Errorf("failed to check existence of chunk indices: %v", err)
+	}
+	if exists {
+		return nil
+	}
+
+	err = s.chunkIndicesTable.Put(bundleKey, encodeChunkIndices(chunkIndices))
+	if err != nil {
+		return fmt.Errorf("failed to put chunk indices: %v", err)
+	}
+	return nil
+}
+
+// encodeChunkIndices serializes chunk indices as a sequence of big endian uint32s.
+func encodeChunkIndices(chunkIndices []uint32) []byte {
+	data := make([]byte, 4*len(chunkIndices))
+	for i, index := range chunkIndices {
+		binary.BigEndian.PutUint32(data[4*i:], index)
+	}
+	return data
+}
+
+// decodeChunkIndices deserializes chunk indices encoded by encodeChunkIndices.
+func decodeChunkIndices(data []byte) ([]uint32, error) {
+	if len(data)%4 != 0 {
+		return nil, fmt.Errorf("invalid chunk indices length %d", len(data))
+	}
+	chunkIndices := make([]uint32, len(data)/4)
+	for i := range chunkIndices {
+		chunkIndices[i] = binary.BigEndian.Uint32(data[4*i:])
+	}
+	return chunkIndices, nil
+}
+
 func (s *validatorStore) GetBundleData(bundleKey []byte) ([]byte, error) {
 
 	// Regardless of migration status, always check littDB first.
-	data, exists, err := s.getChunksLittDB(bundleKey)
+	data, exists, hot, err := s.getChunksLittDB(bundleKey)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get chunks: %v", err)
 	}
@@ -280,41 +440,233 @@ func (s *validatorStore) GetBundleData(bundleKey []byte) ([]byte, error) {
 		return nil, fmt.Errorf("failed to get chunks: not found")
 	}
 
+	s.debitReadRateLimiter(hot, len(bundleKey)+len(data))
+
 	return data, nil
 }
 
-func (s *validatorStore) getChunksLittDB(bundleKey []byte) (data []byte, exists bool, err error) {
+func (s *validatorStore) GetChunks(bundleKey []byte, selectChunks ChunkSelector) ([][]byte, error) {
+	data, exists, hot, err := s.getChunksLittDB(bundleKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chunks: %v", err)
+	}
+
+	if !exists {
+		return nil, fmt.Errorf("failed to get chunks: not found")
+	}
+
+	// The whole bundle was read, regardless of how many of its chunks are selected.
+	s.debitReadRateLimiter(hot, len(bundleKey)+len(data))
+
+	chunks, _, err := DecodeChunks(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode chunks: %w", err)
+	}
+
+	if selectChunks != nil {
+		indexData, exists, err := s.chunkIndicesTable.Get(bundleKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get chunk indices: %w", err)
+		}
+		if !exists {
+			return nil, fmt.Errorf("failed to select chunks: %w", ErrChunkIndicesUnavailable)
+		}
+		chunkIndices, err := decodeChunkIndices(indexData)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode chunk indices: %w", err)
+		}
+		if len(chunkIndices) != len(chunks) {
+			return nil, fmt.Errorf("bundle has %d chunks, but %d chunk indices are recorded",
+				len(chunks), len(chunkIndices))
+		}
+
+		chunks, err = selectChunks(chunkIndices, chunks)
+		if err != nil {
+			return nil, fmt.Errorf("failed to select chunks: %w", err)
+		}
+	}
+
+	return chunks, nil
+}
+
+// getChunksLittDB reads a bundle from littDB, and reports whether the read was served from the cache. The caller is
+// responsible for debiting the read rate limiters.
+func (s *validatorStore) getChunksLittDB(bundleKey []byte) (data []byte, exists bool, hot bool, err error) {
 
 	hotReadsExhausted := s.hotReadRateLimiter.Tokens() <= 0
 	if hotReadsExhausted {
 		// If hot reads are exhausted we do not allow cold reads either.
-		return nil, false, fmt.Errorf("read rate limit exhausted")
+		return nil, false, false, fmt.Errorf("read rate limit exhausted")
 	}
 
 	coldReadsExhausted := s.coldReadRateLimiter.Tokens() <= 0
 
-	bundle, exists, hot, err := s.chunkTable.CacheAwareGet(bundleKey, coldReadsExhausted)
+	// A repaired bundle takes precedence over the damaged bundle it replaces.
+	repairKey, _, err := s.latestRepairKey(bundleKey)
+	if err != nil {
+		return nil, false, false, fmt.Errorf("failed to find repaired bundle: %v", err)
+	}
+	var bundle []byte
+	if repairKey != nil {
+		bundle, exists, hot, err = s.repairedChunkTable.CacheAwareGet(repairKey, coldReadsExhausted)
+		if err != nil {
+			return nil, false, false, fmt.Errorf("failed to get repaired bundle: %v", err)
+		}
+	}
+	if !exists {
+		bundle, exists, hot, err = s.chunkTable.CacheAwareGet(bundleKey, coldReadsExhausted)
+	}
 	if err != nil {
-		return nil, false, fmt.Errorf("failed to get bundle: %v", err)
+		return nil, false, false, fmt.Errorf("failed to get bundle: %v", err)
 	}
 	if exists && bundle == nil {
 		// This can happen when the data is on disk but we've exhausted the cold read rate
-		return nil, false, fmt.Errorf("cold read rate limit exhausted")
+		return nil, false, false, fmt.Errorf("cold read rate limit exhausted")
+	}
+	if !exists {
+		return nil, false, false, nil
+	}
+
+	return bundle, true, hot, nil
+}
+
+func (s *validatorStore) AuditBundle(bundleKey []byte) ([]byte, bool, error) {
+	repairKey, _, err := s.latestRepairKey(bundleKey)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to find repaired bundle: %w", err)
+	}
+	if repairKey != nil {
+		bundle, exists, err := s.repairedChunkTable.Get(repairKey)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to get repaired bundle: %w", err)
+		}
+		if exists {
+			return bundle, true, nil
+		}
+	}
+
+	bundle, exists, err := s.chunkTable.Get(bundleKey)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get bundle: %w", err)
+	}
+	return bundle, exists, nil
+}
+
+func (s *validatorStore) RepairBundles(bundles []*BundleToStore) error {
+	for _, bundle := range bundles {
+		err := s.repairBundle(bundle)
+		if err != nil {
+			return fmt.Errorf("failed to repair bundle %x: %w", bundle.BundleKey, err)
+		}
+	}
+
+	err := s.chunkIndicesTable.Flush()
+	if err != nil {
+		return fmt.Errorf("failed to flush chunk indices table: %w", err)
+	}
+	err = s.chunkTable.Flush()
+	if err != nil {
+		return fmt.Errorf("failed to flush chunk table: %w", err)
+	}
+	err = s.repairedChunkTable.Flush()
+	if err != nil {
+		return fmt.Errorf("failed to flush repaired chunk table: %w", err)
+	}
+
+	return nil
+}
+
+// repairBundle stores a single bundle that replaces a missing or damaged bundle.
+func (s *validatorStore) repairBundle(bundle *BundleToStore) error {
+	// Use the same lock as StoreBatch, so that a repair doesn't race with a new write of the same bundle.
+	hash := util.HashKey(bundle.BundleKey, s.duplicateRequestSalt)
+	lockIndex := uint64(hash)
+	s.duplicateRequestLock.Lock(lockIndex)
+	defer s.duplicateRequestLock.Unlock(lockIndex)
+
+	err := s.putChunkIndices(bundle.BundleKey, bundle.ChunkIndices)
+	if err != nil {
+		return err
+	}
+
+	exists, err := s.chunkTable.Exists(bundle.BundleKey)
+	if err != nil {
+		return fmt.Errorf("failed to check existence: %w", err)
 	}
 	if !exists {
-		return nil, false, nil
+		// The bundle is missing, so it can be stored in the usual place.
+		err = s.chunkTable.Put(bundle.BundleKey, bundle.BundleBytes)
+		if err != nil {
+			return fmt.Errorf("failed to put data: %w", err)
+		}
+		return nil
+	}
+
+	_, repairs, err := s.latestRepairKey(bundle.BundleKey)
+	if err != nil {
+		return fmt.Errorf("failed to find repaired bundle: %w", err)
+	}
+	if repairs >= maxBundleRepairs {
+		return fmt.Errorf("bundle has already been repaired %d times", repairs)
 	}
 
-	// If we read the value, debit the rate limiters. This may cause us to exceed the rate limit, in which
-	// case the number of tokens will be negative. When this happens, we will not be able to read until
-	// we accumulate enough tokens to "pay off the debt".
+	err = s.repairedChunkTable.Put(repairedBundleKey(bundle.BundleKey, repairs+1), bundle.BundleBytes)
+	if err != nil {
+		return fmt.Errorf("failed to put repaired data: %w", err)
+	}
+	return nil
+}
+
+// latestRepairKey returns the key in the repaired chunk table of the most recent repair of a bundle, and the number
+// of times the bundle has been repaired. Returns a nil key if the bundle has never been repaired.
+func (s *validatorStore) latestRepairKey(bundleKey []byte) ([]byte, int, error) {
+	var latest []byte
+	repairs := 0
+	for repairs < maxBundleRepairs {
+		key := repairedBundleKey(bundleKey, repairs+1)
+		exists, err := s.repairedChunkTable.Exists(key)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to check existence of repaired bundle: %w", err)
+		}
+		if !exists {
+			break
+		}
+		latest = key
+		repairs++
+	}
+	return latest, repairs, nil
+}
+
+// repairedBundleKey returns the key in the repaired chunk table of the given repair of a bundle, counting from 1. The
+// first repair is stored under the bundle key itself, and later repairs under the bundle key followed by the repair
+// number.
+func repairedBundleKey(bundleKey []byte, repair int) []byte {
+	if repair == 1 {
+		return bundleKey
+	}
+	key := make([]byte, len(bundleKey)+4)
+	copy(key, bundleKey)
+	binary.BigEndian.PutUint32(key[len(bundleKey):], uint32(repair))
+	return key
+}
+
+func (s *validatorStore) SigningHistory() *SigningHistory {
+	return s.signingHistory
+}
+
+func (s *validatorStore) DataAuditTable() litt.Table {
+	return s.dataAuditTable
+}
+
+// debitReadRateLimiter debits the hot or cold read rate limiter for a read of the given size. This may cause us to
+// exceed the rate limit, in which case the number of tokens will be negative. When this happens, we will not be able
+// to read until we accumulate enough tokens to "pay off the debt".
+func (s *validatorStore) debitReadRateLimiter(hot bool, size int) {
 	if hot {
-		s.hotReadRateLimiter.ReserveN(time.Now(), len(bundleKey)+len(bundle))
+		s.hotReadRateLimiter.ReserveN(time.Now(), size)
 	} else {
-		s.coldReadRateLimiter.ReserveN(time.Now(), len(bundleKey)+len(bundle))
+		s.coldReadRateLimiter.ReserveN(time.Now(), size)
 	}
-
-	return bundle, true, nil
 }
 
 func BundleKey(blobKey corev2.BlobKey, quorumID core.QuorumID) ([]byte, error) {
@@ -327,6 +679,13 @@ func BundleKey(blobKey corev2.BlobKey, quorumID core.QuorumID) ([]byte, error) {
 }
 
 func (s *validatorStore) Stop() error {
+	if s.signingHistory != nil {
+		err := s.signingHistory.Stop()
+		if err != nil {
+			return fmt.Errorf("failed to stop signing history: %w", err)
+		}
+	}
+
 	if s.littDB != nil {
 		err := s.littDB.Close()
 		if err != nil {
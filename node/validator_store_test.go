@@ -1,11 +1,16 @@
 package node
 
 import (
+	"encoding/json"
 	"testing"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/testutils/random"
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
 	"github.com/docker/go-units"
 	"github.com/stretchr/testify/require"
 )
@@ -218,3 +223,284 @@ func TestDoubleInsertionLittDB(t *testing.T) {
 	err = store.Stop()
 	require.NoError(t, err)
 }
+
+func TestGetChunks(t *testing.T) {
+	testDir := t.TempDir()
+
+	logger, err := common.NewLogger(common.DefaultTextLoggerConfig())
+	require.NoError(t, err)
+
+	// Tokens are replenished at one byte per second, so the rate limiter balance only changes due to reads.
+	burstMB := 1.0
+	config := &Config{
+		GetChunksHotCacheReadLimitMB:  1.0 / units.MiB,
+		GetChunksHotBurstLimitMB:      burstMB,
+		GetChunksColdCacheReadLimitMB: 1.0 / units.MiB,
+		GetChunksColdBurstLimitMB:     burstMB,
+		LittDBStoragePaths:            []string{testDir},
+	}
+
+	store, err := NewValidatorStore(logger, config, time.Now, 2*time.Hour, nil)
+	require.NoError(t, err)
+
+	bundle := make(core.Bundle, 0, 8)
+	expectedChunks := make([][]byte, 0, 8)
+	chunkIndices := make([]uint32, 0, 8)
+	for i := 0; i < 8; i++ {
+		chunkIndices = append(chunkIndices, uint32(3*i+1))
+		frame := &encoding.Frame{
+			Coeffs: make([]encoding.Symbol, 4),
+		}
+		for j := range frame.Coeffs {
+			frame.Coeffs[j] = fr.NewElement(uint64(i*len(frame.Coeffs) + j))
+		}
+		bundle = append(bundle, frame)
+
+		chunk, err := frame.SerializeGnark()
+		require.NoError(t, err)
+		expectedChunks = append(expectedChunks, chunk)
+	}
+	bundleBytes, err := bundle.Serialize()
+	require.NoError(t, err)
+	bundleKey := []byte("bundle")
+	// A bundle stored without its chunk indices, e.g. by an older version of the node.
+	unindexedBundleKey := []byte("unindexed")
+
+	_, err = store.StoreBatch([]*BundleToStore{
+		{BundleKey: bundleKey, BundleBytes: bundleBytes, ChunkIndices: chunkIndices},
+		{BundleKey: unindexedBundleKey, BundleBytes: bundleBytes},
+	})
+	require.NoError(t, err)
+
+	chunks, err := store.GetChunks(bundleKey, nil)
+	require.NoError(t, err)
+	require.Equal(t, expectedChunks, chunks)
+
+	selected := []int{5, 1, 6}
+	chunks, err = store.GetChunks(bundleKey, func(indices []uint32, chunks [][]byte) ([][]byte, error) {
+		require.Equal(t, chunkIndices, indices)
+		subset := make([][]byte, 0, len(selected))
+		for _, index := range selected {
+			subset = append(subset, chunks[index])
+		}
+		return subset, nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{expectedChunks[5], expectedChunks[1], expectedChunks[6]}, chunks)
+
+	// The whole bundle is read from the DB, so it counts against the rate limit even if only some chunks are returned.
+	expectedDebit := 2 * (len(bundleKey) + len(bundleBytes))
+	hotLimiter := store.(*validatorStore).hotReadRateLimiter
+	coldLimiter := store.(*validatorStore).coldReadRateLimiter
+	debit := 2*burstMB*units.MiB - hotLimiter.Tokens() - coldLimiter.Tokens()
+	require.InDelta(t, float64(expectedDebit), debit, 10)
+
+	_, err = store.GetChunks([]byte("missing"), nil)
+	require.Error(t, err)
+
+	// All chunks of a bundle without recorded chunk indices can be read, but chunks can't be selected from it.
+	chunks, err = store.GetChunks(unindexedBundleKey, nil)
+	require.NoError(t, err)
+	require.Equal(t, expectedChunks, chunks)
+	_, err = store.GetChunks(unindexedBundleKey, func(indices []uint32, chunks [][]byte) ([][]byte, error) {
+		return chunks, nil
+	})
+	require.ErrorIs(t, err, ErrChunkIndicesUnavailable)
+
+	err = store.Stop()
+	require.NoError(t, err)
+}
+
+func TestRepairBundles(t *testing.T) {
+	rand := random.NewTestRandom()
+	testDir := t.TempDir()
+
+	logger, err := common.NewLogger(common.DefaultTextLoggerConfig())
+	require.NoError(t, err)
+
+	config := &Config{
+		GetChunksHotCacheReadLimitMB:  units.GiB,
+		GetChunksHotBurstLimitMB:      units.GiB,
+		GetChunksColdCacheReadLimitMB: units.GiB,
+		GetChunksColdBurstLimitMB:     units.GiB,
+		LittDBStoragePaths:            []string{testDir},
+	}
+
+	store, err := NewValidatorStore(logger, config, time.Now, 2*time.Hour, nil)
+	require.NoError(t, err)
+
+	missingKey := rand.PrintableBytes(32)
+	damagedKey := rand.PrintableBytes(32)
+	damagedBytes := rand.PrintableVariableBytes(1, 64)
+
+	_, err = store.StoreBatch([]*BundleToStore{{BundleKey: damagedKey, BundleBytes: damagedBytes}})
+	require.NoError(t, err)
+
+	_, exists, err := store.AuditBundle(missingKey)
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	data, exists, err := store.AuditBundle(damagedKey)
+	require.NoError(t, err)
+	require.True(t, exists)
+	require.Equal(t, damagedBytes, data)
+
+	missingRepair := rand.PrintableVariableBytes(1, 64)
+	damagedRepair := rand.PrintableVariableBytes(1, 64)
+	err = store.RepairBundles([]*BundleToStore{
+		{BundleKey: missingKey, BundleBytes: missingRepair},
+		{BundleKey: damagedKey, BundleBytes: damagedRepair},
+	})
+	require.NoError(t, err)
+
+	// Reads should return the repaired data.
+	data, exists, err = store.AuditBundle(missingKey)
+	require.NoError(t, err)
+	require.True(t, exists)
+	require.Equal(t, missingRepair, data)
+	data, err = store.GetBundleData(missingKey)
+	require.NoError(t, err)
+	require.Equal(t, missingRepair, data)
+
+	data, exists, err = store.AuditBundle(damagedKey)
+	require.NoError(t, err)
+	require.True(t, exists)
+	require.Equal(t, damagedRepair, data)
+	data, err = store.GetBundleData(damagedKey)
+	require.NoError(t, err)
+	require.Equal(t, damagedRepair, data)
+
+	// A bundle can be repaired again, and reads return the most recent repair.
+	for i := 1; i < maxBundleRepairs; i++ {
+		damagedRepair = rand.PrintableVariableBytes(1, 64)
+		err = store.RepairBundles([]*BundleToStore{{BundleKey: damagedKey, BundleBytes: damagedRepair}})
+		require.NoError(t, err)
+
+		data, exists, err = store.AuditBundle(damagedKey)
+		require.NoError(t, err)
+		require.True(t, exists)
+		require.Equal(t, damagedRepair, data)
+		data, err = store.GetBundleData(damagedKey)
+		require.NoError(t, err)
+		require.Equal(t, damagedRepair, data)
+	}
+
+	// The number of repairs of a single bundle is limited.
+	err = store.RepairBundles([]*BundleToStore{{BundleKey: damagedKey, BundleBytes: damagedRepair}})
+	require.Error(t, err)
+
+	err = store.Stop()
+	require.NoError(t, err)
+}
+
+func TestSigningHistory(t *testing.T) {
+	rand := random.NewTestRandom()
+	testDir := t.TempDir()
+
+	logger, err := common.NewLogger(common.DefaultTextLoggerConfig())
+	require.NoError(t, err)
+
+	config := &Config{
+		GetChunksHotCacheReadLimitMB:  units.GiB,
+		GetChunksHotBurstLimitMB:      units.GiB,
+		GetChunksColdCacheReadLimitMB: units.GiB,
+		GetChunksColdBurstLimitMB:     units.GiB,
+		LittDBStoragePaths:            []string{testDir},
+		SigningHistoryRetention:       time.Hour,
+	}
+
+	store, err := NewValidatorStore(logger, config, time.Now, 2*time.Hour, nil)
+	require.NoError(t, err)
+	history := store.SigningHistory()
+	require.NotNil(t, history)
+
+	start := time.Now()
+	records := make([]*SigningRecord, 10)
+	for i := range records {
+		records[i] = &SigningRecord{
+			Time:                 start.Add(time.Duration(i) * time.Second),
+			BatchHeaderHash:      rand.Bytes(32),
+			ReferenceBlockNumber: rand.Uint64(),
+			BlobKeys:             []corev2.BlobKey{corev2.BlobKey(rand.Bytes(32))},
+			Latency:              time.Duration(rand.Int63n(int64(time.Second))),
+			DisperserID:          rand.Uint32(),
+			Signed:               i%2 == 0,
+		}
+		if !records[i].Signed {
+			records[i].Error = "failed to validate batch"
+		}
+	}
+
+	// Insert records out of order. Queries should return them in the order they were received.
+	for _, i := range rand.Perm(len(records)) {
+		err = history.Record(records[i])
+		require.NoError(t, err)
+	}
+
+	requireRecordsEqual := func(expected []*SigningRecord, actual []*SigningRecord) {
+		require.Len(t, actual, len(expected))
+		for i := range expected {
+			require.True(t, expected[i].Time.Equal(actual[i].Time))
+			require.Equal(t, expected[i].BatchHeaderHash, actual[i].BatchHeaderHash)
+			require.Equal(t, expected[i].ReferenceBlockNumber, actual[i].ReferenceBlockNumber)
+			require.Equal(t, expected[i].BlobKeys, actual[i].BlobKeys)
+			require.Equal(t, expected[i].Latency, actual[i].Latency)
+			require.Equal(t, expected[i].DisperserID, actual[i].DisperserID)
+			require.Equal(t, expected[i].Signed, actual[i].Signed)
+			require.Equal(t, expected[i].Error, actual[i].Error)
+		}
+	}
+
+	// Query everything.
+	result, truncated, err := history.Query(time.Time{}, time.Time{}, nil, 100)
+	require.NoError(t, err)
+	require.False(t, truncated)
+	requireRecordsEqual(records, result)
+
+	// Query a time range. The start is inclusive, the end is exclusive.
+	result, truncated, err = history.Query(records[3].Time, records[7].Time, nil, 100)
+	require.NoError(t, err)
+	require.False(t, truncated)
+	requireRecordsEqual(records[3:7], result)
+
+	// Query with a limit.
+	result, truncated, err = history.Query(records[2].Time, time.Time{}, nil, 3)
+	require.NoError(t, err)
+	require.True(t, truncated)
+	requireRecordsEqual(records[2:5], result)
+
+	// Query a single batch.
+	result, truncated, err = history.Query(time.Time{}, time.Time{}, records[5].BatchHeaderHash, 100)
+	require.NoError(t, err)
+	require.False(t, truncated)
+	requireRecordsEqual(records[5:6], result)
+
+	// Blob keys are serialized as hex strings.
+	serialized, err := json.Marshal(records[0])
+	require.NoError(t, err)
+	require.Contains(t, string(serialized), records[0].BlobKeys[0].Hex())
+
+	// Records survive a restart, and records written after the restart don't replace them.
+	err = store.Stop()
+	require.NoError(t, err)
+	store, err = NewValidatorStore(logger, config, time.Now, 2*time.Hour, nil)
+	require.NoError(t, err)
+	history = store.SigningHistory()
+
+	records = append(records, &SigningRecord{
+		Time:                 records[len(records)-1].Time,
+		BatchHeaderHash:      rand.Bytes(32),
+		ReferenceBlockNumber: rand.Uint64(),
+		Signed:               true,
+	})
+	err = history.Record(records[len(records)-1])
+	require.NoError(t, err)
+
+	result, truncated, err = history.Query(time.Time{}, time.Time{}, nil, 100)
+	require.NoError(t, err)
+	require.False(t, truncated)
+	requireRecordsEqual(records, result)
+
+	err = store.Stop()
+	require.NoError(t, err)
+}
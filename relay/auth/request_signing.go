@@ -1,11 +1,14 @@
 package auth
 
 import (
+	"crypto/ecdsa"
 	"fmt"
 
 	pb "github.com/Layr-Labs/eigenda/api/grpc/relay"
 	"github.com/Layr-Labs/eigenda/api/hashing"
 	"github.com/Layr-Labs/eigenda/core"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 )
 
 // SignGetChunksRequest signs the given GetChunksRequest with the given private key. Does not
@@ -18,3 +21,60 @@ func SignGetChunksRequest(keys *core.KeyPair, request *pb.GetChunksRequest) ([]b
 	signature := keys.SignMessage(([32]byte)(hash))
 	return signature.Serialize(), nil
 }
+
+// SignGetBlobRequest signs the given GetBlobRequest with the given account key. Does not
+// write the signature into the request.
+func SignGetBlobRequest(key *ecdsa.PrivateKey, request *pb.GetBlobRequest) ([]byte, error) {
+	hash, err := hashing.HashGetBlobRequest(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash request: %w", err)
+	}
+	return signHash(key, hash)
+}
+
+// RecoverGetBlobRequestSigner returns the account that signed the given GetBlobRequest, along with the hash of
+// the request. Any key is accepted, it is up to the caller to decide what to do with the signer.
+func RecoverGetBlobRequestSigner(request *pb.GetBlobRequest) (gethcommon.Address, []byte, error) {
+	hash, err := hashing.HashGetBlobRequest(request)
+	if err != nil {
+		return gethcommon.Address{}, nil, fmt.Errorf("failed to hash request: %w", err)
+	}
+	return recoverSigner(hash, request.GetSignature())
+}
+
+// SignStreamBlobRequest signs the given StreamBlobRequest with the given account key. Does not
+// write the signature into the request.
+func SignStreamBlobRequest(key *ecdsa.PrivateKey, request *pb.StreamBlobRequest) ([]byte, error) {
+	hash, err := hashing.HashStreamBlobRequest(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash request: %w", err)
+	}
+	return signHash(key, hash)
+}
+
+// RecoverStreamBlobRequestSigner returns the account that signed the given StreamBlobRequest, along with the hash
+// of the request. Any key is accepted, it is up to the caller to decide what to do with the signer.
+func RecoverStreamBlobRequestSigner(request *pb.StreamBlobRequest) (gethcommon.Address, []byte, error) {
+	hash, err := hashing.HashStreamBlobRequest(request)
+	if err != nil {
+		return gethcommon.Address{}, nil, fmt.Errorf("failed to hash request: %w", err)
+	}
+	return recoverSigner(hash, request.GetSignature())
+}
+
+func signHash(key *ecdsa.PrivateKey, hash []byte) ([]byte, error) {
+	signature, err := crypto.Sign(hash, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign request: %w", err)
+	}
+	return signature, nil
+}
+
+func recoverSigner(hash []byte, signature []byte) (gethcommon.Address, []byte, error) {
+	publicKey, err := crypto.SigToPub(hash, signature)
+	if err != nil {
+		return gethcommon.Address{}, nil,
+			fmt.Errorf("failed to recover public key from signature %x: %w", signature, err)
+	}
+	return crypto.PubkeyToAddress(*publicKey), hash, nil
+}
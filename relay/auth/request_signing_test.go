@@ -6,6 +6,8 @@ import (
 	pb "github.com/Layr-Labs/eigenda/api/grpc/relay"
 	"github.com/Layr-Labs/eigenda/api/hashing"
 	tu "github.com/Layr-Labs/eigenda/common/testutils"
+	"github.com/Layr-Labs/eigenda/common/testutils/random"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/exp/rand"
 )
@@ -76,3 +78,54 @@ func TestHashGetChunksRequest(t *testing.T) {
 	require.NoError(t, err)
 	require.NotEqual(t, hashA, hashAA)
 }
+
+func TestGetBlobRequestSigning(t *testing.T) {
+	rand := random.NewTestRandom()
+
+	public, private, err := rand.ECDSA()
+	require.NoError(t, err)
+	account := crypto.PubkeyToAddress(*public)
+
+	request := &pb.GetBlobRequest{
+		BlobKey:   rand.Bytes(32),
+		Timestamp: rand.Uint32(),
+	}
+	request.Signature, err = SignGetBlobRequest(private, request)
+	require.NoError(t, err)
+
+	signer, hash, err := RecoverGetBlobRequestSigner(request)
+	require.NoError(t, err)
+	require.Equal(t, account, signer)
+	expectedHash, err := hashing.HashGetBlobRequest(request)
+	require.NoError(t, err)
+	require.Equal(t, expectedHash, hash)
+
+	// Changing the timestamp should change the recovered signer.
+	request.Timestamp++
+	signer, _, err = RecoverGetBlobRequestSigner(request)
+	require.NoError(t, err)
+	require.NotEqual(t, account, signer)
+
+	// A malformed signature should be rejected.
+	request.Signature = rand.Bytes(10)
+	_, _, err = RecoverGetBlobRequestSigner(request)
+	require.Error(t, err)
+
+	streamRequest := &pb.StreamBlobRequest{
+		BlobKey:   rand.Bytes(32),
+		Offset:    rand.Uint64(),
+		Timestamp: rand.Uint32(),
+	}
+	streamRequest.Signature, err = SignStreamBlobRequest(private, streamRequest)
+	require.NoError(t, err)
+
+	signer, _, err = RecoverStreamBlobRequestSigner(streamRequest)
+	require.NoError(t, err)
+	require.Equal(t, account, signer)
+
+	// The offset is covered by the signature, so a signed request can't be used to fetch a different part of a blob.
+	streamRequest.Offset++
+	signer, _, err = RecoverStreamBlobRequestSigner(streamRequest)
+	require.NoError(t, err)
+	require.NotEqual(t, account, signer)
+}
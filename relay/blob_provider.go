@@ -34,6 +34,7 @@ func newBlobProvider(
 	logger logging.Logger,
 	blobStore *blobstore.BlobStore,
 	blobCacheSize uint64,
+	cacheEvictionPolicy cache2.EvictionPolicy,
 	maxIOConcurrency int,
 	fetchTimeout time.Duration,
 	metrics *cache.CacheAccessorMetrics) (*blobProvider, error) {
@@ -45,8 +46,14 @@ func newBlobProvider(
 		fetchTimeout: fetchTimeout,
 	}
 
+	blobCache, err := cache2.NewCache[v2.BlobKey, []byte](
+		cacheEvictionPolicy, blobCacheSize, computeBlobCacheWeight, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating blob cache: %w", err)
+	}
+
 	cacheAccessor, err := cache.NewCacheAccessor[v2.BlobKey, []byte](
-		cache2.NewFIFOCache[v2.BlobKey, []byte](blobCacheSize, computeBlobCacheWeight, nil),
+		blobCache,
 		maxIOConcurrency,
 		server.fetchBlob,
 		metrics)
@@ -3,6 +3,7 @@ package relay
 import (
 	"context"
 	"github.com/Layr-Labs/eigenda/common"
+	cachecommon "github.com/Layr-Labs/eigenda/common/cache"
 	tu "github.com/Layr-Labs/eigenda/common/testutils"
 	v2 "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/stretchr/testify/require"
@@ -40,6 +41,7 @@ func TestReadWrite(t *testing.T) {
 		logger,
 		blobStore,
 		1024*1024*32,
+		cachecommon.FIFOEvictionPolicy,
 		32,
 		10*time.Second,
 		nil)
@@ -78,6 +80,7 @@ func TestNonExistentBlob(t *testing.T) {
 		logger,
 		blobStore,
 		1024*1024*32,
+		cachecommon.FIFOEvictionPolicy,
 		32,
 		10*time.Second,
 		nil)
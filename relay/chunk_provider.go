@@ -51,6 +51,7 @@ func newChunkProvider(
 	logger logging.Logger,
 	chunkReader chunkstore.ChunkReader,
 	cacheSize uint64,
+	cacheEvictionPolicy cachecommon.EvictionPolicy,
 	maxIOConcurrency int,
 	proofFetchTimeout time.Duration,
 	coefficientFetchTimeout time.Duration,
@@ -64,12 +65,17 @@ func newChunkProvider(
 		coefficientFetchTimeout: coefficientFetchTimeout,
 	}
 
-	var err error
+	frameCache, err := cachecommon.NewCache[blobKeyWithMetadata, *core.ChunksData](
+		cacheEvictionPolicy,
+		cacheSize,
+		server.computeFramesCacheWeight,
+		nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating frame cache: %w", err)
+	}
+
 	server.frameCache, err = cache.NewCacheAccessor[blobKeyWithMetadata, *core.ChunksData](
-		cachecommon.NewFIFOCache[blobKeyWithMetadata, *core.ChunksData](
-			cacheSize,
-			server.computeFramesCacheWeight,
-			nil),
+		frameCache,
 		maxIOConcurrency,
 		server.fetchFrames,
 		metrics)
@@ -3,6 +3,7 @@ package relay
 import (
 	"context"
 	"github.com/Layr-Labs/eigenda/common"
+	cachecommon "github.com/Layr-Labs/eigenda/common/cache"
 	tu "github.com/Layr-Labs/eigenda/common/testutils"
 	"github.com/Layr-Labs/eigenda/core"
 	v2 "github.com/Layr-Labs/eigenda/core/v2"
@@ -60,6 +61,7 @@ func TestFetchingIndividualBlobs(t *testing.T) {
 		logger,
 		chunkReader,
 		1024*1024*32,
+		cachecommon.FIFOEvictionPolicy,
 		32,
 		10*time.Second,
 		10*time.Second,
@@ -150,6 +152,7 @@ func TestFetchingBatchedBlobs(t *testing.T) {
 		logger,
 		chunkReader,
 		1024*1024*32,
+		cachecommon.FIFOEvictionPolicy,
 		32,
 		10*time.Second,
 		10*time.Second,
@@ -90,6 +90,13 @@ var (
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "CHUNK_MAX_CONCURRENCY"),
 		Value:    32,
 	}
+	CacheEvictionPolicyFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "cache-eviction-policy"),
+		Usage:    "Eviction policy of the metadata, blob, and chunk caches, one of 'fifo', 'lru', or 's3fifo'",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "CACHE_EVICTION_POLICY"),
+		Value:    "fifo",
+	}
 	MaxKeysPerGetChunksRequestFlag = cli.IntFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "max-keys-per-get-chunks-request"),
 		Usage:    "Max number of keys to fetch in a single GetChunks request",
@@ -97,6 +104,13 @@ var (
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_KEYS_PER_GET_CHUNKS_REQUEST"),
 		Value:    1024,
 	}
+	StreamBlobFrameSizeFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "stream-blob-frame-size"),
+		Usage:    "Max number of bytes of a blob to send in a single StreamBlob message",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "STREAM_BLOB_FRAME_SIZE"),
+		Value:    units.MiB,
+	}
 	MaxGetBlobOpsPerSecondFlag = cli.Float64Flag{
 		Name:     common.PrefixFlag(FlagPrefix, "max-get-blob-ops-per-second"),
 		Usage:    "Max number of GetBlob operations per second",
@@ -132,6 +146,60 @@ var (
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_CONCURRENT_GET_BLOB_OPS"),
 		Value:    1024,
 	}
+	MaxGetBlobOpsPerSecondClientFlag = cli.Float64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-get-blob-ops-per-second-client"),
+		Usage:    "Max number of GetBlob operations per second per client",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_GET_BLOB_OPS_PER_SECOND_CLIENT"),
+		Value:    32,
+	}
+	GetBlobOpsBurstinessClientFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "get-blob-ops-burstiness-client"),
+		Usage:    "Burstiness of the GetBlob rate limiter per client",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "GET_BLOB_OPS_BURSTINESS_CLIENT"),
+		Value:    32,
+	}
+	MaxGetBlobBytesPerSecondClientFlag = cli.Float64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-get-blob-bytes-per-second-client"),
+		Usage:    "Max bandwidth for GetBlob operations in bytes per second per client",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_GET_BLOB_BYTES_PER_SECOND_CLIENT"),
+		Value:    4 * units.MiB,
+	}
+	GetBlobBytesBurstinessClientFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "get-blob-bytes-burstiness-client"),
+		Usage:    "Burstiness of the GetBlob bandwidth rate limiter per client. Must be at least the max blob size",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "GET_BLOB_BYTES_BURSTINESS_CLIENT"),
+		Value:    20 * units.MiB,
+	}
+	MaxConcurrentGetBlobOpsClientFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-concurrent-get-blob-ops-client"),
+		Usage:    "Max number of concurrent GetBlob operations per client",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_CONCURRENT_GET_BLOB_OPS_CLIENT"),
+		Value:    4,
+	}
+	GetBlobClientTiersFileFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "get-blob-client-tiers-file"),
+		Usage:    "Path to a JSON file containing allowlist tiers of clients (account addresses including the initial \"0x\", or IP addresses) with their own per-client GetBlob limits",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "GET_BLOB_CLIENT_TIERS_FILE"),
+	}
+	GetBlobClientCacheSizeFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "get-blob-client-cache-size"),
+		Usage:    "Max number of clients for which per-client GetBlob rate limiting state is kept",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "GET_BLOB_CLIENT_CACHE_SIZE"),
+		Value:    64 * 1024,
+	}
+	ClientIPHeaderFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "client-ip-header"),
+		Usage:    "The name of the header used to get the client IP address of unsigned GetBlob requests. If set to empty string, the IP address will be taken from the connection. The rightmost value of the header will be used.",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "CLIENT_IP_HEADER"),
+	}
 	MaxGetChunkOpsPerSecondFlag = cli.Float64Flag{
 		Name:     common.PrefixFlag(FlagPrefix, "max-get-chunk-ops-per-second"),
 		Usage:    "Max number of GetChunk operations per second",
@@ -254,6 +322,13 @@ var (
 		Required: false,
 		Value:    20 * time.Second,
 	}
+	StreamBlobTimeoutFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "stream-blob-timeout"),
+		Usage:    "Timeout for StreamBlob()",
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "STREAM_BLOB_TIMEOUT"),
+		Required: false,
+		Value:    60 * time.Second,
+	}
 	InternalGetMetadataTimeoutFlag = cli.DurationFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "internal-get-metadata-timeout"),
 		Usage:    "Timeout for internal metadata fetch",
@@ -347,12 +422,22 @@ var optionalFlags = []cli.Flag{
 	BlobMaxConcurrencyFlag,
 	ChunkCacheBytesFlag,
 	ChunkMaxConcurrencyFlag,
+	CacheEvictionPolicyFlag,
 	MaxKeysPerGetChunksRequestFlag,
+	StreamBlobFrameSizeFlag,
 	MaxGetBlobOpsPerSecondFlag,
 	GetBlobOpsBurstinessFlag,
 	MaxGetBlobBytesPerSecondFlag,
 	GetBlobBytesBurstinessFlag,
 	MaxConcurrentGetBlobOpsFlag,
+	MaxGetBlobOpsPerSecondClientFlag,
+	GetBlobOpsBurstinessClientFlag,
+	MaxGetBlobBytesPerSecondClientFlag,
+	GetBlobBytesBurstinessClientFlag,
+	MaxConcurrentGetBlobOpsClientFlag,
+	GetBlobClientTiersFileFlag,
+	GetBlobClientCacheSizeFlag,
+	ClientIPHeaderFlag,
 	MaxGetChunkOpsPerSecondFlag,
 	GetChunkOpsBurstinessFlag,
 	MaxGetChunkBytesPerSecondFlag,
@@ -368,6 +453,7 @@ var optionalFlags = []cli.Flag{
 	AuthenticationDisabledFlag,
 	GetChunksTimeoutFlag,
 	GetBlobTimeoutFlag,
+	StreamBlobTimeoutFlag,
 	InternalGetMetadataTimeoutFlag,
 	InternalGetBlobTimeoutFlag,
 	InternalGetProofsTimeoutFlag,
@@ -5,6 +5,7 @@ import (
 
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/aws"
+	"github.com/Layr-Labs/eigenda/common/cache"
 	"github.com/Layr-Labs/eigenda/common/geth"
 	"github.com/Layr-Labs/eigenda/core/thegraph"
 	core "github.com/Layr-Labs/eigenda/core/v2"
@@ -67,12 +68,19 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 			ChunkCacheBytes:            ctx.Uint64(flags.ChunkCacheBytesFlag.Name),
 			ChunkMaxConcurrency:        ctx.Int(flags.ChunkMaxConcurrencyFlag.Name),
 			MaxKeysPerGetChunksRequest: ctx.Int(flags.MaxKeysPerGetChunksRequestFlag.Name),
+			StreamBlobFrameSize:        ctx.Int(flags.StreamBlobFrameSizeFlag.Name),
 			RateLimits: limiter.Config{
 				MaxGetBlobOpsPerSecond:          ctx.Float64(flags.MaxGetBlobOpsPerSecondFlag.Name),
 				GetBlobOpsBurstiness:            ctx.Int(flags.GetBlobOpsBurstinessFlag.Name),
 				MaxGetBlobBytesPerSecond:        ctx.Float64(flags.MaxGetBlobBytesPerSecondFlag.Name),
 				GetBlobBytesBurstiness:          ctx.Int(flags.GetBlobBytesBurstinessFlag.Name),
 				MaxConcurrentGetBlobOps:         ctx.Int(flags.MaxConcurrentGetBlobOpsFlag.Name),
+				MaxGetBlobOpsPerSecondClient:    ctx.Float64(flags.MaxGetBlobOpsPerSecondClientFlag.Name),
+				GetBlobOpsBurstinessClient:      ctx.Int(flags.GetBlobOpsBurstinessClientFlag.Name),
+				MaxGetBlobBytesPerSecondClient:  ctx.Float64(flags.MaxGetBlobBytesPerSecondClientFlag.Name),
+				GetBlobBytesBurstinessClient:    ctx.Int(flags.GetBlobBytesBurstinessClientFlag.Name),
+				MaxConcurrentGetBlobOpsClient:   ctx.Int(flags.MaxConcurrentGetBlobOpsClientFlag.Name),
+				GetBlobClientCacheSize:          ctx.Int(flags.GetBlobClientCacheSizeFlag.Name),
 				MaxGetChunkOpsPerSecond:         ctx.Float64(flags.MaxGetChunkOpsPerSecondFlag.Name),
 				GetChunkOpsBurstiness:           ctx.Int(flags.GetChunkOpsBurstinessFlag.Name),
 				MaxGetChunkBytesPerSecond:       ctx.Float64(flags.MaxGetChunkBytesPerSecondFlag.Name),
@@ -84,6 +92,7 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 				GetChunkBytesBurstinessClient:   ctx.Int(flags.GetChunkBytesBurstinessClientFlag.Name),
 				MaxConcurrentGetChunkOpsClient:  ctx.Int(flags.MaxConcurrentGetChunkOpsClientFlag.Name),
 			},
+			ClientIPHeader:               ctx.String(flags.ClientIPHeaderFlag.Name),
 			AuthenticationKeyCacheSize:   ctx.Int(flags.AuthenticationKeyCacheSizeFlag.Name),
 			AuthenticationDisabled:       ctx.Bool(flags.AuthenticationDisabledFlag.Name),
 			GetChunksRequestMaxPastAge:   ctx.Duration(flags.GetChunksRequestMaxPastAgeFlag.Name),
@@ -92,6 +101,7 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 			Timeouts: relay.TimeoutConfig{
 				GetChunksTimeout:               ctx.Duration(flags.GetChunksTimeoutFlag.Name),
 				GetBlobTimeout:                 ctx.Duration(flags.GetBlobTimeoutFlag.Name),
+				StreamBlobTimeout:              ctx.Duration(flags.StreamBlobTimeoutFlag.Name),
 				InternalGetMetadataTimeout:     ctx.Duration(flags.InternalGetMetadataTimeoutFlag.Name),
 				InternalGetBlobTimeout:         ctx.Duration(flags.InternalGetBlobTimeoutFlag.Name),
 				InternalGetProofsTimeout:       ctx.Duration(flags.InternalGetProofsTimeoutFlag.Name),
@@ -108,6 +118,18 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 		EigenDAServiceManagerAddr:     ctx.String(flags.EigenDAServiceManagerAddrFlag.Name),
 		ChainStateConfig:              thegraph.ReadCLIConfig(ctx),
 	}
+	config.RelayConfig.CacheEvictionPolicy, err = cache.ParseEvictionPolicy(
+		ctx.String(flags.CacheEvictionPolicyFlag.Name))
+	if err != nil {
+		return Config{}, fmt.Errorf("invalid cache eviction policy: %w", err)
+	}
+	clientTiersFile := ctx.String(flags.GetBlobClientTiersFileFlag.Name)
+	if clientTiersFile != "" {
+		config.RelayConfig.RateLimits.GetBlobClientTiers, err = limiter.ReadClientTiersFile(clientTiersFile)
+		if err != nil {
+			return Config{}, fmt.Errorf("failed to read GetBlob client tiers: %w", err)
+		}
+	}
 	for i, id := range relayKeys {
 		config.RelayConfig.RelayKeys[i] = core.RelayKey(id)
 	}
@@ -3,10 +3,15 @@ package relay
 import (
 	"time"
 
+	"github.com/Layr-Labs/eigenda/common/cache"
 	v2 "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/Layr-Labs/eigenda/relay/limiter"
+	"github.com/docker/go-units"
 )
 
+// The size of StreamBlob frames used if Config.StreamBlobFrameSize is not set.
+const defaultStreamBlobFrameSize = units.MiB
+
 // Config is the configuration for the relay Server.
 type Config struct {
 
@@ -40,12 +45,25 @@ type Config struct {
 	// impact concurrency utilized by the s3 client to upload/download fragmented files.
 	ChunkMaxConcurrency int
 
+	// CacheEvictionPolicy is the eviction policy used by the metadata, blob, and chunk caches. If empty, the
+	// FIFO eviction policy is used.
+	CacheEvictionPolicy cache.EvictionPolicy
+
+	// StreamBlobFrameSize is the maximum number of bytes of a blob sent in a single StreamBlob message. If zero,
+	// a default of 1 MiB is used.
+	StreamBlobFrameSize int
+
 	// MaxKeysPerGetChunksRequest is the maximum number of keys that can be requested in a single GetChunks request.
 	MaxKeysPerGetChunksRequest int
 
 	// RateLimits contains configuration for rate limiting.
 	RateLimits limiter.Config
 
+	// ClientIPHeader is the name of the header, set by a trusted proxy in front of the relay, that contains the IP
+	// address of the client. It is used to identify clients that do not sign their GetBlob requests. If empty, or if
+	// the header is missing, the address of the connection is used.
+	ClientIPHeader string
+
 	// AuthenticationKeyCacheSize is the maximum number of operator public keys that can be cached.
 	AuthenticationKeyCacheSize int
 
@@ -2,18 +2,25 @@ package limiter
 
 import (
 	"fmt"
-	"github.com/Layr-Labs/eigenda/relay/metrics"
-	"golang.org/x/time/rate"
 	"sync"
 	"time"
+
+	"github.com/Layr-Labs/eigenda/relay/metrics"
+	lru "github.com/hashicorp/golang-lru/v2"
+	"golang.org/x/time/rate"
 )
 
+// overflowClientID is the ID under which clients that share the overflow limits are reported.
+const overflowClientID = "overflow"
+
 // BlobRateLimiter enforces rate limits on GetBlob operations.
 type BlobRateLimiter struct {
 
 	// config is the rate limit configuration.
 	config *Config
 
+	// global limiters
+
 	// opLimiter enforces rate limits on the maximum rate of GetBlob operations
 	opLimiter *rate.Limiter
 
@@ -24,6 +31,28 @@ type BlobRateLimiter struct {
 	// operationsInFlight is the number of GetBlob operations currently in flight.
 	operationsInFlight int
 
+	// per-client limiters
+
+	// defaultClientLimits are the limits applied to clients that are not in any allowlist tier.
+	defaultClientLimits *ClientLimits
+
+	// clientTiers maps the ID of each allowlisted client to its tier.
+	clientTiers map[string]*ClientTier
+
+	// allowlistedClients holds the per-client limiters of allowlisted clients. These are never discarded.
+	allowlistedClients map[string]*blobClient
+
+	// clients holds the per-client limiters of recently seen clients that are not allowlisted. A client is only
+	// discarded to make room for another if it is idle, i.e. its limiters are full and it has no operations in
+	// flight, since discarding any other client would reset its limits.
+	clients *lru.Cache[string, *blobClient]
+
+	// overflow holds the limiters shared by new clients while the clients cache is full and no client in it is idle.
+	overflow *blobClient
+
+	// overflowInFlight is the number of operations in flight that each client has charged to the overflow limiters.
+	overflowInFlight map[string]int
+
 	// Encapsulates relay metrics.
 	relayMetrics *metrics.RelayMetrics
 
@@ -31,8 +60,29 @@ type BlobRateLimiter struct {
 	lock sync.Mutex
 }
 
+// blobClient holds the GetBlob rate limiting state for a single client.
+type blobClient struct {
+	// id is the ID under which the client is reported in metrics.
+	id string
+
+	// tier is the name of the allowlist tier the client belongs to.
+	tier string
+
+	// limits are the limits applied to the client.
+	limits *ClientLimits
+
+	// opLimiter enforces rate limits on the maximum rate of GetBlob operations for this client.
+	opLimiter *rate.Limiter
+
+	// bandwidthLimiter enforces rate limits on the maximum bandwidth consumed by this client.
+	bandwidthLimiter *rate.Limiter
+
+	// operationsInFlight is the number of GetBlob operations currently in flight for this client.
+	operationsInFlight int
+}
+
 // NewBlobRateLimiter creates a new BlobRateLimiter.
-func NewBlobRateLimiter(config *Config, relayMetrics *metrics.RelayMetrics) *BlobRateLimiter {
+func NewBlobRateLimiter(config *Config, relayMetrics *metrics.RelayMetrics) (*BlobRateLimiter, error) {
 	globalGetBlobOpLimiter := rate.NewLimiter(
 		rate.Limit(config.MaxGetBlobOpsPerSecond),
 		config.GetBlobOpsBurstiness)
@@ -41,18 +91,116 @@ func NewBlobRateLimiter(config *Config, relayMetrics *metrics.RelayMetrics) *Blo
 		rate.Limit(config.MaxGetBlobBytesPerSecond),
 		config.GetBlobBytesBurstiness)
 
+	defaultClientLimits := &ClientLimits{
+		MaxOpsPerSecond:   config.MaxGetBlobOpsPerSecondClient,
+		OpsBurstiness:     config.GetBlobOpsBurstinessClient,
+		MaxBytesPerSecond: config.MaxGetBlobBytesPerSecondClient,
+		BytesBurstiness:   config.GetBlobBytesBurstinessClient,
+		MaxConcurrentOps:  config.MaxConcurrentGetBlobOpsClient,
+	}
+	err := defaultClientLimits.validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid per-client GetBlob limits: %w", err)
+	}
+
+	clientTiers, err := indexClientTiers(config.GetBlobClientTiers)
+	if err != nil {
+		return nil, fmt.Errorf("invalid GetBlob client tiers: %w", err)
+	}
+
+	clients, err := lru.NewWithEvict(config.GetBlobClientCacheSize, func(clientID string, client *blobClient) {
+		if relayMetrics != nil {
+			relayMetrics.RemoveBlobClient(clientID, client.tier)
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GetBlob client cache: %w", err)
+	}
+
 	return &BlobRateLimiter{
-		config:           config,
-		opLimiter:        globalGetBlobOpLimiter,
-		bandwidthLimiter: globalGetBlobBandwidthLimiter,
-		relayMetrics:     relayMetrics,
+		config:              config,
+		opLimiter:           globalGetBlobOpLimiter,
+		bandwidthLimiter:    globalGetBlobBandwidthLimiter,
+		defaultClientLimits: defaultClientLimits,
+		clientTiers:         clientTiers,
+		allowlistedClients:  make(map[string]*blobClient),
+		clients:             clients,
+		overflow:            newBlobClient(overflowClientID, DefaultClientTierName, defaultClientLimits),
+		overflowInFlight:    make(map[string]int),
+		relayMetrics:        relayMetrics,
+	}, nil
+}
+
+// newBlobClient creates the rate limiting state for a client with the given limits.
+func newBlobClient(id string, tier string, limits *ClientLimits) *blobClient {
+	return &blobClient{
+		id:               id,
+		tier:             tier,
+		limits:           limits,
+		opLimiter:        rate.NewLimiter(rate.Limit(limits.MaxOpsPerSecond), limits.OpsBurstiness),
+		bandwidthLimiter: rate.NewLimiter(rate.Limit(limits.MaxBytesPerSecond), limits.BytesBurstiness),
+	}
+}
+
+// idle returns true if discarding the client's state would not change its limits, i.e. if its limiters are full and
+// it has no operations in flight.
+func (c *blobClient) idle(now time.Time) bool {
+	return c.operationsInFlight == 0 &&
+		c.opLimiter.TokensAt(now) >= float64(c.opLimiter.Burst()) &&
+		c.bandwidthLimiter.TokensAt(now) >= float64(c.bandwidthLimiter.Burst())
+}
+
+// IsAllowlisted returns true if the given client is in one of the allowlist tiers.
+func (l *BlobRateLimiter) IsAllowlisted(clientID string) bool {
+	if l == nil {
+		return false
+	}
+	_, ok := l.clientTiers[clientID]
+	return ok
+}
+
+// getClient returns the rate limiting state for the given client, creating it if this client has not been seen
+// recently. If the clients cache is full and its least recently seen client is not idle, the client shares the
+// overflow state instead, so that cycling through many client IDs can't be used to reset any client's limits. The
+// caller must hold the lock.
+func (l *BlobRateLimiter) getClient(now time.Time, clientID string) *blobClient {
+	if client, ok := l.allowlistedClients[clientID]; ok {
+		return client
+	}
+	if client, ok := l.clients.Get(clientID); ok {
+		return client
+	}
+
+	if tier, ok := l.clientTiers[clientID]; ok {
+		client := newBlobClient(clientID, tier.Name, &tier.ClientLimits)
+		l.allowlistedClients[clientID] = client
+		return client
+	}
+
+	if l.clients.Len() >= l.config.GetBlobClientCacheSize {
+		_, oldest, ok := l.clients.GetOldest()
+		if ok && !oldest.idle(now) {
+			return l.overflow
+		}
+	}
+
+	client := newBlobClient(clientID, DefaultClientTierName, l.defaultClientLimits)
+	l.clients.Add(clientID, client)
+	return client
+}
+
+// reportClientRateLimited reports that a GetBlob operation was rate limited by a per-client limit.
+func (l *BlobRateLimiter) reportClientRateLimited(client *blobClient, reason string) {
+	if l.relayMetrics != nil {
+		l.relayMetrics.ReportBlobRateLimited(reason)
+		l.relayMetrics.ReportBlobClientRateLimited(client.id, client.tier, reason)
 	}
 }
 
 // BeginGetBlobOperation should be called when a GetBlob operation is about to begin. If it returns an error,
 // the operation should not be performed. If it does not return an error, FinishGetBlobOperation should be
 // called when the operation completes.
-func (l *BlobRateLimiter) BeginGetBlobOperation(now time.Time) error {
+func (l *BlobRateLimiter) BeginGetBlobOperation(now time.Time, clientID string) error {
 	if l == nil {
 		// If the rate limiter is nil, do not enforce rate limits.
 		return nil
@@ -61,6 +209,8 @@ func (l *BlobRateLimiter) BeginGetBlobOperation(now time.Time) error {
 	l.lock.Lock()
 	defer l.lock.Unlock()
 
+	client := l.getClient(now, clientID)
+
 	if l.operationsInFlight >= l.config.MaxConcurrentGetBlobOps {
 		if l.relayMetrics != nil {
 			l.relayMetrics.ReportBlobRateLimited("global concurrency")
@@ -75,16 +225,35 @@ func (l *BlobRateLimiter) BeginGetBlobOperation(now time.Time) error {
 		return fmt.Errorf("global rate limit %0.1fhz exceeded for getBlob operations, try again later",
 			l.config.MaxGetBlobOpsPerSecond)
 	}
+	if client.operationsInFlight >= client.limits.MaxConcurrentOps {
+		l.reportClientRateLimited(client, "client concurrency")
+		return fmt.Errorf("client concurrent request limit %d exceeded for getBlob operations, try again later",
+			client.limits.MaxConcurrentOps)
+	}
+	if client.opLimiter.TokensAt(now) < 1 {
+		l.reportClientRateLimited(client, "client rate")
+		return fmt.Errorf("client rate limit %0.1fhz exceeded for getBlob operations, try again later",
+			client.limits.MaxOpsPerSecond)
+	}
 
 	l.operationsInFlight++
+	client.operationsInFlight++
+	if client == l.overflow {
+		l.overflowInFlight[clientID]++
+	}
 	l.opLimiter.AllowN(now, 1)
+	client.opLimiter.AllowN(now, 1)
+
+	if l.relayMetrics != nil {
+		l.relayMetrics.ReportBlobClientRequest(client.id, client.tier)
+	}
 
 	return nil
 }
 
 // FinishGetBlobOperation should be called exactly once for each time BeginGetBlobOperation is called and
 // returns nil.
-func (l *BlobRateLimiter) FinishGetBlobOperation() {
+func (l *BlobRateLimiter) FinishGetBlobOperation(clientID string) {
 	if l == nil {
 		// If the rate limiter is nil, do not enforce rate limits.
 		return
@@ -94,21 +263,46 @@ func (l *BlobRateLimiter) FinishGetBlobOperation() {
 	defer l.lock.Unlock()
 
 	l.operationsInFlight--
+
+	if count, ok := l.overflowInFlight[clientID]; ok {
+		if count > 1 {
+			l.overflowInFlight[clientID] = count - 1
+		} else {
+			delete(l.overflowInFlight, clientID)
+		}
+		l.overflow.operationsInFlight--
+		return
+	}
+
+	// A client with operations in flight is never idle, so it can't have been discarded from the cache.
+	client, ok := l.allowlistedClients[clientID]
+	if !ok {
+		client, ok = l.clients.Peek(clientID)
+	}
+	if ok && client.operationsInFlight > 0 {
+		client.operationsInFlight--
+	}
 }
 
 // RequestGetBlobBandwidth should be called when a GetBlob is about to start downloading blob data
 // from S3. It returns an error if there is insufficient bandwidth available. If it returns nil, the
 // operation should proceed.
-func (l *BlobRateLimiter) RequestGetBlobBandwidth(now time.Time, bytes uint32) error {
+func (l *BlobRateLimiter) RequestGetBlobBandwidth(now time.Time, clientID string, bytes uint32) error {
 	if l == nil {
 		// If the rate limiter is nil, do not enforce rate limits.
 		return nil
 	}
 
-	// no locking needed, the only thing we touch here is the bandwidthLimiter, which is inherently thread-safe
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	client := l.getClient(now, clientID)
 
-	allowed := l.bandwidthLimiter.AllowN(now, int(bytes))
-	if !allowed {
+	// Bandwidth is reserved rather than taken, so that the global reservation can be cancelled if the client's
+	// limit is exceeded.
+	reservation := l.bandwidthLimiter.ReserveN(now, int(bytes))
+	if !reservation.OK() || reservation.DelayFrom(now) > 0 {
+		reservation.CancelAt(now)
 		if l.relayMetrics != nil {
 			l.relayMetrics.ReportBlobRateLimited("global bandwidth")
 		}
@@ -120,5 +314,24 @@ func (l *BlobRateLimiter) RequestGetBlobBandwidth(now time.Time, bytes uint32) e
 			"global rate limit %0.1fMiB/s (burstiness %dMiB) exceeded for getBlob bandwidth, try again later",
 			rateLimit, burstiness)
 	}
+
+	clientReservation := client.bandwidthLimiter.ReserveN(now, int(bytes))
+	if !clientReservation.OK() || clientReservation.DelayFrom(now) > 0 {
+		clientReservation.CancelAt(now)
+		reservation.CancelAt(now)
+		l.reportClientRateLimited(client, "client bandwidth")
+
+		rateLimit := client.limits.MaxBytesPerSecond / 1024 / 1024
+		burstiness := client.limits.BytesBurstiness / 1024 / 1024
+
+		return fmt.Errorf(
+			"client rate limit %0.1fMiB/s (burstiness %dMiB) exceeded for getBlob bandwidth, try again later",
+			rateLimit, burstiness)
+	}
+
+	if l.relayMetrics != nil {
+		l.relayMetrics.ReportBlobClientBandwidthUsage(client.id, client.tier, int(bytes))
+	}
+
 	return nil
 }
@@ -1,11 +1,15 @@
 package limiter
 
 import (
+	"encoding/hex"
+	"math"
+	"testing"
+	"time"
+
 	tu "github.com/Layr-Labs/eigenda/common/testutils"
+	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/exp/rand"
-	"testing"
-	"time"
 )
 
 func defaultConfig() *Config {
@@ -15,6 +19,12 @@ func defaultConfig() *Config {
 		MaxGetBlobBytesPerSecond:        20 * 1024 * 1024,
 		GetBlobBytesBurstiness:          20 * 1024 * 1024,
 		MaxConcurrentGetBlobOps:         1024,
+		MaxGetBlobOpsPerSecondClient:    1024,
+		GetBlobOpsBurstinessClient:      1024,
+		MaxGetBlobBytesPerSecondClient:  20 * 1024 * 1024,
+		GetBlobBytesBurstinessClient:    20 * 1024 * 1024,
+		MaxConcurrentGetBlobOpsClient:   1024,
+		GetBlobClientCacheSize:          1024,
 		MaxGetChunkOpsPerSecond:         1024,
 		GetChunkOpsBurstiness:           1024,
 		MaxGetChunkBytesPerSecond:       20 * 1024 * 1024,
@@ -38,26 +48,29 @@ func TestConcurrentBlobOperations(t *testing.T) {
 	// Make the burstiness limit high enough that we won't be rate limited
 	config.GetBlobOpsBurstiness = concurrencyLimit * 100
 
-	limiter := NewBlobRateLimiter(config, nil)
+	limiter, err := NewBlobRateLimiter(config, nil)
+	require.NoError(t, err)
+
+	clientID := tu.RandomString(64)
 
 	// time starts at current time, but advances manually afterward
 	now := time.Now()
 
 	// We should be able to start this many operations concurrently
 	for i := 0; i < concurrencyLimit; i++ {
-		err := limiter.BeginGetBlobOperation(now)
+		err := limiter.BeginGetBlobOperation(now, clientID)
 		require.NoError(t, err)
 	}
 
 	// Starting one more operation should fail due to the concurrency limit
-	err := limiter.BeginGetBlobOperation(now)
+	err = limiter.BeginGetBlobOperation(now, clientID)
 	require.Error(t, err)
 
 	// Finish an operation. This should permit exactly one more operation to start
-	limiter.FinishGetBlobOperation()
-	err = limiter.BeginGetBlobOperation(now)
+	limiter.FinishGetBlobOperation(clientID)
+	err = limiter.BeginGetBlobOperation(now, clientID)
 	require.NoError(t, err)
-	err = limiter.BeginGetBlobOperation(now)
+	err = limiter.BeginGetBlobOperation(now, clientID)
 	require.Error(t, err)
 }
 
@@ -69,56 +82,59 @@ func TestGetBlobOpRateLimit(t *testing.T) {
 	config.GetBlobOpsBurstiness = int(config.MaxGetBlobOpsPerSecond) + rand.Intn(10)
 	config.MaxConcurrentGetBlobOps = 1
 
-	limiter := NewBlobRateLimiter(config, nil)
+	limiter, err := NewBlobRateLimiter(config, nil)
+	require.NoError(t, err)
+
+	clientID := tu.RandomString(64)
 
 	// time starts at current time, but advances manually afterward
 	now := time.Now()
 
 	// Without advancing time, we should be able to perform a number of operations equal to the burstiness limit.
 	for i := 0; i < config.GetBlobOpsBurstiness; i++ {
-		err := limiter.BeginGetBlobOperation(now)
+		err := limiter.BeginGetBlobOperation(now, clientID)
 		require.NoError(t, err)
-		limiter.FinishGetBlobOperation()
+		limiter.FinishGetBlobOperation(clientID)
 	}
 
 	// We are not at the rate limit, and should be able to start another operation.
-	err := limiter.BeginGetBlobOperation(now)
+	err = limiter.BeginGetBlobOperation(now, clientID)
 	require.Error(t, err)
 
 	// Advance time by one second. We should gain a number of tokens equal to the rate limit.
 	now = now.Add(time.Second)
 	for i := 0; i < int(config.MaxGetBlobOpsPerSecond); i++ {
-		err = limiter.BeginGetBlobOperation(now)
+		err = limiter.BeginGetBlobOperation(now, clientID)
 		require.NoError(t, err)
-		limiter.FinishGetBlobOperation()
+		limiter.FinishGetBlobOperation(clientID)
 	}
 
 	// We have once again hit the rate limit. We should not be able to start another operation.
-	err = limiter.BeginGetBlobOperation(now)
+	err = limiter.BeginGetBlobOperation(now, clientID)
 	require.Error(t, err)
 
 	// Advance time by another second. We should gain another number of tokens equal to the rate limit.
 	// Intentionally do not finish the next operation. We are attempting to get a failure by exceeding
 	// the max concurrent operations limit.
 	now = now.Add(time.Second)
-	err = limiter.BeginGetBlobOperation(now)
+	err = limiter.BeginGetBlobOperation(now, clientID)
 	require.NoError(t, err)
 
 	// This operation should fail since we have limited concurrent operations to 1. It should not count
 	// against the rate limit.
-	err = limiter.BeginGetBlobOperation(now)
+	err = limiter.BeginGetBlobOperation(now, clientID)
 	require.Error(t, err)
 
 	// "finish" the prior operation. Verify that we have all expected tokens available.
-	limiter.FinishGetBlobOperation()
+	limiter.FinishGetBlobOperation(clientID)
 	for i := 0; i < int(config.MaxGetBlobOpsPerSecond)-1; i++ {
-		err = limiter.BeginGetBlobOperation(now)
+		err = limiter.BeginGetBlobOperation(now, clientID)
 		require.NoError(t, err)
-		limiter.FinishGetBlobOperation()
+		limiter.FinishGetBlobOperation(clientID)
 	}
 
 	// We should now be at the rate limit. We should not be able to start another operation.
-	err = limiter.BeginGetBlobOperation(now)
+	err = limiter.BeginGetBlobOperation(now, clientID)
 	require.Error(t, err)
 }
 
@@ -129,7 +145,10 @@ func TestGetBlobBandwidthLimit(t *testing.T) {
 	config.MaxGetBlobBytesPerSecond = float64(1024 + rand.Intn(1024*1024))
 	config.GetBlobBytesBurstiness = int(config.MaxGetBlobBytesPerSecond) + rand.Intn(1024*1024)
 
-	limiter := NewBlobRateLimiter(config, nil)
+	limiter, err := NewBlobRateLimiter(config, nil)
+	require.NoError(t, err)
+
+	clientID := tu.RandomString(64)
 
 	// time starts at current time, but advances manually afterward
 	now := time.Now()
@@ -138,13 +157,13 @@ func TestGetBlobBandwidthLimit(t *testing.T) {
 	bytesRemaining := config.GetBlobBytesBurstiness
 	for bytesRemaining > 0 {
 		bytesToRequest := 1 + rand.Intn(bytesRemaining)
-		err := limiter.RequestGetBlobBandwidth(now, uint32(bytesToRequest))
+		err := limiter.RequestGetBlobBandwidth(now, clientID, uint32(bytesToRequest))
 		require.NoError(t, err)
 		bytesRemaining -= bytesToRequest
 	}
 
 	// Requesting one more byte should fail due to the bandwidth limit
-	err := limiter.RequestGetBlobBandwidth(now, 1)
+	err = limiter.RequestGetBlobBandwidth(now, clientID, 1)
 	require.Error(t, err)
 
 	// Advance time by one second. We should gain a number of tokens equal to the rate limit.
@@ -152,12 +171,216 @@ func TestGetBlobBandwidthLimit(t *testing.T) {
 	bytesRemaining = int(config.MaxGetBlobBytesPerSecond)
 	for bytesRemaining > 0 {
 		bytesToRequest := 1 + rand.Intn(bytesRemaining)
-		err = limiter.RequestGetBlobBandwidth(now, uint32(bytesToRequest))
+		err = limiter.RequestGetBlobBandwidth(now, clientID, uint32(bytesToRequest))
 		require.NoError(t, err)
 		bytesRemaining -= bytesToRequest
 	}
 
 	// Requesting one more byte should fail due to the bandwidth limit
-	err = limiter.RequestGetBlobBandwidth(now, 1)
+	err = limiter.RequestGetBlobBandwidth(now, clientID, 1)
+	require.Error(t, err)
+}
+
+func TestGetBlobLimitsPerClient(t *testing.T) {
+	tu.InitializeRandom()
+
+	config := defaultConfig()
+	config.MaxConcurrentGetBlobOpsClient = 1 + rand.Intn(10)
+	config.MaxGetBlobOpsPerSecondClient = float64(config.MaxConcurrentGetBlobOpsClient + rand.Intn(10))
+	config.GetBlobOpsBurstinessClient = int(config.MaxGetBlobOpsPerSecondClient)
+	config.MaxGetBlobBytesPerSecondClient = float64(1024 + rand.Intn(1024*1024))
+	config.GetBlobBytesBurstinessClient = int(config.MaxGetBlobBytesPerSecondClient)
+	config.GetBlobOpsBurstiness = math.MaxInt32
+	config.GetBlobBytesBurstiness = math.MaxInt32
+
+	userID1 := tu.RandomString(64)
+	userID2 := tu.RandomString(64)
+
+	limiter, err := NewBlobRateLimiter(config, nil)
+	require.NoError(t, err)
+
+	// time starts at current time, but advances manually afterward
+	now := time.Now()
+
+	// Start the maximum permitted number of operations for client 1.
+	for i := 0; i < config.MaxConcurrentGetBlobOpsClient; i++ {
+		err = limiter.BeginGetBlobOperation(now, userID1)
+		require.NoError(t, err)
+	}
+	err = limiter.BeginGetBlobOperation(now, userID1)
+	require.Error(t, err)
+
+	// Client 2 is not affected by the operations in flight for client 1.
+	err = limiter.BeginGetBlobOperation(now, userID2)
+	require.NoError(t, err)
+	limiter.FinishGetBlobOperation(userID2)
+
+	// Use up the rest of client 1's operations.
+	for i := 0; i < config.MaxConcurrentGetBlobOpsClient; i++ {
+		limiter.FinishGetBlobOperation(userID1)
+	}
+	for i := config.MaxConcurrentGetBlobOpsClient; i < config.GetBlobOpsBurstinessClient; i++ {
+		err = limiter.BeginGetBlobOperation(now, userID1)
+		require.NoError(t, err)
+		limiter.FinishGetBlobOperation(userID1)
+	}
+	err = limiter.BeginGetBlobOperation(now, userID1)
+	require.Error(t, err)
+	err = limiter.BeginGetBlobOperation(now, userID2)
+	require.NoError(t, err)
+	limiter.FinishGetBlobOperation(userID2)
+
+	// Exhaust client 1's bandwidth. Client 2 still has its full allowance.
+	err = limiter.RequestGetBlobBandwidth(now, userID1, uint32(config.GetBlobBytesBurstinessClient))
+	require.NoError(t, err)
+	err = limiter.RequestGetBlobBandwidth(now, userID1, 1)
+	require.Error(t, err)
+	err = limiter.RequestGetBlobBandwidth(now, userID2, uint32(config.GetBlobBytesBurstinessClient))
+	require.NoError(t, err)
+
+	// Advancing time restores the budget of client 1.
+	now = now.Add(time.Second)
+	err = limiter.BeginGetBlobOperation(now, userID1)
+	require.NoError(t, err)
+	limiter.FinishGetBlobOperation(userID1)
+	err = limiter.RequestGetBlobBandwidth(now, userID1, uint32(config.MaxGetBlobBytesPerSecondClient))
+	require.NoError(t, err)
+}
+
+func TestGetBlobClientTiers(t *testing.T) {
+	tu.InitializeRandom()
+
+	rollupAccount := "0x" + hex.EncodeToString(tu.RandomBytes(20))
+	rollupIP := "10.0.0.1"
+
+	config := defaultConfig()
+	config.MaxGetBlobOpsPerSecondClient = 1
+	config.GetBlobOpsBurstinessClient = 1
+	config.MaxConcurrentGetBlobOpsClient = 1
+	config.GetBlobClientTiers = []*ClientTier{
+		{
+			Name:    "rollup",
+			Clients: []string{rollupAccount, rollupIP},
+			ClientLimits: ClientLimits{
+				MaxOpsPerSecond:   10,
+				OpsBurstiness:     10,
+				MaxBytesPerSecond: 1024,
+				BytesBurstiness:   1024,
+				MaxConcurrentOps:  10,
+			},
+		},
+	}
+
+	limiter, err := NewBlobRateLimiter(config, nil)
+	require.NoError(t, err)
+
+	now := time.Now()
+
+	// A client that isn't on the allowlist gets the default limits.
+	otherClient := "10.0.0.2"
+	err = limiter.BeginGetBlobOperation(now, otherClient)
+	require.NoError(t, err)
+	err = limiter.BeginGetBlobOperation(now, otherClient)
+	require.Error(t, err)
+
+	// Allowlisted clients get the limits of their tier, each client separately. Accounts are identified in the
+	// checksummed form recovered from request signatures.
+	for _, clientID := range []string{gethcommon.HexToAddress(rollupAccount).Hex(), rollupIP} {
+		for i := 0; i < 10; i++ {
+			err = limiter.BeginGetBlobOperation(now, clientID)
+			require.NoError(t, err)
+		}
+		err = limiter.BeginGetBlobOperation(now, clientID)
+		require.Error(t, err)
+
+		err = limiter.RequestGetBlobBandwidth(now, clientID, 1024)
+		require.NoError(t, err)
+		err = limiter.RequestGetBlobBandwidth(now, clientID, 1)
+		require.Error(t, err)
+	}
+
+	// Only clients in a tier are allowlisted.
+	require.True(t, limiter.IsAllowlisted(gethcommon.HexToAddress(rollupAccount).Hex()))
+	require.True(t, limiter.IsAllowlisted(rollupIP))
+	require.False(t, limiter.IsAllowlisted(otherClient))
+
+	// Duplicate clients are rejected.
+	config.GetBlobClientTiers = append(config.GetBlobClientTiers, &ClientTier{
+		Name:         "other",
+		Clients:      []string{rollupIP},
+		ClientLimits: config.GetBlobClientTiers[0].ClientLimits,
+	})
+	_, err = NewBlobRateLimiter(config, nil)
+	require.Error(t, err)
+}
+
+func TestGetBlobClientCacheChurn(t *testing.T) {
+	tu.InitializeRandom()
+
+	config := defaultConfig()
+	config.MaxGetBlobOpsPerSecondClient = 1
+	config.GetBlobOpsBurstinessClient = 1
+	config.GetBlobClientCacheSize = 2
+
+	limiter, err := NewBlobRateLimiter(config, nil)
+	require.NoError(t, err)
+
+	now := time.Now()
+
+	// Fill the cache with clients that have used up their limits.
+	for _, clientID := range []string{"10.0.0.1", "10.0.0.2"} {
+		err = limiter.BeginGetBlobOperation(now, clientID)
+		require.NoError(t, err)
+		limiter.FinishGetBlobOperation(clientID)
+	}
+
+	// New clients can't push those clients out of the cache, and share the overflow limits instead.
+	err = limiter.BeginGetBlobOperation(now, "10.0.0.3")
+	require.NoError(t, err)
+	limiter.FinishGetBlobOperation("10.0.0.3")
+	err = limiter.BeginGetBlobOperation(now, "10.0.0.4")
+	require.Error(t, err)
+
+	// Cycling through client IDs doesn't reset the limits of the clients in the cache.
+	for i := 0; i < 10; i++ {
+		_ = limiter.BeginGetBlobOperation(now, tu.RandomString(16))
+	}
+	err = limiter.BeginGetBlobOperation(now, "10.0.0.1")
+	require.Error(t, err)
+
+	// Once the clients in the cache are idle, new clients replace them.
+	now = now.Add(time.Second)
+	err = limiter.BeginGetBlobOperation(now, "10.0.0.5")
+	require.NoError(t, err)
+	limiter.FinishGetBlobOperation("10.0.0.5")
+	err = limiter.BeginGetBlobOperation(now, "10.0.0.6")
+	require.NoError(t, err)
+	limiter.FinishGetBlobOperation("10.0.0.6")
+}
+
+func TestGetBlobBandwidthRefund(t *testing.T) {
+	tu.InitializeRandom()
+
+	config := defaultConfig()
+	config.MaxGetBlobBytesPerSecond = 2048
+	config.GetBlobBytesBurstiness = 2048
+	config.MaxGetBlobBytesPerSecondClient = 1024
+	config.GetBlobBytesBurstinessClient = 1024
+
+	limiter, err := NewBlobRateLimiter(config, nil)
+	require.NoError(t, err)
+
+	now := time.Now()
+
+	// A request rejected by the client limit doesn't consume the global bandwidth.
+	err = limiter.RequestGetBlobBandwidth(now, "10.0.0.1", 1024)
+	require.NoError(t, err)
+	err = limiter.RequestGetBlobBandwidth(now, "10.0.0.1", 1024)
+	require.Error(t, err)
+	err = limiter.RequestGetBlobBandwidth(now, "10.0.0.2", 1024)
+	require.NoError(t, err)
+
+	// The global bandwidth is now used up.
+	err = limiter.RequestGetBlobBandwidth(now, "10.0.0.3", 1)
 	require.Error(t, err)
 }
@@ -0,0 +1,111 @@
+package limiter
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// DefaultClientTierName is the name of the tier of clients that are not on the allowlist.
+const DefaultClientTierName = "default"
+
+// ReadClientTiersFile reads allowlist tiers from a JSON file. The file contains a list of tiers, for example:
+//
+//	[
+//	  {
+//	    "name": "rollup-a",
+//	    "clients": ["0x1234567890123456789012345678901234567890", "10.0.0.1"],
+//	    "maxOpsPerSecond": 256,
+//	    "opsBurstiness": 256,
+//	    "maxBytesPerSecond": 67108864,
+//	    "bytesBurstiness": 67108864,
+//	    "maxConcurrentOps": 32
+//	  }
+//	]
+func ReadClientTiersFile(path string) ([]*ClientTier, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read client tiers file %s: %w", path, err)
+	}
+
+	var tiers []*ClientTier
+	err = json.Unmarshal(data, &tiers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse client tiers file %s: %w", path, err)
+	}
+
+	_, err = indexClientTiers(tiers)
+	if err != nil {
+		return nil, fmt.Errorf("invalid client tiers file %s: %w", path, err)
+	}
+	return tiers, nil
+}
+
+// NormalizeClientID returns the canonical form of a client ID. Account addresses are converted to their checksummed
+// form so that they match the accounts recovered from request signatures. Other IDs are returned unchanged.
+func NormalizeClientID(clientID string) string {
+	if strings.HasPrefix(clientID, "0x") && gethcommon.IsHexAddress(clientID) {
+		return gethcommon.HexToAddress(clientID).Hex()
+	}
+	return clientID
+}
+
+// indexClientTiers validates the given tiers, and returns a map from client ID to the tier containing that client.
+func indexClientTiers(tiers []*ClientTier) (map[string]*ClientTier, error) {
+	names := make(map[string]struct{}, len(tiers))
+	index := make(map[string]*ClientTier)
+
+	for _, tier := range tiers {
+		if tier == nil {
+			return nil, fmt.Errorf("tier must not be null")
+		}
+		if tier.Name == "" {
+			return nil, fmt.Errorf("tier name must not be empty")
+		}
+		if tier.Name == DefaultClientTierName {
+			return nil, fmt.Errorf("tier name %q is reserved", DefaultClientTierName)
+		}
+		if _, ok := names[tier.Name]; ok {
+			return nil, fmt.Errorf("duplicate tier %q", tier.Name)
+		}
+		names[tier.Name] = struct{}{}
+
+		err := tier.validate()
+		if err != nil {
+			return nil, fmt.Errorf("invalid limits for tier %q: %w", tier.Name, err)
+		}
+
+		for _, client := range tier.Clients {
+			clientID := NormalizeClientID(client)
+			if other, ok := index[clientID]; ok {
+				return nil, fmt.Errorf("client %s is in both tier %q and tier %q", client, other.Name, tier.Name)
+			}
+			index[clientID] = tier
+		}
+	}
+
+	return index, nil
+}
+
+// validate checks that the limits permit at least some traffic.
+func (c *ClientLimits) validate() error {
+	if c.MaxOpsPerSecond <= 0 {
+		return fmt.Errorf("maxOpsPerSecond must be positive, got %f", c.MaxOpsPerSecond)
+	}
+	if c.OpsBurstiness <= 0 {
+		return fmt.Errorf("opsBurstiness must be positive, got %d", c.OpsBurstiness)
+	}
+	if c.MaxBytesPerSecond <= 0 {
+		return fmt.Errorf("maxBytesPerSecond must be positive, got %f", c.MaxBytesPerSecond)
+	}
+	if c.BytesBurstiness <= 0 {
+		return fmt.Errorf("bytesBurstiness must be positive, got %d", c.BytesBurstiness)
+	}
+	if c.MaxConcurrentOps <= 0 {
+		return fmt.Errorf("maxConcurrentOps must be positive, got %d", c.MaxConcurrentOps)
+	}
+	return nil
+}
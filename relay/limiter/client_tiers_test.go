@@ -0,0 +1,74 @@
+package limiter
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadClientTiersFile(t *testing.T) {
+	directory := t.TempDir()
+
+	path := filepath.Join(directory, "tiers.json")
+	err := os.WriteFile(path, []byte(`[
+		{
+			"name": "rollup",
+			"clients": ["0xabcdefabcdefabcdefabcdefabcdefabcdefabcd", "10.0.0.1"],
+			"maxOpsPerSecond": 256,
+			"opsBurstiness": 512,
+			"maxBytesPerSecond": 1048576,
+			"bytesBurstiness": 2097152,
+			"maxConcurrentOps": 32
+		}
+	]`), 0600)
+	require.NoError(t, err)
+
+	tiers, err := ReadClientTiersFile(path)
+	require.NoError(t, err)
+	require.Len(t, tiers, 1)
+	require.Equal(t, "rollup", tiers[0].Name)
+	require.Equal(t, []string{"0xabcdefabcdefabcdefabcdefabcdefabcdefabcd", "10.0.0.1"}, tiers[0].Clients)
+	require.Equal(t, ClientLimits{
+		MaxOpsPerSecond:   256,
+		OpsBurstiness:     512,
+		MaxBytesPerSecond: 1048576,
+		BytesBurstiness:   2097152,
+		MaxConcurrentOps:  32,
+	}, tiers[0].ClientLimits)
+
+	// Limits that don't permit any traffic are rejected.
+	err = os.WriteFile(path, []byte(`[{"name": "rollup", "clients": ["10.0.0.1"], "maxOpsPerSecond": 1}]`), 0600)
+	require.NoError(t, err)
+	_, err = ReadClientTiersFile(path)
+	require.Error(t, err)
+
+	// The default tier name is reserved.
+	err = os.WriteFile(path, []byte(`[{
+		"name": "default",
+		"maxOpsPerSecond": 1,
+		"opsBurstiness": 1,
+		"maxBytesPerSecond": 1,
+		"bytesBurstiness": 1,
+		"maxConcurrentOps": 1
+	}]`), 0600)
+	require.NoError(t, err)
+	_, err = ReadClientTiersFile(path)
+	require.Error(t, err)
+
+	_, err = ReadClientTiersFile(filepath.Join(directory, "missing.json"))
+	require.Error(t, err)
+}
+
+func TestNormalizeClientID(t *testing.T) {
+	// Accounts are converted to the form recovered from request signatures, regardless of case.
+	account := gethcommon.HexToAddress("0xabcdefabcdefabcdefabcdefabcdefabcdefabcd").Hex()
+	require.Equal(t, account, NormalizeClientID("0xabcdefabcdefabcdefabcdefabcdefabcdefabcd"))
+	require.Equal(t, account, NormalizeClientID("0xABCDEFABCDEFABCDEFABCDEFABCDEFABCDEFABCD"))
+
+	// Anything else is left alone.
+	require.Equal(t, "10.0.0.1", NormalizeClientID("10.0.0.1"))
+	require.Equal(t, "0x1234", NormalizeClientID("0x1234"))
+}
@@ -23,6 +23,37 @@ type Config struct {
 	// This is in addition to the rate limits. Default is 1024.
 	MaxConcurrentGetBlobOps int
 
+	// Client rate limiting for GetBlob operations. A client is identified by the account that signed the request,
+	// or by its IP address if the request is not signed.
+
+	// MaxGetBlobOpsPerSecondClient is the maximum permitted number of GetBlob operations per second for a single
+	// client. Default is 32.
+	MaxGetBlobOpsPerSecondClient float64
+	// The burstiness of the MaxGetBlobOpsPerSecondClient rate limiter. This is the maximum burst size that happen
+	// within a short time window. Default is 32.
+	GetBlobOpsBurstinessClient int
+
+	// MaxGetBlobBytesPerSecondClient is the maximum bandwidth, in bytes, that GetBlob operations are permitted
+	// to consume per second for a single client. Default is 4MiB/s.
+	MaxGetBlobBytesPerSecondClient float64
+	// The burstiness of the MaxGetBlobBytesPerSecondClient rate limiter. This is the maximum burst size that happen
+	// within a short time window. Must be at least as large as the largest blob. Default is 20MiB.
+	GetBlobBytesBurstinessClient int
+
+	// MaxConcurrentGetBlobOpsClient is the maximum number of concurrent GetBlob operations that are permitted for a
+	// single client. Default is 4.
+	MaxConcurrentGetBlobOpsClient int
+
+	// GetBlobClientTiers describes allowlisted clients (e.g. known rollups) that are given their own per-client
+	// GetBlob limits in place of the defaults above. Default is empty.
+	GetBlobClientTiers []*ClientTier
+
+	// GetBlobClientCacheSize is the maximum number of clients (other than allowlisted clients) for which per-client
+	// GetBlob state is kept. When exceeded, the state of the least recently seen client is discarded if it is idle.
+	// Otherwise, new clients share a single set of default per-client limits until a client becomes idle.
+	// Default is 65536.
+	GetBlobClientCacheSize int
+
 	// Chunk rate limiting
 
 	// MaxGetChunkOpsPerSecond is the maximum permitted number of GetChunk operations per second. Default is
@@ -63,3 +94,30 @@ type Config struct {
 	// Default is 1.
 	MaxConcurrentGetChunkOpsClient int
 }
+
+// ClientLimits describes the GetBlob limits applied to a single client.
+type ClientLimits struct {
+	// MaxOpsPerSecond is the maximum permitted number of GetBlob operations per second.
+	MaxOpsPerSecond float64 `json:"maxOpsPerSecond"`
+	// OpsBurstiness is the burstiness of the MaxOpsPerSecond rate limiter.
+	OpsBurstiness int `json:"opsBurstiness"`
+	// MaxBytesPerSecond is the maximum bandwidth, in bytes, that GetBlob operations are permitted to consume
+	// per second.
+	MaxBytesPerSecond float64 `json:"maxBytesPerSecond"`
+	// BytesBurstiness is the burstiness of the MaxBytesPerSecond rate limiter.
+	BytesBurstiness int `json:"bytesBurstiness"`
+	// MaxConcurrentOps is the maximum number of concurrent GetBlob operations.
+	MaxConcurrentOps int `json:"maxConcurrentOps"`
+}
+
+// ClientTier is a named set of clients that share the same per-client GetBlob limits. Each client in the tier
+// is limited separately.
+type ClientTier struct {
+	// Name is the name of the tier, used in metrics and logs.
+	Name string `json:"name"`
+	// Clients are the IDs of the clients in this tier. A client ID is either an account address (including the
+	// initial "0x") for clients that sign their requests, or an IP address for clients that do not.
+	Clients []string `json:"clients"`
+	// Limits are the limits applied to each client in this tier.
+	ClientLimits
+}
@@ -57,6 +57,7 @@ func newMetadataProvider(
 	logger logging.Logger,
 	metadataStore blobstore.MetadataStore,
 	metadataCacheSize int,
+	cacheEvictionPolicy cache2.EvictionPolicy,
 	maxIOConcurrency int,
 	relayKeys []v2.RelayKey,
 	fetchTimeout time.Duration,
@@ -77,8 +78,14 @@ func newMetadataProvider(
 	}
 	server.blobParamsMap.Store(blobParamsMap)
 
-	metadataCache, err := cache.NewCacheAccessor[v2.BlobKey, *blobMetadata](
-		cache2.NewFIFOCache[v2.BlobKey, *blobMetadata](uint64(metadataCacheSize), nil, nil),
+	metadataCache, err := cache2.NewCache[v2.BlobKey, *blobMetadata](
+		cacheEvictionPolicy, uint64(metadataCacheSize), nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating metadata cache: %w", err)
+	}
+
+	metadataCacheAccessor, err := cache.NewCacheAccessor[v2.BlobKey, *blobMetadata](
+		metadataCache,
 		maxIOConcurrency,
 		server.fetchMetadata,
 		metrics)
@@ -86,7 +93,7 @@ func newMetadataProvider(
 		return nil, fmt.Errorf("error creating metadata cache: %w", err)
 	}
 
-	server.metadataCache = metadataCache
+	server.metadataCache = metadataCacheAccessor
 
 	return server, nil
 }
@@ -2,6 +2,7 @@ package relay
 
 import (
 	"context"
+	cachecommon "github.com/Layr-Labs/eigenda/common/cache"
 	"math/rand"
 	"testing"
 
@@ -29,6 +30,7 @@ func TestGetNonExistentBlob(t *testing.T) {
 		logger,
 		metadataStore,
 		1024*1024,
+		cachecommon.FIFOEvictionPolicy,
 		32,
 		nil,
 		10*time.Second,
@@ -96,6 +98,7 @@ func TestFetchingIndividualMetadata(t *testing.T) {
 		logger,
 		metadataStore,
 		1024*1024,
+		cachecommon.FIFOEvictionPolicy,
 		32,
 		nil,
 		10*time.Second,
@@ -182,6 +185,7 @@ func TestBatchedFetch(t *testing.T) {
 		logger,
 		metadataStore,
 		1024*1024,
+		cachecommon.FIFOEvictionPolicy,
 		32,
 		nil,
 		10*time.Second,
@@ -289,6 +293,7 @@ func TestIndividualFetchWithSharding(t *testing.T) {
 		logger,
 		metadataStore,
 		1024*1024,
+		cachecommon.FIFOEvictionPolicy,
 		32,
 		shardList,
 		10*time.Second,
@@ -422,6 +427,7 @@ func TestBatchedFetchWithSharding(t *testing.T) {
 		logger,
 		metadataStore,
 		1024*1024,
+		cachecommon.FIFOEvictionPolicy,
 		32,
 		shardList,
 		10*time.Second,
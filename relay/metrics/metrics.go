@@ -47,6 +47,11 @@ type RelayMetrics struct {
 	getBlobRateLimited        *prometheus.CounterVec
 	getBlobBandwidth          *prometheus.CounterVec
 	getBlobRequestedBandwidth *prometheus.CounterVec
+
+	// Per-client GetBlob metrics
+	getBlobClientRequests    *prometheus.CounterVec
+	getBlobClientRateLimited *prometheus.CounterVec
+	getBlobClientBandwidth   *prometheus.CounterVec
 }
 
 // NewRelayMetrics creates a new RelayMetrics instance, which encapsulates all metrics related to the relay.
@@ -223,6 +228,33 @@ func NewRelayMetrics(registry *prometheus.Registry, logger logging.Logger, port
 		[]string{},
 	)
 
+	getBlobClientRequests := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "get_blob_client_request_count",
+			Help:      "Number of GetBlob requests admitted for each client.",
+		},
+		[]string{"client", "tier"},
+	)
+
+	getBlobClientRateLimited := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "get_blob_client_rate_limited_count",
+			Help:      "Number of GetBlob requests rate limited by per-client limits for each client.",
+		},
+		[]string{"client", "tier", "reason"},
+	)
+
+	getBlobClientBandwidth := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "get_blob_client_bandwidth_bytes",
+			Help:      "Running total bandwidth granted to GetBlob requests for each client.",
+		},
+		[]string{"client", "tier"},
+	)
+
 	return &RelayMetrics{
 		logger:                         logger,
 		grpcServerOption:               grpcServerOption,
@@ -245,6 +277,9 @@ func NewRelayMetrics(registry *prometheus.Registry, logger logging.Logger, port
 		getBlobRateLimited:             getBlobRateLimited,
 		getBlobBandwidth:               getBlobBandwidth,
 		getBlobRequestedBandwidth:      getBlobRequestedBandwidth,
+		getBlobClientRequests:          getBlobClientRequests,
+		getBlobClientRateLimited:       getBlobClientRateLimited,
+		getBlobClientBandwidth:         getBlobClientBandwidth,
 	}
 }
 
@@ -327,3 +362,24 @@ func (m *RelayMetrics) ReportBlobBandwidthUsage(size int) {
 func (m *RelayMetrics) ReportBlobRequestedBandwidthUsage(size int) {
 	m.getBlobRequestedBandwidth.WithLabelValues().Add(float64(size))
 }
+
+func (m *RelayMetrics) ReportBlobClientRequest(client string, tier string) {
+	m.getBlobClientRequests.WithLabelValues(client, tier).Inc()
+}
+
+func (m *RelayMetrics) ReportBlobClientRateLimited(client string, tier string, reason string) {
+	m.getBlobClientRateLimited.WithLabelValues(client, tier, reason).Inc()
+}
+
+func (m *RelayMetrics) ReportBlobClientBandwidthUsage(client string, tier string, size int) {
+	m.getBlobClientBandwidth.WithLabelValues(client, tier).Add(float64(size))
+}
+
+// RemoveBlobClient removes the per-client GetBlob metrics of a client that is no longer tracked, so that the number
+// of reported clients stays bounded.
+func (m *RelayMetrics) RemoveBlobClient(client string, tier string) {
+	labels := prometheus.Labels{"client": client, "tier": tier}
+	m.getBlobClientRequests.Delete(labels)
+	m.getBlobClientRateLimited.DeletePartialMatch(labels)
+	m.getBlobClientBandwidth.Delete(labels)
+}
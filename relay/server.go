@@ -10,6 +10,7 @@ import (
 
 	"github.com/Layr-Labs/eigenda/api"
 	pb "github.com/Layr-Labs/eigenda/api/grpc/relay"
+	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/healthcheck"
 	"github.com/Layr-Labs/eigenda/common/pprof"
 	"github.com/Layr-Labs/eigenda/common/replay"
@@ -21,6 +22,7 @@ import (
 	"github.com/Layr-Labs/eigenda/relay/limiter"
 	"github.com/Layr-Labs/eigenda/relay/metrics"
 	"github.com/Layr-Labs/eigensdk-go/logging"
+	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/prometheus/client_golang/prometheus"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/peer"
@@ -99,6 +101,7 @@ func NewServer(
 		logger,
 		metadataStore,
 		config.MetadataCacheSize,
+		config.CacheEvictionPolicy,
 		config.MetadataMaxConcurrency,
 		config.RelayKeys,
 		config.Timeouts.InternalGetMetadataTimeout,
@@ -114,6 +117,7 @@ func NewServer(
 		logger,
 		blobStore,
 		config.BlobCacheBytes,
+		config.CacheEvictionPolicy,
 		config.BlobMaxConcurrency,
 		config.Timeouts.InternalGetBlobTimeout,
 		relayMetrics.BlobCacheMetrics)
@@ -126,6 +130,7 @@ func NewServer(
 		logger,
 		chunkReader,
 		config.ChunkCacheBytes,
+		config.CacheEvictionPolicy,
 		config.ChunkMaxConcurrency,
 		config.Timeouts.InternalGetProofsTimeout,
 		config.Timeouts.InternalGetCoefficientsTimeout,
@@ -142,6 +147,11 @@ func NewServer(
 		}
 	}
 
+	blobRateLimiter, err := limiter.NewBlobRateLimiter(&config.RateLimits, relayMetrics)
+	if err != nil {
+		return nil, fmt.Errorf("error creating blob rate limiter: %w", err)
+	}
+
 	replayGuardian := replay.NewReplayGuardian(
 		time.Now,
 		config.GetChunksRequestMaxPastAge,
@@ -153,7 +163,7 @@ func NewServer(
 		metadataProvider: mp,
 		blobProvider:     bp,
 		chunkProvider:    cp,
-		blobRateLimiter:  limiter.NewBlobRateLimiter(&config.RateLimits, relayMetrics),
+		blobRateLimiter:  blobRateLimiter,
 		chunkRateLimiter: limiter.NewChunkRateLimiter(&config.RateLimits, relayMetrics),
 		authenticator:    authenticator,
 		replayGuardian:   replayGuardian,
@@ -178,11 +188,19 @@ func (s *Server) GetBlob(ctx context.Context, request *pb.GetBlobRequest) (*pb.G
 	}
 	s.logger.Debug("GetBlob request received", "key", key.Hex())
 
-	err = s.blobRateLimiter.BeginGetBlobOperation(time.Now())
+	clientID, err := s.getBlobClientID(ctx, len(request.GetSignature()) > 0, request.GetTimestamp(),
+		func() (gethcommon.Address, []byte, error) {
+			return auth.RecoverGetBlobRequestSigner(request)
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.blobRateLimiter.BeginGetBlobOperation(time.Now(), clientID)
 	if err != nil {
 		return nil, api.NewErrorResourceExhausted(fmt.Sprintf("rate limit exceeded: %v", err))
 	}
-	defer s.blobRateLimiter.FinishGetBlobOperation()
+	defer s.blobRateLimiter.FinishGetBlobOperation(clientID)
 
 	keys := []v2.BlobKey{key}
 	mMap, err := s.metadataProvider.GetMetadataForBlobs(ctx, keys)
@@ -199,7 +217,7 @@ func (s *Server) GetBlob(ctx context.Context, request *pb.GetBlobRequest) (*pb.G
 	s.metrics.ReportBlobMetadataLatency(finishedFetchingMetadata.Sub(start))
 
 	s.metrics.ReportBlobRequestedBandwidthUsage(int(metadata.blobSizeBytes))
-	err = s.blobRateLimiter.RequestGetBlobBandwidth(time.Now(), metadata.blobSizeBytes)
+	err = s.blobRateLimiter.RequestGetBlobBandwidth(time.Now(), clientID, metadata.blobSizeBytes)
 	if err != nil {
 		return nil, api.NewErrorResourceExhausted(fmt.Sprintf("bandwidth limit exceeded: %v", err))
 	}
@@ -219,6 +237,139 @@ func (s *Server) GetBlob(ctx context.Context, request *pb.GetBlobRequest) (*pb.G
 	return reply, nil
 }
 
+// StreamBlob retrieves a blob stored by the relay, and sends it to the client as a sequence of frames.
+func (s *Server) StreamBlob(request *pb.StreamBlobRequest, stream pb.Relay_StreamBlobServer) error {
+	start := time.Now()
+	ctx := stream.Context()
+
+	if s.config.Timeouts.StreamBlobTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.config.Timeouts.StreamBlobTimeout)
+		defer cancel()
+	}
+
+	// Validate the request params before any further processing (as validation is cheaper)
+	key, err := v2.BytesToBlobKey(request.GetBlobKey())
+	if err != nil {
+		return api.NewErrorInvalidArg(fmt.Sprintf("invalid blob key: %v", err))
+	}
+	offset := request.GetOffset()
+	s.logger.Debug("StreamBlob request received", "key", key.Hex(), "offset", offset)
+
+	clientID, err := s.getBlobClientID(ctx, len(request.GetSignature()) > 0, request.GetTimestamp(),
+		func() (gethcommon.Address, []byte, error) {
+			return auth.RecoverStreamBlobRequestSigner(request)
+		})
+	if err != nil {
+		return err
+	}
+
+	err = s.blobRateLimiter.BeginGetBlobOperation(time.Now(), clientID)
+	if err != nil {
+		return api.NewErrorResourceExhausted(fmt.Sprintf("rate limit exceeded: %v", err))
+	}
+	defer s.blobRateLimiter.FinishGetBlobOperation(clientID)
+
+	mMap, err := s.metadataProvider.GetMetadataForBlobs(ctx, []v2.BlobKey{key})
+	if err != nil {
+		return api.NewErrorInternal(fmt.Sprintf(
+			"error fetching metadata for blob, check if blob exists and is assigned to this relay: %v", err))
+	}
+	metadata := mMap[key]
+	if metadata == nil {
+		return api.NewErrorNotFound("blob not found")
+	}
+
+	finishedFetchingMetadata := time.Now()
+	s.metrics.ReportBlobMetadataLatency(finishedFetchingMetadata.Sub(start))
+
+	// A resumed stream is only charged for the bytes it has not already received.
+	requestedBytes := metadata.blobSizeBytes
+	if offset < uint64(requestedBytes) {
+		requestedBytes -= uint32(offset)
+	} else {
+		requestedBytes = 0
+	}
+	s.metrics.ReportBlobRequestedBandwidthUsage(int(requestedBytes))
+	err = s.blobRateLimiter.RequestGetBlobBandwidth(time.Now(), clientID, requestedBytes)
+	if err != nil {
+		return api.NewErrorResourceExhausted(fmt.Sprintf("bandwidth limit exceeded: %v", err))
+	}
+
+	data, err := s.blobProvider.GetBlob(ctx, key)
+	if err != nil {
+		return api.NewErrorInternal(fmt.Sprintf("error fetching blob %s: %v", key.Hex(), err))
+	}
+	s.metrics.ReportBlobDataLatency(time.Since(finishedFetchingMetadata))
+
+	blobSize := uint64(len(data))
+	if offset > blobSize || (offset == blobSize && blobSize > 0) {
+		return api.NewErrorInvalidArg(fmt.Sprintf("offset %d is out of range for blob of size %d", offset, blobSize))
+	}
+
+	frameSize := uint64(s.config.StreamBlobFrameSize)
+	if frameSize == 0 {
+		frameSize = defaultStreamBlobFrameSize
+	}
+
+	// An empty blob is sent as a single empty frame, so that the client learns the size of the blob.
+	for {
+		end := offset + frameSize
+		if end > blobSize {
+			end = blobSize
+		}
+
+		err = stream.Send(&pb.StreamBlobReply{
+			BlobSize: blobSize,
+			Offset:   offset,
+			Data:     data[offset:end],
+		})
+		if err != nil {
+			return fmt.Errorf("error sending frame of blob %s at offset %d: %w", key.Hex(), offset, err)
+		}
+		s.metrics.ReportBlobBandwidthUsage(int(end - offset))
+
+		offset = end
+		if offset >= blobSize {
+			break
+		}
+	}
+
+	s.metrics.ReportBlobLatency(time.Since(start))
+
+	return nil
+}
+
+// getBlobClientID returns the ID of the client used to rate limit a GetBlob or StreamBlob request. Requests signed
+// by an allowlisted account are identified by that account. All other requests, including requests signed by other
+// accounts, are identified by the IP address of the client, since anyone can create new accounts to sign with.
+func (s *Server) getBlobClientID(
+	ctx context.Context,
+	signed bool,
+	timestamp uint32,
+	recoverSigner func() (gethcommon.Address, []byte, error)) (string, error) {
+
+	if signed {
+		account, hash, err := recoverSigner()
+		if err != nil {
+			return "", api.NewErrorInvalidArg(fmt.Sprintf("invalid request signature: %v", err))
+		}
+		if s.blobRateLimiter.IsAllowlisted(account.Hex()) {
+			err = s.replayGuardian.VerifyRequest(hash, time.Unix(int64(timestamp), 0))
+			if err != nil {
+				return "", api.NewErrorInvalidArg(fmt.Sprintf("failed to verify request: %v", err))
+			}
+			return account.Hex(), nil
+		}
+	}
+
+	clientAddress, err := common.GetClientAddress(ctx, s.config.ClientIPHeader, 1, true)
+	if err != nil {
+		return "", api.NewErrorInvalidArg(fmt.Sprintf("could not determine client address: %v", err))
+	}
+	return clientAddress, nil
+}
+
 func (s *Server) validateGetChunksRequest(request *pb.GetChunksRequest) error {
 	if request == nil {
 		return api.NewErrorInvalidArg("request is nil")
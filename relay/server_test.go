@@ -3,6 +3,8 @@ package relay
 import (
 	"context"
 	"encoding/binary"
+	"errors"
+	"io"
 	"testing"
 	"time"
 
@@ -45,6 +47,12 @@ func defaultConfig() *Config {
 			MaxGetBlobBytesPerSecond:        20 * 1024 * 1024,
 			GetBlobBytesBurstiness:          20 * 1024 * 1024,
 			MaxConcurrentGetBlobOps:         1024,
+			MaxGetBlobOpsPerSecondClient:    1024,
+			GetBlobOpsBurstinessClient:      1024,
+			MaxGetBlobBytesPerSecondClient:  20 * 1024 * 1024,
+			GetBlobBytesBurstinessClient:    20 * 1024 * 1024,
+			MaxConcurrentGetBlobOpsClient:   1024,
+			GetBlobClientCacheSize:          1024,
 			MaxGetChunkOpsPerSecond:         1024,
 			GetChunkOpsBurstiness:           1024,
 			MaxGetChunkBytesPerSecond:       20 * 1024 * 1024,
@@ -84,6 +92,36 @@ func getBlob(t *testing.T, request *pb.GetBlobRequest) (*pb.GetBlobReply, error)
 	return response, err
 }
 
+func streamBlob(t *testing.T, request *pb.StreamBlobRequest) ([]*pb.StreamBlobReply, error) {
+	var opts []grpc.DialOption
+	opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+
+	conn, err := grpc.NewClient("0.0.0.0:50051", opts...)
+	require.NoError(t, err)
+	defer func() {
+		err = conn.Close()
+		require.NoError(t, err)
+	}()
+
+	client := pb.NewRelayClient(conn)
+	stream, err := client.StreamBlob(context.Background(), request)
+	if err != nil {
+		return nil, err
+	}
+
+	frames := make([]*pb.StreamBlobReply, 0)
+	for {
+		frame, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return frames, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		frames = append(frames, frame)
+	}
+}
+
 func getChunks(
 	t *testing.T,
 	random *random.TestRandom,
@@ -258,6 +296,108 @@ func TestReadNonExistentBlob(t *testing.T) {
 	}
 }
 
+func TestStreamBlobs(t *testing.T) {
+	rand := random.NewTestRandom()
+
+	logger, err := common.NewLogger(common.DefaultLoggerConfig())
+	require.NoError(t, err)
+
+	setup(t)
+	defer teardown()
+
+	// These are used to write data to S3/dynamoDB
+	metadataStore := buildMetadataStore(t)
+	blobStore := buildBlobStore(t, logger)
+	chainReader := newMockChainReader()
+
+	ics := &coremock.MockIndexedChainState{}
+	blockNumber := uint(rand.Uint32())
+	ics.Mock.On("GetCurrentBlockNumber").Return(blockNumber, nil)
+	operatorInfo := make(map[core.OperatorID]*core.IndexedOperatorInfo)
+	ics.Mock.On("GetIndexedOperators", blockNumber).Return(operatorInfo, nil)
+
+	// Use small frames so that each blob is split across many messages.
+	config := defaultConfig()
+	config.StreamBlobFrameSize = 100
+	server, err := NewServer(
+		context.Background(),
+		prometheus.NewRegistry(),
+		logger,
+		config,
+		metadataStore,
+		blobStore,
+		nil, /* not used in this test*/
+		chainReader,
+		ics)
+	require.NoError(t, err)
+
+	go func() {
+		err = server.Start(context.Background())
+		require.NoError(t, err)
+	}()
+	defer func() {
+		err = server.Stop()
+		require.NoError(t, err)
+	}()
+
+	expectedData := make(map[v2.BlobKey][]byte)
+
+	blobCount := 10
+	for i := 0; i < blobCount; i++ {
+		header, data := randomBlob(t)
+
+		blobKey, err := header.BlobKey()
+		require.NoError(t, err)
+		expectedData[blobKey] = data
+
+		err = metadataStore.PutBlobCertificate(
+			context.Background(),
+			&v2.BlobCertificate{
+				BlobHeader: header,
+			},
+			&encoding.FragmentInfo{})
+		require.NoError(t, err)
+
+		err = blobStore.StoreBlob(context.Background(), blobKey, data)
+		require.NoError(t, err)
+	}
+
+	for key, data := range expectedData {
+		// Stream the entire blob.
+		frames, err := streamBlob(t, &pb.StreamBlobRequest{BlobKey: key[:]})
+		require.NoError(t, err)
+
+		received := make([]byte, 0, len(data))
+		for _, frame := range frames {
+			require.Equal(t, uint64(len(data)), frame.BlobSize)
+			require.Equal(t, uint64(len(received)), frame.Offset)
+			require.LessOrEqual(t, len(frame.Data), config.StreamBlobFrameSize)
+			received = append(received, frame.Data...)
+		}
+		require.Equal(t, data, received)
+
+		// Resume from the middle of the blob.
+		offset := rand.Intn(len(data))
+		frames, err = streamBlob(t, &pb.StreamBlobRequest{BlobKey: key[:], Offset: uint64(offset)})
+		require.NoError(t, err)
+
+		received = make([]byte, 0, len(data)-offset)
+		for _, frame := range frames {
+			require.Equal(t, uint64(offset+len(received)), frame.Offset)
+			received = append(received, frame.Data...)
+		}
+		require.Equal(t, data[offset:], received)
+
+		// An offset past the end of the blob is rejected.
+		_, err = streamBlob(t, &pb.StreamBlobRequest{BlobKey: key[:], Offset: uint64(len(data))})
+		require.Error(t, err)
+	}
+
+	// Blobs that don't exist can't be streamed.
+	_, err = streamBlob(t, &pb.StreamBlobRequest{BlobKey: tu.RandomBytes(32)})
+	require.Error(t, err)
+}
+
 func TestReadWriteBlobsWithSharding(t *testing.T) {
 	rand := random.NewTestRandom()
 
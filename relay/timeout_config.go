@@ -11,6 +11,9 @@ type TimeoutConfig struct {
 	// The maximum time permitted for a GetBlob GRPC to complete. If zero then no timeout is enforced.
 	GetBlobTimeout time.Duration
 
+	// The maximum time permitted for a StreamBlob GRPC to complete. If zero then no timeout is enforced.
+	StreamBlobTimeout time.Duration
+
 	// The maximum time permitted for a single request to the metadata store to fetch the metadata
 	// for an individual blob.
 	InternalGetMetadataTimeout time.Duration